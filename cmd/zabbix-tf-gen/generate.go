@@ -0,0 +1,402 @@
+// ABOUTME: Generates Terraform resource and import blocks for the objects of a Zabbix server.
+// ABOUTME: References generated groups and templates by address so the configuration stays linked.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kinds of objects configuration can be generated for, in the order they are written.
+const (
+	kindHostGroups     = "host_groups"
+	kindTemplateGroups = "template_groups"
+	kindTemplates      = "templates"
+	kindHosts          = "hosts"
+)
+
+// allKinds are the kinds of objects generated by default.
+var allKinds = []string{kindHostGroups, kindTemplateGroups, kindTemplates, kindHosts}
+
+// interfaceTypes maps the interface types of the Zabbix API to the type names of zabbix_host.
+var interfaceTypes = map[int]string{
+	1: "agent",
+	2: "snmp",
+	3: "ipmi",
+	4: "jmx",
+}
+
+// SNMPv3 settings of the Zabbix API mapped to their names in the snmp attribute of zabbix_host.
+var (
+	snmpSecurityLevels = []string{"noAuthNoPriv", "authNoPriv", "authPriv"}
+	snmpAuthProtocols  = []string{"md5", "sha1", "sha224", "sha256", "sha384", "sha512"}
+	snmpPrivProtocols  = []string{"des", "aes128", "aes192", "aes256", "aes192c", "aes256c"}
+)
+
+// invalidNameChars matches the characters that are not allowed in resource names.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// summary counts the objects configuration was generated for.
+type summary struct {
+	HostGroups      int
+	TemplateGroups  int
+	Templates       int
+	Hosts           int
+	DiscoveredHosts int
+}
+
+// generator writes the configuration of Zabbix objects and remembers the resource
+// addresses of generated objects, so that later objects can reference them.
+type generator struct {
+	body *hclwrite.Body
+	// addresses maps the resource type and ID of generated objects to their resource name.
+	addresses map[string]map[string]string
+	// names holds the resource names already used for each resource type.
+	names map[string]map[string]bool
+}
+
+// generate reads the objects of the given kinds from src and writes a resource block and an
+// import block for each of them to w. Hosts created by low-level discovery are skipped, as
+// their configuration is owned by the host prototypes of their discovery rule.
+func generate(ctx context.Context, src source, kinds []string, w io.Writer) (summary, error) {
+	var sum summary
+	file := hclwrite.NewEmptyFile()
+	g := &generator{
+		body:      file.Body(),
+		addresses: make(map[string]map[string]string),
+		names:     make(map[string]map[string]bool),
+	}
+
+	enabled := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		enabled[kind] = true
+	}
+
+	if enabled[kindHostGroups] {
+		groups, err := src.HostGroups(ctx)
+		if err != nil {
+			return sum, fmt.Errorf("could not read host groups: %w", err)
+		}
+		for _, group := range groups {
+			g.hostGroup(group)
+		}
+		sum.HostGroups = len(groups)
+	}
+
+	if enabled[kindTemplateGroups] {
+		groups, err := src.TemplateGroups(ctx)
+		if err != nil {
+			return sum, fmt.Errorf("could not read template groups: %w", err)
+		}
+		for _, group := range groups {
+			g.templateGroup(group)
+		}
+		sum.TemplateGroups = len(groups)
+	}
+
+	if enabled[kindTemplates] {
+		templates, err := src.Templates(ctx)
+		if err != nil {
+			return sum, fmt.Errorf("could not read templates: %w", err)
+		}
+		// Templates link each other, so all addresses are known before the first block
+		for _, template := range templates {
+			g.address("zabbix_template", template.TemplateID, template.Host)
+		}
+		for _, template := range templates {
+			g.template(template)
+		}
+		sum.Templates = len(templates)
+	}
+
+	if enabled[kindHosts] {
+		hosts, err := src.Hosts(ctx)
+		if err != nil {
+			return sum, fmt.Errorf("could not read hosts: %w", err)
+		}
+		for _, host := range hosts {
+			if host.Flags == zabbix.HostFlagDiscovered {
+				sum.DiscoveredHosts++
+				continue
+			}
+			g.host(host)
+			sum.Hosts++
+		}
+	}
+
+	_, err := w.Write(hclwrite.Format(file.Bytes()))
+	return sum, err
+}
+
+// hostGroup writes the configuration of a host group.
+func (g *generator) hostGroup(group zabbix.HostGroup) {
+	name := g.address("zabbix_host_group", group.GroupID, group.Name)
+	body := g.resource("zabbix_host_group", name, group.GroupID)
+	body.SetAttributeValue("name", cty.StringVal(group.Name))
+}
+
+// templateGroup writes the configuration of a template group.
+func (g *generator) templateGroup(group zabbix.TemplateGroup) {
+	name := g.address("zabbix_template_group", group.GroupID, group.Name)
+	body := g.resource("zabbix_template_group", name, group.GroupID)
+	body.SetAttributeValue("name", cty.StringVal(group.Name))
+}
+
+// template writes the configuration of a template that is managed by its attributes.
+func (g *generator) template(template zabbix.Template) {
+	body := g.resource("zabbix_template", g.addresses["zabbix_template"][template.TemplateID], template.TemplateID)
+
+	body.SetAttributeValue("host", cty.StringVal(template.Host))
+	if template.Name != "" && template.Name != template.Host {
+		body.SetAttributeValue("name", cty.StringVal(template.Name))
+	}
+	if template.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(template.Description))
+	}
+
+	groupIDs := make([]string, len(template.Groups))
+	for i, group := range template.Groups {
+		groupIDs[i] = group.GroupID
+	}
+	g.references(body, "groups", "zabbix_template_group", groupIDs)
+
+	tags := make([]zabbix.HostTag, len(template.Tags))
+	for i, tag := range template.Tags {
+		tags[i] = zabbix.HostTag(tag)
+	}
+	setTags(body, tags)
+
+	linkedIDs := make([]string, len(template.ParentTemplates))
+	for i, linked := range template.ParentTemplates {
+		linkedIDs[i] = linked.TemplateID
+	}
+	g.references(body, "linked_templates", "zabbix_template", linkedIDs)
+}
+
+// host writes the configuration of a host.
+func (g *generator) host(host zabbix.Host) {
+	name := g.address("zabbix_host", host.HostID, host.Host)
+	body := g.resource("zabbix_host", name, host.HostID)
+
+	body.SetAttributeValue("host", cty.StringVal(host.Host))
+	if host.Name != "" && host.Name != host.Host {
+		body.SetAttributeValue("name", cty.StringVal(host.Name))
+	}
+
+	groupIDs := make([]string, len(host.Groups))
+	for i, group := range host.Groups {
+		groupIDs[i] = group.GroupID
+	}
+	g.references(body, "groups", "zabbix_host_group", groupIDs)
+
+	templateIDs := make([]string, len(host.ParentTemplates))
+	for i, template := range host.ParentTemplates {
+		templateIDs[i] = template.TemplateID
+	}
+	g.references(body, "templates", "zabbix_template", templateIDs)
+
+	if host.Status != 0 {
+		body.SetAttributeValue("status", cty.NumberIntVal(int64(host.Status)))
+	}
+	if host.TLSConnect != 1 {
+		body.SetAttributeValue("tls_connect", cty.NumberIntVal(int64(host.TLSConnect)))
+	}
+	if host.TLSAccept != 1 {
+		body.SetAttributeValue("tls_accept", cty.NumberIntVal(int64(host.TLSAccept)))
+	}
+	if host.TLSPSKIdentity != "" {
+		body.SetAttributeValue("tls_psk_identity", cty.StringVal(host.TLSPSKIdentity))
+		appendComment(body, "tls_psk_wo is write-only and not returned by Zabbix, set it to manage the pre-shared key.")
+	}
+	if host.IPMIUsername != nil && *host.IPMIUsername != "" {
+		body.SetAttributeValue("ipmi_username", cty.StringVal(*host.IPMIUsername))
+	}
+
+	if len(host.Interfaces) > 0 {
+		interfaces := make([]hclwrite.Tokens, len(host.Interfaces))
+		for i, iface := range host.Interfaces {
+			interfaces[i] = interfaceTokens(iface)
+			if iface.Details != nil && iface.Details.Version == zabbix.SNMPVersion3 && iface.Details.SecurityLevel > 0 {
+				appendComment(body, fmt.Sprintf("The SNMPv3 passphrases of interface %d are write-only and not returned by Zabbix, set auth_passphrase_wo and priv_passphrase_wo to manage them.", i+1))
+			}
+		}
+		body.SetAttributeRaw("interfaces", objectList(interfaces))
+	}
+
+	setTags(body, host.Tags)
+}
+
+// resource writes the import block and the empty resource block of an object and returns
+// the body of the resource block.
+func (g *generator) resource(resourceType, name, id string) *hclwrite.Body {
+	if len(g.body.Blocks()) > 0 {
+		g.body.AppendNewline()
+	}
+
+	importBlock := g.body.AppendNewBlock("import", nil).Body()
+	importBlock.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	importBlock.SetAttributeValue("id", cty.StringVal(id))
+	g.body.AppendNewline()
+
+	return g.body.AppendNewBlock("resource", []string{resourceType, name}).Body()
+}
+
+// address assigns a resource name to an object, derived from its name and unique within its
+// resource type, and returns it.
+func (g *generator) address(resourceType, id, objectName string) string {
+	if g.addresses[resourceType] == nil {
+		g.addresses[resourceType] = make(map[string]string)
+		g.names[resourceType] = make(map[string]bool)
+	}
+
+	name := resourceName(resourceType, objectName)
+	unique := name
+	for i := 2; g.names[resourceType][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+
+	g.names[resourceType][unique] = true
+	g.addresses[resourceType][id] = unique
+	return unique
+}
+
+// references sets a set attribute of IDs, referencing the id of generated resources and
+// falling back to the literal ID for objects that are not generated.
+func (g *generator) references(body *hclwrite.Body, attribute, resourceType string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	elements := make([]hclwrite.Tokens, len(ids))
+	for i, id := range ids {
+		if name, ok := g.addresses[resourceType][id]; ok {
+			elements[i] = hclwrite.TokensForTraversal(hcl.Traversal{
+				hcl.TraverseRoot{Name: resourceType},
+				hcl.TraverseAttr{Name: name},
+				hcl.TraverseAttr{Name: "id"},
+			})
+		} else {
+			elements[i] = hclwrite.TokensForValue(cty.StringVal(id))
+		}
+	}
+	body.SetAttributeRaw(attribute, hclwrite.TokensForTuple(elements))
+}
+
+// resourceName derives a resource name from the name of an object: lower case letters,
+// digits and underscores, starting with a letter.
+func resourceName(resourceType, objectName string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(objectName), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = strings.TrimPrefix(resourceType, "zabbix_") + "_" + name
+	}
+	return strings.TrimSuffix(name, "_")
+}
+
+// interfaceTokens returns an interface of a host as an object expression.
+func interfaceTokens(iface zabbix.HostInterface) hclwrite.Tokens {
+	attrs := []hclwrite.ObjectAttrTokens{
+		objectAttr("type", cty.StringVal(interfaceTypes[iface.Type])),
+		objectAttr("ip", cty.StringVal(iface.IP)),
+	}
+	if iface.DNS != "" {
+		attrs = append(attrs, objectAttr("dns", cty.StringVal(iface.DNS)))
+	}
+	attrs = append(attrs,
+		objectAttr("port", cty.StringVal(iface.Port)),
+		objectAttr("main", cty.BoolVal(iface.Main == 1)),
+		objectAttr("use_ip", cty.BoolVal(iface.UseIP == 1)),
+	)
+
+	if details := iface.Details; details != nil {
+		snmp := map[string]cty.Value{
+			"version": cty.NumberIntVal(int64(details.Version)),
+			"bulk":    cty.BoolVal(details.Bulk == 1),
+		}
+		if details.Version == zabbix.SNMPVersion3 {
+			snmp["security_name"] = cty.StringVal(details.SecurityName)
+			snmp["security_level"] = cty.StringVal(snmpName(snmpSecurityLevels, details.SecurityLevel))
+			snmp["auth_protocol"] = cty.StringVal(snmpName(snmpAuthProtocols, details.AuthProtocol))
+			snmp["priv_protocol"] = cty.StringVal(snmpName(snmpPrivProtocols, details.PrivProtocol))
+			if details.ContextName != "" {
+				snmp["context_name"] = cty.StringVal(details.ContextName)
+			}
+		} else {
+			snmp["community"] = cty.StringVal(details.Community)
+		}
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForIdentifier("snmp"),
+			Value: hclwrite.TokensForValue(cty.ObjectVal(snmp)),
+		})
+	}
+
+	return hclwrite.TokensForObject(attrs)
+}
+
+// setTags sets the tags attribute, omitting empty values.
+func setTags(body *hclwrite.Body, tags []zabbix.HostTag) {
+	if len(tags) == 0 {
+		return
+	}
+
+	elements := make([]hclwrite.Tokens, len(tags))
+	for i, tag := range tags {
+		attrs := []hclwrite.ObjectAttrTokens{objectAttr("tag", cty.StringVal(tag.Tag))}
+		if tag.Value != "" {
+			attrs = append(attrs, objectAttr("value", cty.StringVal(tag.Value)))
+		}
+		elements[i] = hclwrite.TokensForObject(attrs)
+	}
+	body.SetAttributeRaw("tags", objectList(elements))
+}
+
+// objectList returns a list of object expressions with one object per line.
+func objectList(objects []hclwrite.Tokens) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")},
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+	}
+	for _, object := range objects {
+		tokens = append(tokens, object...)
+		tokens = append(tokens,
+			&hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")},
+			&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		)
+	}
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+}
+
+// objectAttr returns an attribute of an object expression.
+func objectAttr(name string, value cty.Value) hclwrite.ObjectAttrTokens {
+	return hclwrite.ObjectAttrTokens{
+		Name:  hclwrite.TokensForIdentifier(name),
+		Value: hclwrite.TokensForValue(value),
+	}
+}
+
+// appendComment appends a comment line to a block body.
+func appendComment(body *hclwrite.Body, text string) {
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")},
+	})
+}
+
+// snmpName returns the name of an SNMPv3 setting, or the API value when it is not known.
+func snmpName(names []string, value int) string {
+	if value >= 0 && value < len(names) {
+		return names[value]
+	}
+	return fmt.Sprint(value)
+}
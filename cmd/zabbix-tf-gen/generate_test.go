@@ -0,0 +1,185 @@
+// ABOUTME: Unit tests for the configuration generated from the objects of a Zabbix server.
+// ABOUTME: Runs the generator against a fake source and checks blocks, references and names.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// fakeSource returns fixed objects, or err for every kind when it is set.
+type fakeSource struct {
+	hostGroups     []zabbix.HostGroup
+	templateGroups []zabbix.TemplateGroup
+	templates      []zabbix.Template
+	hosts          []zabbix.Host
+	err            error
+}
+
+func (s *fakeSource) HostGroups(ctx context.Context) ([]zabbix.HostGroup, error) {
+	return s.hostGroups, s.err
+}
+
+func (s *fakeSource) TemplateGroups(ctx context.Context) ([]zabbix.TemplateGroup, error) {
+	return s.templateGroups, s.err
+}
+
+func (s *fakeSource) Templates(ctx context.Context) ([]zabbix.Template, error) {
+	return s.templates, s.err
+}
+
+func (s *fakeSource) Hosts(ctx context.Context) ([]zabbix.Host, error) {
+	return s.hosts, s.err
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		hostGroups: []zabbix.HostGroup{
+			{GroupID: "2", Name: "Linux servers"},
+			{GroupID: "3", Name: "Linux-servers"},
+		},
+		templateGroups: []zabbix.TemplateGroup{
+			{GroupID: "12", Name: "Templates/Operating systems"},
+		},
+		templates: []zabbix.Template{
+			{
+				TemplateID:      "10001",
+				Host:            "Linux by Zabbix agent",
+				Groups:          []zabbix.TemplateGroupID{{GroupID: "12"}},
+				ParentTemplates: []zabbix.ParentTemplate{{TemplateID: "10002"}},
+			},
+			{
+				TemplateID: "10002",
+				Host:       "Linux CPU",
+				Groups:     []zabbix.TemplateGroupID{{GroupID: "12"}},
+				Tags:       []zabbix.TemplateTag{{Tag: "class", Value: "os"}},
+			},
+		},
+		hosts: []zabbix.Host{
+			{
+				HostID:          "10084",
+				Host:            "Zabbix server",
+				Name:            "Zabbix server",
+				TLSConnect:      1,
+				TLSAccept:       1,
+				Groups:          []zabbix.HostGroupID{{GroupID: "2"}, {GroupID: "99"}},
+				ParentTemplates: []zabbix.ParentTemplate{{TemplateID: "10001"}},
+				Interfaces: []zabbix.HostInterface{
+					{Type: 1, IP: "127.0.0.1", Port: "10050", Main: 1, UseIP: 1},
+				},
+				Tags: []zabbix.HostTag{{Tag: "role", Value: "server"}, {Tag: "managed"}},
+			},
+			{
+				HostID:         "10085",
+				Host:           "switch01",
+				Status:         1,
+				TLSConnect:     2,
+				TLSAccept:      2,
+				TLSPSKIdentity: "switch01",
+				Groups:         []zabbix.HostGroupID{{GroupID: "3"}},
+				Interfaces: []zabbix.HostInterface{
+					{Type: 2, DNS: "switch01.example.com", Port: "161", Main: 1, Details: &zabbix.SNMPDetails{
+						Version: zabbix.SNMPVersion3, Bulk: 1, SecurityName: "monitor", SecurityLevel: 2, AuthProtocol: 3, PrivProtocol: 1,
+					}},
+				},
+			},
+			{HostID: "10086", Host: "discovered01", Flags: zabbix.HostFlagDiscovered},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	var out bytes.Buffer
+	sum, err := generate(context.Background(), newFakeSource(), allKinds, &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sum.HostGroups != 2 || sum.TemplateGroups != 1 || sum.Templates != 2 || sum.Hosts != 2 || sum.DiscoveredHosts != 1 {
+		t.Errorf("unexpected summary: %+v", sum)
+	}
+
+	config := out.String()
+	if _, diags := hclsyntax.ParseConfig(out.Bytes(), "zabbix.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("generated configuration does not parse: %s\n%s", diags.Error(), config)
+	}
+
+	for _, want := range []string{
+		"import {\n  to = zabbix_host_group.linux_servers\n  id = \"2\"\n}\n\nresource \"zabbix_host_group\" \"linux_servers\" {\n  name = \"Linux servers\"\n}",
+		`resource "zabbix_host_group" "linux_servers_2" {`,
+		`resource "zabbix_template_group" "templates_operating_systems" {`,
+		`groups           = [zabbix_template_group.templates_operating_systems.id]`,
+		`linked_templates = [zabbix_template.linux_cpu.id]`,
+		`groups    = [zabbix_host_group.linux_servers.id, "99"]`,
+		`templates = [zabbix_template.linux_by_zabbix_agent.id]`,
+		`security_level = "authPriv"`,
+		`auth_protocol  = "sha256"`,
+		`dns    = "switch01.example.com"`,
+		`tls_psk_identity = "switch01"`,
+		"# tls_psk_wo is write-only",
+		"# The SNMPv3 passphrases of interface 1 are write-only",
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("expected the configuration to contain %q, got:\n%s", want, config)
+		}
+	}
+
+	for _, unwanted := range []string{`name = "Zabbix server"`, "discovered01", `tls_connect = 1`, `community`} {
+		if strings.Contains(config, unwanted) {
+			t.Errorf("expected the configuration not to contain %q, got:\n%s", unwanted, config)
+		}
+	}
+}
+
+func TestGenerate_Kinds(t *testing.T) {
+	var out bytes.Buffer
+	sum, err := generate(context.Background(), newFakeSource(), []string{kindHosts}, &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sum.HostGroups != 0 || sum.Hosts != 2 {
+		t.Errorf("expected only hosts, got %+v", sum)
+	}
+	config := out.String()
+	if strings.Contains(config, `resource "zabbix_host_group"`) {
+		t.Error("expected no host groups when they are not selected")
+	}
+	if !strings.Contains(config, `groups    = ["2", "99"]`) {
+		t.Errorf("expected groups that are not generated to be referenced by ID, got:\n%s", config)
+	}
+}
+
+func TestGenerate_Error(t *testing.T) {
+	src := newFakeSource()
+	src.err = errors.New("connection refused")
+
+	_, err := generate(context.Background(), src, allKinds, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "could not read host groups") {
+		t.Errorf("expected the error of the source, got %v", err)
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := map[string]string{
+		"Linux servers":               "linux_servers",
+		"Templates/Operating systems": "templates_operating_systems",
+		"  web-01.example.com ":       "web_01_example_com",
+		"10.0.0.1":                    "host_10_0_0_1",
+		"Ünïcode":                     "n_code",
+		"***":                         "host",
+	}
+	for objectName, want := range tests {
+		if got := resourceName("zabbix_host", objectName); got != want {
+			t.Errorf("resourceName(%q) = %q, want %q", objectName, got, want)
+		}
+	}
+}
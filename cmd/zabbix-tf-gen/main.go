@@ -0,0 +1,96 @@
+// ABOUTME: Command zabbix-tf-gen writes Terraform configuration for the objects of an existing Zabbix server.
+// ABOUTME: Emits resource blocks with import blocks so brownfield servers can be adopted in one apply.
+
+// Command zabbix-tf-gen scans a Zabbix server and writes a resource block and an import
+// block for each host group, template group, template and host, so that an existing
+// server can be brought under Terraform without writing the configuration by hand:
+//
+//	ZABBIX_URL=https://zabbix.example.com ZABBIX_API_TOKEN=... zabbix-tf-gen -out zabbix.tf
+//	terraform plan
+//
+// Proxies and macros are not generated, as the provider has no resources for them yet.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "zabbix-tf-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line, generates the configuration and writes it to the output
+// file or to stdout.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("zabbix-tf-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	url := flags.String("url", os.Getenv("ZABBIX_URL"), "URL of the Zabbix frontend or API endpoint, defaults to ZABBIX_URL")
+	token := flags.String("token", os.Getenv("ZABBIX_API_TOKEN"), "API token, defaults to ZABBIX_API_TOKEN")
+	kinds := flags.String("types", strings.Join(allKinds, ","), "comma-separated kinds of objects to generate: "+strings.Join(allKinds, ", "))
+	out := flags.String("out", "", "file to write the configuration to, defaults to stdout")
+	pageSize := flags.Int("page-size", zabbix.DefaultPageSize, "number of objects requested per API call")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of each API request")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *url == "" || *token == "" {
+		return fmt.Errorf("the URL and API token must be set with -url and -token or ZABBIX_URL and ZABBIX_API_TOKEN")
+	}
+
+	selected := strings.Split(*kinds, ",")
+	for _, kind := range selected {
+		if !slices.Contains(allKinds, kind) {
+			return fmt.Errorf("unknown type %q, must be one of %s", kind, strings.Join(allKinds, ", "))
+		}
+	}
+
+	endpoint := strings.TrimSuffix(*url, "/")
+	if !strings.HasSuffix(endpoint, "/api_jsonrpc.php") {
+		endpoint += "/api_jsonrpc.php"
+	}
+	client := zabbix.NewClient(endpoint,
+		zabbix.WithToken(*token),
+		zabbix.WithTimeout(*timeout),
+		zabbix.WithUserAgent("zabbix-tf-gen"),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	w := stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	sum, err := generate(ctx, &clientSource{client: client, pageSize: *pageSize}, selected, w)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stderr, "Generated %d host groups, %d template groups, %d templates and %d hosts.\n",
+		sum.HostGroups, sum.TemplateGroups, sum.Templates, sum.Hosts)
+	if sum.DiscoveredHosts > 0 {
+		fmt.Fprintf(stderr, "Skipped %d hosts created by low-level discovery.\n", sum.DiscoveredHosts)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// ABOUTME: Reads the objects to generate configuration for from a Zabbix server.
+// ABOUTME: Fetches host groups, template groups, templates and hosts in pages with all related objects.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// source provides the Zabbix objects that configuration is generated for.
+type source interface {
+	HostGroups(ctx context.Context) ([]zabbix.HostGroup, error)
+	TemplateGroups(ctx context.Context) ([]zabbix.TemplateGroup, error)
+	Templates(ctx context.Context) ([]zabbix.Template, error)
+	Hosts(ctx context.Context) ([]zabbix.Host, error)
+}
+
+// clientSource reads objects through the Zabbix API client, in pages of pageSize objects.
+type clientSource struct {
+	client   *zabbix.Client
+	pageSize int
+}
+
+func (s *clientSource) HostGroups(ctx context.Context) ([]zabbix.HostGroup, error) {
+	return fetchAll[zabbix.HostGroup](ctx, s, "hostgroup.get", map[string]interface{}{
+		"output":    []string{"groupid", "name", "uuid"},
+		"sortfield": "name",
+	})
+}
+
+func (s *clientSource) TemplateGroups(ctx context.Context) ([]zabbix.TemplateGroup, error) {
+	return fetchAll[zabbix.TemplateGroup](ctx, s, "templategroup.get", map[string]interface{}{
+		"output":    []string{"groupid", "name", "uuid"},
+		"sortfield": "name",
+	})
+}
+
+func (s *clientSource) Templates(ctx context.Context) ([]zabbix.Template, error) {
+	return fetchAll[zabbix.Template](ctx, s, "template.get", zabbix.GetTemplateParams{
+		Output:                "extend",
+		SelectGroups:          "extend",
+		SelectTags:            "extend",
+		SelectParentTemplates: "extend",
+		SortField:             "host",
+	})
+}
+
+func (s *clientSource) Hosts(ctx context.Context) ([]zabbix.Host, error) {
+	return fetchAll[zabbix.Host](ctx, s, "host.get", zabbix.GetHostParams{
+		Output:                "extend",
+		SelectGroups:          "extend",
+		SelectInterfaces:      "extend",
+		SelectTags:            "extend",
+		SelectParentTemplates: "extend",
+		SortField:             "host",
+	})
+}
+
+// fetchAll returns all objects of a get method, requesting them page by page so that large
+// servers do not need a single huge response.
+func fetchAll[T any](ctx context.Context, s *clientSource, method string, params interface{}) ([]T, error) {
+	paginator, err := zabbix.NewPaginator[T](s.client, method, params, s.pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := paginator.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", method, err)
+	}
+	return objects, nil
+}
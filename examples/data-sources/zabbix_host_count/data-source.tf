@@ -0,0 +1,16 @@
+# Count production hosts and the items and triggers on them
+data "zabbix_host_count" "production" {
+  tags = [{
+    tag   = "env"
+    value = "prod"
+  }]
+}
+
+output "production_item_count" {
+  value = data.zabbix_host_count.production.item_count
+}
+
+# Count the hosts in a host group
+data "zabbix_host_count" "linux" {
+  group_ids = [var.linux_group_id]
+}
@@ -0,0 +1,8 @@
+# List every host group below prod/
+data "zabbix_host_groups" "prod" {
+  search = "prod/*"
+}
+
+output "prod_group_ids" {
+  value = data.zabbix_host_groups.prod.groups[*].id
+}
@@ -0,0 +1,27 @@
+# List all production hosts in the Linux servers group
+data "zabbix_host_group" "linux" {
+  name = "Linux servers"
+}
+
+data "zabbix_hosts" "production" {
+  group_ids = [data.zabbix_host_group.linux.id]
+
+  tags = [{
+    tag   = "env"
+    value = "prod"
+  }]
+}
+
+output "production_host_ids" {
+  value = data.zabbix_hosts.production.hosts[*].id
+}
+
+# Find hosts by name pattern, including hosts without a given tag
+data "zabbix_hosts" "untagged_web" {
+  search = "web-*"
+
+  tags = [{
+    tag      = "owner"
+    operator = "not_exists"
+  }]
+}
@@ -0,0 +1,19 @@
+# Look up an item by host technical name and item key
+data "zabbix_item" "cpu_load" {
+  host = "web-01"
+  key  = "system.cpu.load[all,avg1]"
+}
+
+output "cpu_load_item_id" {
+  value = data.zabbix_item.cpu_load.id
+}
+
+# Look up the master item a dependent item takes its values from
+data "zabbix_item" "nginx_active" {
+  host = "web-01"
+  key  = "nginx.connections.active"
+}
+
+output "nginx_master_item_id" {
+  value = data.zabbix_item.nginx_active.master_item_id
+}
@@ -16,3 +16,17 @@ output "template_content" {
   value     = data.zabbix_template.linux.exported_content
   sensitive = true
 }
+
+# Export the template as JSON for downstream tooling
+data "zabbix_template" "linux_json" {
+  host          = "Linux by Zabbix agent"
+  export_format = "json"
+}
+
+# Discover the template published with the class:baseline tag
+data "zabbix_template" "baseline" {
+  tag_filters = [{
+    tag   = "class"
+    value = "baseline"
+  }]
+}
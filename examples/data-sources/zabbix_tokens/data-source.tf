@@ -0,0 +1,9 @@
+# List the API tokens of a user
+data "zabbix_tokens" "admin" {
+  user_ids = ["1"]
+}
+
+# Report tokens that never expire
+output "non_expiring_tokens" {
+  value = [for t in data.zabbix_tokens.admin.tokens : t.name if t.expires_at == 0]
+}
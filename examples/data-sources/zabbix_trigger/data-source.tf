@@ -0,0 +1,9 @@
+# Look up a trigger by host technical name and trigger name
+data "zabbix_trigger" "passwd_changed" {
+  host        = "web-01"
+  description = "Linux: /etc/passwd has been changed"
+}
+
+output "passwd_changed_trigger_id" {
+  value = data.zabbix_trigger.passwd_changed.id
+}
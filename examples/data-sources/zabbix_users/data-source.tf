@@ -0,0 +1,13 @@
+# List all users with the Super admin role
+data "zabbix_users" "super_admins" {
+  role_ids = ["3"]
+}
+
+# List the members of a user group
+data "zabbix_users" "operators" {
+  user_group_ids = [var.operators_group_id]
+}
+
+output "operator_usernames" {
+  value = data.zabbix_users.operators.users[*].username
+}
@@ -0,0 +1,5 @@
+# Compare time values written in different units
+output "same_interval" {
+  # true
+  value = provider::zabbix::normalize_time("3600") == provider::zabbix::normalize_time("60m")
+}
@@ -0,0 +1,9 @@
+locals {
+  check_interval_seconds = 5 * 60
+}
+
+# Compute an interval in seconds and pass it to Zabbix in suffix form
+output "check_interval" {
+  # "5m"
+  value = provider::zabbix::seconds_to_time(local.check_interval_seconds)
+}
@@ -0,0 +1,9 @@
+data "zabbix_trigger" "passwd_changed" {
+  host        = "web-01"
+  description = "Linux: /etc/passwd has been changed"
+}
+
+# Compare severities by name instead of magic numbers
+output "passwd_changed_is_severe" {
+  value = data.zabbix_trigger.passwd_changed.priority >= provider::zabbix::severity("high")
+}
@@ -0,0 +1,10 @@
+data "zabbix_trigger" "passwd_changed" {
+  host        = "web-01"
+  description = "Linux: /etc/passwd has been changed"
+}
+
+# Show the severity of a trigger by name
+output "passwd_changed_severity" {
+  # For example "warning"
+  value = provider::zabbix::severity_name(data.zabbix_trigger.passwd_changed.priority)
+}
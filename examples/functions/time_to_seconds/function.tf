@@ -0,0 +1,10 @@
+variable "history" {
+  type    = string
+  default = "7d"
+}
+
+# Check a retention period before passing it on
+output "history_seconds" {
+  # 604800
+  value = provider::zabbix::time_to_seconds(var.history)
+}
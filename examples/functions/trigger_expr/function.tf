@@ -0,0 +1,16 @@
+# Build the function calls of a trigger expression instead of writing them by hand
+locals {
+  cpu_load  = provider::zabbix::trigger_expr("web-01", "system.cpu.load[all,avg1]", "avg", "5m")
+  free_root = provider::zabbix::trigger_expr("web-01", "vfs.fs.size[/,pfree]", "last")
+  errors    = provider::zabbix::trigger_expr("web-01", "log[/var/log/app.log]", "find", "10m", "regexp", "fail(ed|ure)")
+}
+
+output "high_load_expression" {
+  # avg(/web-01/system.cpu.load[all,avg1],5m)>5 and last(/web-01/vfs.fs.size[/,pfree])<10
+  value = "${local.cpu_load}>5 and ${local.free_root}<10"
+}
+
+output "application_errors_expression" {
+  # find(/web-01/log[/var/log/app.log],10m,regexp,"fail(ed|ure)")=1
+  value = "${local.errors}=1"
+}
@@ -0,0 +1,9 @@
+# Reject malformed trigger expressions at plan time
+variable "trigger_expression" {
+  type    = string
+  default = "last(/web-01/agent.ping)=0"
+}
+
+output "trigger_expression" {
+  value = provider::zabbix::validate_expression(var.trigger_expression)
+}
@@ -0,0 +1,9 @@
+# Reject malformed calculated item formulas at plan time
+variable "disk_used_formula" {
+  type    = string
+  default = "sum(last_foreach(/*/vfs.fs.size[/,used]?[group=\"Databases\"]))"
+}
+
+output "disk_used_formula" {
+  value = provider::zabbix::validate_formula(var.disk_used_formula)
+}
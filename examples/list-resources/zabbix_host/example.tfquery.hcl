@@ -0,0 +1,10 @@
+# List the web servers of a host group, with their full configuration
+list "zabbix_host" "web" {
+  provider         = zabbix
+  include_resource = true
+
+  config {
+    group_ids = ["2"]
+    search    = "web-*"
+  }
+}
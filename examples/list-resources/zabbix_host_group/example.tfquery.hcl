@@ -0,0 +1,8 @@
+# List the host groups under prod/ that are not yet managed by Terraform
+list "zabbix_host_group" "prod" {
+  provider = zabbix
+
+  config {
+    search = "prod/*"
+  }
+}
@@ -7,6 +7,92 @@ terraform {
 }
 
 provider "zabbix" {
-  url       = "https://zabbix.example.com/api_jsonrpc.php"
+  # The frontend URL is enough; /api_jsonrpc.php is appended automatically
+  url       = "https://zabbix.example.com"
   api_token = "your-api-token"
-}
\ No newline at end of file
+
+  # Allow slow template imports to finish
+  request_timeout = "2m"
+
+  # Ride out network errors and gateway errors of reads, waiting up to 2s, 4s and 8s between attempts
+  max_retries     = 3
+  retry_backoff   = "2s"
+  retry_on_status = [502, 503]
+
+  # Send at most 10 requests per second on average, in bursts of up to 20
+  requests_per_second = 10
+  burst               = 20
+
+  # Keep at most 4 requests in flight, whatever the parallelism of Terraform
+  max_concurrent_requests = 4
+
+  # Fail early when the server is not running Zabbix 7.0
+  min_api_version = "7.0"
+  max_api_version = "7.0"
+
+  # Tag every host and template managed by this configuration
+  default_tags = {
+    managed_by = "terraform"
+    owner      = "platform"
+  }
+}
+
+# Connect to a Zabbix server whose certificate is issued by a private CA
+provider "zabbix" {
+  alias        = "internal"
+  url          = "https://zabbix.internal.example.com/api_jsonrpc.php"
+  api_token    = "your-api-token"
+  ca_cert_file = "/etc/ssl/certs/internal-ca.pem"
+}
+
+# Authenticate to an mTLS-terminating proxy in front of Zabbix
+provider "zabbix" {
+  alias           = "mtls"
+  url             = "https://zabbix.example.com/api_jsonrpc.php"
+  api_token       = "your-api-token"
+  client_cert_pem = file("${path.module}/client.crt")
+  client_key_pem  = file("${path.module}/client.key")
+}
+
+# Reach Zabbix through an egress proxy
+provider "zabbix" {
+  alias      = "proxied"
+  url        = "https://zabbix.example.com/api_jsonrpc.php"
+  api_token  = "your-api-token"
+  http_proxy = "http://proxy.example.com:3128"
+}
+
+# Pass HTTP basic authentication required by a reverse proxy in front of the API
+provider "zabbix" {
+  alias              = "basic_auth"
+  url                = "https://zabbix.example.com/api_jsonrpc.php"
+  api_token          = "your-api-token"
+  http_auth_username = "terraform"
+  http_auth_password = var.proxy_password
+}
+
+# Fail over between active/passive frontends without waiting for DNS
+provider "zabbix" {
+  alias         = "ha"
+  url           = "https://zabbix-a.example.com"
+  fallback_urls = ["https://zabbix-b.example.com"]
+  api_token     = "your-api-token"
+}
+
+# Compress large template imports sent to a remote frontend whose web server decompresses
+# request bodies
+provider "zabbix" {
+  alias             = "remote"
+  url               = "https://zabbix.remote.example.com"
+  api_token         = "your-api-token"
+  compress_requests = true
+}
+
+# Reuse connections during applies with high parallelism instead of opening new ones
+provider "zabbix" {
+  alias                = "large"
+  url                  = "https://zabbix.example.com"
+  api_token            = "your-api-token"
+  max_idle_connections = 64
+  max_connections      = 64
+}
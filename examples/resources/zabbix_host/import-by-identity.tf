@@ -0,0 +1,6 @@
+import {
+  to       = zabbix_host.server01
+  identity = {
+    hostid = "10084"
+  }
+}
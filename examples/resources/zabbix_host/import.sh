@@ -0,0 +1,8 @@
+# Import a host by its ID
+terraform import zabbix_host.server01 10084
+
+# Import a host by its technical name
+terraform import zabbix_host.server01 name=server01
+
+# Zabbix does not return tls_psk_identity and the write-only secrets, so add
+# them to configuration generated with terraform plan -generate-config-out
@@ -54,11 +54,13 @@ resource "zabbix_host" "server02" {
 }
 
 # Create a host with linked templates
+# Removed templates are unlinked and their inherited items and triggers cleared
 resource "zabbix_host" "webserver" {
-  host      = "webserver01"
-  name      = "Web Server 01"
-  groups    = [zabbix_host_group.web.id]
-  templates = ["10001"] # Template ID for Linux by Zabbix agent
+  host        = "webserver01"
+  name        = "Web Server 01"
+  groups      = [zabbix_host_group.web.id]
+  templates   = ["10001"] # Template ID for Linux by Zabbix agent
+  unlink_mode = "unlink_and_clear"
 
   interfaces = [{
     type   = "agent"
@@ -70,6 +72,30 @@ resource "zabbix_host" "webserver" {
   }]
 }
 
+# Link built-in templates by technical name instead of ID
+resource "zabbix_host" "appserver" {
+  host           = "appserver01"
+  name           = "App Server 01"
+  groups         = [zabbix_host_group.linux.id]
+  template_names = ["Linux by Zabbix agent"]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.210"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+# Create a host without interfaces, monitored only by active agent checks
+resource "zabbix_host" "active_only" {
+  host   = "active-agent01"
+  name   = "Active Agent 01"
+  groups = [zabbix_host_group.linux.id]
+}
+
 # Create a disabled host
 resource "zabbix_host" "maintenance" {
   host   = "maintenance-server"
@@ -87,6 +113,99 @@ resource "zabbix_host" "maintenance" {
   }]
 }
 
+# Manage only the Linux group membership, leaving groups assigned by
+# discovery rules or other tooling untouched
+resource "zabbix_host" "shared" {
+  host       = "shared-server"
+  groups     = [zabbix_host_group.linux.id]
+  group_mode = "additive"
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.160"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+# Encrypt agent connections with a pre-shared key. The key is write-only
+# and never stored in state, changing it bumps secrets_revision.
+variable "db_psk" {
+  type      = string
+  sensitive = true
+}
+
+resource "zabbix_host" "encrypted" {
+  host             = "db-server"
+  groups           = [zabbix_host_group.linux.id]
+  tls_connect      = 2
+  tls_accept       = 2
+  tls_psk_identity = "db-server-psk"
+  tls_psk_wo       = var.db_psk
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.170"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+# Monitor a switch over SNMPv3. The passphrases are write-only like the PSK.
+variable "snmp_auth_passphrase" {
+  type      = string
+  sensitive = true
+}
+
+variable "snmp_priv_passphrase" {
+  type      = string
+  sensitive = true
+}
+
+resource "zabbix_host" "switch" {
+  host   = "core-switch"
+  groups = [zabbix_host_group.linux.id]
+
+  interfaces = [{
+    type   = "snmp"
+    ip     = "192.168.1.180"
+    dns    = ""
+    port   = "161"
+    main   = true
+    use_ip = true
+    snmp = {
+      version            = 3
+      security_name      = "zabbix"
+      security_level     = "authPriv"
+      auth_protocol      = "sha256"
+      auth_passphrase_wo = var.snmp_auth_passphrase
+      priv_protocol      = "aes256"
+      priv_passphrase_wo = var.snmp_priv_passphrase
+    }
+  }]
+}
+
+# Leave hosts that operators disable temporarily in the frontend disabled,
+# while changes of status in the configuration are still applied
+resource "zabbix_host" "batch" {
+  host                = "batch-worker01"
+  groups              = [zabbix_host_group.linux.id]
+  ignore_status_drift = true
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.190"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
 # Referenced host groups
 resource "zabbix_host_group" "linux" {
   name = "Linux servers"
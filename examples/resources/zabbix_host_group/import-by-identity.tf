@@ -0,0 +1,6 @@
+import {
+  to       = zabbix_host_group.linux
+  identity = {
+    id = "2"
+  }
+}
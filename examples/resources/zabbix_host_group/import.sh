@@ -0,0 +1,5 @@
+# Import a host group by its ID
+terraform import zabbix_host_group.linux 2
+
+# Import a host group by its name
+terraform import zabbix_host_group.linux "name=Linux servers"
@@ -7,3 +7,23 @@ resource "zabbix_host_group" "linux" {
 resource "zabbix_host_group" "web" {
   name = "Web servers"
 }
+
+# Protect a production host group from accidental terraform destroy
+resource "zabbix_host_group" "production" {
+  name                = "Production"
+  deletion_protection = true
+}
+
+# Take over a host group that was created by hand in Zabbix
+resource "zabbix_host_group" "databases" {
+  name           = "Databases"
+  adopt_existing = true
+}
+
+# Remove the hosts from a temporary group when it is destroyed, deleting
+# those that are in no other group
+resource "zabbix_host_group" "migration" {
+  name         = "Migration batch 3"
+  force        = true
+  delete_hosts = true
+}
@@ -0,0 +1,3 @@
+# Import existing hosts by their technical names. The hosts must share groups,
+# templates, status and agent port, and have a single agent interface each.
+terraform import zabbix_hosts_bulk.edge "edge-0001,edge-0002,edge-0003"
@@ -0,0 +1,27 @@
+# Manage a fleet of uniform edge nodes with batched API calls
+resource "zabbix_host_group" "edge" {
+  name = "Edge nodes"
+}
+
+resource "zabbix_hosts_bulk" "edge" {
+  groups = [zabbix_host_group.edge.id]
+
+  hosts = {
+    "edge-0001" = { ip = "10.20.0.1" }
+    "edge-0002" = { ip = "10.20.0.2" }
+    "edge-0003" = { dns = "edge-0003.example.com", name = "Edge node 3" }
+  }
+}
+
+# Generate host entries from an inventory map
+variable "edge_nodes" {
+  type    = map(string)
+  default = {}
+}
+
+resource "zabbix_hosts_bulk" "inventory" {
+  groups = [zabbix_host_group.edge.id]
+  status = 1
+
+  hosts = { for host, ip in var.edge_nodes : host => { ip = ip } }
+}
@@ -0,0 +1,6 @@
+import {
+  to       = zabbix_template.example
+  identity = {
+    id = "10001"
+  }
+}
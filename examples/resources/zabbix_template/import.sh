@@ -0,0 +1,5 @@
+# Import a template by its ID
+terraform import zabbix_template.example 10001
+
+# Import a template by its technical name
+terraform import zabbix_template.example name=my_custom_template
@@ -21,9 +21,64 @@ resource "zabbix_template" "example" {
   ]
 }
 
+# Assemble a composite template from the built-in Linux template and custom additions
+data "zabbix_template" "linux" {
+  host = "Linux by Zabbix agent"
+}
+
+resource "zabbix_template" "linux_custom" {
+  host             = "linux_custom"
+  name             = "Linux by Zabbix agent (custom)"
+  groups           = [zabbix_template_group.custom.id]
+  linked_templates = [data.zabbix_template.linux.id, zabbix_template.example.id]
+}
+
 # Import an official Zabbix template from YAML content
 # The template will be created with its embedded metadata including groups
 resource "zabbix_template" "apache" {
   source_format  = "yaml"
   source_content = file("apache_template.yaml")
+
+  # Large templates can take minutes to import
+  timeouts {
+    create = "10m"
+    update = "10m"
+  }
+}
+
+# Keep an imported template converged with its source: items, triggers and
+# discovery rules removed from the YAML are deleted on the next re-import
+resource "zabbix_template" "nginx" {
+  source_format  = "yaml"
+  source_content = file("nginx_template.yaml")
+  prune          = true
+}
+
+# Import an official Zabbix template directly from the Zabbix repository,
+# pinned to the SHA-256 digest of the reviewed revision
+variable "mysql_template_sha256" {
+  type = string
+}
+
+resource "zabbix_template" "mysql" {
+  source_format   = "yaml"
+  source_url      = "https://raw.githubusercontent.com/zabbix/zabbix/refs/tags/7.0.22/templates/db/mysql_agent2/template_db_mysql_agent2.yaml"
+  source_checksum = var.mysql_template_sha256
+}
+
+# Allow the template to be destroyed while hosts managed elsewhere still use it;
+# it is unlinked from those hosts and their inherited items are removed first
+resource "zabbix_template" "legacy" {
+  host         = "legacy_template"
+  groups       = [zabbix_template_group.custom.id]
+  force_delete = true
+  unlink_mode  = "unlink_and_clear"
+}
+
+# Fail loudly if an import produced an empty template
+check "apache_template_content" {
+  assert {
+    condition     = zabbix_template.apache.items_count > 0
+    error_message = "The Apache template was imported without any items."
+  }
 }
@@ -0,0 +1,6 @@
+import {
+  to       = zabbix_template_group.applications
+  identity = {
+    id = "12"
+  }
+}
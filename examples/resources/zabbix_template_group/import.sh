@@ -0,0 +1,5 @@
+# Import a template group by its ID
+terraform import zabbix_template_group.applications 12
+
+# Import a template group by its name
+terraform import zabbix_template_group.applications "name=Templates/Applications"
@@ -0,0 +1,53 @@
+// ABOUTME: Shared adopt_existing attribute for resources whose objects are identified by name.
+// ABOUTME: Create takes over an object that already exists in Zabbix instead of failing.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+// adoptExistingAttribute returns the schema of the adopt_existing attribute for the named object kind.
+func adoptExistingAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether creating the %s adopts an existing %s of the same name into the state instead of failing. The existing %s is then managed, and deleted, like one created by Terraform. Defaults to false.", kind, kind, kind),
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+}
+
+// adoptExisting looks up the ID of the existing object of the named kind after its creation
+// failed because it already exists. It returns a warning naming the adopted object, or an
+// error when the object cannot be found.
+func adoptExisting(ctx context.Context, kind, name string, lookup func(ctx context.Context, name string) (string, error)) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	id, err := lookup(ctx, name)
+	if err != nil {
+		diags.AddError(
+			"Error Adopting "+titleCase(kind),
+			fmt.Sprintf("Could not look up the existing %s %q: %s", kind, name, err),
+		)
+		return "", diags
+	}
+	if id == "" {
+		diags.AddError(
+			"Error Adopting "+titleCase(kind),
+			fmt.Sprintf("Zabbix reported that the %s %q already exists, but it could not be found. It may be named differently in case or be hidden by the permissions of the API user.", kind, name),
+		)
+		return "", diags
+	}
+
+	diags.AddWarning(
+		"Adopted Existing "+titleCase(kind),
+		fmt.Sprintf("The %s %q already existed with ID %s and is now managed by Terraform. Destroying the resource deletes it from Zabbix.", kind, name, id),
+	)
+
+	return id, diags
+}
@@ -0,0 +1,157 @@
+// ABOUTME: Helpers for the provider-level default_tags merged into hosts and templates.
+// ABOUTME: Merges defaults with resource tags and hides default tags from the configured tags.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProviderData is passed to resources on Configure. Data sources receive the *zabbix.Client only.
+type ProviderData struct {
+	Client      ZabbixAPI
+	DefaultTags map[string]string
+	// Transport carries the proxy and TLS settings of the provider to requests made
+	// outside the Zabbix API, such as downloads of template sources.
+	Transport http.RoundTripper
+}
+
+// tagObjectType is the object type of host and template tags.
+var tagObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"tag":   types.StringType,
+		"value": types.StringType,
+	},
+}
+
+// tagValue is a tag name and value, independent of the host or template tag types.
+type tagValue struct {
+	Tag   string
+	Value string
+}
+
+// tagsAttribute is the tags attribute of a host or a template.
+type tagsAttribute interface {
+	attr.Value
+	ElementsAs(context.Context, interface{}, bool) diag.Diagnostics
+}
+
+// tagValuesFromModel reads the tag name and value of every element of a tags attribute.
+// Null and unknown attributes have no tags.
+func tagValuesFromModel(ctx context.Context, tags tagsAttribute) ([]tagValue, diag.Diagnostics) {
+	if tags.IsNull() || tags.IsUnknown() {
+		return nil, nil
+	}
+
+	// Host and template tags have the same attributes
+	var models []HostTagModel
+	diags := tags.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	values := make([]tagValue, len(models))
+	for i, m := range models {
+		values[i] = tagValue{Tag: m.Tag.ValueString(), Value: m.Value.ValueString()}
+	}
+
+	return values, diags
+}
+
+// mergeDefaultTags returns the resource tags followed by the default tags whose name is not
+// used by a resource tag, so a resource overrides a default by setting a tag of the same name.
+func mergeDefaultTags(defaults map[string]string, tags []tagValue) []tagValue {
+	names := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		names[t.Tag] = true
+	}
+
+	merged := append([]tagValue(nil), tags...)
+	for _, name := range slices.Sorted(maps.Keys(defaults)) {
+		if !names[name] {
+			merged = append(merged, tagValue{Tag: name, Value: defaults[name]})
+		}
+	}
+
+	return merged
+}
+
+// withoutDefaultTags drops the tags that were added from the default tags, so they do not
+// show up as a difference in the configured tags. Tags whose name is configured are kept.
+func withoutDefaultTags(defaults map[string]string, tags []tagValue, configured []tagValue) []tagValue {
+	names := make(map[string]bool, len(configured))
+	for _, t := range configured {
+		names[t.Tag] = true
+	}
+
+	var kept []tagValue
+	for _, t := range tags {
+		if value, ok := defaults[t.Tag]; ok && value == t.Value && !names[t.Tag] {
+			continue
+		}
+		kept = append(kept, t)
+	}
+
+	return kept
+}
+
+// tagObjects converts tags to tag objects of tagObjectType.
+func tagObjects(tags []tagValue) ([]attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, len(tags))
+	for i, t := range tags {
+		obj, d := types.ObjectValue(tagObjectType.AttrTypes, map[string]attr.Value{
+			"tag":   types.StringValue(t.Tag),
+			"value": types.StringValue(t.Value),
+		})
+		diags.Append(d...)
+		values[i] = obj
+	}
+
+	return values, diags
+}
+
+// tagsAllValue converts all tags of a host or template to the tags_all set.
+func tagsAllValue(tags []tagValue) (types.Set, diag.Diagnostics) {
+	values, diags := tagObjects(tags)
+	set, d := types.SetValue(tagObjectType, values)
+	diags.Append(d...)
+	return set, diags
+}
+
+// plannedTagsAll returns tags_all for the planned tags, which is the tags merged with the
+// default tags. It is unknown until every planned tag is known.
+func plannedTagsAll(ctx context.Context, tags tagsAttribute, defaults map[string]string) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tfValue, err := tags.ToTerraformValue(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Planning Tags",
+			fmt.Sprintf("Could not read the planned tags: %s", err),
+		)
+		return types.SetUnknown(tagObjectType), diags
+	}
+	if !tfValue.IsFullyKnown() {
+		return types.SetUnknown(tagObjectType), diags
+	}
+
+	configured, d := tagValuesFromModel(ctx, tags)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.SetUnknown(tagObjectType), diags
+	}
+
+	tagsAll, d := tagsAllValue(mergeDefaultTags(defaults, configured))
+	diags.Append(d...)
+	return tagsAll, diags
+}
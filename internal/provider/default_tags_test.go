@@ -0,0 +1,78 @@
+// ABOUTME: Unit tests for merging the provider default tags into host and template tags.
+// ABOUTME: Covers overriding defaults and hiding default tags from the configured tags.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	defaults := map[string]string{"owner": "platform", "environment": "production"}
+
+	tests := map[string]struct {
+		tags     []tagValue
+		expected []tagValue
+	}{
+		"no resource tags": {
+			expected: []tagValue{{"environment", "production"}, {"owner", "platform"}},
+		},
+		"additional tag": {
+			tags:     []tagValue{{"service", "web"}},
+			expected: []tagValue{{"service", "web"}, {"environment", "production"}, {"owner", "platform"}},
+		},
+		"resource tag overrides default": {
+			tags:     []tagValue{{"environment", "staging"}},
+			expected: []tagValue{{"environment", "staging"}, {"owner", "platform"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			merged := mergeDefaultTags(defaults, tc.tags)
+			if !reflect.DeepEqual(merged, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, merged)
+			}
+		})
+	}
+}
+
+func TestMergeDefaultTags_NoDefaults(t *testing.T) {
+	if merged := mergeDefaultTags(nil, nil); len(merged) != 0 {
+		t.Errorf("expected no tags, got %v", merged)
+	}
+}
+
+func TestWithoutDefaultTags(t *testing.T) {
+	defaults := map[string]string{"owner": "platform"}
+
+	tests := map[string]struct {
+		tags       []tagValue
+		configured []tagValue
+		expected   []tagValue
+	}{
+		"default tag hidden": {
+			tags:     []tagValue{{"service", "web"}, {"owner", "platform"}},
+			expected: []tagValue{{"service", "web"}},
+		},
+		"configured default tag kept": {
+			tags:       []tagValue{{"owner", "platform"}},
+			configured: []tagValue{{"owner", "platform"}},
+			expected:   []tagValue{{"owner", "platform"}},
+		},
+		"changed default tag kept": {
+			tags:     []tagValue{{"owner", "sre"}},
+			expected: []tagValue{{"owner", "sre"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tags := withoutDefaultTags(defaults, tc.tags, tc.configured)
+			if !reflect.DeepEqual(tags, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, tags)
+			}
+		})
+	}
+}
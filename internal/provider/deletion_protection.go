@@ -0,0 +1,37 @@
+// ABOUTME: Shared deletion_protection attribute for resources managing long-lived monitoring objects.
+// ABOUTME: Delete refuses to remove an object while its deletion protection is enabled.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the schema of the deletion_protection attribute for the named object kind.
+func deletionProtectionAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether Terraform refuses to delete the %s. Set to false and apply before destroying or replacing the %s. Defaults to false.", kind, kind),
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+}
+
+// checkDeletionProtection returns an error diagnostic when deletion protection is enabled.
+func checkDeletionProtection(protected types.Bool, kind, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if protected.ValueBool() {
+		diags.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("The %s with ID %s has deletion_protection enabled. Set deletion_protection = false and apply before deleting it.", kind, id),
+		)
+	}
+
+	return diags
+}
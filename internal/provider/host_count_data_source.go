@@ -0,0 +1,137 @@
+// ABOUTME: Terraform data source for counting Zabbix hosts and the items and triggers on them.
+// ABOUTME: Uses countOutput so that no full objects are transferred, for capacity and licensing checks.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &HostCountDataSource{}
+
+// HostCountDataSource defines the data source implementation.
+type HostCountDataSource struct {
+	client *zabbix.Client
+}
+
+// HostCountDataSourceModel describes the data source data model.
+type HostCountDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	GroupIDs     types.Set    `tfsdk:"group_ids"`
+	Tags         types.List   `tfsdk:"tags"`
+	HostCount    types.Int64  `tfsdk:"host_count"`
+	ItemCount    types.Int64  `tfsdk:"item_count"`
+	TriggerCount types.Int64  `tfsdk:"trigger_count"`
+}
+
+// NewHostCountDataSource creates a new data source instance.
+func NewHostCountDataSource() datasource.DataSource {
+	return &HostCountDataSource{}
+}
+
+func (d *HostCountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_count"
+}
+
+func (d *HostCountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to count the Zabbix hosts matching all of the given filters, and the items and triggers on them, without retrieving the objects themselves. Without filters, every host is counted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the result, derived from the filters.",
+				Computed:    true,
+			},
+			"group_ids": schema.SetAttribute{
+				Description: "Only count hosts that belong to any of these host group IDs.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tags": tagFilterSchema("Only count hosts matching all of these host tag filters."),
+			"host_count": schema.Int64Attribute{
+				Description: "Number of matching hosts.",
+				Computed:    true,
+			},
+			"item_count": schema.Int64Attribute{
+				Description: "Number of items on the matching hosts, including discovered items.",
+				Computed:    true,
+			},
+			"trigger_count": schema.Int64Attribute{
+				Description: "Number of triggers on the matching hosts, including discovered triggers.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *HostCountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostCountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostCountDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filter zabbix.ObjectCountFilter
+	if !data.GroupIDs.IsNull() {
+		resp.Diagnostics.Append(data.GroupIDs.ElementsAs(ctx, &filter.GroupIDs, false)...)
+	}
+	tags, diags := tagFiltersFromModel(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	filter.Tags = tags
+
+	counts, err := d.client.CountObjects(ctx, filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Counting Hosts",
+			fmt.Sprintf("Could not count hosts: %s", err),
+		)
+		return
+	}
+
+	data.HostCount = types.Int64Value(int64(counts.Hosts))
+	data.ItemCount = types.Int64Value(int64(counts.Items))
+	data.TriggerCount = types.Int64Value(int64(counts.Triggers))
+	data.ID = types.StringValue(hostCountID(filter))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hostCountID derives a stable identifier from the filters, as the result contains no IDs.
+func hostCountID(filter zabbix.ObjectCountFilter) string {
+	groupIDs := append([]string(nil), filter.GroupIDs...)
+	sort.Strings(groupIDs)
+
+	parts := []string{strings.Join(groupIDs, ",")}
+	for _, tag := range filter.Tags {
+		parts = append(parts, fmt.Sprintf("%s/%d/%s", tag.Tag, tag.Operator, tag.Value))
+	}
+	return listDataSourceID(parts)
+}
@@ -0,0 +1,104 @@
+// ABOUTME: Acceptance tests for the zabbix_host_count data source.
+// ABOUTME: Tests counting hosts, items and triggers by host group and host tag.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostCountDataSource_filters(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	positive := regexp.MustCompile(`^[1-9][0-9]*$`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostCountDataSourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_host_count.group", "host_count", "2"),
+					resource.TestMatchResourceAttr("data.zabbix_host_count.group", "item_count", positive),
+					resource.TestMatchResourceAttr("data.zabbix_host_count.group", "trigger_count", positive),
+					resource.TestCheckResourceAttr("data.zabbix_host_count.tagged", "host_count", "1"),
+					resource.TestMatchResourceAttr("data.zabbix_host_count.tagged", "item_count", positive),
+					resource.TestCheckResourceAttr("data.zabbix_host_count.none", "host_count", "0"),
+					resource.TestCheckResourceAttr("data.zabbix_host_count.none", "item_count", "0"),
+					resource.TestCheckResourceAttr("data.zabbix_host_count.none", "trigger_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHostCountDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "tagged" {
+  host           = "%[1]s-tagged"
+  groups         = [zabbix_host_group.test.id]
+  template_names = ["Linux by Zabbix agent"]
+
+  tags = [{
+    tag   = %[1]q
+    value = "yes"
+  }]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+resource "zabbix_host" "untagged" {
+  host   = "%[1]s-untagged"
+  groups = [zabbix_host_group.test.id]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.101"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+data "zabbix_host_count" "group" {
+  group_ids = [zabbix_host_group.test.id]
+
+  depends_on = [zabbix_host.tagged, zabbix_host.untagged]
+}
+
+data "zabbix_host_count" "tagged" {
+  tags = [{
+    tag   = %[1]q
+    value = "yes"
+  }]
+
+  depends_on = [zabbix_host.tagged, zabbix_host.untagged]
+}
+
+data "zabbix_host_count" "none" {
+  tags = [{
+    tag   = %[1]q
+    value = "no"
+  }]
+
+  depends_on = [zabbix_host.tagged, zabbix_host.untagged]
+}
+`, name)
+}
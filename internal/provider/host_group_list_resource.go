@@ -0,0 +1,87 @@
+// ABOUTME: List resource enumerating Zabbix host groups for terraform query.
+// ABOUTME: Implemented by the host group resource, so listed groups have the state an import gives.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &HostGroupResource{}
+	_ list.ListResourceWithConfigure = &HostGroupResource{}
+)
+
+// HostGroupListModel describes the list block of the host group list resource.
+type HostGroupListModel struct {
+	Search types.String `tfsdk:"search"`
+}
+
+// NewHostGroupListResource creates a new list resource instance.
+func NewHostGroupListResource() list.ListResource {
+	return &HostGroupResource{}
+}
+
+func (r *HostGroupResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists Zabbix host groups, for example to generate configuration for groups not yet managed by Terraform.",
+		Attributes: map[string]listschema.Attribute{
+			"search": listschema.StringAttribute{
+				Description: "Case-insensitive pattern the whole host group name must match. Use * as a wildcard, for example prod/* for every group starting with prod/. When omitted, all host groups are listed.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *HostGroupResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config HostGroupListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	groups, err := r.client.SearchHostGroups(ctx, config.Search.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Listing Host Groups",
+			fmt.Sprintf("Could not list host groups matching %q: %s", config.Search.ValueString(), err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	groups = groups[:listLimit(len(groups), req.Limit)]
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i := range groups {
+			group := &groups[i]
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = group.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, idNameIdentityModel{
+				ID:   types.StringValue(group.GroupID),
+				Name: types.StringValue(group.Name),
+			})...)
+
+			if req.IncludeResource {
+				var data HostGroupResourceModel
+				result.Diagnostics.Append(listResultModel(ctx, result.Resource, group.GroupID, &data)...)
+				if !result.Diagnostics.HasError() {
+					r.apiToModel(group, &data)
+					result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -5,11 +5,14 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,20 +20,27 @@ import (
 )
 
 var (
-	_ resource.Resource                = &HostGroupResource{}
-	_ resource.ResourceWithImportState = &HostGroupResource{}
+	_ resource.Resource                 = &HostGroupResource{}
+	_ resource.ResourceWithIdentity     = &HostGroupResource{}
+	_ resource.ResourceWithImportState  = &HostGroupResource{}
+	_ resource.ResourceWithUpgradeState = &HostGroupResource{}
 )
 
 // HostGroupResource defines the resource implementation.
 type HostGroupResource struct {
-	client *zabbix.Client
+	client ZabbixAPI
 }
 
 // HostGroupResourceModel describes the resource data model.
 type HostGroupResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
-	UUID types.String `tfsdk:"uuid"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	UUID               types.String `tfsdk:"uuid"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	Force              types.Bool   `tfsdk:"force"`
+	DeleteHosts        types.Bool   `tfsdk:"delete_hosts"`
+	Timeouts           types.Object `tfsdk:"timeouts"`
 }
 
 // NewHostGroupResource creates a new resource instance.
@@ -40,11 +50,18 @@ func NewHostGroupResource() resource.Resource {
 
 func (r *HostGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_host_group"
+	// The identity includes the name, which changes when the object is renamed
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *HostGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idNameIdentitySchema("host group", "The name of the host group.")
 }
 
 func (r *HostGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Zabbix host group.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the host group (groupid in Zabbix).",
@@ -64,6 +81,23 @@ func (r *HostGroupResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("host group"),
+			"adopt_existing":      adoptExistingAttribute("host group"),
+			"force": schema.BoolAttribute{
+				Description: "Whether the hosts in the host group are removed from it before it is deleted, as Zabbix refuses to delete a group that is the only group of a host. Hosts that belong to other groups as well keep those groups. Deletion still fails for hosts in no other group unless delete_hosts is set. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"delete_hosts": schema.BoolAttribute{
+				Description: "Whether force deletes the hosts that belong to no other group along with the host group. Destructive: the hosts are deleted in Zabbix with their items, triggers and history, including hosts managed by other Terraform resources. Only used when force is set. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
@@ -73,16 +107,16 @@ func (r *HostGroupResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*zabbix.Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
 }
 
 func (r *HostGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -93,11 +127,24 @@ func (r *HostGroupResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	groupID, err := r.client.CreateHostGroup(ctx, data.Name.ValueString())
-	if err != nil {
+	if errors.Is(err, zabbix.ErrAlreadyExists) && data.AdoptExisting.ValueBool() {
+		groupID, diags = adoptExisting(ctx, "host group", data.Name.ValueString(), r.client.HostGroupIDByName)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Host Group",
-			fmt.Sprintf("Could not create host group: %s", err),
+			fmt.Sprintf("Could not create host group: %s%s", err, alreadyExistsHint(err, "host group", true)),
 		)
 		return
 	}
@@ -108,6 +155,16 @@ func (r *HostGroupResource) Create(ctx context.Context, req resource.CreateReque
 			"Error Reading Host Group",
 			fmt.Sprintf("Could not read host group after creation: %s", err),
 		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, groupID)...)
+		return
+	}
+
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Host Group",
+			fmt.Sprintf("Host group %s was created but could not be found", groupID),
+		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, groupID)...)
 		return
 	}
 
@@ -116,6 +173,7 @@ func (r *HostGroupResource) Create(ctx context.Context, req resource.CreateReque
 	data.UUID = types.StringValue(group.UUID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 }
 
 func (r *HostGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -126,8 +184,15 @@ func (r *HostGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	group, err := r.client.GetHostGroup(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Reading Host Group",
 			fmt.Sprintf("Could not read host group ID %s: %s", data.ID.ValueString(), err),
@@ -136,15 +201,15 @@ func (r *HostGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	if group == nil {
+		resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	data.ID = types.StringValue(group.GroupID)
-	data.Name = types.StringValue(group.Name)
-	data.UUID = types.StringValue(group.UUID)
+	r.apiToModel(group, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 }
 
 func (r *HostGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -155,6 +220,13 @@ func (r *HostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state HostGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -184,6 +256,7 @@ func (r *HostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 	data.UUID = types.StringValue(group.UUID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 }
 
 func (r *HostGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -194,8 +267,28 @@ func (r *HostGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "host group", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Force.ValueBool() {
+		resp.Diagnostics.Append(r.releaseHosts(ctx, data.ID.ValueString(), data.DeleteHosts.ValueBool())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// A host group deleted outside of Terraform is already gone
 	err := r.client.DeleteHostGroup(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Host Group",
 			fmt.Sprintf("Could not delete host group ID %s: %s", data.ID.ValueString(), err),
@@ -204,6 +297,109 @@ func (r *HostGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 }
 
+// releaseHosts removes the hosts from the host group so it can be deleted. Hosts in no
+// other group are deleted when deleteHosts is set; otherwise nothing is changed, as the
+// group could not be deleted anyway.
+func (r *HostGroupResource) releaseHosts(ctx context.Context, groupID string, deleteHosts bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	hosts, err := r.client.GetHostsByGroup(ctx, groupID)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Group Hosts",
+			fmt.Sprintf("Could not read hosts in host group ID %s: %s", groupID, err),
+		)
+		return diags
+	}
+
+	var shared, exclusive []zabbix.Host
+	for _, host := range hosts {
+		if len(host.Groups) > 1 {
+			shared = append(shared, host)
+		} else {
+			exclusive = append(exclusive, host)
+		}
+	}
+
+	if len(exclusive) > 0 && !deleteHosts {
+		diags.AddError(
+			"Hosts Only in Host Group",
+			fmt.Sprintf("Host group ID %s is the only group of %d host(s): %s. Add them to another group, or set delete_hosts = true to delete them along with the host group.", groupID, len(exclusive), strings.Join(sortedHostNames(exclusive), ", ")),
+		)
+		return diags
+	}
+
+	if len(shared) > 0 {
+		if err := r.client.MassRemoveHostGroups(ctx, hostIDs(shared), []string{groupID}); err != nil {
+			diags.AddError(
+				"Error Removing Hosts",
+				fmt.Sprintf("Could not remove hosts from host group ID %s: %s", groupID, err),
+			)
+			return diags
+		}
+		diags.AddWarning(
+			"Hosts Removed From Host Group",
+			fmt.Sprintf("%d host(s) were removed from host group ID %s before deletion because force is set: %s", len(shared), groupID, strings.Join(sortedHostNames(shared), ", ")),
+		)
+	}
+
+	if len(exclusive) > 0 {
+		if err := r.client.DeleteHosts(ctx, hostIDs(exclusive)); err != nil {
+			diags.AddError(
+				"Error Deleting Hosts",
+				fmt.Sprintf("Could not delete the hosts of host group ID %s: %s", groupID, err),
+			)
+			return diags
+		}
+		diags.AddWarning(
+			"Hosts Deleted",
+			fmt.Sprintf("%d host(s) in no other group were deleted with host group ID %s because delete_hosts is set: %s", len(exclusive), groupID, strings.Join(sortedHostNames(exclusive), ", ")),
+		)
+	}
+
+	return diags
+}
+
+// hostIDs returns the IDs of the hosts.
+func hostIDs(hosts []zabbix.Host) []string {
+	ids := make([]string, len(hosts))
+	for i, host := range hosts {
+		ids[i] = host.HostID
+	}
+	return ids
+}
+
 func (r *HostGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "host group", "id", r.client.HostGroupIDByName)
+}
+
+// apiToModel converts a host group read from Zabbix to the Terraform model, defaulting the
+// attributes that only exist in Terraform as after an import.
+func (r *HostGroupResource) apiToModel(group *zabbix.HostGroup, data *HostGroupResourceModel) {
+	data.ID = types.StringValue(group.GroupID)
+	data.Name = types.StringValue(group.Name)
+	data.UUID = types.StringValue(group.UUID)
+
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
+	if data.Force.IsNull() {
+		data.Force = types.BoolValue(false)
+	}
+
+	if data.DeleteHosts.IsNull() {
+		data.DeleteHosts = types.BoolValue(false)
+	}
+}
+
+// UpgradeState has no upgraders while the schema is at its first version. Changes of the
+// schema that alter the state must increase the version and add an upgrader from the
+// previous one here.
+func (r *HostGroupResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
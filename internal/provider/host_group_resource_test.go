@@ -1,14 +1,22 @@
-// ABOUTME: Acceptance tests for the zabbix_host_group resource.
-// ABOUTME: Tests full CRUD lifecycle and import functionality.
+// ABOUTME: Acceptance and unit tests for the zabbix_host_group resource.
+// ABOUTME: Tests full CRUD lifecycle and import functionality, and CRUD logic against a fake client.
 
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 func TestAccHostGroupResource_basic(t *testing.T) {
@@ -59,6 +67,35 @@ func TestAccHostGroupResource_update(t *testing.T) {
 	})
 }
 
+func TestAccHostGroupResource_deletionProtection(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostGroupResourceConfigProtected(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host_group.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccHostGroupResourceConfigProtected(rName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion Protection Enabled"),
+			},
+			{
+				// Disabling protection allows the final destroy to succeed
+				Config: testAccHostGroupResourceConfigProtected(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host_group.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccHostGroupResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "zabbix_host_group" "test" {
@@ -66,3 +103,375 @@ resource "zabbix_host_group" "test" {
 }
 `, name)
 }
+
+func testAccHostGroupResourceConfigProtected(name string, protected bool) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name                = %q
+  deletion_protection = %t
+}
+`, name, protected)
+}
+
+func TestHostGroupResource_CRUD(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	// Create
+	createResp := &fwresource.CreateResponse{State: emptyState(t, schemaResp)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: testPlan(t, schemaResp, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+			"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		}),
+	}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create error: %s", createResp.Diagnostics.Errors())
+	}
+
+	var created HostGroupResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.ValueString() != "101" || created.UUID.ValueString() != "uuid-101" {
+		t.Errorf("expected ID 101 and UUID uuid-101, got %s and %s", created.ID, created.UUID)
+	}
+
+	// Update
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{
+		State: createResp.State,
+		Plan: testPlan(t, schemaResp, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "101"),
+			"name":                tftypes.NewValue(tftypes.String, "Linux hosts"),
+			"uuid":                tftypes.NewValue(tftypes.String, "uuid-101"),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		}),
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update error: %s", updateResp.Diagnostics.Errors())
+	}
+	if name := client.hostGroups["101"].Name; name != "Linux hosts" {
+		t.Errorf("expected the host group to be renamed, got %s", name)
+	}
+
+	// Delete
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete error: %s", deleteResp.Diagnostics.Errors())
+	}
+	if len(client.hostGroups) != 0 {
+		t.Errorf("expected the host group to be deleted, got %v", client.hostGroups)
+	}
+
+	expected := []string{"CreateHostGroup", "GetHostGroup", "UpdateHostGroup", "GetHostGroup", "DeleteHostGroup"}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+}
+
+func TestHostGroupResource_ReadRemoved(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "42"),
+		"name":                tftypes.NewValue(tftypes.String, "Deleted outside Terraform"),
+		"uuid":                tftypes.NewValue(tftypes.String, "uuid-42"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+	})}
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %s", readResp.Diagnostics.Errors())
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected the host group to be removed from state")
+	}
+}
+
+func TestHostGroupResource_Identity(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostGroupResource(), client)
+	values := map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+		"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"adopt_existing":      tftypes.NewValue(tftypes.Bool, false),
+	}
+	h.mustSucceed("create", h.create(values))
+
+	var identity idNameIdentityModel
+	h.identityModel(&identity)
+	if identity.ID.ValueString() != "101" || identity.Name.ValueString() != "Linux servers" {
+		t.Errorf("expected the identity of host group 101 named Linux servers, got %+v", identity)
+	}
+
+	// The identity follows renames
+	values["id"] = tftypes.NewValue(tftypes.String, "101")
+	values["name"] = tftypes.NewValue(tftypes.String, "Linux hosts")
+	values["uuid"] = tftypes.NewValue(tftypes.String, "uuid-101")
+	h.mustSucceed("update", h.update(values))
+
+	h.identityModel(&identity)
+	if identity.ID.ValueString() != "101" || identity.Name.ValueString() != "Linux hosts" {
+		t.Errorf("expected the identity to carry the new name, got %+v", identity)
+	}
+}
+
+func TestHostGroupResource_DeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "42"),
+		"name":                tftypes.NewValue(tftypes.String, "Protected"),
+		"uuid":                tftypes.NewValue(tftypes.String, "uuid-42"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, true),
+	})}
+
+	deleteResp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, deleteResp)
+
+	if !deleteResp.Diagnostics.HasError() {
+		t.Fatal("expected deletion protection error")
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", client.calls)
+	}
+}
+
+// notFoundError is the error of Zabbix for objects that do not exist.
+var notFoundError = &zabbix.APIError{
+	Method: "hostgroup.delete",
+	Err:    &zabbix.Error{Code: -32602, Message: "Invalid params.", Data: "No permissions to referred object or it does not exist!"},
+}
+
+func TestHostGroupResource_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.errs["GetHostGroup"] = notFoundError
+	client.errs["DeleteHostGroup"] = notFoundError
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "42"),
+		"name":                tftypes.NewValue(tftypes.String, "Deleted outside Terraform"),
+		"uuid":                tftypes.NewValue(tftypes.String, "uuid-42"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+	})}
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %s", readResp.Diagnostics.Errors())
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected the host group to be removed from state")
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("expected deleting a missing host group to succeed, got %s", deleteResp.Diagnostics.Errors())
+	}
+}
+
+func TestHostGroupResource_CreatedButNotFound(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostGroupResource(), lostHostGroupAPI{client})
+
+	diags := h.create(map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+		"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"adopt_existing":      tftypes.NewValue(tftypes.Bool, false),
+	})
+	h.mustFail("create", diags, "Error Reading Host Group")
+
+	var data HostGroupResourceModel
+	h.model(&data)
+	if data.ID.ValueString() != "101" || !data.Name.IsNull() {
+		t.Errorf("expected only the ID of the created host group in state, got %+v", data)
+	}
+}
+
+// lostHostGroupAPI is a fake whose host groups cannot be read back after they are written.
+type lostHostGroupAPI struct {
+	*fakeZabbixAPI
+}
+
+func (f lostHostGroupAPI) GetHostGroup(ctx context.Context, groupID string) (*zabbix.HostGroup, error) {
+	return nil, f.call(ctx, "GetHostGroup")
+}
+
+func TestHostGroupResource_CreateAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.errs["CreateHostGroup"] = &zabbix.APIError{
+		Method: "hostgroup.create",
+		Err:    &zabbix.Error{Code: -32602, Message: "Invalid params.", Data: `Host group "Linux servers" already exists.`},
+	}
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, schemaResp)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: testPlan(t, schemaResp, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+			"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		}),
+	}, createResp)
+
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("expected create error")
+	}
+	if detail := createResp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "import it or set adopt_existing") {
+		t.Errorf("expected the error to suggest importing or adopting the host group, got %q", detail)
+	}
+}
+
+func TestHostGroupResource_AdoptExisting(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.hostGroups["42"] = &zabbix.HostGroup{GroupID: "42", Name: "Linux servers", UUID: "uuid-42"}
+	client.errs["CreateHostGroup"] = &zabbix.APIError{
+		Method: "hostgroup.create",
+		Err:    &zabbix.Error{Code: -32602, Message: "Invalid params.", Data: `Host group "Linux servers" already exists.`},
+	}
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, schemaResp)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: testPlan(t, schemaResp, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+			"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"adopt_existing":      tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create error: %s", createResp.Diagnostics.Errors())
+	}
+	if warnings := createResp.Diagnostics.Warnings(); len(warnings) != 1 || warnings[0].Summary() != "Adopted Existing Host Group" {
+		t.Errorf("expected a warning about the adopted host group, got %v", warnings)
+	}
+
+	var created HostGroupResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.ID.ValueString() != "42" || created.UUID.ValueString() != "uuid-42" {
+		t.Errorf("expected the existing host group 42 to be adopted, got %s", created.ID)
+	}
+
+	expected := []string{"CreateHostGroup", "HostGroupIDByName", "GetHostGroup"}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+}
+
+func TestHostGroupResource_AdoptExistingNotFound(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.errs["CreateHostGroup"] = &zabbix.APIError{
+		Method: "hostgroup.create",
+		Err:    &zabbix.Error{Code: -32602, Message: "Invalid params.", Data: `Host group "Linux servers" already exists.`},
+	}
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, schemaResp)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: testPlan(t, schemaResp, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+			"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"adopt_existing":      tftypes.NewValue(tftypes.Bool, true),
+		}),
+	}, createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("expected an error when the existing host group cannot be found")
+	}
+	if summary := createResp.Diagnostics.Errors()[0].Summary(); summary != "Error Adopting Host Group" {
+		t.Errorf("expected an adoption error, got %q", summary)
+	}
+}
+
+func TestHostGroupResource_Force(t *testing.T) {
+	groupValues := func(force, deleteHosts bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name":                tftypes.NewValue(tftypes.String, "Retired servers"),
+			"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"adopt_existing":      tftypes.NewValue(tftypes.Bool, false),
+			"force":               tftypes.NewValue(tftypes.Bool, force),
+			"delete_hosts":        tftypes.NewValue(tftypes.Bool, deleteHosts),
+		}
+	}
+	// setup creates the group 101 with a host that is also in group 2 and one that is only in the group
+	setup := func(t *testing.T, force, deleteHosts bool) (*fakeZabbixAPI, *resourceHarness) {
+		client := newFakeZabbixAPI()
+		h := newResourceHarness(t, NewHostGroupResource(), client)
+		h.mustSucceed("create", h.create(groupValues(force, deleteHosts)))
+		client.hosts["201"] = &zabbix.Host{HostID: "201", Host: "web01", Groups: []zabbix.HostGroupID{{GroupID: "2"}, {GroupID: "101"}}}
+		client.hosts["202"] = &zabbix.Host{HostID: "202", Host: "db01", Groups: []zabbix.HostGroupID{{GroupID: "101"}}}
+		client.calls = nil
+		return client, h
+	}
+
+	t.Run("without force", func(t *testing.T) {
+		client, h := setup(t, false, false)
+		h.mustFail("delete", h.delete(), "Error Deleting Host Group")
+		if expected := []string{"DeleteHostGroup"}; !reflect.DeepEqual(client.calls, expected) {
+			t.Errorf("expected the hosts not to be touched, got calls %v", client.calls)
+		}
+	})
+
+	t.Run("hosts only in the group", func(t *testing.T) {
+		client, h := setup(t, true, false)
+		diags := h.delete()
+		h.mustFail("delete", diags, "Hosts Only in Host Group")
+		if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "1 host(s): db01") || !strings.Contains(detail, "delete_hosts") {
+			t.Errorf("expected the error to name the host and suggest delete_hosts, got %q", detail)
+		}
+		if expected := []string{"GetHostsByGroup"}; !reflect.DeepEqual(client.calls, expected) {
+			t.Errorf("expected nothing to be changed, got calls %v", client.calls)
+		}
+	})
+
+	t.Run("delete hosts", func(t *testing.T) {
+		client, h := setup(t, true, true)
+		diags := h.delete()
+		h.mustSucceed("delete", diags)
+
+		if len(diags.Warnings()) != 2 {
+			t.Errorf("expected warnings about the removed and deleted hosts, got %v", diags)
+		}
+		if web01 := client.hosts["201"]; web01 == nil || !reflect.DeepEqual(web01.Groups, []zabbix.HostGroupID{{GroupID: "2"}}) {
+			t.Errorf("expected web01 to keep only its other group, got %+v", web01)
+		}
+		if _, ok := client.hosts["202"]; ok {
+			t.Error("expected db01 to be deleted")
+		}
+		if _, ok := client.hostGroups["101"]; ok {
+			t.Error("expected the host group to be deleted")
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// ABOUTME: Terraform data source for listing Zabbix host groups by name pattern.
+// ABOUTME: Supports * wildcards so configurations can cover families of groups such as prod/*.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &HostGroupsDataSource{}
+
+// HostGroupsDataSource defines the data source implementation.
+type HostGroupsDataSource struct {
+	client *zabbix.Client
+}
+
+// HostGroupsDataSourceModel describes the data source data model.
+type HostGroupsDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Search types.String `tfsdk:"search"`
+	Groups types.List   `tfsdk:"groups"`
+}
+
+// NewHostGroupsDataSource creates a new data source instance.
+func NewHostGroupsDataSource() datasource.DataSource {
+	return &HostGroupsDataSource{}
+}
+
+func (d *HostGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_groups"
+}
+
+func (d *HostGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the Zabbix host groups whose name matches a pattern.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the result, derived from the IDs of the matching host groups.",
+				Computed:    true,
+			},
+			"search": schema.StringAttribute{
+				Description: "Case-insensitive pattern the whole host group name must match. Use * as a wildcard, for example prod/* for every group starting with prod/. When omitted, all host groups are returned.",
+				Optional:    true,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "Matching host groups, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the host group (groupid in Zabbix).",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the host group.",
+							Computed:    true,
+						},
+						"uuid": schema.StringAttribute{
+							Description: "The universally unique identifier of the host group.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostGroupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.SearchHostGroups(ctx, data.Search.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Host Groups",
+			fmt.Sprintf("Could not list host groups matching %q: %s", data.Search.ValueString(), err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(d.apiToModel(groups, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apiToModel converts the matching host groups to the Terraform model.
+func (d *HostGroupsDataSource) apiToModel(groups []zabbix.HostGroup, data *HostGroupsDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	groupType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":   types.StringType,
+			"name": types.StringType,
+			"uuid": types.StringType,
+		},
+	}
+
+	ids := make([]string, len(groups))
+	groupValues := make([]attr.Value, len(groups))
+	for i, group := range groups {
+		obj, diagsGroup := types.ObjectValue(groupType.AttrTypes, map[string]attr.Value{
+			"id":   types.StringValue(group.GroupID),
+			"name": types.StringValue(group.Name),
+			"uuid": types.StringValue(group.UUID),
+		})
+		diags.Append(diagsGroup...)
+		groupValues[i] = obj
+		ids[i] = group.GroupID
+	}
+	groupsList, diagsGroups := types.ListValue(groupType, groupValues)
+	diags.Append(diagsGroups...)
+	data.Groups = groupsList
+
+	data.ID = types.StringValue(listDataSourceID(ids))
+
+	return diags
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Acceptance tests for the zabbix_host_groups data source.
+// ABOUTME: Tests listing host groups with wildcard name patterns.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostGroupsDataSource_search(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostGroupsDataSourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_host_groups.test", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.zabbix_host_groups.test", "groups.0.name", rName+"/db"),
+					resource.TestCheckResourceAttr("data.zabbix_host_groups.test", "groups.1.name", rName+"/web"),
+					resource.TestCheckResourceAttrPair("data.zabbix_host_groups.test", "groups.1.id", "zabbix_host_group.web", "id"),
+					resource.TestCheckResourceAttrSet("data.zabbix_host_groups.test", "groups.0.uuid"),
+					resource.TestCheckResourceAttrSet("data.zabbix_host_groups.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHostGroupsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "db" {
+  name = "%[1]s/db"
+}
+
+resource "zabbix_host_group" "web" {
+  name = "%[1]s/web"
+}
+
+resource "zabbix_host_group" "other" {
+  name = "%[1]s-other"
+}
+
+data "zabbix_host_groups" "test" {
+  search = "%[1]s/*"
+
+  depends_on = [zabbix_host_group.db, zabbix_host_group.web, zabbix_host_group.other]
+}
+`, name)
+}
@@ -0,0 +1,251 @@
+// ABOUTME: SNMP settings of host interfaces, including write-only SNMPv3 passphrases.
+// ABOUTME: Converts the snmp attribute of an interface to and from the details of the Zabbix API.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// HostInterfaceSNMPModel describes the SNMP settings of a host interface.
+type HostInterfaceSNMPModel struct {
+	Version        types.Int64  `tfsdk:"version"`
+	Bulk           types.Bool   `tfsdk:"bulk"`
+	Community      types.String `tfsdk:"community"`
+	SecurityName   types.String `tfsdk:"security_name"`
+	SecurityLevel  types.String `tfsdk:"security_level"`
+	AuthProtocol   types.String `tfsdk:"auth_protocol"`
+	AuthPassphrase types.String `tfsdk:"auth_passphrase_wo"`
+	PrivProtocol   types.String `tfsdk:"priv_protocol"`
+	PrivPassphrase types.String `tfsdk:"priv_passphrase_wo"`
+	ContextName    types.String `tfsdk:"context_name"`
+}
+
+// hostInterfaceSNMPAttrTypes are the attribute types of the snmp attribute of an interface.
+var hostInterfaceSNMPAttrTypes = map[string]attr.Type{
+	"version":            types.Int64Type,
+	"bulk":               types.BoolType,
+	"community":          types.StringType,
+	"security_name":      types.StringType,
+	"security_level":     types.StringType,
+	"auth_protocol":      types.StringType,
+	"auth_passphrase_wo": types.StringType,
+	"priv_protocol":      types.StringType,
+	"priv_passphrase_wo": types.StringType,
+	"context_name":       types.StringType,
+}
+
+// snmpSecurityLevels maps SNMPv3 security level names to their API values.
+var snmpSecurityLevels = map[string]int{
+	"noAuthNoPriv": 0,
+	"authNoPriv":   1,
+	"authPriv":     2,
+}
+
+// snmpAuthProtocols maps SNMPv3 authentication protocol names to their API values.
+var snmpAuthProtocols = map[string]int{
+	"md5":    0,
+	"sha1":   1,
+	"sha224": 2,
+	"sha256": 3,
+	"sha384": 4,
+	"sha512": 5,
+}
+
+// snmpPrivProtocols maps SNMPv3 privacy protocol names to their API values.
+var snmpPrivProtocols = map[string]int{
+	"des":     0,
+	"aes128":  1,
+	"aes192":  2,
+	"aes256":  3,
+	"aes192c": 4,
+	"aes256c": 5,
+}
+
+// snmpName returns the name of an API value in one of the SNMP name maps, or the value
+// itself when the name is not known.
+func snmpName(names map[string]int, value int) string {
+	for name, v := range names {
+		if v == value {
+			return name
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// snmpNames returns the names of one of the SNMP name maps in the order of their API values.
+func snmpNames(names map[string]int) []string {
+	sorted := make([]string, len(names))
+	for name, v := range names {
+		sorted[v] = name
+	}
+	return sorted
+}
+
+// hostInterfaceSNMPAttribute returns the schema of the snmp attribute of an interface.
+func hostInterfaceSNMPAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "SNMP settings, required for interfaces of type snmp and not allowed for other types.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"version": schema.Int64Attribute{
+				Description: "SNMP version: 1, 2 (for SNMPv2c) or 3.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(zabbix.SNMPVersion1, zabbix.SNMPVersion2c, zabbix.SNMPVersion3),
+				},
+			},
+			"bulk": schema.BoolAttribute{
+				Description: "Whether to use bulk SNMP requests.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"community": schema.StringAttribute{
+				Description: "SNMP community of SNMPv1 and SNMPv2c, typically a user macro such as {$SNMP_COMMUNITY}.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"security_name": schema.StringAttribute{
+				Description: "SNMPv3 security name.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"security_level": schema.StringAttribute{
+				Description: "SNMPv3 security level: " + strings.Join(snmpNames(snmpSecurityLevels), ", ") + ".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("noAuthNoPriv"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpNames(snmpSecurityLevels)...),
+				},
+			},
+			"auth_protocol": schema.StringAttribute{
+				Description: "SNMPv3 authentication protocol: " + strings.Join(snmpNames(snmpAuthProtocols), ", ") + ".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("md5"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpNames(snmpAuthProtocols)...),
+				},
+			},
+			"auth_passphrase_wo": schema.StringAttribute{
+				Description: "SNMPv3 authentication passphrase. Write-only: the passphrase is not stored in state and changes are detected through a hash in private state.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"priv_protocol": schema.StringAttribute{
+				Description: "SNMPv3 privacy protocol: " + strings.Join(snmpNames(snmpPrivProtocols), ", ") + ".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("des"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpNames(snmpPrivProtocols)...),
+				},
+			},
+			"priv_passphrase_wo": schema.StringAttribute{
+				Description: "SNMPv3 privacy passphrase. Write-only: the passphrase is not stored in state and changes are detected through a hash in private state.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"context_name": schema.StringAttribute{
+				Description: "SNMPv3 context name.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+// snmpDetailsFromModel converts the snmp attribute of an interface to API details, or nil
+// when it is not set. The write-only passphrases are added from the configuration by
+// applyHostSecrets, as plans never contain them.
+func snmpDetailsFromModel(ctx context.Context, snmp types.Object) (*zabbix.SNMPDetails, diag.Diagnostics) {
+	if snmp.IsNull() || snmp.IsUnknown() {
+		return nil, nil
+	}
+
+	var data HostInterfaceSNMPModel
+	diags := snmp.As(ctx, &data, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &zabbix.SNMPDetails{
+		Version:       int(data.Version.ValueInt64()),
+		Bulk:          boolToInt(data.Bulk.ValueBool()),
+		Community:     data.Community.ValueString(),
+		SecurityName:  data.SecurityName.ValueString(),
+		SecurityLevel: snmpSecurityLevels[data.SecurityLevel.ValueString()],
+		AuthProtocol:  snmpAuthProtocols[data.AuthProtocol.ValueString()],
+		PrivProtocol:  snmpPrivProtocols[data.PrivProtocol.ValueString()],
+		ContextName:   data.ContextName.ValueString(),
+	}, diags
+}
+
+// snmpDetailsToObject converts the details of an interface read from Zabbix to the snmp
+// attribute. Zabbix does not return the passphrases, so they are always null.
+func snmpDetailsToObject(details *zabbix.SNMPDetails) (types.Object, diag.Diagnostics) {
+	if details == nil {
+		return types.ObjectNull(hostInterfaceSNMPAttrTypes), nil
+	}
+
+	return types.ObjectValue(hostInterfaceSNMPAttrTypes, map[string]attr.Value{
+		"version":            types.Int64Value(int64(details.Version)),
+		"bulk":               types.BoolValue(details.Bulk == 1),
+		"community":          types.StringValue(details.Community),
+		"security_name":      types.StringValue(details.SecurityName),
+		"security_level":     types.StringValue(snmpName(snmpSecurityLevels, details.SecurityLevel)),
+		"auth_protocol":      types.StringValue(snmpName(snmpAuthProtocols, details.AuthProtocol)),
+		"auth_passphrase_wo": types.StringNull(),
+		"priv_protocol":      types.StringValue(snmpName(snmpPrivProtocols, details.PrivProtocol)),
+		"priv_passphrase_wo": types.StringNull(),
+		"context_name":       types.StringValue(details.ContextName),
+	})
+}
+
+// snmpPassphrasesFromConfig returns the write-only SNMPv3 passphrases of each configured
+// interface, in the order of the interfaces, as authentication and privacy passphrase.
+func snmpPassphrasesFromConfig(ctx context.Context, config tfsdk.Config) ([][2]string, diag.Diagnostics) {
+	var interfaces []HostInterfaceModel
+	diags := config.GetAttribute(ctx, path.Root("interfaces"), &interfaces)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	passphrases := make([][2]string, len(interfaces))
+	for i, iface := range interfaces {
+		if iface.SNMP.IsNull() || iface.SNMP.IsUnknown() {
+			continue
+		}
+		var snmp HostInterfaceSNMPModel
+		diags.Append(iface.SNMP.As(ctx, &snmp, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		passphrases[i] = [2]string{snmp.AuthPassphrase.ValueString(), snmp.PrivPassphrase.ValueString()}
+	}
+
+	return passphrases, diags
+}
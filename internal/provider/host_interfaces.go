@@ -0,0 +1,228 @@
+// ABOUTME: Matching of host interfaces by identity instead of their position in the interfaces list.
+// ABOUTME: Keeps interface IDs with their interfaces in plans and orders read interfaces like the configuration.
+
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// hostInterfaceIdentity identifies an interface independent of its position in the list.
+// Values that are not known yet are empty.
+type hostInterfaceIdentity struct {
+	id      string
+	kind    string
+	address string
+	port    string
+}
+
+// identity returns the identity of a configured interface. The address is the IP address,
+// or the DNS name for interfaces without one.
+func (m HostInterfaceModel) identity() hostInterfaceIdentity {
+	known := func(v types.String) string {
+		if v.IsNull() || v.IsUnknown() {
+			return ""
+		}
+		return v.ValueString()
+	}
+
+	identity := hostInterfaceIdentity{
+		id:      known(m.InterfaceID),
+		kind:    known(m.Type),
+		address: known(m.IP),
+		port:    known(m.Port),
+	}
+	if identity.address == "" {
+		identity.address = known(m.DNS)
+	}
+	return identity
+}
+
+// hostInterfaceIdentities returns the identities of the configured interfaces.
+func hostInterfaceIdentities(interfaces []HostInterfaceModel) []hostInterfaceIdentity {
+	identities := make([]hostInterfaceIdentity, len(interfaces))
+	for i, iface := range interfaces {
+		identities[i] = iface.identity()
+	}
+	return identities
+}
+
+// apiHostInterfaceIdentity returns the identity of an interface returned by the API.
+func apiHostInterfaceIdentity(iface zabbix.HostInterface) hostInterfaceIdentity {
+	identity := hostInterfaceIdentity{
+		id:      iface.InterfaceID,
+		kind:    interfaceTypeToString(iface.Type),
+		address: iface.IP,
+		port:    iface.Port,
+	}
+	if identity.address == "" {
+		identity.address = iface.DNS
+	}
+	return identity
+}
+
+// matchHostInterfaces returns for each wanted interface the index of the candidate it matches,
+// or -1. Interfaces match by ID first, then by type, address and port, and finally by type
+// alone in list order, so an interface whose address or port changes keeps its ID.
+func matchHostInterfaces(wanted, candidates []hostInterfaceIdentity) []int {
+	matches := make([]int, len(wanted))
+	used := make([]bool, len(candidates))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	passes := []func(w, c hostInterfaceIdentity) bool{
+		func(w, c hostInterfaceIdentity) bool {
+			return w.id != "" && w.id == c.id
+		},
+		func(w, c hostInterfaceIdentity) bool {
+			return w.kind != "" && w.address != "" && w.port != "" &&
+				w.kind == c.kind && w.address == c.address && w.port == c.port
+		},
+		func(w, c hostInterfaceIdentity) bool {
+			return w.id == "" && w.kind != "" && w.kind == c.kind
+		},
+	}
+
+	for _, match := range passes {
+		for i, w := range wanted {
+			if matches[i] >= 0 {
+				continue
+			}
+			for j, c := range candidates {
+				if !used[j] && match(w, c) {
+					matches[i] = j
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// orderHostInterfaces orders the interfaces returned by the API like the prior interfaces they
+// match, so reordering in API responses does not show as a change. Interfaces without a match
+// follow in the order of their IDs.
+func orderHostInterfaces(ctx context.Context, prior types.List, interfaces []zabbix.HostInterface) ([]zabbix.HostInterface, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sorted := append([]zabbix.HostInterface(nil), interfaces...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].InterfaceID < sorted[j].InterfaceID
+	})
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return sorted, diags
+	}
+
+	var priorInterfaces []HostInterfaceModel
+	diags.Append(prior.ElementsAs(ctx, &priorInterfaces, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	candidates := make([]hostInterfaceIdentity, len(sorted))
+	for i, iface := range sorted {
+		candidates[i] = apiHostInterfaceIdentity(iface)
+	}
+
+	ordered := make([]zabbix.HostInterface, 0, len(sorted))
+	placed := make([]bool, len(sorted))
+	for _, j := range matchHostInterfaces(hostInterfaceIdentities(priorInterfaces), candidates) {
+		if j >= 0 {
+			ordered = append(ordered, sorted[j])
+			placed[j] = true
+		}
+	}
+	for j, iface := range sorted {
+		if !placed[j] {
+			ordered = append(ordered, iface)
+		}
+	}
+
+	return ordered, diags
+}
+
+// hostInterfacesPlanModifier plans the interface IDs of the interfaces list by matching
+// planned and prior interfaces by identity instead of list position, so reordering the
+// configuration does not move IDs between interfaces and replace every interface.
+type hostInterfacesPlanModifier struct{}
+
+func (m hostInterfacesPlanModifier) Description(ctx context.Context) string {
+	return "Keeps the ID of each interface by matching interfaces by type, address and port."
+}
+
+func (m hostInterfacesPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m hostInterfacesPlanModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var planned, current []HostInterfaceModel
+	resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, j := range matchHostInterfaces(hostInterfaceIdentities(planned), hostInterfaceIdentities(current)) {
+		if j >= 0 && planned[i].InterfaceID.IsUnknown() {
+			planned[i].InterfaceID = current[j].InterfaceID
+		}
+	}
+
+	planValue, diags := types.ListValueFrom(ctx, req.PlanValue.ElementType(ctx), planned)
+	resp.Diagnostics.Append(diags...)
+	resp.PlanValue = planValue
+}
+
+// hostInterfacesChanged reports whether the configured interface attributes differ between plan and state.
+// Interfaces are compared by identity, so reordering them is not a change. Attributes computed by
+// Zabbix are ignored when they are not known in the plan yet.
+func hostInterfacesChanged(ctx context.Context, plan, state types.List) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.IsNull() || state.IsNull() {
+		return plan.IsNull() != state.IsNull(), diags
+	}
+
+	var planned, current []HostInterfaceModel
+	diags.Append(plan.ElementsAs(ctx, &planned, false)...)
+	diags.Append(state.ElementsAs(ctx, &current, false)...)
+	if diags.HasError() {
+		return false, diags
+	}
+
+	if len(planned) != len(current) {
+		return true, diags
+	}
+
+	for i, j := range matchHostInterfaces(hostInterfaceIdentities(planned), hostInterfaceIdentities(current)) {
+		if j < 0 {
+			return true, diags
+		}
+		p, c := planned[i], current[j]
+		if !p.Type.Equal(c.Type) || !p.IP.Equal(c.IP) || !p.Port.Equal(c.Port) || !p.Main.Equal(c.Main) || !p.UseIP.Equal(c.UseIP) {
+			return true, diags
+		}
+		if !p.DNS.IsUnknown() && !p.DNS.Equal(c.DNS) {
+			return true, diags
+		}
+		if !p.SNMP.IsUnknown() && !p.SNMP.Equal(c.SNMP) {
+			return true, diags
+		}
+	}
+
+	return false, diags
+}
@@ -0,0 +1,212 @@
+// ABOUTME: Unit tests for matching host interfaces by identity.
+// ABOUTME: Covers reordered, changed and added interfaces in plans and in API responses.
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var testHostInterfaceAttrTypes = map[string]attr.Type{
+	"interface_id": types.StringType,
+	"type":         types.StringType,
+	"ip":           types.StringType,
+	"dns":          types.StringType,
+	"port":         types.StringType,
+	"main":         types.BoolType,
+	"use_ip":       types.BoolType,
+	"available":    types.StringType,
+	"error":        types.StringType,
+	"snmp":         types.ObjectType{AttrTypes: hostInterfaceSNMPAttrTypes},
+}
+
+// testHostInterface returns an interface model; an empty ID is unknown, as in a plan.
+func testHostInterface(id, kind, ip, port string) HostInterfaceModel {
+	iface := HostInterfaceModel{
+		InterfaceID: types.StringUnknown(),
+		Type:        types.StringValue(kind),
+		IP:          types.StringValue(ip),
+		DNS:         types.StringValue(""),
+		Port:        types.StringValue(port),
+		Main:        types.BoolValue(true),
+		UseIP:       types.BoolValue(true),
+		Available:   types.StringUnknown(),
+		Error:       types.StringUnknown(),
+		SNMP:        types.ObjectNull(hostInterfaceSNMPAttrTypes),
+	}
+	if id != "" {
+		iface.InterfaceID = types.StringValue(id)
+		iface.Available = types.StringValue("available")
+		iface.Error = types.StringValue("")
+	}
+	return iface
+}
+
+func testHostInterfaceList(t *testing.T, interfaces ...HostInterfaceModel) types.List {
+	t.Helper()
+
+	list, diags := types.ListValueFrom(context.Background(), types.ObjectType{AttrTypes: testHostInterfaceAttrTypes}, interfaces)
+	if diags.HasError() {
+		t.Fatalf("unexpected error building interfaces: %s", diags.Errors())
+	}
+	return list
+}
+
+func TestMatchHostInterfaces(t *testing.T) {
+	current := hostInterfaceIdentities([]HostInterfaceModel{
+		testHostInterface("1", "agent", "192.0.2.1", "10050"),
+		testHostInterface("2", "snmp", "192.0.2.1", "161"),
+	})
+
+	tests := map[string]struct {
+		wanted []HostInterfaceModel
+		want   []int
+	}{
+		"same order": {
+			wanted: []HostInterfaceModel{testHostInterface("", "agent", "192.0.2.1", "10050"), testHostInterface("", "snmp", "192.0.2.1", "161")},
+			want:   []int{0, 1},
+		},
+		"reordered": {
+			wanted: []HostInterfaceModel{testHostInterface("", "snmp", "192.0.2.1", "161"), testHostInterface("", "agent", "192.0.2.1", "10050")},
+			want:   []int{1, 0},
+		},
+		"changed address keeps type": {
+			wanted: []HostInterfaceModel{testHostInterface("", "snmp", "192.0.2.1", "161"), testHostInterface("", "agent", "192.0.2.9", "10050")},
+			want:   []int{1, 0},
+		},
+		"added interface": {
+			wanted: []HostInterfaceModel{testHostInterface("", "jmx", "192.0.2.1", "12345"), testHostInterface("", "agent", "192.0.2.1", "10050")},
+			want:   []int{-1, 0},
+		},
+		"by ID": {
+			wanted: []HostInterfaceModel{testHostInterface("2", "snmp", "192.0.2.5", "1161")},
+			want:   []int{1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := matchHostInterfaces(hostInterfaceIdentities(test.wanted), current)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected matches %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHostInterfacesPlanModifier(t *testing.T) {
+	ctx := context.Background()
+
+	state := testHostInterfaceList(t,
+		testHostInterface("1", "agent", "192.0.2.1", "10050"),
+		testHostInterface("2", "snmp", "192.0.2.1", "161"),
+	)
+	plan := testHostInterfaceList(t,
+		testHostInterface("", "snmp", "192.0.2.1", "161"),
+		testHostInterface("", "jmx", "192.0.2.1", "12345"),
+		testHostInterface("", "agent", "192.0.2.1", "10050"),
+	)
+
+	resp := &planmodifier.ListResponse{PlanValue: plan}
+	hostInterfacesPlanModifier{}.PlanModifyList(ctx, planmodifier.ListRequest{
+		Path:       path.Root("interfaces"),
+		PlanValue:  plan,
+		StateValue: state,
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	var planned []HostInterfaceModel
+	resp.Diagnostics.Append(resp.PlanValue.ElementsAs(ctx, &planned, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error decoding the plan: %s", resp.Diagnostics.Errors())
+	}
+
+	want := []types.String{types.StringValue("2"), types.StringUnknown(), types.StringValue("1")}
+	for i, iface := range planned {
+		if !iface.InterfaceID.Equal(want[i]) {
+			t.Errorf("interface %d: expected ID %s, got %s", i, want[i], iface.InterfaceID)
+		}
+	}
+}
+
+func TestHostInterfacesPlanModifier_Create(t *testing.T) {
+	plan := testHostInterfaceList(t, testHostInterface("", "agent", "192.0.2.1", "10050"))
+
+	resp := &planmodifier.ListResponse{PlanValue: plan}
+	hostInterfacesPlanModifier{}.PlanModifyList(context.Background(), planmodifier.ListRequest{
+		Path:       path.Root("interfaces"),
+		PlanValue:  plan,
+		StateValue: types.ListNull(types.ObjectType{AttrTypes: testHostInterfaceAttrTypes}),
+	}, resp)
+
+	if !resp.PlanValue.Equal(plan) {
+		t.Errorf("expected the plan of a new host to be unchanged, got %s", resp.PlanValue)
+	}
+}
+
+func TestOrderHostInterfaces(t *testing.T) {
+	prior := testHostInterfaceList(t,
+		testHostInterface("", "snmp", "192.0.2.1", "161"),
+		testHostInterface("", "agent", "192.0.2.1", "10050"),
+	)
+	returned := []zabbix.HostInterface{
+		{InterfaceID: "7", Type: 4, IP: "192.0.2.1", Port: "12345"},
+		{InterfaceID: "5", Type: 1, IP: "192.0.2.1", Port: "10050"},
+		{InterfaceID: "6", Type: 2, IP: "192.0.2.1", Port: "161"},
+	}
+
+	tests := map[string]struct {
+		prior types.List
+		want  []string
+	}{
+		"in prior order":     {prior: prior, want: []string{"6", "5", "7"}},
+		"without prior":      {prior: types.ListNull(types.ObjectType{AttrTypes: testHostInterfaceAttrTypes}), want: []string{"5", "6", "7"}},
+		"with prior unknown": {prior: types.ListUnknown(types.ObjectType{AttrTypes: testHostInterfaceAttrTypes}), want: []string{"5", "6", "7"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ordered, diags := orderHostInterfaces(context.Background(), test.prior, returned)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %s", diags.Errors())
+			}
+
+			var got []string
+			for _, iface := range ordered {
+				got = append(got, iface.InterfaceID)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected interface order %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestHostInterfacesChanged_Reordered(t *testing.T) {
+	state := testHostInterfaceList(t,
+		testHostInterface("1", "agent", "192.0.2.1", "10050"),
+		testHostInterface("2", "snmp", "192.0.2.1", "161"),
+	)
+	reordered := testHostInterfaceList(t,
+		testHostInterface("2", "snmp", "192.0.2.1", "161"),
+		testHostInterface("1", "agent", "192.0.2.1", "10050"),
+	)
+
+	changed, diags := hostInterfacesChanged(context.Background(), reordered, state)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %s", diags.Errors())
+	}
+	if changed {
+		t.Error("expected reordered interfaces not to be a change")
+	}
+}
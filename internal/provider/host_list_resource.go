@@ -0,0 +1,134 @@
+// ABOUTME: List resource enumerating Zabbix hosts for terraform query.
+// ABOUTME: Filters by host group, proxy and name pattern like the zabbix_hosts data source.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var (
+	_ list.ListResource              = &HostResource{}
+	_ list.ListResourceWithConfigure = &HostResource{}
+)
+
+// HostListModel describes the list block of the host list resource.
+type HostListModel struct {
+	GroupIDs types.List   `tfsdk:"group_ids"`
+	ProxyID  types.String `tfsdk:"proxy_id"`
+	Search   types.String `tfsdk:"search"`
+}
+
+// NewHostListResource creates a new list resource instance.
+func NewHostListResource() list.ListResource {
+	return &HostResource{}
+}
+
+func (r *HostResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists Zabbix hosts matching all of the given filters, for example to generate configuration for hosts not yet managed by Terraform. Without filters, every host is listed.",
+		Attributes: map[string]listschema.Attribute{
+			"group_ids": listschema.ListAttribute{
+				Description: "Only list hosts that belong to any of these host group IDs.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"proxy_id": listschema.StringAttribute{
+				Description: "Only list hosts monitored by this proxy ID.",
+				Optional:    true,
+			},
+			"search": listschema.StringAttribute{
+				Description: "Only list hosts whose technical or visible name matches this case-insensitive pattern. Use * as a wildcard, for example web-* for names starting with web-.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *HostResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config HostListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var search zabbix.HostSearch
+	if !config.GroupIDs.IsNull() {
+		diags.Append(config.GroupIDs.ElementsAs(ctx, &search.GroupIDs, false)...)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+	if !config.ProxyID.IsNull() {
+		search.ProxyIDs = []string{config.ProxyID.ValueString()}
+	}
+	search.Pattern = config.Search.ValueString()
+
+	hosts, err := r.client.SearchHosts(ctx, search)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Hosts",
+			fmt.Sprintf("Could not list hosts: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	hosts = hosts[:listLimit(len(hosts), req.Limit)]
+
+	// The search only returns names and status, so the full hosts are read in one request
+	var details map[string]*zabbix.Host
+	if req.IncludeResource && len(hosts) > 0 {
+		ids := make([]string, len(hosts))
+		for i, host := range hosts {
+			ids[i] = host.HostID
+		}
+
+		full, err := r.client.GetHosts(ctx, ids)
+		if err != nil {
+			diags.AddError(
+				"Error Listing Hosts",
+				fmt.Sprintf("Could not read the listed hosts: %s", err),
+			)
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+
+		details = make(map[string]*zabbix.Host, len(full))
+		for i := range full {
+			details[full[i].HostID] = &full[i]
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, host := range hosts {
+			result := req.NewListResult(ctx)
+			result.DisplayName = host.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, hostIdentityModel{HostID: types.StringValue(host.HostID)})...)
+
+			// Hosts deleted between the search and the read are listed without their details
+			if detail := details[host.HostID]; detail != nil {
+				var data HostResourceModel
+				result.Diagnostics.Append(listResultModel(ctx, result.Resource, host.HostID, &data)...)
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(r.apiToModel(ctx, detail, &data)...)
+				}
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+				}
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -5,47 +5,97 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"sort"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/validators"
 	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 var (
-	_ resource.Resource                = &HostResource{}
-	_ resource.ResourceWithImportState = &HostResource{}
+	_ resource.Resource                   = &HostResource{}
+	_ resource.ResourceWithIdentity       = &HostResource{}
+	_ resource.ResourceWithImportState    = &HostResource{}
+	_ resource.ResourceWithModifyPlan     = &HostResource{}
+	_ resource.ResourceWithUpgradeState   = &HostResource{}
+	_ resource.ResourceWithValidateConfig = &HostResource{}
 )
 
 // HostResource defines the resource implementation.
 type HostResource struct {
-	client *zabbix.Client
+	client      ZabbixAPI
+	defaultTags map[string]string
 }
 
 // HostResourceModel describes the resource data model.
 type HostResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Host       types.String `tfsdk:"host"`
-	Name       types.String `tfsdk:"name"`
-	Groups     types.List   `tfsdk:"groups"`
-	Templates  types.List   `tfsdk:"templates"`
-	Status     types.Int64  `tfsdk:"status"`
-	Interfaces types.List   `tfsdk:"interfaces"`
-	Tags       types.List   `tfsdk:"tags"`
+	ID                types.String `tfsdk:"id"`
+	Host              types.String `tfsdk:"host"`
+	Name              types.String `tfsdk:"name"`
+	Groups            types.Set    `tfsdk:"groups"`
+	GroupMode         types.String `tfsdk:"group_mode"`
+	Templates         types.Set    `tfsdk:"templates"`
+	TemplateNames     types.Set    `tfsdk:"template_names"`
+	TemplateNameIDs   types.Map    `tfsdk:"template_name_ids"`
+	UnlinkMode        types.String `tfsdk:"unlink_mode"`
+	Status            types.Int64  `tfsdk:"status"`
+	IgnoreStatusDrift types.Bool   `tfsdk:"ignore_status_drift"`
+	Discovered        types.Bool   `tfsdk:"discovered"`
+	MaintenanceStatus types.Int64  `tfsdk:"maintenance_status"`
+	ActiveAvailable   types.String `tfsdk:"active_available"`
+	TLSConnect        types.Int64  `tfsdk:"tls_connect"`
+	TLSAccept         types.Int64  `tfsdk:"tls_accept"`
+	TLSPSKIdentity    types.String `tfsdk:"tls_psk_identity"`
+	TLSPSK            types.String `tfsdk:"tls_psk_wo"`
+	IPMIUsername      types.String `tfsdk:"ipmi_username"`
+	IPMIPassword      types.String `tfsdk:"ipmi_password_wo"`
+	SecretsRevision   types.Int64  `tfsdk:"secrets_revision"`
+	Interfaces        types.List   `tfsdk:"interfaces"`
+	Tags              types.Set    `tfsdk:"tags"`
+	TagsAll           types.Set    `tfsdk:"tags_all"`
+
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	Timeouts           types.Object `tfsdk:"timeouts"`
+}
+
+// hostSecretHashes holds the hashes of the write-only secrets last sent to Zabbix.
+// It is kept in private state to detect rotated secrets without storing them.
+type hostSecretHashes struct {
+	TLSPSK       string `json:"tls_psk,omitempty"`
+	IPMIPassword string `json:"ipmi_password,omitempty"`
+	// SNMPPassphrases is a single hash over the SNMPv3 passphrases of all interfaces.
+	SNMPPassphrases string `json:"snmp_passphrases,omitempty"`
 }
 
+// hostSecretHashesKey is the private state key holding the hostSecretHashes.
+const hostSecretHashesKey = "secret_hashes"
+
 // HostInterfaceModel describes a host interface.
 type HostInterfaceModel struct {
 	InterfaceID types.String `tfsdk:"interface_id"`
@@ -55,6 +105,9 @@ type HostInterfaceModel struct {
 	Port        types.String `tfsdk:"port"`
 	Main        types.Bool   `tfsdk:"main"`
 	UseIP       types.Bool   `tfsdk:"use_ip"`
+	Available   types.String `tfsdk:"available"`
+	Error       types.String `tfsdk:"error"`
+	SNMP        types.Object `tfsdk:"snmp"`
 }
 
 // HostTagModel describes a host tag.
@@ -72,9 +125,14 @@ func (r *HostResource) Metadata(ctx context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_host"
 }
 
+func (r *HostResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = hostIdentitySchema()
+}
+
 func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Zabbix host.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the host (hostid in Zabbix).",
@@ -92,19 +150,55 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Computed:    true,
 			},
-			"groups": schema.ListAttribute{
-				Description: "List of host group IDs the host belongs to.",
+			"groups": schema.SetAttribute{
+				Description: "Set of host group IDs the host belongs to.",
 				Required:    true,
 				ElementType: types.StringType,
-				Validators: []validator.List{
-					listvalidator.SizeAtLeast(1),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					validators.UniqueIDs(),
+				},
+			},
+			"group_mode": schema.StringAttribute{
+				Description: "How host group membership is managed: authoritative (default) replaces all groups of the host with groups, additive only adds and removes the groups listed in groups and leaves groups assigned by other tooling untouched.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("authoritative"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("authoritative", "additive"),
+				},
+			},
+			"templates": schema.SetAttribute{
+				Description: "Set of template IDs to link to the host.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					validators.UniqueIDs(),
 				},
 			},
-			"templates": schema.ListAttribute{
-				Description: "List of template IDs to link to the host.",
+			"template_names": schema.SetAttribute{
+				Description: "Set of template technical names to link to the host. Names are resolved to template IDs by the provider, so built-in templates can be linked without a data source.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"template_name_ids": schema.MapAttribute{
+				Description: "IDs of the templates linked through template_names, keyed by technical name. Names are only resolved when they are added, " +
+					"so the templates are not looked up again on every apply and a template renamed in Zabbix stays linked.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"unlink_mode": schema.StringAttribute{
+				Description: "How templates removed from the host are detached: unlink (default) keeps inherited items and triggers on the host, unlink_and_clear removes them as well.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("unlink"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("unlink", "unlink_and_clear"),
+				},
+			},
 			"status": schema.Int64Attribute{
 				Description: "Status of the host. 0 = enabled (default), 1 = disabled.",
 				Optional:    true,
@@ -114,20 +208,89 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					int64validator.OneOf(0, 1),
 				},
 			},
+			"ignore_status_drift": schema.BoolAttribute{
+				Description: "Whether changes of the status made outside of Terraform, such as hosts disabled temporarily in the frontend, are ignored. The status is then only sent to Zabbix when it is changed in the configuration. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"discovered": schema.BoolAttribute{
+				Description: "Whether the host was created by low-level discovery from a host prototype. Only status, templates and tags of discovered hosts can be changed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute("host"),
+			"maintenance_status": schema.Int64Attribute{
+				Description: "Maintenance status of the host as reported by Zabbix. 0 = no maintenance, 1 = in maintenance.",
+				Computed:    true,
+			},
+			"active_available": schema.StringAttribute{
+				Description: "Availability of active agent checks as reported by Zabbix: unknown, available or unavailable.",
+				Computed:    true,
+			},
+			"tls_connect": schema.Int64Attribute{
+				Description: "Connections to the host: 1 = unencrypted (default), 2 = PSK, 4 = certificate.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.OneOf(1, 2, 4),
+				},
+			},
+			"tls_accept": schema.Int64Attribute{
+				Description: "Connections accepted from the host as a bitmask: 1 = unencrypted (default), 2 = PSK, 4 = certificate.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 7),
+				},
+			},
+			"tls_psk_identity": schema.StringAttribute{
+				Description: "PSK identity. Required when tls_connect or tls_accept use PSK.",
+				Optional:    true,
+			},
+			"tls_psk_wo": schema.StringAttribute{
+				Description: "Pre-shared key of at least 32 hex digits. Write-only: Zabbix never returns the key, so it is not stored in state and changes are detected through a hash in private state.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([0-9a-fA-F]{2}){16,}$`), "must be an even number of at least 32 hex digits"),
+				},
+			},
+			"ipmi_username": schema.StringAttribute{
+				Description: "IPMI username.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"ipmi_password_wo": schema.StringAttribute{
+				Description: "IPMI password. Write-only: the password is not stored in state and changes are detected through a hash in private state.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"secrets_revision": schema.Int64Attribute{
+				Description: "Counter that is increased whenever tls_psk_wo, ipmi_password_wo or the SNMPv3 passphrases of the interfaces change, so rotated secrets show up in the plan.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"interfaces": schema.ListNestedAttribute{
-				Description: "Host interfaces for monitoring.",
-				Required:    true,
-				Validators: []validator.List{
-					listvalidator.SizeAtLeast(1),
+				Description: "Host interfaces for monitoring. May be omitted for hosts that are only monitored through active agent checks or trapper items. Interfaces are matched by type, address and port rather than position, so reordering them does not replace them.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					hostInterfacesPlanModifier{},
 				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"interface_id": schema.StringAttribute{
 							Description: "ID of the interface (computed by Zabbix).",
 							Computed:    true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.UseStateForUnknown(),
-							},
 						},
 						"type": schema.StringAttribute{
 							Description: "Interface type: agent, snmp, ipmi, or jmx.",
@@ -157,12 +320,24 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							Description: "Whether to use IP address instead of DNS name.",
 							Required:    true,
 						},
+						"available": schema.StringAttribute{
+							Description: "Availability of the interface as reported by Zabbix: unknown, available or unavailable.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Last error reported by Zabbix when the interface is unavailable.",
+							Computed:    true,
+						},
+						"snmp": hostInterfaceSNMPAttribute(),
 					},
 				},
 			},
-			"tags": schema.ListNestedAttribute{
+			"tags": schema.SetNestedAttribute{
 				Description: "Host tags.",
 				Optional:    true,
+				Validators: []validator.Set{
+					validators.UniqueTags(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"tag": schema.StringAttribute{
@@ -173,11 +348,31 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							Description: "Tag value.",
 							Optional:    true,
 							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+					},
+				},
+			},
+			"tags_all": schema.SetNestedAttribute{
+				Description: "All tags of the host, including the default_tags of the provider.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tag": schema.StringAttribute{
+							Description: "Tag name.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Tag value.",
+							Computed:    true,
 						},
 					},
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -186,16 +381,229 @@ func (r *HostResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*zabbix.Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.defaultTags = data.DefaultTags
+}
+
+func (r *HostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HostResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usesPSK := (!data.TLSConnect.IsUnknown() && data.TLSConnect.ValueInt64() == 2) ||
+		(!data.TLSAccept.IsUnknown() && data.TLSAccept.ValueInt64()&2 != 0)
+	if usesPSK && data.TLSPSKIdentity.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_psk_identity"),
+			"Missing PSK Identity",
+			"tls_psk_identity must be set when tls_connect or tls_accept use PSK encryption.",
+		)
+	}
+
+	if data.Interfaces.IsNull() || data.Interfaces.IsUnknown() {
+		return
+	}
+
+	var interfaces []HostInterfaceModel
+	resp.Diagnostics.Append(data.Interfaces.ElementsAs(ctx, &interfaces, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mainCount := make(map[string]int)
+	mainUnknown := make(map[string]bool)
+	var interfaceTypes []string
+
+	for i, iface := range interfaces {
+		ifacePath := path.Root("interfaces").AtListIndex(i)
+
+		if !iface.IP.IsUnknown() && iface.IP.ValueString() != "" && net.ParseIP(iface.IP.ValueString()) == nil {
+			resp.Diagnostics.AddAttributeError(
+				ifacePath.AtName("ip"),
+				"Invalid Interface IP Address",
+				fmt.Sprintf("%q is not a valid IPv4 or IPv6 address.", iface.IP.ValueString()),
+			)
+		}
+
+		if !iface.UseIP.IsUnknown() && !iface.UseIP.IsNull() {
+			if iface.UseIP.ValueBool() && !iface.IP.IsUnknown() && iface.IP.ValueString() == "" {
+				resp.Diagnostics.AddAttributeError(
+					ifacePath.AtName("ip"),
+					"Missing Interface IP Address",
+					"ip must be set when use_ip is true.",
+				)
+			}
+			if !iface.UseIP.ValueBool() && !iface.DNS.IsUnknown() && iface.DNS.ValueString() == "" {
+				resp.Diagnostics.AddAttributeError(
+					ifacePath.AtName("dns"),
+					"Missing Interface DNS Name",
+					"dns must be set when use_ip is false.",
+				)
+			}
+		}
+
+		if iface.Type.IsUnknown() || iface.Type.IsNull() {
+			continue
+		}
+		ifaceType := iface.Type.ValueString()
+
+		if !iface.SNMP.IsUnknown() {
+			switch {
+			case ifaceType == "snmp" && iface.SNMP.IsNull():
+				resp.Diagnostics.AddAttributeError(
+					ifacePath.AtName("snmp"),
+					"Missing SNMP Settings",
+					"snmp must be set for interfaces of type snmp.",
+				)
+			case ifaceType != "snmp" && !iface.SNMP.IsNull():
+				resp.Diagnostics.AddAttributeError(
+					ifacePath.AtName("snmp"),
+					"Unexpected SNMP Settings",
+					fmt.Sprintf("snmp can only be set for interfaces of type snmp, not %s.", ifaceType),
+				)
+			}
+		}
+
+		if _, seen := mainCount[ifaceType]; !seen {
+			mainCount[ifaceType] = 0
+			interfaceTypes = append(interfaceTypes, ifaceType)
+		}
+		if iface.Main.IsUnknown() {
+			mainUnknown[ifaceType] = true
+		} else if iface.Main.ValueBool() {
+			mainCount[ifaceType]++
+		}
+	}
+
+	for _, ifaceType := range interfaceTypes {
+		if mainUnknown[ifaceType] {
+			continue
+		}
+		switch count := mainCount[ifaceType]; {
+		case count == 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("interfaces"),
+				"Missing Main Interface",
+				fmt.Sprintf("Exactly one %s interface must have main = true, found none.", ifaceType),
+			)
+		case count > 1:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("interfaces"),
+				"Multiple Main Interfaces",
+				fmt.Sprintf("Exactly one %s interface must have main = true, found %d.", ifaceType, count),
+			)
+		}
+	}
+}
+
+func (r *HostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Default tags are not part of the resource configuration, so tags_all is planned here
+	var tags types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll, diags := plannedTagsAll(ctx, tags, r.defaultTags)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateTemplateLinks(ctx, req.Plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing else to check on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// Write-only secrets never reach the state, so a rotation is detected by
+	// comparing hashes in private state and surfaced through secrets_revision
+	hashes, diags := hostSecretHashesFromConfig(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	prior, diags := readHostSecretHashes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if hashes != prior {
+		var revision types.Int64
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("secrets_revision"), &revision)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secrets_revision"), types.Int64Value(revision.ValueInt64()+1))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var plan, state HostResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolved IDs are kept while the names are unchanged; changed names are resolved during apply
+	if !plan.TemplateNames.Equal(state.TemplateNames) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("template_name_ids"), types.MapUnknown(types.StringType))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !state.Discovered.ValueBool() {
+		return
+	}
+
+	var changed []string
+	if !plan.Host.IsUnknown() && !plan.Host.Equal(state.Host) {
+		changed = append(changed, "host")
+	}
+	if !plan.Name.IsUnknown() && !plan.Name.Equal(state.Name) {
+		changed = append(changed, "name")
+	}
+	if !plan.Groups.IsUnknown() && !plan.Groups.Equal(state.Groups) {
+		changed = append(changed, "groups")
+	}
+	if !plan.Interfaces.IsUnknown() {
+		interfacesChanged, diags := hostInterfacesChanged(ctx, plan.Interfaces, state.Interfaces)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if interfacesChanged {
+			changed = append(changed, "interfaces")
+		}
+	}
+
+	for _, attribute := range changed {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Discovered Host Attribute Not Updatable",
+			fmt.Sprintf("Host %s was created by low-level discovery, so Zabbix does not allow changing %s. "+
+				"Change the host prototype of the discovery rule instead, or only manage status, templates and tags of this host.",
+				state.ID.ValueString(), attribute),
+		)
+	}
 }
 
 func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -206,8 +614,16 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	host, diags := r.modelToAPI(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(applyHostSecrets(ctx, req.Config, host)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -216,7 +632,7 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Host",
-			fmt.Sprintf("Could not create host: %s", err),
+			fmt.Sprintf("Could not create host: %s%s", err, alreadyExistsHint(err, "host", false)),
 		)
 		return
 	}
@@ -227,6 +643,7 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 			"Error Reading Host",
 			fmt.Sprintf("Could not read host after creation: %s", err),
 		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, hostID)...)
 		return
 	}
 
@@ -235,16 +652,19 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 			"Error Reading Host",
 			fmt.Sprintf("Host %s was created but could not be found", hostID),
 		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, hostID)...)
 		return
 	}
 
 	diags = r.apiToModel(ctx, apiHost, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(writeHostSecretHashes(ctx, req.Config, resp.Private)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, hostIdentityModel{HostID: data.ID})...)
 }
 
 func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -255,8 +675,15 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	host, err := r.client.GetHost(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Reading Host",
 			fmt.Sprintf("Could not read host ID %s: %s", data.ID.ValueString(), err),
@@ -265,17 +692,25 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	if host == nil {
+		resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, hostIdentityModel{HostID: data.ID})...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	diags := r.apiToModel(ctx, host, &data)
+	status := data.Status
+	diags = r.apiToModel(ctx, host, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// A status changed outside of Terraform is not reported as drift when it is ignored
+	if data.IgnoreStatusDrift.ValueBool() && !status.IsNull() {
+		data.Status = status
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, hostIdentityModel{HostID: data.ID})...)
 }
 
 func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -286,20 +721,77 @@ func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state HostResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Names that were already linked keep the IDs they resolved to, only added names are looked up
+	if data.TemplateNameIDs.IsUnknown() {
+		data.TemplateNameIDs = state.TemplateNameIDs
+	}
+
 	host, diags := r.modelToAPI(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(applyHostSecrets(ctx, req.Config, host)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	host.HostID = state.ID.ValueString()
 
+	// In additive mode groups are changed with host.massadd and host.massremove below
+	additive := data.GroupMode.ValueString() == "additive"
+	if additive {
+		host.Groups = nil
+	}
+
+	// Discovered hosts inherit these attributes from their host prototype and reject updates to them
+	discovered := state.Discovered.ValueBool()
+	if discovered {
+		host.Host = ""
+		host.Name = ""
+		host.Groups = nil
+		additive = false
+	}
+
+	// An empty template list unlinks every template that is currently linked
+	if host.Templates == nil {
+		host.Templates = []zabbix.TemplateID{}
+	}
+
+	// A status that is not changed in the configuration may have been changed in Zabbix on purpose
+	host.KeepStatus = data.IgnoreStatusDrift.ValueBool() && data.Status.Equal(state.Status)
+
+	// An empty interface list removes every interface from the host
+	if discovered {
+		host.Interfaces = nil
+	} else if host.Interfaces == nil {
+		host.Interfaces = []zabbix.HostInterface{}
+	}
+
+	if data.UnlinkMode.ValueString() == "unlink_and_clear" {
+		current, err := r.client.GetHost(ctx, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Host",
+				fmt.Sprintf("Could not read linked templates of host ID %s: %s", state.ID.ValueString(), err),
+			)
+			return
+		}
+		if current != nil {
+			host.TemplatesClear = templatesToClear(current.ParentTemplates, host.Templates)
+		}
+	}
+
 	err := r.client.UpdateHost(ctx, host)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -309,6 +801,13 @@ func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	if additive {
+		resp.Diagnostics.Append(r.updateGroupsAdditive(ctx, state.ID.ValueString(), &state, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	apiHost, err := r.client.GetHost(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -326,13 +825,19 @@ func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	status := data.Status
 	diags = r.apiToModel(ctx, apiHost, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(writeHostSecretHashes(ctx, req.Config, resp.Private)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if host.KeepStatus {
+		data.Status = status
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, hostIdentityModel{HostID: data.ID})...)
 }
 
 func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -343,8 +848,21 @@ func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "host", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A host deleted outside of Terraform is already gone
 	err := r.client.DeleteHost(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Host",
 			fmt.Sprintf("Could not delete host ID %s: %s", data.ID.ValueString(), err),
@@ -353,8 +871,24 @@ func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// ImportState imports a host by ID or, with name=<host>, by its technical name.
 func (r *HostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "host", "hostid", func(ctx context.Context, name string) (string, error) {
+		host, err := r.client.GetHostByName(ctx, name)
+		if err != nil || host == nil {
+			return "", err
+		}
+		return host.HostID, nil
+	})
+}
+
+func (r *HostResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored groups, templates and tags as lists. Their JSON
+		// encoding in state is identical to sets, so the raw state is re-read
+		// against the current schema.
+		0: rereadStateUpgrader(r, "host"),
+	}
 }
 
 // modelToAPI converts the Terraform model to Zabbix API struct.
@@ -362,9 +896,16 @@ func (r *HostResource) modelToAPI(ctx context.Context, data *HostResourceModel)
 	var diags diag.Diagnostics
 
 	host := &zabbix.Host{
-		Host:   data.Host.ValueString(),
-		Name:   data.Name.ValueString(),
-		Status: int(data.Status.ValueInt64()),
+		Host:           data.Host.ValueString(),
+		Name:           data.Name.ValueString(),
+		Status:         int(data.Status.ValueInt64()),
+		TLSConnect:     int(data.TLSConnect.ValueInt64()),
+		TLSAccept:      int(data.TLSAccept.ValueInt64()),
+		TLSPSKIdentity: data.TLSPSKIdentity.ValueString(),
+	}
+	if !data.IPMIUsername.IsNull() && !data.IPMIUsername.IsUnknown() {
+		ipmiUsername := data.IPMIUsername.ValueString()
+		host.IPMIUsername = &ipmiUsername
 	}
 
 	// Convert groups
@@ -389,12 +930,41 @@ func (r *HostResource) modelToAPI(ctx context.Context, data *HostResourceModel)
 		}
 	}
 
-	// Convert interfaces
-	var interfaces []HostInterfaceModel
-	diags.Append(data.Interfaces.ElementsAs(ctx, &interfaces, false)...)
+	// Resolve template names to IDs, keeping the IDs they resolved to before
+	known, d := templateNameIDsOf(ctx, data.TemplateNameIDs)
+	diags.Append(d...)
 	if diags.HasError() {
 		return nil, diags
 	}
+	data.TemplateNameIDs = types.MapNull(types.StringType)
+	if !data.TemplateNames.IsNull() {
+		var templateNames []string
+		diags.Append(data.TemplateNames.ElementsAs(ctx, &templateNames, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		templateIDs, d := r.resolveTemplateNames(ctx, templateNames, known)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		resolved := make(map[string]string, len(templateNames))
+		for i, templateID := range templateIDs {
+			host.Templates = append(host.Templates, zabbix.TemplateID{TemplateID: templateID})
+			resolved[templateNames[i]] = templateID
+		}
+		data.TemplateNameIDs, d = types.MapValueFrom(ctx, types.StringType, resolved)
+		diags.Append(d...)
+	}
+
+	// Convert interfaces
+	var interfaces []HostInterfaceModel
+	if !data.Interfaces.IsNull() {
+		diags.Append(data.Interfaces.ElementsAs(ctx, &interfaces, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
 	for _, iface := range interfaces {
 		apiIface := zabbix.HostInterface{
 			Type:  interfaceTypeToInt(iface.Type.ValueString()),
@@ -407,25 +977,129 @@ func (r *HostResource) modelToAPI(ctx context.Context, data *HostResourceModel)
 		if !iface.InterfaceID.IsNull() && !iface.InterfaceID.IsUnknown() {
 			apiIface.InterfaceID = iface.InterfaceID.ValueString()
 		}
+		details, d := snmpDetailsFromModel(ctx, iface.SNMP)
+		diags.Append(d...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		apiIface.Details = details
 		host.Interfaces = append(host.Interfaces, apiIface)
 	}
 
-	// Convert tags
-	if !data.Tags.IsNull() {
-		var tags []HostTagModel
-		diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
-		if diags.HasError() {
+	// Convert tags, adding the default tags of the provider
+	tags, d := tagValuesFromModel(ctx, data.Tags)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for _, tag := range mergeDefaultTags(r.defaultTags, tags) {
+		host.Tags = append(host.Tags, zabbix.HostTag{
+			Tag:   tag.Tag,
+			Value: tag.Value,
+		})
+	}
+
+	return host, diags
+}
+
+// resolveTemplateNames looks up the IDs of templates by technical name, in the order of
+// the names. Names in known are not looked up again and keep the ID they resolved to.
+func (r *HostResource) resolveTemplateNames(ctx context.Context, names []string, known map[string]string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		if templateID, ok := known[name]; ok {
+			ids = append(ids, templateID)
+			continue
+		}
+		templateID, err := r.client.TemplateIDByHost(ctx, name)
+		if err != nil {
+			diags.AddError(
+				"Error Resolving Template",
+				fmt.Sprintf("Could not look up template with technical name %q: %s", name, err),
+			)
 			return nil, diags
 		}
-		for _, tag := range tags {
-			host.Tags = append(host.Tags, zabbix.HostTag{
-				Tag:   tag.Tag.ValueString(),
-				Value: tag.Value.ValueString(),
-			})
+		if templateID == "" {
+			diags.AddError(
+				"Template Not Found",
+				fmt.Sprintf("No template found with technical name %q.", name),
+			)
+			return nil, diags
 		}
+		ids = append(ids, templateID)
 	}
 
-	return host, diags
+	return ids, diags
+}
+
+// templateNameIDsOf returns the template IDs of template_name_ids keyed by name, or nil
+// when they are not known.
+func templateNameIDsOf(ctx context.Context, templateNameIDs types.Map) (map[string]string, diag.Diagnostics) {
+	if templateNameIDs.IsNull() || templateNameIDs.IsUnknown() {
+		return nil, nil
+	}
+	var ids map[string]string
+	diags := templateNameIDs.ElementsAs(ctx, &ids, false)
+	return ids, diags
+}
+
+// validateTemplateLinks rejects plans linking the same template through both templates
+// and template_names, which the API only reports as a duplicate at apply time. Names are
+// only resolved when both are set, so plans of most hosts make no API requests.
+func (r *HostResource) validateTemplateLinks(ctx context.Context, plan tfsdk.Plan) diag.Diagnostics {
+	var templates, templateNames types.Set
+	var templateNameIDs types.Map
+
+	diags := plan.GetAttribute(ctx, path.Root("templates"), &templates)
+	diags.Append(plan.GetAttribute(ctx, path.Root("template_names"), &templateNames)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("template_name_ids"), &templateNameIDs)...)
+	if diags.HasError() || r.client == nil || !isKnownSet(templates) || !isKnownSet(templateNames) {
+		return diags
+	}
+
+	var ids, names []string
+	diags.Append(templates.ElementsAs(ctx, &ids, false)...)
+	diags.Append(templateNames.ElementsAs(ctx, &names, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	known, d := templateNameIDsOf(ctx, templateNameIDs)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	resolved, d := r.resolveTemplateNames(ctx, names, known)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, id := range resolved {
+		if slices.Contains(ids, id) {
+			diags.AddAttributeError(
+				path.Root("template_names"),
+				"Duplicate Template",
+				fmt.Sprintf("Template %q is linked by name and by its ID %s in templates. Link it through only one of them.", names[i], id),
+			)
+		}
+	}
+	return diags
+}
+
+// isKnownSet reports whether a set is not empty and all of its elements are known.
+func isKnownSet(set types.Set) bool {
+	if set.IsNull() || set.IsUnknown() || len(set.Elements()) == 0 {
+		return false
+	}
+	for _, element := range set.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
 }
 
 // apiToModel converts the Zabbix API struct to Terraform model.
@@ -436,33 +1110,144 @@ func (r *HostResource) apiToModel(ctx context.Context, host *zabbix.Host, data *
 	data.Host = types.StringValue(host.Host)
 	data.Name = types.StringValue(host.Name)
 	data.Status = types.Int64Value(int64(host.Status))
+	data.Discovered = types.BoolValue(host.Flags == zabbix.HostFlagDiscovered)
+	data.MaintenanceStatus = types.Int64Value(int64(host.MaintenanceStatus))
+	data.ActiveAvailable = types.StringValue(availabilityToString(host.ActiveAvailable))
+	data.TLSConnect = types.Int64Value(int64(host.TLSConnect))
+	data.TLSAccept = types.Int64Value(int64(host.TLSAccept))
+	data.IPMIUsername = types.StringValue("")
+	if host.IPMIUsername != nil {
+		data.IPMIUsername = types.StringValue(*host.IPMIUsername)
+	}
 
-	// Convert groups
-	groupIDs := make([]attr.Value, len(host.Groups))
-	for i, g := range host.Groups {
-		groupIDs[i] = types.StringValue(g.GroupID)
+	// Zabbix never returns the PSK identity or secrets, so the configured values are kept
+	data.TLSPSK = types.StringNull()
+	data.IPMIPassword = types.StringNull()
+	if data.SecretsRevision.IsNull() || data.SecretsRevision.IsUnknown() {
+		data.SecretsRevision = types.Int64Value(0)
+	}
+
+	if data.DeletionProtection.IsNull() || data.DeletionProtection.IsUnknown() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
+	if data.GroupMode.IsNull() || data.GroupMode.IsUnknown() {
+		data.GroupMode = types.StringValue("authoritative")
+	}
+
+	if data.IgnoreStatusDrift.IsNull() || data.IgnoreStatusDrift.IsUnknown() {
+		data.IgnoreStatusDrift = types.BoolValue(false)
+	}
+
+	// Convert groups, in additive mode only the groups already known to Terraform are kept
+	var knownGroups map[string]bool
+	if data.GroupMode.ValueString() == "additive" && !data.Groups.IsNull() && !data.Groups.IsUnknown() {
+		var configured []string
+		diags.Append(data.Groups.ElementsAs(ctx, &configured, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		knownGroups = make(map[string]bool, len(configured))
+		for _, id := range configured {
+			knownGroups[id] = true
+		}
+	}
+	groupIDs := make([]attr.Value, 0, len(host.Groups))
+	for _, g := range host.Groups {
+		if knownGroups != nil && !knownGroups[g.GroupID] {
+			continue
+		}
+		groupIDs = append(groupIDs, types.StringValue(g.GroupID))
+	}
+	groupsSet, d := types.SetValue(types.StringType, groupIDs)
+	diags.Append(d...)
+	data.Groups = groupsSet
+
+	// Split parentTemplates between templates linked by name and by ID
+	var configuredIDs, configuredNames []string
+	if !data.Templates.IsNull() && !data.Templates.IsUnknown() {
+		diags.Append(data.Templates.ElementsAs(ctx, &configuredIDs, false)...)
 	}
-	groupsList, d := types.ListValue(types.StringType, groupIDs)
+	if !data.TemplateNames.IsNull() && !data.TemplateNames.IsUnknown() {
+		diags.Append(data.TemplateNames.ElementsAs(ctx, &configuredNames, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	byID := make(map[string]bool, len(configuredIDs))
+	for _, id := range configuredIDs {
+		byID[id] = true
+	}
+	knownIDs, d := templateNameIDsOf(ctx, data.TemplateNameIDs)
 	diags.Append(d...)
-	data.Groups = groupsList
+	if diags.HasError() {
+		return diags
+	}
 
-	// Convert templates from parentTemplates
-	if len(host.ParentTemplates) > 0 {
-		templateIDs := make([]attr.Value, len(host.ParentTemplates))
-		for i, t := range host.ParentTemplates {
-			templateIDs[i] = types.StringValue(t.TemplateID)
+	// Names keep the template they resolved to even when it was renamed since, and
+	// otherwise match the template with that technical name
+	linked := make(map[string]bool, len(host.ParentTemplates))
+	hostIDs := make(map[string]string, len(host.ParentTemplates))
+	for _, t := range host.ParentTemplates {
+		linked[t.TemplateID] = true
+		hostIDs[t.Host] = t.TemplateID
+	}
+	linkedNames := make(map[string]string)
+	linkedByName := make(map[string]bool)
+	for _, name := range configuredNames {
+		templateID, ok := knownIDs[name]
+		if !ok || !linked[templateID] {
+			templateID, ok = hostIDs[name]
 		}
-		templatesList, d := types.ListValue(types.StringType, templateIDs)
+		if ok {
+			linkedNames[name] = templateID
+			linkedByName[templateID] = true
+		}
+	}
+
+	var templateIDs []attr.Value
+	for _, t := range host.ParentTemplates {
+		if linkedByName[t.TemplateID] && !byID[t.TemplateID] {
+			continue
+		}
+		templateIDs = append(templateIDs, types.StringValue(t.TemplateID))
+	}
+	if len(templateIDs) > 0 {
+		templatesSet, d := types.SetValue(types.StringType, templateIDs)
 		diags.Append(d...)
-		data.Templates = templatesList
+		data.Templates = templatesSet
 	} else {
-		data.Templates = types.ListNull(types.StringType)
+		data.Templates = types.SetNull(types.StringType)
 	}
 
-	// Convert interfaces - sort by interface_id for stable ordering
-	sort.Slice(host.Interfaces, func(i, j int) bool {
-		return host.Interfaces[i].InterfaceID < host.Interfaces[j].InterfaceID
-	})
+	if data.UnlinkMode.IsNull() || data.UnlinkMode.IsUnknown() {
+		data.UnlinkMode = types.StringValue("unlink")
+	}
+
+	// Keep only the configured template names that are still linked
+	if data.TemplateNames.IsNull() || data.TemplateNames.IsUnknown() {
+		data.TemplateNames = types.SetNull(types.StringType)
+		data.TemplateNameIDs = types.MapNull(types.StringType)
+	} else {
+		templateNames := make([]attr.Value, 0, len(configuredNames))
+		for _, name := range configuredNames {
+			if _, ok := linkedNames[name]; ok {
+				templateNames = append(templateNames, types.StringValue(name))
+			}
+		}
+		templateNamesSet, d := types.SetValue(types.StringType, templateNames)
+		diags.Append(d...)
+		data.TemplateNames = templateNamesSet
+		data.TemplateNameIDs, d = types.MapValueFrom(ctx, types.StringType, linkedNames)
+		diags.Append(d...)
+	}
+
+	// Convert interfaces in the order of the prior interfaces, so reordering by the API is not a change
+	apiInterfaces, d := orderHostInterfaces(ctx, data.Interfaces, host.Interfaces)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
 	interfaceType := types.ObjectType{
 		AttrTypes: map[string]attr.Type{
 			"interface_id": types.StringType,
@@ -472,10 +1257,15 @@ func (r *HostResource) apiToModel(ctx context.Context, host *zabbix.Host, data *
 			"port":         types.StringType,
 			"main":         types.BoolType,
 			"use_ip":       types.BoolType,
+			"available":    types.StringType,
+			"error":        types.StringType,
+			"snmp":         types.ObjectType{AttrTypes: hostInterfaceSNMPAttrTypes},
 		},
 	}
-	interfaceValues := make([]attr.Value, len(host.Interfaces))
-	for i, iface := range host.Interfaces {
+	interfaceValues := make([]attr.Value, len(apiInterfaces))
+	for i, iface := range apiInterfaces {
+		snmp, d := snmpDetailsToObject(iface.Details)
+		diags.Append(d...)
 		obj, d := types.ObjectValue(interfaceType.AttrTypes, map[string]attr.Value{
 			"interface_id": types.StringValue(iface.InterfaceID),
 			"type":         types.StringValue(interfaceTypeToString(iface.Type)),
@@ -484,47 +1274,224 @@ func (r *HostResource) apiToModel(ctx context.Context, host *zabbix.Host, data *
 			"port":         types.StringValue(iface.Port),
 			"main":         types.BoolValue(iface.Main == 1),
 			"use_ip":       types.BoolValue(iface.UseIP == 1),
+			"available":    types.StringValue(availabilityToString(iface.Available)),
+			"error":        types.StringValue(iface.Error),
+			"snmp":         snmp,
 		})
 		diags.Append(d...)
 		interfaceValues[i] = obj
 	}
-	interfacesList, d := types.ListValue(interfaceType, interfaceValues)
+	if len(interfaceValues) == 0 && data.Interfaces.IsNull() {
+		data.Interfaces = types.ListNull(interfaceType)
+	} else {
+		interfacesList, d := types.ListValue(interfaceType, interfaceValues)
+		diags.Append(d...)
+		data.Interfaces = interfacesList
+	}
+
+	// Convert tags; tags added from the default tags are only shown in tags_all
+	allTags := make([]tagValue, len(host.Tags))
+	for i, tag := range host.Tags {
+		allTags[i] = tagValue{Tag: tag.Tag, Value: tag.Value}
+	}
+	tagsAll, d := tagsAllValue(allTags)
 	diags.Append(d...)
-	data.Interfaces = interfacesList
-
-	// Convert tags
-	if len(host.Tags) > 0 {
-		tagType := types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"tag":   types.StringType,
-				"value": types.StringType,
-			},
-		}
-		tagValues := make([]attr.Value, len(host.Tags))
-		for i, tag := range host.Tags {
-			obj, d := types.ObjectValue(tagType.AttrTypes, map[string]attr.Value{
-				"tag":   types.StringValue(tag.Tag),
-				"value": types.StringValue(tag.Value),
-			})
-			diags.Append(d...)
-			tagValues[i] = obj
-		}
-		tagsList, d := types.ListValue(tagType, tagValues)
+	data.TagsAll = tagsAll
+
+	configured, d := tagValuesFromModel(ctx, data.Tags)
+	diags.Append(d...)
+	if tags := withoutDefaultTags(r.defaultTags, allTags, configured); len(tags) > 0 {
+		tagValues, d := tagObjects(tags)
+		diags.Append(d...)
+		tagsSet, d := types.SetValue(tagObjectType, tagValues)
 		diags.Append(d...)
-		data.Tags = tagsList
+		data.Tags = tagsSet
 	} else {
-		tagType := types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"tag":   types.StringType,
-				"value": types.StringType,
-			},
+		data.Tags = types.SetNull(tagObjectType)
+	}
+
+	return diags
+}
+
+// updateGroupsAdditive adds the groups new to the plan and removes the groups dropped from it,
+// leaving any other groups of the host in place.
+func (r *HostResource) updateGroupsAdditive(ctx context.Context, hostID string, state, plan *HostResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var prior, desired []string
+	diags.Append(state.Groups.ElementsAs(ctx, &prior, false)...)
+	diags.Append(plan.Groups.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	added := stringsNotIn(desired, prior)
+	removed := stringsNotIn(prior, desired)
+
+	// Groups are added first so the host never ends up without a group
+	if len(added) > 0 {
+		if err := r.client.MassAddHostGroups(ctx, []string{hostID}, added); err != nil {
+			diags.AddError(
+				"Error Updating Host Groups",
+				fmt.Sprintf("Could not add groups to host ID %s: %s", hostID, err),
+			)
+			return diags
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := r.client.MassRemoveHostGroups(ctx, []string{hostID}, removed); err != nil {
+			diags.AddError(
+				"Error Updating Host Groups",
+				fmt.Sprintf("Could not remove groups from host ID %s: %s", hostID, err),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// applyHostSecrets copies the write-only secrets from the configuration to the API struct.
+func applyHostSecrets(ctx context.Context, config tfsdk.Config, host *zabbix.Host) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var tlsPSK, ipmiPassword types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("tls_psk_wo"), &tlsPSK)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("ipmi_password_wo"), &ipmiPassword)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	host.TLSPSK = tlsPSK.ValueString()
+	host.IPMIPassword = ipmiPassword.ValueString()
+
+	// The interfaces of the API struct are in the order of the configured interfaces
+	passphrases, d := snmpPassphrasesFromConfig(ctx, config)
+	diags.Append(d...)
+	for i := range host.Interfaces {
+		if details := host.Interfaces[i].Details; details != nil && i < len(passphrases) {
+			details.AuthPassphrase, details.PrivPassphrase = passphrases[i][0], passphrases[i][1]
+		}
+	}
+
+	return diags
+}
+
+// hostSecretHashesFromConfig hashes the write-only secrets of the configuration.
+func hostSecretHashesFromConfig(ctx context.Context, config tfsdk.Config) (hostSecretHashes, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var hashes hostSecretHashes
+
+	var tlsPSK, ipmiPassword types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("tls_psk_wo"), &tlsPSK)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("ipmi_password_wo"), &ipmiPassword)...)
+	if diags.HasError() {
+		return hashes, diags
+	}
+
+	hashes.TLSPSK = hashSecret(tlsPSK.ValueString())
+	hashes.IPMIPassword = hashSecret(ipmiPassword.ValueString())
+
+	passphrases, d := snmpPassphrasesFromConfig(ctx, config)
+	diags.Append(d...)
+	var joined strings.Builder
+	for _, pair := range passphrases {
+		if pair[0] != "" || pair[1] != "" {
+			fmt.Fprintf(&joined, "%q %q\n", pair[0], pair[1])
 		}
-		data.Tags = types.ListNull(tagType)
+	}
+	hashes.SNMPPassphrases = hashSecret(joined.String())
+
+	return hashes, diags
+}
+
+// privateState is implemented by the private state data of requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// readHostSecretHashes returns the secret hashes stored in private state.
+func readHostSecretHashes(ctx context.Context, private privateState) (hostSecretHashes, diag.Diagnostics) {
+	var hashes hostSecretHashes
+
+	raw, diags := private.GetKey(ctx, hostSecretHashesKey)
+	if diags.HasError() || len(raw) == 0 {
+		return hashes, diags
+	}
+
+	if err := json.Unmarshal(raw, &hashes); err != nil {
+		diags.AddError(
+			"Error Reading Private State",
+			fmt.Sprintf("Could not decode stored secret hashes: %s", err),
+		)
+	}
+
+	return hashes, diags
+}
+
+// writeHostSecretHashes stores the hashes of the configured secrets in private state.
+func writeHostSecretHashes(ctx context.Context, config tfsdk.Config, private privateState) diag.Diagnostics {
+	hashes, diags := hostSecretHashesFromConfig(ctx, config)
+	if diags.HasError() {
+		return diags
 	}
 
+	raw, err := json.Marshal(hashes)
+	if err != nil {
+		diags.AddError(
+			"Error Writing Private State",
+			fmt.Sprintf("Could not encode secret hashes: %s", err),
+		)
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, hostSecretHashesKey, raw)...)
 	return diags
 }
 
+// hashSecret returns the hex encoded SHA-256 hash of a secret, or an empty string when no secret is set.
+func hashSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// stringsNotIn returns the values of a that are not present in b.
+func stringsNotIn(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, v := range b {
+		present[v] = true
+	}
+
+	var result []string
+	for _, v := range a {
+		if !present[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// templatesToClear returns the currently linked templates that are not part of the desired template list.
+func templatesToClear(linked []zabbix.ParentTemplate, desired []zabbix.TemplateID) []zabbix.TemplateID {
+	keep := make(map[string]bool, len(desired))
+	for _, t := range desired {
+		keep[t.TemplateID] = true
+	}
+
+	var cleared []zabbix.TemplateID
+	for _, t := range linked {
+		if !keep[t.TemplateID] {
+			cleared = append(cleared, zabbix.TemplateID{TemplateID: t.TemplateID})
+		}
+	}
+	return cleared
+}
+
 // interfaceTypeToInt converts interface type string to Zabbix API integer.
 func interfaceTypeToInt(t string) int {
 	switch t {
@@ -557,6 +1524,18 @@ func interfaceTypeToString(t int) string {
 	}
 }
 
+// availabilityToString converts a Zabbix availability value to its string representation.
+func availabilityToString(a int) string {
+	switch a {
+	case 1:
+		return "available"
+	case 2:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
 // boolToInt converts bool to Zabbix API integer (0 or 1).
 func boolToInt(b bool) int {
 	if b {
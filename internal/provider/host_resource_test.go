@@ -1,14 +1,31 @@
-// ABOUTME: Acceptance tests for the zabbix_host resource.
-// ABOUTME: Tests full CRUD lifecycle, interfaces, templates, tags, and import functionality.
+// ABOUTME: Acceptance and unit tests for the zabbix_host resource.
+// ABOUTME: Tests full CRUD lifecycle, interfaces, templates, tags, import, state upgrades, and CRUD logic against a fake client.
 
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 func TestAccHostResource_basic(t *testing.T) {
@@ -24,6 +41,11 @@ func TestAccHostResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("zabbix_host.test", "host", rName),
 					resource.TestCheckResourceAttr("zabbix_host.test", "name", rName+"-display"),
 					resource.TestCheckResourceAttr("zabbix_host.test", "status", "0"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "discovered", "false"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "maintenance_status", "0"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "deletion_protection", "false"),
+					resource.TestCheckResourceAttrSet("zabbix_host.test", "active_available"),
+					resource.TestCheckResourceAttrSet("zabbix_host.test", "interfaces.0.available"),
 					resource.TestCheckResourceAttrSet("zabbix_host.test", "id"),
 					resource.TestCheckResourceAttr("zabbix_host.test", "interfaces.#", "1"),
 					resource.TestCheckResourceAttr("zabbix_host.test", "interfaces.0.type", "agent"),
@@ -94,6 +116,42 @@ func TestAccHostResource_withTags(t *testing.T) {
 	})
 }
 
+func TestAccHostResource_defaultTags(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigDefaultTags(rName, "platform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "tags_all.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_host.test", "tags_all.*", map[string]string{
+						"tag":   "environment",
+						"value": "test",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_host.test", "tags_all.*", map[string]string{
+						"tag":   "owner",
+						"value": "platform",
+					}),
+				),
+			},
+			{
+				Config: testAccHostResourceConfigDefaultTags(rName, "sre"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_host.test", "tags_all.*", map[string]string{
+						"tag":   "owner",
+						"value": "sre",
+					}),
+				),
+			},
+		},
+	})
+}
+
 func TestAccHostResource_multipleInterfaces(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 
@@ -112,6 +170,90 @@ func TestAccHostResource_multipleInterfaces(t *testing.T) {
 	})
 }
 
+func TestAccHostResource_withoutInterfaces(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigWithoutInterfaces(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "host", rName),
+					resource.TestCheckNoResourceAttr("zabbix_host.test", "interfaces"),
+				),
+			},
+			{
+				ResourceName:      "zabbix_host.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccHostResourceConfigBasic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "interfaces.#", "1"),
+				),
+			},
+			{
+				Config: testAccHostResourceConfigWithoutInterfaces(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zabbix_host.test", "interfaces"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHostResource_invalidInterfaces(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigInterface(rName, `{
+    type   = "agent"
+    ip     = "192.168.1.300"
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }`),
+				ExpectError: regexp.MustCompile("Invalid Interface IP Address"),
+			},
+			{
+				Config: testAccHostResourceConfigInterface(rName, `{
+    type   = "agent"
+    ip     = ""
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = false
+  }`),
+				ExpectError: regexp.MustCompile("Missing Interface DNS Name"),
+			},
+			{
+				Config: testAccHostResourceConfigInterface(rName, `{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    port   = "10050"
+    main   = true
+    use_ip = true
+  },
+  {
+    type   = "agent"
+    ip     = "192.168.1.101"
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }`),
+				ExpectError: regexp.MustCompile("Multiple Main Interfaces"),
+			},
+		},
+	})
+}
+
 func TestAccHostResource_multipleGroups(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 
@@ -130,6 +272,113 @@ func TestAccHostResource_multipleGroups(t *testing.T) {
 	})
 }
 
+func TestAccHostResource_additiveGroups(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigAdditiveGroups(rName, "zabbix_host_group.test.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "group_mode", "additive"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "groups.#", "1"),
+					testAccAddHostToGroup("zabbix_host.test", "zabbix_host_group.external"),
+				),
+			},
+			{
+				// The group assigned outside Terraform does not show up as drift
+				Config:   testAccHostResourceConfigAdditiveGroups(rName, "zabbix_host_group.test.id"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccHostResourceConfigAdditiveGroups(rName, "zabbix_host_group.other.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("zabbix_host.test", "groups.*", "zabbix_host_group.other", "id"),
+					testAccCheckHostInGroup("zabbix_host.test", "zabbix_host_group.external"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHostResource_orderInsensitive(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigOrdered(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "groups.#", "2"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "tags.#", "2"),
+				),
+			},
+			{
+				Config:   testAccHostResourceConfigOrdered(rName, true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestHostResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &HostResource{}
+
+	rawState := `{
+		"id": "10084",
+		"host": "test-server",
+		"name": "Test Server",
+		"groups": ["2", "5"],
+		"templates": ["10001"],
+		"template_names": null,
+		"unlink_mode": "unlink",
+		"status": 0,
+		"interfaces": [{"interface_id": "1", "type": "agent", "ip": "192.168.1.100", "dns": "", "port": "10050", "main": true, "use_ip": true}],
+		"tags": [{"tag": "environment", "value": "test"}]
+	}`
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	req := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+	}
+	resp := &fwresource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+
+	r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+	if resp.DynamicValue == nil {
+		t.Fatal("expected upgraded state, got nil")
+	}
+
+	value, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("failed to unmarshal upgraded state: %s", err)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := value.As(&attrs); err != nil {
+		t.Fatalf("failed to read upgraded state: %s", err)
+	}
+	if !attrs["groups"].Type().Is(tftypes.Set{}) {
+		t.Errorf("expected groups to be a set, got %s", attrs["groups"].Type())
+	}
+	if !attrs["tags"].Type().Is(tftypes.Set{}) {
+		t.Errorf("expected tags to be a set, got %s", attrs["tags"].Type())
+	}
+}
+
 func testAccHostResourceConfigBasic(name string) string {
 	return fmt.Sprintf(`
 resource "zabbix_host_group" "test" {
@@ -213,6 +462,42 @@ resource "zabbix_host" "test" {
 `, name)
 }
 
+func testAccHostResourceConfigDefaultTags(name, owner string) string {
+	return fmt.Sprintf(`
+provider "zabbix" {
+  default_tags = {
+    environment = "default"
+    owner       = %[2]q
+  }
+}
+
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host   = %[1]q
+  groups = [zabbix_host_group.test.id]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+
+  tags = [
+    {
+      tag   = "environment"
+      value = "test"
+    }
+  ]
+}
+`, name, owner)
+}
+
 func testAccHostResourceConfigMultipleInterfaces(name string) string {
 	return fmt.Sprintf(`
 resource "zabbix_host_group" "test" {
@@ -247,6 +532,120 @@ resource "zabbix_host" "test" {
 `, name)
 }
 
+func testAccHostResourceConfigAdditiveGroups(name, group string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host_group" "other" {
+  name = "%[1]s-other"
+}
+
+resource "zabbix_host_group" "external" {
+  name = "%[1]s-external"
+}
+
+resource "zabbix_host" "test" {
+  host       = %[1]q
+  groups     = [%[2]s]
+  group_mode = "additive"
+}
+`, name, group)
+}
+
+// testAccAddHostToGroup adds the host to a group outside of Terraform, as other tooling would.
+func testAccAddHostToGroup(hostResource, groupResource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		host, ok := s.RootModule().Resources[hostResource]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", hostResource)
+		}
+		group, ok := s.RootModule().Resources[groupResource]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", groupResource)
+		}
+
+		client := zabbix.NewClient(os.Getenv("ZABBIX_URL"), zabbix.WithToken(os.Getenv("ZABBIX_API_TOKEN")))
+		return client.MassAddHostGroups(context.Background(), []string{host.Primary.ID}, []string{group.Primary.ID})
+	}
+}
+
+// testAccCheckHostInGroup verifies in Zabbix that the host is still a member of the group.
+func testAccCheckHostInGroup(hostResource, groupResource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		host, ok := s.RootModule().Resources[hostResource]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", hostResource)
+		}
+		group, ok := s.RootModule().Resources[groupResource]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", groupResource)
+		}
+
+		client := zabbix.NewClient(os.Getenv("ZABBIX_URL"), zabbix.WithToken(os.Getenv("ZABBIX_API_TOKEN")))
+		apiHost, err := client.GetHost(context.Background(), host.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if apiHost == nil {
+			return fmt.Errorf("host %s not found", host.Primary.ID)
+		}
+		for _, g := range apiHost.Groups {
+			if g.GroupID == group.Primary.ID {
+				return nil
+			}
+		}
+		return fmt.Errorf("host %s is no longer in group %s", host.Primary.ID, group.Primary.ID)
+	}
+}
+
+func testAccHostResourceConfigPSK(name, identity, psk string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host        = %[1]q
+  groups      = [zabbix_host_group.test.id]
+  tls_connect = 2
+  tls_accept  = 2
+  tls_psk_wo  = %[3]q
+  %[2]s
+}
+`, name, identity, psk)
+}
+
+func testAccHostResourceConfigWithoutInterfaces(name string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host   = %[1]q
+  name   = "%[1]s-display"
+  groups = [zabbix_host_group.test.id]
+}
+`, name)
+}
+
+func testAccHostResourceConfigInterface(name, iface string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host   = %[1]q
+  groups = [zabbix_host_group.test.id]
+
+  interfaces = [%[2]s]
+}
+`, name, iface)
+}
+
 func testAccHostResourceConfigMultipleGroups(name string) string {
 	return fmt.Sprintf(`
 resource "zabbix_host_group" "test1" {
@@ -275,6 +674,135 @@ resource "zabbix_host" "test" {
 `, name)
 }
 
+func testAccHostResourceConfigOrdered(name string, reversed bool) string {
+	groups := "[zabbix_host_group.test1.id, zabbix_host_group.test2.id]"
+	tags := `[{ tag = "environment", value = "test" }, { tag = "team", value = "platform" }]`
+	if reversed {
+		groups = "[zabbix_host_group.test2.id, zabbix_host_group.test1.id]"
+		tags = `[{ tag = "team", value = "platform" }, { tag = "environment", value = "test" }]`
+	}
+
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test1" {
+  name = "%[1]s-group1"
+}
+
+resource "zabbix_host_group" "test2" {
+  name = "%[1]s-group2"
+}
+
+resource "zabbix_host" "test" {
+  host   = %[1]q
+  groups = %[2]s
+  tags   = %[3]s
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+`, name, groups, tags)
+}
+
+func TestAccHostResource_withPSK(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigPSK(rName, `tls_psk_identity = "psk-001"`, "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "tls_connect", "2"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "tls_accept", "2"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "tls_psk_identity", "psk-001"),
+					resource.TestCheckNoResourceAttr("zabbix_host.test", "tls_psk_wo"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "secrets_revision", "0"),
+				),
+			},
+			{
+				// Re-applying the same secret produces no diff
+				Config:   testAccHostResourceConfigPSK(rName, `tls_psk_identity = "psk-001"`, "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccHostResourceConfigPSK(rName, `tls_psk_identity = "psk-001"`, "af87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "secrets_revision", "1"),
+				),
+			},
+			{
+				Config:      testAccHostResourceConfigPSK(rName, "", "af87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"),
+				ExpectError: regexp.MustCompile("Missing PSK Identity"),
+			},
+		},
+	})
+}
+
+func TestHostInterfacesChanged(t *testing.T) {
+	ctx := context.Background()
+
+	interfaceType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"interface_id": types.StringType,
+			"type":         types.StringType,
+			"ip":           types.StringType,
+			"dns":          types.StringType,
+			"port":         types.StringType,
+			"main":         types.BoolType,
+			"use_ip":       types.BoolType,
+			"available":    types.StringType,
+			"error":        types.StringType,
+			"snmp":         types.ObjectType{AttrTypes: hostInterfaceSNMPAttrTypes},
+		},
+	}
+	newList := func(ip string, dns types.String) types.List {
+		obj := types.ObjectValueMust(interfaceType.AttrTypes, map[string]attr.Value{
+			"interface_id": types.StringValue("1"),
+			"type":         types.StringValue("agent"),
+			"ip":           types.StringValue(ip),
+			"dns":          dns,
+			"port":         types.StringValue("10050"),
+			"main":         types.BoolValue(true),
+			"use_ip":       types.BoolValue(true),
+			"available":    types.StringValue("unknown"),
+			"error":        types.StringValue(""),
+			"snmp":         types.ObjectNull(hostInterfaceSNMPAttrTypes),
+		})
+		return types.ListValueMust(interfaceType, []attr.Value{obj})
+	}
+
+	state := newList("192.168.1.100", types.StringValue(""))
+
+	tests := []struct {
+		name string
+		plan types.List
+		want bool
+	}{
+		{"unchanged", newList("192.168.1.100", types.StringValue("")), false},
+		{"unknown dns", newList("192.168.1.100", types.StringUnknown()), false},
+		{"changed ip", newList("192.168.1.200", types.StringValue("")), true},
+		{"removed", types.ListNull(interfaceType), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := hostInterfacesChanged(ctx, tt.plan, state)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestAccHostResource_withTemplates(t *testing.T) {
 	rName := acctest.RandomWithPrefix("tf-acc-test")
 
@@ -327,6 +855,76 @@ func TestAccHostResource_templateUpdate(t *testing.T) {
 	})
 }
 
+func TestAccHostResource_templateUnlinkAndClear(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigWithMultipleTemplatesUnlinkMode(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "templates.#", "2"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "unlink_mode", "unlink_and_clear"),
+				),
+			},
+			{
+				Config: testAccHostResourceConfigWithMultipleTemplatesUnlinkMode(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "templates.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHostResourceConfigWithMultipleTemplatesUnlinkMode(name string, linkSecond bool) string {
+	templates := "[zabbix_template.test.id]"
+	if linkSecond {
+		templates = "[zabbix_template.test.id, zabbix_template.test2.id]"
+	}
+
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_template_group" "test" {
+  name = "%[1]s-tpl-group"
+}
+
+resource "zabbix_template" "test" {
+  host   = "%[1]s-template"
+  name   = "%[1]s-template-display"
+  groups = [zabbix_template_group.test.id]
+}
+
+resource "zabbix_template" "test2" {
+  host   = "%[1]s-template2"
+  name   = "%[1]s-template2-display"
+  groups = [zabbix_template_group.test.id]
+}
+
+resource "zabbix_host" "test" {
+  host        = %[1]q
+  name        = "%[1]s-display"
+  groups      = [zabbix_host_group.test.id]
+  templates   = %[2]s
+  unlink_mode = "unlink_and_clear"
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+`, name, templates)
+}
+
 func testAccHostResourceConfigWithTemplates(name string) string {
 	return fmt.Sprintf(`
 resource "zabbix_host_group" "test" {
@@ -402,3 +1000,506 @@ resource "zabbix_host" "test" {
 }
 `, name)
 }
+
+func TestAccHostResource_withTemplateNames(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostResourceConfigWithTemplateNames(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_host.test", "template_names.#", "1"),
+					resource.TestCheckResourceAttr("zabbix_host.test", "template_names.0", rName+"-template"),
+					resource.TestCheckResourceAttrPair("zabbix_host.test", "template_name_ids."+rName+"-template", "zabbix_template.test", "id"),
+					resource.TestCheckNoResourceAttr("zabbix_host.test", "templates"),
+				),
+			},
+			{
+				Config:   testAccHostResourceConfigWithTemplateNames(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccHostResourceConfigWithTemplateNames(name string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_template_group" "test" {
+  name = "%[1]s-tpl-group"
+}
+
+resource "zabbix_template" "test" {
+  host   = "%[1]s-template"
+  name   = "%[1]s-template-display"
+  groups = [zabbix_template_group.test.id]
+}
+
+resource "zabbix_host" "test" {
+  host           = %[1]q
+  name           = "%[1]s-display"
+  groups         = [zabbix_host_group.test.id]
+  template_names = [zabbix_template.test.host]
+  status         = 0
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+`, name)
+}
+
+// hostInterfaceType is the Terraform type of an element of the interfaces attribute.
+var hostInterfaceType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"interface_id": tftypes.String,
+	"type":         tftypes.String,
+	"ip":           tftypes.String,
+	"dns":          tftypes.String,
+	"port":         tftypes.String,
+	"main":         tftypes.Bool,
+	"use_ip":       tftypes.Bool,
+	"available":    tftypes.String,
+	"error":        tftypes.String,
+	"snmp":         hostInterfaceSNMPType,
+}}
+
+// hostInterfaceSNMPType is the Terraform type of the snmp attribute of an interface.
+var hostInterfaceSNMPType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"version":            tftypes.Number,
+	"bulk":               tftypes.Bool,
+	"community":          tftypes.String,
+	"security_name":      tftypes.String,
+	"security_level":     tftypes.String,
+	"auth_protocol":      tftypes.String,
+	"auth_passphrase_wo": tftypes.String,
+	"priv_protocol":      tftypes.String,
+	"priv_passphrase_wo": tftypes.String,
+	"context_name":       tftypes.String,
+}}
+
+// hostValues returns the planned values of a host in the groups with an agent interface on ip.
+func hostValues(host, ip string, groupIDs ...string) map[string]tftypes.Value {
+	groups := make([]tftypes.Value, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = tftypes.NewValue(tftypes.String, id)
+	}
+
+	unknown := func(typ tftypes.Type) tftypes.Value { return tftypes.NewValue(typ, tftypes.UnknownValue) }
+	tagType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tag": tftypes.String, "value": tftypes.String}}
+
+	return map[string]tftypes.Value{
+		"id":                  unknown(tftypes.String),
+		"host":                tftypes.NewValue(tftypes.String, host),
+		"name":                unknown(tftypes.String),
+		"groups":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, groups),
+		"group_mode":          tftypes.NewValue(tftypes.String, "authoritative"),
+		"unlink_mode":         tftypes.NewValue(tftypes.String, "unlink"),
+		"status":              tftypes.NewValue(tftypes.Number, 0),
+		"ignore_status_drift": tftypes.NewValue(tftypes.Bool, false),
+		"discovered":          unknown(tftypes.Bool),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"maintenance_status":  unknown(tftypes.Number),
+		"active_available":    unknown(tftypes.String),
+		"tls_connect":         tftypes.NewValue(tftypes.Number, 1),
+		"tls_accept":          tftypes.NewValue(tftypes.Number, 1),
+		"ipmi_username":       tftypes.NewValue(tftypes.String, ""),
+		"secrets_revision":    unknown(tftypes.Number),
+		"interfaces": tftypes.NewValue(tftypes.List{ElementType: hostInterfaceType}, []tftypes.Value{
+			tftypes.NewValue(hostInterfaceType, map[string]tftypes.Value{
+				"interface_id": unknown(tftypes.String),
+				"type":         tftypes.NewValue(tftypes.String, "agent"),
+				"ip":           tftypes.NewValue(tftypes.String, ip),
+				"dns":          unknown(tftypes.String),
+				"port":         tftypes.NewValue(tftypes.String, "10050"),
+				"main":         tftypes.NewValue(tftypes.Bool, true),
+				"use_ip":       tftypes.NewValue(tftypes.Bool, true),
+				"available":    unknown(tftypes.String),
+				"error":        unknown(tftypes.String),
+				"snmp":         tftypes.NewValue(hostInterfaceSNMPType, nil),
+			}),
+		}),
+		"tags_all": unknown(tftypes.Set{ElementType: tagType}),
+	}
+}
+
+func TestHostResource_CRUD(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	h.mustSucceed("create", h.create(hostValues("web01", "192.0.2.10", "2")))
+	var created HostResourceModel
+	h.model(&created)
+	if created.ID.ValueString() != "101" || created.Name.ValueString() != "web01" {
+		t.Errorf("expected host 101 named web01, got %s named %s", created.ID, created.Name)
+	}
+
+	var interfaces []HostInterfaceModel
+	h.mustSucceed("decode interfaces", created.Interfaces.ElementsAs(context.Background(), &interfaces, false))
+	if len(interfaces) != 1 || interfaces[0].InterfaceID.ValueString() != "102" {
+		t.Errorf("expected the interface ID assigned by Zabbix, got %+v", interfaces)
+	}
+
+	h.mustSucceed("read", h.read())
+
+	h.mustSucceed("update", h.update(hostValues("web01", "192.0.2.20", "2", "3")))
+	updated := client.hosts["101"]
+	if len(updated.Groups) != 2 || updated.Interfaces[0].IP != "192.0.2.20" {
+		t.Errorf("expected the host to be moved to 2 groups with a new IP, got %+v", updated)
+	}
+
+	h.mustSucceed("delete", h.delete())
+	if len(client.hosts) != 0 {
+		t.Errorf("expected the host to be deleted, got %v", client.hosts)
+	}
+
+	expected := []string{"CreateHost", "GetHost", "GetHost", "UpdateHost", "GetHost", "DeleteHost"}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+}
+
+func TestHostResource_ReadRemoved(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), client)
+	h.mustSucceed("create", h.create(hostValues("web01", "192.0.2.10", "2")))
+
+	delete(client.hosts, "101")
+	h.mustSucceed("read", h.read())
+	if !h.removed() {
+		t.Error("expected the host deleted outside of Terraform to be removed from state")
+	}
+}
+
+func TestHostResource_Identity(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), client)
+	h.mustSucceed("create", h.create(hostValues("web01", "192.0.2.10", "2")))
+
+	var identity hostIdentityModel
+	h.identityModel(&identity)
+	if identity.HostID.ValueString() != "101" {
+		t.Errorf("expected the identity of host 101, got %+v", identity)
+	}
+}
+
+func TestHostResource_SNMPv3Interface(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	values := hostValues("switch01", "192.0.2.1", "2")
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	values["interfaces"] = tftypes.NewValue(tftypes.List{ElementType: hostInterfaceType}, []tftypes.Value{
+		tftypes.NewValue(hostInterfaceType, map[string]tftypes.Value{
+			"interface_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"type":         str("snmp"),
+			"ip":           str("192.0.2.1"),
+			"dns":          str(""),
+			"port":         str("161"),
+			"main":         tftypes.NewValue(tftypes.Bool, true),
+			"use_ip":       tftypes.NewValue(tftypes.Bool, true),
+			"available":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"error":        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"snmp": tftypes.NewValue(hostInterfaceSNMPType, map[string]tftypes.Value{
+				"version":            tftypes.NewValue(tftypes.Number, 3),
+				"bulk":               tftypes.NewValue(tftypes.Bool, true),
+				"community":          str(""),
+				"security_name":      str("monitor"),
+				"security_level":     str("authPriv"),
+				"auth_protocol":      str("sha256"),
+				"auth_passphrase_wo": str("auth-secret"),
+				"priv_protocol":      str("aes256"),
+				"priv_passphrase_wo": str("priv-secret"),
+				"context_name":       str(""),
+			}),
+		}),
+	})
+	h.mustSucceed("create", h.create(values))
+
+	details := client.hosts["101"].Interfaces[0].Details
+	if details == nil || details.SecurityLevel != 2 || details.AuthProtocol != 3 || details.PrivProtocol != 3 {
+		t.Fatalf("expected SNMPv3 authPriv details with SHA256 and AES256, got %+v", details)
+	}
+	if details.AuthPassphrase != "auth-secret" || details.PrivPassphrase != "priv-secret" {
+		t.Errorf("expected the passphrases from the configuration to be sent, got %+v", details)
+	}
+
+	var data HostResourceModel
+	h.model(&data)
+	var interfaces []HostInterfaceModel
+	h.mustSucceed("decode interfaces", data.Interfaces.ElementsAs(context.Background(), &interfaces, false))
+	var snmp HostInterfaceSNMPModel
+	h.mustSucceed("decode snmp", interfaces[0].SNMP.As(context.Background(), &snmp, basetypes.ObjectAsOptions{}))
+	if snmp.SecurityName.ValueString() != "monitor" || snmp.AuthProtocol.ValueString() != "sha256" {
+		t.Errorf("expected the SNMPv3 settings in state, got %+v", snmp)
+	}
+	if !snmp.AuthPassphrase.IsNull() || !snmp.PrivPassphrase.IsNull() {
+		t.Error("expected the write-only passphrases not to be stored in state")
+	}
+}
+
+func TestHostResource_AlreadyExists(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.hosts["7"] = &zabbix.Host{HostID: "7", Host: "web01"}
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	diags := h.create(hostValues("web01", "192.0.2.10", "2"))
+	h.mustFail("create", diags, "Error Creating Host")
+	if !strings.Contains(diags.Errors()[0].Detail(), "import it instead of creating it") {
+		t.Errorf("expected the error to suggest importing the host, got %q", diags.Errors()[0].Detail())
+	}
+}
+
+// lostHostAPI is a fake whose hosts cannot be read back after they are written.
+type lostHostAPI struct {
+	*fakeZabbixAPI
+}
+
+func (f lostHostAPI) GetHost(ctx context.Context, hostID string) (*zabbix.Host, error) {
+	return nil, f.call(ctx, "GetHost")
+}
+
+func TestHostResource_CreatedButNotFound(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), lostHostAPI{client})
+
+	diags := h.create(hostValues("web01", "192.0.2.10", "2"))
+	h.mustFail("create", diags, "Error Reading Host")
+	if !strings.Contains(diags.Errors()[0].Detail(), "was created but could not be found") {
+		t.Errorf("expected the error to explain the host is missing, got %q", diags.Errors()[0].Detail())
+	}
+
+	var data HostResourceModel
+	h.model(&data)
+	if data.ID.ValueString() != "101" || !data.Host.IsNull() {
+		t.Errorf("expected only the ID of the created host in state, got %+v", data)
+	}
+}
+
+func TestHostResource_ReadAfterCreateFails(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.errs["GetHost"] = errors.New("connection reset by peer")
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	h.mustFail("create", h.create(hostValues("web01", "192.0.2.10", "2")), "Error Reading Host")
+
+	// The next refresh reconciles the partial state with the created host
+	delete(client.errs, "GetHost")
+	h.mustSucceed("read", h.read())
+
+	var data HostResourceModel
+	h.model(&data)
+	if data.ID.ValueString() != "101" || data.Host.ValueString() != "web01" {
+		t.Errorf("expected the created host to be read into state, got %+v", data)
+	}
+
+	// The tainted host is replaced by deleting it before creating a new one
+	h.mustSucceed("delete", h.delete())
+	if len(client.hosts) != 0 {
+		t.Errorf("expected the host to be deleted, got %v", client.hosts)
+	}
+}
+
+func TestHostResource_DeletionProtection(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	values := hostValues("web01", "192.0.2.10", "2")
+	values["deletion_protection"] = tftypes.NewValue(tftypes.Bool, true)
+	h.mustSucceed("create", h.create(values))
+
+	h.mustFail("delete", h.delete(), "Deletion Protection Enabled")
+	if _, ok := client.hosts["101"]; !ok {
+		t.Error("expected the protected host to be kept")
+	}
+}
+
+func TestHostResource_APIErrors(t *testing.T) {
+	tests := map[string]struct {
+		method  string
+		run     func(h *resourceHarness) diag.Diagnostics
+		summary string
+	}{
+		"create": {
+			method:  "CreateHost",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.create(hostValues("web02", "192.0.2.11", "2")) },
+			summary: "Error Creating Host",
+		},
+		"read": {
+			method:  "GetHost",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.read() },
+			summary: "Error Reading Host",
+		},
+		"update": {
+			method:  "UpdateHost",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.update(hostValues("web01", "192.0.2.20", "2")) },
+			summary: "Error Updating Host",
+		},
+		"delete": {
+			method:  "DeleteHost",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.delete() },
+			summary: "Error Deleting Host",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeZabbixAPI()
+			h := newResourceHarness(t, NewHostResource(), client)
+			h.mustSucceed("create", h.create(hostValues("web01", "192.0.2.10", "2")))
+
+			client.errs[test.method] = errors.New("connection reset by peer")
+			h.mustFail(name, test.run(h), test.summary)
+		})
+	}
+}
+
+func TestHostResource_DuplicateTemplateLink(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.templates["20"] = &zabbix.Template{TemplateID: "20", Host: "Linux by Zabbix agent"}
+	client.templates["21"] = &zabbix.Template{TemplateID: "21", Host: "ICMP Ping"}
+
+	r := NewHostResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	modifyPlan := func(templateIDs ...string) diag.Diagnostics {
+		ids := make([]tftypes.Value, len(templateIDs))
+		for i, id := range templateIDs {
+			ids[i] = tftypes.NewValue(tftypes.String, id)
+		}
+		values := hostValues("web01", "192.0.2.10", "2")
+		values["templates"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, ids)
+		values["template_names"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "Linux by Zabbix agent"),
+		})
+
+		plan := testPlan(t, schemaResp, values)
+		resp := &fwresource.ModifyPlanResponse{Plan: plan}
+		r.(fwresource.ResourceWithModifyPlan).ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+			Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+			Plan:   plan,
+			State:  emptyState(t, schemaResp),
+		}, resp)
+		return resp.Diagnostics
+	}
+
+	if diags := modifyPlan("21"); diags.HasError() {
+		t.Fatalf("expected templates linked once to be valid, got %s", diags.Errors())
+	}
+
+	diags := modifyPlan("20", "21")
+	if !diags.HasError() {
+		t.Fatal("expected a template linked by ID and by name to be rejected")
+	}
+	if summary := diags.Errors()[0].Summary(); summary != "Duplicate Template" {
+		t.Errorf("unexpected diagnostic: %s", summary)
+	}
+}
+
+func TestHostResource_TemplateNameIDs(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.templates["20"] = &zabbix.Template{TemplateID: "20", Host: "Linux by Zabbix agent"}
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	values := hostValues("web01", "192.0.2.10", "2")
+	values["template_names"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "Linux by Zabbix agent"),
+	})
+	values["template_name_ids"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue)
+	h.mustSucceed("create", h.create(values))
+
+	expected := map[string]string{"Linux by Zabbix agent": "20"}
+	checkState := func(step string) {
+		t.Helper()
+		var data HostResourceModel
+		h.model(&data)
+		var names []string
+		var ids map[string]string
+		h.mustSucceed(step, data.TemplateNames.ElementsAs(ctx, &names, false))
+		h.mustSucceed(step, data.TemplateNameIDs.ElementsAs(ctx, &ids, false))
+		if !reflect.DeepEqual(names, []string{"Linux by Zabbix agent"}) || !reflect.DeepEqual(ids, expected) {
+			t.Errorf("%s: expected the template to stay linked by name, got names %v and IDs %v", step, names, ids)
+		}
+		if !data.Templates.IsNull() {
+			t.Errorf("%s: expected the template linked by name not to show up in templates, got %s", step, data.Templates)
+		}
+	}
+	checkState("create")
+
+	// A template renamed in Zabbix stays linked under the configured name
+	client.templates["20"].Host = "Linux by Zabbix agent active"
+	h.mustSucceed("read", h.read())
+	checkState("read")
+
+	// Unchanged names keep their IDs from state, so the update makes no lookups
+	client.calls = nil
+	values["template_name_ids"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"Linux by Zabbix agent": tftypes.NewValue(tftypes.String, "20"),
+	})
+	h.mustSucceed("update", h.update(values))
+	checkState("update")
+	if slices.Contains(client.calls, "TemplateIDByHost") {
+		t.Errorf("expected no template lookups on update, got calls %v", client.calls)
+	}
+	if templates := client.hosts["101"].Templates; len(templates) != 1 || templates[0].TemplateID != "20" {
+		t.Errorf("expected the host to stay linked to template 20, got %v", templates)
+	}
+}
+
+func TestHostResource_IgnoreStatusDrift(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostResource(), client)
+
+	values := hostValues("web01", "192.0.2.10", "2")
+	values["ignore_status_drift"] = tftypes.NewValue(tftypes.Bool, true)
+	h.mustSucceed("create", h.create(values))
+
+	status := func() int64 {
+		var data HostResourceModel
+		h.model(&data)
+		return data.Status.ValueInt64()
+	}
+
+	// The host is disabled temporarily in the frontend
+	client.hosts["101"].Status = 1
+	h.mustSucceed("read", h.read())
+	if status() != 0 {
+		t.Errorf("expected the manual status change to be ignored, got status %d", status())
+	}
+
+	values["name"] = tftypes.NewValue(tftypes.String, "Web 01")
+	h.mustSucceed("update", h.update(values))
+	if client.hosts["101"].Status != 1 || status() != 0 {
+		t.Errorf("expected an unrelated update to keep the manual status, got %d in Zabbix and %d in state", client.hosts["101"].Status, status())
+	}
+
+	// An explicit change of the configured status is still applied
+	values["status"] = tftypes.NewValue(tftypes.Number, 1)
+	h.mustSucceed("update", h.update(values))
+	values["status"] = tftypes.NewValue(tftypes.Number, 0)
+	h.mustSucceed("update", h.update(values))
+	if client.hosts["101"].Status != 0 || status() != 0 {
+		t.Errorf("expected the configured status to be applied, got %d in Zabbix and %d in state", client.hosts["101"].Status, status())
+	}
+
+	// Without the option the manual change shows up as drift
+	values["ignore_status_drift"] = tftypes.NewValue(tftypes.Bool, false)
+	h.mustSucceed("update", h.update(values))
+	client.hosts["101"].Status = 1
+	h.mustSucceed("read", h.read())
+	if status() != 1 {
+		t.Errorf("expected the manual status change to be read, got status %d", status())
+	}
+}
@@ -0,0 +1,851 @@
+// ABOUTME: Terraform resource for managing large numbers of uniform Zabbix hosts.
+// ABOUTME: Batches host.create, host.get, host.update and host.delete calls across all hosts.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/validators"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// hostsBulkBatchSize limits the number of hosts sent in a single API request.
+const hostsBulkBatchSize = 500
+
+var (
+	_ resource.Resource                   = &HostsBulkResource{}
+	_ resource.ResourceWithValidateConfig = &HostsBulkResource{}
+	_ resource.ResourceWithImportState    = &HostsBulkResource{}
+)
+
+// HostsBulkResource defines the resource implementation.
+type HostsBulkResource struct {
+	client ZabbixAPI
+}
+
+// HostsBulkResourceModel describes the resource data model.
+type HostsBulkResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Groups        types.Set    `tfsdk:"groups"`
+	Templates     types.Set    `tfsdk:"templates"`
+	Status        types.Int64  `tfsdk:"status"`
+	InterfacePort types.String `tfsdk:"interface_port"`
+	Hosts         types.Map    `tfsdk:"hosts"`
+	Timeouts      types.Object `tfsdk:"timeouts"`
+}
+
+// HostsBulkEntryModel describes a single host managed by the bulk resource.
+type HostsBulkEntryModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	IP          types.String `tfsdk:"ip"`
+	DNS         types.String `tfsdk:"dns"`
+	InterfaceID types.String `tfsdk:"interface_id"`
+}
+
+var hostsBulkEntryAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"ip":           types.StringType,
+	"dns":          types.StringType,
+	"interface_id": types.StringType,
+}
+
+// NewHostsBulkResource creates a new resource instance.
+func NewHostsBulkResource() resource.Resource {
+	return &HostsBulkResource{}
+}
+
+func (r *HostsBulkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts_bulk"
+}
+
+func (r *HostsBulkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a fleet of uniform Zabbix hosts that share groups, templates, status and a single agent interface. " +
+			"Hosts are created, read, updated and deleted in batches instead of one API call per host, which keeps plans fast for thousands of hosts. " +
+			"Changes to the shared settings of any host made outside of Terraform are detected, and the next apply sets the configured settings on all hosts again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the host fleet, derived from the technical names of the hosts at creation.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"groups": schema.SetAttribute{
+				Description: "Set of host group IDs every host belongs to.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					validators.UniqueIDs(),
+				},
+			},
+			"templates": schema.SetAttribute{
+				Description: "Set of template IDs to link to every host.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					validators.UniqueIDs(),
+				},
+			},
+			"status": schema.Int64Attribute{
+				Description: "Status of every host. 0 = enabled (default), 1 = disabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators: []validator.Int64{
+					int64validator.OneOf(0, 1),
+				},
+			},
+			"interface_port": schema.StringAttribute{
+				Description: "Port of the agent interface created on every host. Defaults to 10050.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("10050"),
+			},
+			"hosts": schema.MapNestedAttribute{
+				Description: "Hosts to manage, keyed by technical host name.",
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the host (hostid in Zabbix).",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"name": schema.StringAttribute{
+							Description: "Visible name of the host. Defaults to the technical name if not set. The current name is kept when name is removed from the configuration.",
+							Optional:    true,
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"ip": schema.StringAttribute{
+							Description: "IP address of the agent interface. The interface connects by IP when set and by DNS name otherwise.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+						"dns": schema.StringAttribute{
+							Description: "DNS name of the agent interface.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+						},
+						"interface_id": schema.StringAttribute{
+							Description: "ID of the agent interface (computed by Zabbix).",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *HostsBulkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *HostsBulkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HostsBulkResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Hosts.IsNull() || data.Hosts.IsUnknown() {
+		return
+	}
+
+	entries := make(map[string]HostsBulkEntryModel)
+	resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, entry := range entries {
+		entryPath := path.Root("hosts").AtMapKey(name)
+
+		if entry.IP.IsUnknown() || entry.DNS.IsUnknown() {
+			continue
+		}
+
+		ip := entry.IP.ValueString()
+		if ip != "" && net.ParseIP(ip) == nil {
+			resp.Diagnostics.AddAttributeError(
+				entryPath.AtName("ip"),
+				"Invalid Interface IP Address",
+				fmt.Sprintf("%q is not a valid IPv4 or IPv6 address.", ip),
+			)
+		}
+
+		if ip == "" && entry.DNS.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(
+				entryPath,
+				"Missing Interface Address",
+				fmt.Sprintf("Host %q must set ip or dns.", name),
+			)
+		}
+	}
+}
+
+func (r *HostsBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data HostsBulkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make(map[string]HostsBulkEntryModel)
+	resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names := sortedKeys(entries)
+
+	hosts, diags := r.modelToAPI(ctx, &data, names, entries, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created []string
+	for batch := range slices.Chunk(hosts, hostsBulkBatchSize) {
+		hostIDs, err := r.client.CreateHosts(ctx, batch)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating Hosts",
+				fmt.Sprintf("Could not create hosts: %s", err),
+			)
+			r.rollback(ctx, created, &resp.Diagnostics)
+			return
+		}
+		created = append(created, hostIDs...)
+	}
+
+	for i, name := range names {
+		entry := entries[name]
+		entry.ID = types.StringValue(created[i])
+		entries[name] = entry
+	}
+
+	data.ID = types.StringValue(hostsBulkID(names))
+
+	resp.Diagnostics.Append(r.refresh(ctx, &data, entries, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostsBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data HostsBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make(map[string]HostsBulkEntryModel)
+	resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.refresh(ctx, &data, entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.Hosts.Elements()) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostsBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data HostsBulkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state HostsBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make(map[string]HostsBulkEntryModel)
+	resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateEntries := make(map[string]HostsBulkEntryModel)
+	resp.Diagnostics.Append(state.Hosts.ElementsAs(ctx, &stateEntries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Hosts dropped from the map are deleted
+	var removed []string
+	for _, name := range sortedKeys(stateEntries) {
+		if _, ok := entries[name]; !ok {
+			removed = append(removed, stateEntries[name].ID.ValueString())
+		}
+	}
+	for batch := range slices.Chunk(removed, hostsBulkBatchSize) {
+		if err := r.client.DeleteHosts(ctx, batch); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Hosts",
+				fmt.Sprintf("Could not delete hosts removed from the fleet: %s", err),
+			)
+			return
+		}
+	}
+
+	var added, kept []string
+	for _, name := range sortedKeys(entries) {
+		if _, ok := stateEntries[name]; ok {
+			kept = append(kept, name)
+		} else {
+			added = append(added, name)
+		}
+	}
+
+	// Hosts still in the map are updated with the shared attributes
+	if len(kept) > 0 {
+		hosts, diags := r.modelToAPI(ctx, &data, kept, entries, stateEntries)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for batch := range slices.Chunk(hosts, hostsBulkBatchSize) {
+			if err := r.client.UpdateHosts(ctx, batch); err != nil {
+				resp.Diagnostics.AddError(
+					"Error Updating Hosts",
+					fmt.Sprintf("Could not update hosts: %s", err),
+				)
+				return
+			}
+		}
+		for _, name := range kept {
+			entry := entries[name]
+			entry.ID = stateEntries[name].ID
+			entries[name] = entry
+		}
+	}
+
+	// Hosts new to the map are created
+	if len(added) > 0 {
+		hosts, diags := r.modelToAPI(ctx, &data, added, entries, nil)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var created []string
+		for batch := range slices.Chunk(hosts, hostsBulkBatchSize) {
+			hostIDs, err := r.client.CreateHosts(ctx, batch)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Creating Hosts",
+					fmt.Sprintf("Could not create hosts added to the fleet: %s", err),
+				)
+				r.rollback(ctx, created, &resp.Diagnostics)
+				return
+			}
+			created = append(created, hostIDs...)
+		}
+		for i, name := range added {
+			entry := entries[name]
+			entry.ID = types.StringValue(created[i])
+			entries[name] = entry
+		}
+	}
+
+	data.ID = state.ID
+
+	resp.Diagnostics.Append(r.refresh(ctx, &data, entries, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostsBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data HostsBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make(map[string]HostsBulkEntryModel)
+	resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostIDs := make([]string, 0, len(entries))
+	for _, name := range sortedKeys(entries) {
+		hostIDs = append(hostIDs, entries[name].ID.ValueString())
+	}
+
+	for batch := range slices.Chunk(hostIDs, hostsBulkBatchSize) {
+		if err := r.client.DeleteHosts(ctx, batch); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Hosts",
+				fmt.Sprintf("Could not delete hosts: %s", err),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports a fleet from the comma-separated technical names of its hosts. The
+// settings the resource manages for all hosts alike are taken from the hosts, so they must
+// agree on them, and each host must only have the agent interface the resource manages.
+func (r *HostsBulkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var names []string
+	for _, name := range strings.Split(req.ID, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the comma-separated technical names of the hosts, got: %q", req.ID),
+		)
+		return
+	}
+	sort.Strings(names)
+
+	entries := make(map[string]HostsBulkEntryModel, len(names))
+	var first hostsBulkSettings
+	for i, name := range names {
+		host, err := r.client.GetHostByName(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Hosts",
+				fmt.Sprintf("Could not look up host %q: %s", name, err),
+			)
+			return
+		}
+		if host == nil {
+			resp.Diagnostics.AddError(
+				"Host Not Found",
+				fmt.Sprintf("No host named %q exists in Zabbix.", name),
+			)
+			return
+		}
+
+		settings, err := hostsBulkSettingsOf(host)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unsupported Host",
+				fmt.Sprintf("Host %q cannot be managed by zabbix_hosts_bulk: %s.", name, err),
+			)
+			return
+		}
+		if i == 0 {
+			first = settings
+		} else if !settings.equal(first) {
+			resp.Diagnostics.AddError(
+				"Hosts Not Uniform",
+				fmt.Sprintf("Host %q does not have the same groups, templates, status and agent interface port as host %q, "+
+					"which zabbix_hosts_bulk manages for all hosts alike.", name, names[0]),
+			)
+			return
+		}
+
+		entries[name] = HostsBulkEntryModel{
+			ID:          types.StringValue(host.HostID),
+			Name:        types.StringNull(),
+			IP:          types.StringNull(),
+			DNS:         types.StringNull(),
+			InterfaceID: types.StringNull(),
+		}
+	}
+
+	groups, diags := types.SetValueFrom(ctx, types.StringType, first.groups)
+	resp.Diagnostics.Append(diags...)
+	templates := types.SetNull(types.StringType)
+	if len(first.templates) > 0 {
+		templates, diags = types.SetValueFrom(ctx, types.StringType, first.templates)
+		resp.Diagnostics.Append(diags...)
+	}
+	hosts, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: hostsBulkEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The host details are filled in by the read that follows the import
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), hostsBulkID(names))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("groups"), groups)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("templates"), templates)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), int64(first.status))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("interface_port"), first.port)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("hosts"), hosts)...)
+}
+
+// hostsBulkSettings are the settings the resource manages for all hosts of a fleet alike.
+type hostsBulkSettings struct {
+	groups    []string
+	templates []string
+	status    int
+	port      string
+}
+
+// hostsBulkSettingsOf returns the fleet settings of a host, or an error when the host has
+// interfaces besides the main agent interface, which updates of the fleet would remove.
+func hostsBulkSettingsOf(host *zabbix.Host) (hostsBulkSettings, error) {
+	settings := hostsBulkCurrentSettings(host)
+
+	if len(host.Interfaces) != 1 || host.Interfaces[0].Type != interfaceTypeToInt("agent") {
+		return settings, errors.New("it must have a single agent interface and no other interfaces")
+	}
+
+	return settings, nil
+}
+
+// hostsBulkCurrentSettings returns the fleet settings a host has in Zabbix, with the port
+// of its main agent interface, or an empty port when it has none.
+func hostsBulkCurrentSettings(host *zabbix.Host) hostsBulkSettings {
+	settings := hostsBulkSettings{status: host.Status}
+
+	for _, group := range host.Groups {
+		settings.groups = append(settings.groups, group.GroupID)
+	}
+	for _, template := range host.ParentTemplates {
+		settings.templates = append(settings.templates, template.TemplateID)
+	}
+	sort.Strings(settings.groups)
+	sort.Strings(settings.templates)
+
+	for _, iface := range host.Interfaces {
+		if iface.Type == interfaceTypeToInt("agent") && iface.Main == 1 {
+			settings.port = iface.Port
+			break
+		}
+	}
+
+	return settings
+}
+
+// hostsBulkModelSettings returns the fleet settings of the model.
+func hostsBulkModelSettings(ctx context.Context, data *HostsBulkResourceModel) (hostsBulkSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	settings := hostsBulkSettings{
+		status: int(data.Status.ValueInt64()),
+		port:   data.InterfacePort.ValueString(),
+	}
+
+	diags.Append(data.Groups.ElementsAs(ctx, &settings.groups, false)...)
+	if !data.Templates.IsNull() {
+		diags.Append(data.Templates.ElementsAs(ctx, &settings.templates, false)...)
+	}
+	sort.Strings(settings.groups)
+	sort.Strings(settings.templates)
+
+	return settings, diags
+}
+
+// setHostsBulkSettings sets the fleet settings of the model. Templates stay null when none
+// are linked and the model has none, as they are optional.
+func setHostsBulkSettings(ctx context.Context, data *HostsBulkResourceModel, settings hostsBulkSettings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	groups, d := types.SetValueFrom(ctx, types.StringType, settings.groups)
+	diags.Append(d...)
+	data.Groups = groups
+
+	if len(settings.templates) > 0 || !data.Templates.IsNull() {
+		templates, d := types.SetValueFrom(ctx, types.StringType, append([]string{}, settings.templates...))
+		diags.Append(d...)
+		data.Templates = templates
+	}
+
+	data.Status = types.Int64Value(int64(settings.status))
+	data.InterfacePort = types.StringValue(settings.port)
+
+	return diags
+}
+
+// equal reports whether two hosts share their fleet settings.
+func (s hostsBulkSettings) equal(other hostsBulkSettings) bool {
+	return slices.Equal(s.groups, other.groups) && slices.Equal(s.templates, other.templates) &&
+		s.status == other.status && s.port == other.port
+}
+
+// modelToAPI converts the named entries to Zabbix API structs sharing the fleet attributes.
+// When state entries are given, host and interface IDs are taken from them for an update.
+func (r *HostsBulkResource) modelToAPI(ctx context.Context, data *HostsBulkResourceModel, names []string, entries, stateEntries map[string]HostsBulkEntryModel) ([]*zabbix.Host, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var groupIDs []string
+	diags.Append(data.Groups.ElementsAs(ctx, &groupIDs, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	groups := make([]zabbix.HostGroupID, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = zabbix.HostGroupID{GroupID: id}
+	}
+
+	// An empty template list unlinks every template that is currently linked
+	templates := []zabbix.TemplateID{}
+	if !data.Templates.IsNull() {
+		var templateIDs []string
+		diags.Append(data.Templates.ElementsAs(ctx, &templateIDs, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, id := range templateIDs {
+			templates = append(templates, zabbix.TemplateID{TemplateID: id})
+		}
+	}
+
+	hosts := make([]*zabbix.Host, len(names))
+	for i, name := range names {
+		entry := entries[name]
+
+		iface := zabbix.HostInterface{
+			Type:  interfaceTypeToInt("agent"),
+			Main:  1,
+			UseIP: boolToInt(entry.IP.ValueString() != ""),
+			IP:    entry.IP.ValueString(),
+			DNS:   entry.DNS.ValueString(),
+			Port:  data.InterfacePort.ValueString(),
+		}
+
+		host := &zabbix.Host{
+			Host:      name,
+			Status:    int(data.Status.ValueInt64()),
+			Groups:    groups,
+			Templates: templates,
+		}
+		if !entry.Name.IsNull() && !entry.Name.IsUnknown() {
+			host.Name = entry.Name.ValueString()
+		}
+
+		if prior, ok := stateEntries[name]; ok {
+			host.HostID = prior.ID.ValueString()
+			iface.InterfaceID = prior.InterfaceID.ValueString()
+		}
+		host.Interfaces = []zabbix.HostInterface{iface}
+
+		hosts[i] = host
+	}
+
+	return hosts, diags
+}
+
+// refresh reads all hosts of the fleet in batches and updates the entries from the API.
+// Hosts that no longer exist are dropped unless mustExist is set, in which case an error is reported.
+// The shared settings are read back as well: when hosts differ from them, the settings of
+// the first such host are taken, so that Terraform plans to apply the configured settings
+// to every host again, and a warning names the hosts that differ.
+func (r *HostsBulkResource) refresh(ctx context.Context, data *HostsBulkResourceModel, entries map[string]HostsBulkEntryModel, mustExist bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	hostIDs := make([]string, 0, len(entries))
+	for _, name := range sortedKeys(entries) {
+		hostIDs = append(hostIDs, entries[name].ID.ValueString())
+	}
+
+	found := make(map[string]zabbix.Host, len(hostIDs))
+	for batch := range slices.Chunk(hostIDs, hostsBulkBatchSize) {
+		hosts, err := r.client.GetHosts(ctx, batch)
+		if err != nil {
+			diags.AddError(
+				"Error Reading Hosts",
+				fmt.Sprintf("Could not read hosts: %s", err),
+			)
+			return diags
+		}
+		for _, host := range hosts {
+			found[host.HostID] = host
+		}
+	}
+
+	prior, d := hostsBulkModelSettings(ctx, data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	var drifted []string
+	var current hostsBulkSettings
+
+	for _, name := range sortedKeys(entries) {
+		entry := entries[name]
+		host, ok := found[entry.ID.ValueString()]
+		if !ok {
+			if mustExist {
+				diags.AddError(
+					"Error Reading Hosts",
+					fmt.Sprintf("Host %s (ID %s) was written but could not be found", name, entry.ID.ValueString()),
+				)
+				return diags
+			}
+			delete(entries, name)
+			continue
+		}
+
+		if settings := hostsBulkCurrentSettings(&host); !settings.equal(prior) {
+			if len(drifted) == 0 {
+				current = settings
+			}
+			drifted = append(drifted, name)
+		}
+
+		entry.Name = types.StringValue(host.Name)
+		entry.IP = types.StringValue("")
+		entry.DNS = types.StringValue("")
+		entry.InterfaceID = types.StringNull()
+		for _, iface := range host.Interfaces {
+			if iface.Type == interfaceTypeToInt("agent") && iface.Main == 1 {
+				entry.IP = types.StringValue(iface.IP)
+				entry.DNS = types.StringValue(iface.DNS)
+				entry.InterfaceID = types.StringValue(iface.InterfaceID)
+				break
+			}
+		}
+		entries[name] = entry
+	}
+
+	if len(drifted) > 0 {
+		diags.Append(setHostsBulkSettings(ctx, data, current)...)
+		if len(drifted) < len(entries) {
+			diags.AddWarning(
+				"Hosts Not Uniform",
+				fmt.Sprintf("The groups, templates, status or agent interface port of %s were changed outside of Terraform and differ from the other hosts of the fleet. "+
+					"The state shows the settings of %s, and the next apply sets the configured settings on all hosts.", strings.Join(drifted, ", "), drifted[0]),
+			)
+		}
+	}
+
+	hostsMap, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: hostsBulkEntryAttrTypes}, entries)
+	diags.Append(d...)
+	data.Hosts = hostsMap
+
+	return diags
+}
+
+// rollback deletes hosts created earlier in a failed batch operation so they are not orphaned.
+func (r *HostsBulkResource) rollback(ctx context.Context, hostIDs []string, diags *diag.Diagnostics) {
+	for batch := range slices.Chunk(hostIDs, hostsBulkBatchSize) {
+		if err := r.client.DeleteHosts(ctx, batch); err != nil {
+			diags.AddWarning(
+				"Error Rolling Back Hosts",
+				fmt.Sprintf("Could not delete hosts created before the failure, they must be removed manually: %s", err),
+			)
+			return
+		}
+	}
+}
+
+// hostsBulkID derives the fleet identifier from the technical names of its hosts.
+func hostsBulkID(names []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(names, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// sortedKeys returns the keys of the entry map in a stable order.
+func sortedKeys(entries map[string]HostsBulkEntryModel) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,262 @@
+// ABOUTME: Acceptance and unit tests for the zabbix_hosts_bulk resource.
+// ABOUTME: Tests batched creation, adding, removing and updating hosts in a fleet, and rollback against a fake client.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+func TestAccHostsBulkResource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostsBulkResourceConfig(rName, 0, []string{"node-01", "node-02"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("zabbix_hosts_bulk.test", "id"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", "status", "0"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", "interface_port", "10050"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", "hosts.%", "2"),
+					resource.TestCheckResourceAttrSet("zabbix_hosts_bulk.test", fmt.Sprintf("hosts.%s-node-01.id", rName)),
+					resource.TestCheckResourceAttrSet("zabbix_hosts_bulk.test", fmt.Sprintf("hosts.%s-node-01.interface_id", rName)),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", fmt.Sprintf("hosts.%s-node-01.name", rName), rName+"-node-01"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", fmt.Sprintf("hosts.%s-node-02.ip", rName), "10.0.0.2"),
+				),
+			},
+			{
+				Config: testAccHostsBulkResourceConfig(rName, 1, []string{"node-02", "node-03"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", "status", "1"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.test", "hosts.%", "2"),
+					resource.TestCheckNoResourceAttr("zabbix_hosts_bulk.test", fmt.Sprintf("hosts.%s-node-01.id", rName)),
+					resource.TestCheckResourceAttrSet("zabbix_hosts_bulk.test", fmt.Sprintf("hosts.%s-node-03.id", rName)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHostsBulkResource_missingAddress(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "zabbix_hosts_bulk" "test" {
+  groups = ["2"]
+
+  hosts = {
+    %[1]q = {}
+  }
+}
+`, rName),
+				ExpectError: regexp.MustCompile(`Missing Interface Address`),
+			},
+		},
+	})
+}
+
+func testAccHostsBulkResourceConfig(name string, status int, nodes []string) string {
+	hosts := ""
+	for _, node := range nodes {
+		hosts += fmt.Sprintf("    \"%s-%s\" = { ip = \"10.0.0.%s\" }\n", name, node, node[len(node)-1:])
+	}
+
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_hosts_bulk" "test" {
+  groups = [zabbix_host_group.test.id]
+  status = %[2]d
+
+  hosts = {
+%[3]s  }
+}
+`, name, status, hosts)
+}
+
+// hostsBulkEntryType is the Terraform type of an element of the hosts attribute.
+var hostsBulkEntryType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"id":           tftypes.String,
+	"name":         tftypes.String,
+	"ip":           tftypes.String,
+	"dns":          tftypes.String,
+	"interface_id": tftypes.String,
+}}
+
+// hostsBulkValues returns the planned values of a fleet of hosts in group 2, keyed by
+// technical name with their IP addresses.
+func hostsBulkValues(ips map[string]string) map[string]tftypes.Value {
+	unknown := func() tftypes.Value { return tftypes.NewValue(tftypes.String, tftypes.UnknownValue) }
+
+	hosts := make(map[string]tftypes.Value, len(ips))
+	for name, ip := range ips {
+		hosts[name] = tftypes.NewValue(hostsBulkEntryType, map[string]tftypes.Value{
+			"id":           unknown(),
+			"name":         unknown(),
+			"ip":           tftypes.NewValue(tftypes.String, ip),
+			"dns":          tftypes.NewValue(tftypes.String, ""),
+			"interface_id": unknown(),
+		})
+	}
+
+	return map[string]tftypes.Value{
+		"id":             unknown(),
+		"groups":         tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "2")}),
+		"status":         tftypes.NewValue(tftypes.Number, 0),
+		"interface_port": tftypes.NewValue(tftypes.String, "10050"),
+		"hosts":          tftypes.NewValue(tftypes.Map{ElementType: hostsBulkEntryType}, hosts),
+	}
+}
+
+func TestHostsBulkResource_CRUD(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostsBulkResource(), client)
+
+	h.mustSucceed("create", h.create(hostsBulkValues(map[string]string{"node1": "192.0.2.1", "node2": "192.0.2.2"})))
+	if len(client.hosts) != 2 {
+		t.Fatalf("expected 2 hosts to be created, got %v", client.hosts)
+	}
+
+	h.mustSucceed("read", h.read())
+
+	h.mustSucceed("update", h.update(hostsBulkValues(map[string]string{"node2": "192.0.2.20", "node3": "192.0.2.3"})))
+	var names []string
+	for _, id := range sortedIDs(client.hosts) {
+		names = append(names, client.hosts[id].Host+"="+client.hosts[id].Interfaces[0].IP)
+	}
+	if expected := []string{"node2=192.0.2.20", "node3=192.0.2.3"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected hosts %v after the update, got %v", expected, names)
+	}
+
+	h.mustSucceed("delete", h.delete())
+	if len(client.hosts) != 0 {
+		t.Errorf("expected all hosts to be deleted, got %v", client.hosts)
+	}
+
+	expected := []string{"CreateHosts", "GetHosts", "GetHosts", "DeleteHosts", "UpdateHosts", "CreateHosts", "GetHosts", "DeleteHosts"}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+}
+
+func TestHostsBulkResource_ReadDropsMissingHosts(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostsBulkResource(), client)
+	h.mustSucceed("create", h.create(hostsBulkValues(map[string]string{"node1": "192.0.2.1", "node2": "192.0.2.2"})))
+
+	delete(client.hosts, "101")
+	h.mustSucceed("read", h.read())
+
+	var data HostsBulkResourceModel
+	h.model(&data)
+	if _, ok := data.Hosts.Elements()["node1"]; ok || len(data.Hosts.Elements()) != 1 {
+		t.Errorf("expected only node2 to remain in state, got %v", data.Hosts)
+	}
+
+	delete(client.hosts, "103")
+	h.mustSucceed("read", h.read())
+	if !h.removed() {
+		t.Error("expected the fleet to be removed from state once all hosts are gone")
+	}
+}
+
+func TestHostsBulkResource_ReadDetectsDrift(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostsBulkResource(), client)
+	values := hostsBulkValues(map[string]string{"node1": "192.0.2.1", "node2": "192.0.2.2"})
+	h.mustSucceed("create", h.create(values))
+
+	// All hosts disabled outside of Terraform: the state shows it without a warning
+	for _, host := range client.hosts {
+		host.Status = 1
+	}
+	d := h.read()
+	h.mustSucceed("read", d)
+	var data HostsBulkResourceModel
+	h.model(&data)
+	if data.Status.ValueInt64() != 1 || len(d.Warnings()) != 0 {
+		t.Errorf("expected the changed status without warnings, got status %d and %v", data.Status.ValueInt64(), d)
+	}
+
+	h.mustSucceed("update", h.update(values))
+
+	// One host moved to another group: the state shows its groups and warns
+	client.hosts["103"].Groups = []zabbix.HostGroupID{{GroupID: "2"}, {GroupID: "7"}}
+	d = h.read()
+	h.mustSucceed("read", d)
+	h.model(&data)
+	var groups []string
+	data.Groups.ElementsAs(context.Background(), &groups, false)
+	if !reflect.DeepEqual(groups, []string{"2", "7"}) || data.Status.ValueInt64() != 0 {
+		t.Errorf("expected the groups of the changed host, got %v with status %d", groups, data.Status.ValueInt64())
+	}
+	if len(d.Warnings()) != 1 || d.Warnings()[0].Summary() != "Hosts Not Uniform" {
+		t.Errorf("expected a warning about the changed host, got %v", d)
+	}
+
+	h.mustSucceed("update", h.update(values))
+	for _, host := range client.hosts {
+		if len(host.Groups) != 1 || host.Status != 0 {
+			t.Errorf("expected the configured settings on %s after the update, got %+v", host.Host, host)
+		}
+	}
+	d = h.read()
+	h.mustSucceed("read", d)
+	h.model(&data)
+	if len(data.Groups.Elements()) != 1 || len(d.Warnings()) != 0 {
+		t.Errorf("expected no drift after the update, got %v and %v", data.Groups, d)
+	}
+}
+
+// failingBatchAPI is a fake that fails to create hosts after the first batch.
+type failingBatchAPI struct {
+	*fakeZabbixAPI
+}
+
+func (f failingBatchAPI) CreateHosts(ctx context.Context, hosts []*zabbix.Host) ([]string, error) {
+	if len(f.hosts) > 0 {
+		_ = f.call(ctx, "CreateHosts")
+		return nil, errors.New("connection reset by peer")
+	}
+	return f.fakeZabbixAPI.CreateHosts(ctx, hosts)
+}
+
+func TestHostsBulkResource_CreateRollsBack(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostsBulkResource(), failingBatchAPI{client})
+
+	ips := make(map[string]string, hostsBulkBatchSize+1)
+	for i := 0; i <= hostsBulkBatchSize; i++ {
+		ips[fmt.Sprintf("node%04d", i)] = fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+	}
+	h.mustFail("create", h.create(hostsBulkValues(ips)), "Error Creating Hosts")
+	if expected := []string{"CreateHosts", "CreateHosts", "DeleteHosts"}; !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+	if len(client.hosts) != 0 {
+		t.Errorf("expected the hosts of the first batch to be rolled back, %d remain", len(client.hosts))
+	}
+	if !h.removed() {
+		t.Error("expected no state for a fleet that failed to be created")
+	}
+}
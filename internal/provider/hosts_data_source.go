@@ -0,0 +1,207 @@
+// ABOUTME: Terraform data source for listing Zabbix hosts that match a set of filters.
+// ABOUTME: Filters by host group, proxy, tags and name pattern on the Zabbix server.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &HostsDataSource{}
+
+// HostsDataSource defines the data source implementation.
+type HostsDataSource struct {
+	client *zabbix.Client
+}
+
+// HostsDataSourceModel describes the data source data model.
+type HostsDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	GroupIDs types.Set    `tfsdk:"group_ids"`
+	ProxyID  types.String `tfsdk:"proxy_id"`
+	Search   types.String `tfsdk:"search"`
+	Tags     types.List   `tfsdk:"tags"`
+	Hosts    types.List   `tfsdk:"hosts"`
+}
+
+// NewHostsDataSource creates a new data source instance.
+func NewHostsDataSource() datasource.DataSource {
+	return &HostsDataSource{}
+}
+
+func (d *HostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts"
+}
+
+func (d *HostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the Zabbix hosts matching all of the given filters. Without filters, every host is returned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the result, derived from the IDs of the matching hosts.",
+				Computed:    true,
+			},
+			"group_ids": schema.SetAttribute{
+				Description: "Only return hosts that belong to any of these host group IDs.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"proxy_id": schema.StringAttribute{
+				Description: "Only return hosts monitored by this proxy ID.",
+				Optional:    true,
+			},
+			"search": schema.StringAttribute{
+				Description: "Only return hosts whose technical or visible name matches this case-insensitive pattern. Use * as a wildcard, for example web-* for names starting with web-.",
+				Optional:    true,
+			},
+			"tags": tagFilterSchema("Only return hosts matching all of these tag filters."),
+			"hosts": schema.ListNestedAttribute{
+				Description: "Matching hosts, sorted by technical name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the host (hostid in Zabbix).",
+							Computed:    true,
+						},
+						"host": schema.StringAttribute{
+							Description: "Technical name of the host.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Visible name of the host.",
+							Computed:    true,
+						},
+						"status": schema.Int64Attribute{
+							Description: "Status of the host. 0 = enabled, 1 = disabled.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	search, diags := d.modelToSearch(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := d.client.SearchHosts(ctx, search)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Hosts",
+			fmt.Sprintf("Could not list hosts: %s", err),
+		)
+		return
+	}
+
+	diags = d.apiToModel(hosts, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// modelToSearch converts the configured filters to host.get search criteria.
+func (d *HostsDataSource) modelToSearch(ctx context.Context, data *HostsDataSourceModel) (zabbix.HostSearch, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var search zabbix.HostSearch
+
+	if !data.GroupIDs.IsNull() {
+		diags.Append(data.GroupIDs.ElementsAs(ctx, &search.GroupIDs, false)...)
+	}
+
+	if !data.ProxyID.IsNull() {
+		search.ProxyIDs = []string{data.ProxyID.ValueString()}
+	}
+
+	search.Pattern = data.Search.ValueString()
+
+	tags, diagsTags := tagFiltersFromModel(ctx, data.Tags)
+	diags.Append(diagsTags...)
+	search.Tags = tags
+
+	return search, diags
+}
+
+// apiToModel converts the matching hosts to the Terraform model.
+func (d *HostsDataSource) apiToModel(hosts []zabbix.Host, data *HostsDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	hostType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":     types.StringType,
+			"host":   types.StringType,
+			"name":   types.StringType,
+			"status": types.Int64Type,
+		},
+	}
+
+	ids := make([]string, len(hosts))
+	hostValues := make([]attr.Value, len(hosts))
+	for i, host := range hosts {
+		obj, diagsHost := types.ObjectValue(hostType.AttrTypes, map[string]attr.Value{
+			"id":     types.StringValue(host.HostID),
+			"host":   types.StringValue(host.Host),
+			"name":   types.StringValue(host.Name),
+			"status": types.Int64Value(int64(host.Status)),
+		})
+		diags.Append(diagsHost...)
+		hostValues[i] = obj
+		ids[i] = host.HostID
+	}
+	hostsList, diagsHosts := types.ListValue(hostType, hostValues)
+	diags.Append(diagsHosts...)
+	data.Hosts = hostsList
+
+	data.ID = types.StringValue(listDataSourceID(ids))
+
+	return diags
+}
+
+// listDataSourceID derives a stable identifier for a list data source from the IDs it returned.
+func listDataSourceID(ids []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,107 @@
+// ABOUTME: Acceptance tests for the zabbix_hosts data source.
+// ABOUTME: Tests listing hosts by group, name pattern and tag filters.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostsDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostsDataSourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_group", "hosts.#", "2"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_group", "hosts.0.host", rName+"-a"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_group", "hosts.1.host", rName+"-b"),
+					resource.TestCheckResourceAttrSet("data.zabbix_hosts.by_group", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_tag", "hosts.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zabbix_hosts.by_tag", "hosts.0.id", "zabbix_host.a", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_tag", "hosts.0.status", "0"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_search", "hosts.#", "1"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_search", "hosts.0.name", rName+"-b-display"),
+				),
+			},
+		},
+	})
+}
+
+func testAccHostsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "a" {
+  host   = "%[1]s-a"
+  groups = [zabbix_host_group.test.id]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.101"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+
+  tags = [{
+    tag   = "env"
+    value = "prod"
+  }]
+}
+
+resource "zabbix_host" "b" {
+  host   = "%[1]s-b"
+  name   = "%[1]s-b-display"
+  groups = [zabbix_host_group.test.id]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.102"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+
+  tags = [{
+    tag   = "env"
+    value = "staging"
+  }]
+}
+
+data "zabbix_hosts" "by_group" {
+  group_ids = [zabbix_host_group.test.id]
+
+  depends_on = [zabbix_host.a, zabbix_host.b]
+}
+
+data "zabbix_hosts" "by_tag" {
+  group_ids = [zabbix_host_group.test.id]
+
+  tags = [{
+    tag   = "env"
+    value = "prod"
+  }]
+
+  depends_on = [zabbix_host.a, zabbix_host.b]
+}
+
+data "zabbix_hosts" "by_search" {
+  search = "%[1]s-b*"
+
+  depends_on = [zabbix_host.a, zabbix_host.b]
+}
+`, name)
+}
@@ -0,0 +1,64 @@
+// ABOUTME: Import IDs of resources given either as Zabbix IDs or as names.
+// ABOUTME: Resolves name=<value> import IDs with the name lookups of the client.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importNamePrefix marks import IDs that are names to look up instead of IDs.
+const importNamePrefix = "name="
+
+// importStateByIDOrName imports a resource by its ID, or by name for import IDs of the form
+// name=<value>, so that import scripts need not look up IDs first. lookup returns the ID
+// of the object with the name, or an empty string if there is none. kind names the kind
+// of object in diagnostics, such as "host group". Import blocks with an identity instead
+// of an ID give the ID in the identity attribute named identityAttr.
+func importStateByIDOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind, identityAttr string, lookup func(ctx context.Context, name string) (string, error)) {
+	name, byName := strings.CutPrefix(req.ID, importNamePrefix)
+	if !byName {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root(identityAttr), req, resp)
+		return
+	}
+
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the ID of the %s or %s<name>, got: %q", kind, importNamePrefix, req.ID),
+		)
+		return
+	}
+
+	id, err := lookup(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing "+titleCase(kind),
+			fmt.Sprintf("Could not look up %s %q: %s", kind, name, err),
+		)
+		return
+	}
+	if id == "" {
+		resp.Diagnostics.AddError(
+			titleCase(kind)+" Not Found",
+			fmt.Sprintf("No %s named %q exists in Zabbix.", kind, name),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// titleCase capitalizes the words of a kind of object for diagnostic summaries.
+func titleCase(kind string) string {
+	words := strings.Fields(kind)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
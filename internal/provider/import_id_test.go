@@ -0,0 +1,136 @@
+// ABOUTME: Unit tests for importing resources by Zabbix ID or by name.
+// ABOUTME: Uses the host group resource with the fake Zabbix API.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// importTestHostGroup imports a host group with the import ID and returns the response.
+func importTestHostGroup(t *testing.T, client *fakeZabbixAPI, id string) *fwresource.ImportStateResponse {
+	t.Helper()
+
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	resp := &fwresource.ImportStateResponse{State: emptyState(t, schemaResp)}
+	r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: id}, resp)
+	return resp
+}
+
+// importedID returns the ID set in the state by an import.
+func importedID(t *testing.T, state tfsdk.State) string {
+	t.Helper()
+
+	var id string
+	if diags := state.GetAttribute(context.Background(), path.Root("id"), &id); diags.HasError() {
+		t.Fatalf("unexpected error reading the imported ID: %s", diags.Errors())
+	}
+	return id
+}
+
+func TestImportStateByIDOrName(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.hostGroups["42"] = &zabbix.HostGroup{GroupID: "42", Name: "Linux servers"}
+
+	tests := map[string]struct {
+		importID string
+		expected string
+		calls    int
+	}{
+		"id":           {importID: "42", expected: "42"},
+		"name":         {importID: "name=Linux servers", expected: "42", calls: 1},
+		"unknown name": {importID: "name=Databases", calls: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client.calls = nil
+			resp := importTestHostGroup(t, client, tc.importID)
+
+			if tc.expected == "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Host Group Not Found" {
+					t.Fatalf("expected not found error, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected import error: %s", resp.Diagnostics.Errors())
+			}
+			if id := importedID(t, resp.State); id != tc.expected {
+				t.Errorf("expected ID %s, got %s", tc.expected, id)
+			}
+			if len(client.calls) != tc.calls {
+				t.Errorf("expected %d lookups, got %v", tc.calls, client.calls)
+			}
+		})
+	}
+}
+
+func TestImportStateByIDOrName_EmptyName(t *testing.T) {
+	client := newFakeZabbixAPI()
+	resp := importTestHostGroup(t, client, "name=")
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import ID" {
+		t.Fatalf("expected invalid import ID error, got %v", resp.Diagnostics)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", client.calls)
+	}
+}
+
+func TestImportStateByIDOrName_LookupError(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.errs["HostGroupIDByName"] = errors.New("connection refused")
+	resp := importTestHostGroup(t, client, "name=Linux servers")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected lookup error")
+	}
+	diag := resp.Diagnostics.Errors()[0]
+	if diag.Summary() != "Error Importing Host Group" || !strings.Contains(diag.Detail(), "connection refused") {
+		t.Errorf("unexpected diagnostic: %s: %s", diag.Summary(), diag.Detail())
+	}
+}
+
+func TestImportStateByIDOrName_Identity(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	identityResp := &fwresource.IdentitySchemaResponse{}
+	r.(fwresource.ResourceWithIdentity).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identityResp)
+	identityType := identityResp.IdentitySchema.Type().TerraformType(ctx)
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identityResp.IdentitySchema,
+		Raw: tftypes.NewValue(identityType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "42"),
+			"name": tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	resp := &fwresource.ImportStateResponse{State: emptyState(t, schemaResp), Identity: identity}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{Identity: identity}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import error: %s", resp.Diagnostics.Errors())
+	}
+	if id := importedID(t, resp.State); id != "42" {
+		t.Errorf("expected ID 42 from the identity, got %s", id)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", client.calls)
+	}
+}
@@ -0,0 +1,151 @@
+// ABOUTME: Unit tests for the state of imported resources, from which configuration is generated.
+// ABOUTME: Checks that imports leave no configurable attribute null that Zabbix knows the value of.
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// nullAfterImport returns the configurable attributes that are null in the state of the
+// harness. terraform plan -generate-config-out leaves them out of the configuration, so
+// attributes with a default would be planned to change right after the import.
+func nullAfterImport(t *testing.T, h *resourceHarness) []string {
+	t.Helper()
+
+	var null []string
+	for name, attribute := range h.schema.Schema.Attributes {
+		if !attribute.IsOptional() || attribute.IsWriteOnly() {
+			continue
+		}
+
+		var value attr.Value
+		if diags := h.state.GetAttribute(context.Background(), path.Root(name), &value); diags.HasError() {
+			t.Fatalf("unexpected error reading %s: %s", name, diags.Errors())
+		}
+		if value.IsNull() {
+			null = append(null, name)
+		}
+	}
+	sort.Strings(null)
+	return null
+}
+
+func TestImportState_Complete(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.hostGroups["2"] = &zabbix.HostGroup{GroupID: "2", Name: "Linux servers"}
+	client.templateGroups["12"] = &zabbix.TemplateGroup{GroupID: "12", Name: "Templates"}
+	client.templates["20"] = &zabbix.Template{
+		TemplateID: "20", Host: "Linux", Name: "Linux",
+		Groups: []zabbix.TemplateGroupID{{GroupID: "12"}},
+		Tags:   []zabbix.TemplateTag{{Tag: "class", Value: "os"}},
+	}
+	client.templates["21"] = &zabbix.Template{
+		TemplateID: "21", Host: "Linux extended", Name: "Linux extended",
+		Groups:          []zabbix.TemplateGroupID{{GroupID: "12"}},
+		ParentTemplates: []zabbix.ParentTemplate{{TemplateID: "20"}},
+	}
+	agent := zabbix.HostInterface{InterfaceID: "1", Type: 1, IP: "192.0.2.10", Port: "10050", Main: 1, UseIP: 1}
+	for id, name := range map[string]string{"10": "web01", "11": "web02"} {
+		client.hosts[id] = &zabbix.Host{
+			HostID: id, Host: name, Name: name, TLSConnect: 1, TLSAccept: 1,
+			Groups:     []zabbix.HostGroupID{{GroupID: "2"}},
+			Templates:  []zabbix.TemplateID{{TemplateID: "20"}},
+			Interfaces: []zabbix.HostInterface{agent},
+			Tags:       []zabbix.HostTag{{Tag: "role", Value: "web"}},
+		}
+	}
+
+	tests := map[string]struct {
+		resource fwresource.Resource
+		importID string
+		// null are the attributes expected to stay null, as they are alternatives to the
+		// imported attributes or Zabbix does not return them
+		null []string
+	}{
+		"host group":     {resource: NewHostGroupResource(), importID: "2"},
+		"template group": {resource: NewTemplateGroupResource(), importID: "12"},
+		"template": {
+			resource: NewTemplateResource(),
+			importID: "21",
+			null:     []string{"source_checksum", "source_content", "source_format", "source_url", "tags"},
+		},
+		"host": {
+			resource: NewHostResource(),
+			importID: "10",
+			null:     []string{"template_names", "tls_psk_identity"},
+		},
+		"hosts bulk": {resource: NewHostsBulkResource(), importID: "web01,web02"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := newResourceHarness(t, tc.resource, client)
+			h.mustSucceed("import", h.importState(tc.importID))
+
+			if null := nullAfterImport(t, h); !slices.Equal(null, tc.null) {
+				t.Errorf("expected only %v to be null after the import, got %v", tc.null, null)
+			}
+		})
+	}
+}
+
+func TestHostsBulkResource_Import(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewHostsBulkResource(), client)
+	h.mustSucceed("create", h.create(hostsBulkValues(map[string]string{"node1": "192.0.2.1", "node2": "192.0.2.2"})))
+	var created HostsBulkResourceModel
+	h.model(&created)
+
+	imported := newResourceHarness(t, NewHostsBulkResource(), client)
+	imported.mustSucceed("import", imported.importState("node2, node1"))
+	var data HostsBulkResourceModel
+	imported.model(&data)
+
+	if data.ID.ValueString() != created.ID.ValueString() {
+		t.Errorf("expected the ID %s given at creation, got %s", created.ID.ValueString(), data.ID.ValueString())
+	}
+	if !data.Groups.Equal(created.Groups) || data.Status.ValueInt64() != 0 || data.InterfacePort.ValueString() != "10050" {
+		t.Errorf("expected the shared settings of the hosts, got %+v", data)
+	}
+	if !data.Hosts.Equal(created.Hosts) {
+		t.Errorf("expected the hosts as created, got %s, want %s", data.Hosts, created.Hosts)
+	}
+}
+
+func TestHostsBulkResource_ImportErrors(t *testing.T) {
+	client := newFakeZabbixAPI()
+	agent := func(ip string) []zabbix.HostInterface {
+		return []zabbix.HostInterface{{InterfaceID: ip, Type: 1, IP: ip, Port: "10050", Main: 1, UseIP: 1}}
+	}
+	client.hosts["10"] = &zabbix.Host{HostID: "10", Host: "node1", Groups: []zabbix.HostGroupID{{GroupID: "2"}}, Interfaces: agent("192.0.2.1")}
+	client.hosts["11"] = &zabbix.Host{HostID: "11", Host: "node2", Groups: []zabbix.HostGroupID{{GroupID: "3"}}, Interfaces: agent("192.0.2.2")}
+	client.hosts["12"] = &zabbix.Host{HostID: "12", Host: "switch1", Groups: []zabbix.HostGroupID{{GroupID: "2"}}, Interfaces: []zabbix.HostInterface{
+		{InterfaceID: "5", Type: 2, IP: "192.0.2.3", Port: "161", Main: 1, UseIP: 1},
+	}}
+
+	tests := map[string]struct {
+		importID string
+		summary  string
+	}{
+		"empty":        {importID: " , ", summary: "Invalid Import ID"},
+		"missing host": {importID: "node1,node9", summary: "Host Not Found"},
+		"other groups": {importID: "node1,node2", summary: "Hosts Not Uniform"},
+		"snmp only":    {importID: "switch1", summary: "Unsupported Host"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := newResourceHarness(t, NewHostsBulkResource(), client)
+			h.mustFail("import", h.importState(tc.importID), tc.summary)
+		})
+	}
+}
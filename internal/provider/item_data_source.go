@@ -0,0 +1,259 @@
+// ABOUTME: Terraform data source for looking up a single Zabbix item.
+// ABOUTME: Resolves exactly one item by host technical name and item key.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &ItemDataSource{}
+
+// ItemDataSource defines the data source implementation.
+type ItemDataSource struct {
+	client *zabbix.Client
+}
+
+// ItemDataSourceModel describes the data source data model.
+type ItemDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Host          types.String `tfsdk:"host"`
+	Key           types.String `tfsdk:"key"`
+	HostID        types.String `tfsdk:"host_id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.Int64  `tfsdk:"type"`
+	ValueType     types.Int64  `tfsdk:"value_type"`
+	Status        types.Int64  `tfsdk:"status"`
+	Delay         types.String `tfsdk:"delay"`
+	Units         types.String `tfsdk:"units"`
+	Description   types.String `tfsdk:"description"`
+	Preprocessing types.List   `tfsdk:"preprocessing"`
+	MasterItemID  types.String `tfsdk:"master_item_id"`
+	Formula       types.String `tfsdk:"formula"`
+	HTTPAgent     types.Object `tfsdk:"http_agent"`
+}
+
+// NewItemDataSource creates a new data source instance.
+func NewItemDataSource() datasource.DataSource {
+	return &ItemDataSource{}
+}
+
+func (d *ItemDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_item"
+}
+
+func (d *ItemDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to look up a single Zabbix item by host technical name and item key, for example as the master item of dependent items or as a graph item reference.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the item (itemid in Zabbix).",
+				Computed:    true,
+			},
+			"host": schema.StringAttribute{
+				Description: "Technical name of the host or template the item belongs to.",
+				Required:    true,
+			},
+			"key": schema.StringAttribute{
+				Description: "Key of the item to look up, for example system.cpu.load[all,avg1].",
+				Required:    true,
+			},
+			"host_id": schema.StringAttribute{
+				Description: "The ID of the host or template the item belongs to.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the item.",
+				Computed:    true,
+			},
+			"type": schema.Int64Attribute{
+				Description: "Type of the item as defined by the Zabbix API, for example 0 = Zabbix agent, 2 = Zabbix trapper, 15 = calculated, 18 = dependent item, 19 = HTTP agent.",
+				Computed:    true,
+			},
+			"value_type": schema.Int64Attribute{
+				Description: "Type of information of the item. 0 = numeric float, 1 = character, 2 = log, 3 = numeric unsigned, 4 = text, 5 = binary.",
+				Computed:    true,
+			},
+			"status": schema.Int64Attribute{
+				Description: "Status of the item. 0 = enabled, 1 = disabled.",
+				Computed:    true,
+			},
+			"delay": schema.StringAttribute{
+				Description: "Update interval of the item.",
+				Computed:    true,
+			},
+			"units": schema.StringAttribute{
+				Description: "Value units of the item.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the item.",
+				Computed:    true,
+			},
+			"master_item_id": schema.StringAttribute{
+				Description: "The ID of the master item of a dependent item, null for items of other types.",
+				Computed:    true,
+			},
+			"formula": schema.StringAttribute{
+				Description: "Formula of a calculated item, for example last(//net.if.in[eth0])*8, null for items of other types.",
+				Computed:    true,
+			},
+			"http_agent": itemHTTPAgentAttribute(),
+			"preprocessing": schema.ListNestedAttribute{
+				Description: "Preprocessing steps of the item, in the order they are applied.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.Int64Attribute{
+							Description: "Type of the step as defined by the Zabbix API, for example 5 = regular expression, 10 = change per second, 12 = JSONPath, 21 = JavaScript.",
+							Computed:    true,
+						},
+						"params": schema.ListAttribute{
+							Description: "Parameters of the step, for example the JSONPath expression.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"error_handler": schema.Int64Attribute{
+							Description: "Action taken when the step fails. 0 = set the item unsupported, 1 = discard the value, 2 = set a custom value, 3 = set a custom error message.",
+							Computed:    true,
+						},
+						"error_handler_params": schema.StringAttribute{
+							Description: "Custom value or error message of the error handler.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ItemDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ItemDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ItemDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := d.client.GetItemsByHostKey(ctx, data.Host.ValueString(), data.Key.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Item",
+			fmt.Sprintf("Could not read item with key %q on host %q: %s", data.Key.ValueString(), data.Host.ValueString(), err),
+		)
+		return
+	}
+
+	if len(items) == 0 {
+		resp.Diagnostics.AddError(
+			"Item Not Found",
+			fmt.Sprintf("No item found with key %q on host %q.", data.Key.ValueString(), data.Host.ValueString()),
+		)
+		return
+	}
+
+	if len(items) > 1 {
+		resp.Diagnostics.AddError(
+			"Multiple Items Found",
+			fmt.Sprintf("Found %d items with key %q on host %q; the lookup must match exactly one item.", len(items), data.Key.ValueString(), data.Host.ValueString()),
+		)
+		return
+	}
+
+	item := items[0]
+	data.ID = types.StringValue(item.ItemID)
+	data.HostID = types.StringValue(item.HostID)
+	data.Name = types.StringValue(item.Name)
+	data.Key = types.StringValue(item.Key)
+	data.Type = types.Int64Value(int64(item.Type))
+	data.ValueType = types.Int64Value(int64(item.ValueType))
+	data.Status = types.Int64Value(int64(item.Status))
+	data.Delay = types.StringValue(item.Delay)
+	data.Units = types.StringValue(item.Units)
+	data.Description = types.StringValue(item.Description)
+	data.MasterItemID = types.StringNull()
+	if item.MasterItemID != "" {
+		data.MasterItemID = types.StringValue(item.MasterItemID)
+	}
+	data.Formula = types.StringNull()
+	if item.Type == zabbix.ItemTypeCalculated {
+		data.Formula = types.StringValue(item.Params)
+	}
+
+	preprocessing, diags := preprocessingToList(ctx, item.Preprocessing)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Preprocessing = preprocessing
+
+	httpAgent, diags := itemHTTPAgentToObject(item)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.HTTPAgent = httpAgent
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// preprocessingStepType is the object type of the elements of the preprocessing attribute.
+var preprocessingStepType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"type":                 types.Int64Type,
+		"params":               types.ListType{ElemType: types.StringType},
+		"error_handler":        types.Int64Type,
+		"error_handler_params": types.StringType,
+	},
+}
+
+// preprocessingToList converts the preprocessing steps of an item to the list of the
+// preprocessing attribute, which is empty rather than null for items without steps.
+func preprocessingToList(ctx context.Context, steps []zabbix.ItemPreprocessing) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(steps))
+	for _, step := range steps {
+		params, d := types.ListValueFrom(ctx, types.StringType, step.ParamList())
+		diags.Append(d...)
+
+		obj, d := types.ObjectValue(preprocessingStepType.AttrTypes, map[string]attr.Value{
+			"type":                 types.Int64Value(int64(step.Type)),
+			"params":               params,
+			"error_handler":        types.Int64Value(int64(step.ErrorHandler)),
+			"error_handler_params": types.StringValue(step.ErrorHandlerParams),
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(preprocessingStepType, values)
+	diags.Append(d...)
+	return list, diags
+}
@@ -0,0 +1,70 @@
+// ABOUTME: Acceptance tests for the zabbix_item data source.
+// ABOUTME: Tests resolving items inherited from a linked template by host and key.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccItemDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccItemDataSourceConfig(rName, "system.cpu.load[all,avg1]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.zabbix_item.test", "id"),
+					resource.TestCheckResourceAttrPair("data.zabbix_item.test", "host_id", "zabbix_host.test", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_item.test", "key", "system.cpu.load[all,avg1]"),
+					resource.TestCheckResourceAttr("data.zabbix_item.test", "value_type", "0"),
+					resource.TestCheckResourceAttrSet("data.zabbix_item.test", "name"),
+					resource.TestCheckResourceAttrSet("data.zabbix_item.test", "preprocessing.#"),
+					resource.TestCheckNoResourceAttr("data.zabbix_item.test", "master_item_id"),
+					resource.TestCheckNoResourceAttr("data.zabbix_item.test", "http_agent"),
+					resource.TestCheckNoResourceAttr("data.zabbix_item.test", "formula"),
+				),
+			},
+			{
+				Config:      testAccItemDataSourceConfig(rName, "no.such.key"),
+				ExpectError: regexp.MustCompile("Item Not Found"),
+			},
+		},
+	})
+}
+
+func testAccItemDataSourceConfig(name, key string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host           = %[1]q
+  groups         = [zabbix_host_group.test.id]
+  template_names = ["Linux by Zabbix agent"]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+data "zabbix_item" "test" {
+  host = zabbix_host.test.host
+  key  = %[2]q
+}
+`, name, key)
+}
@@ -0,0 +1,206 @@
+// ABOUTME: HTTP agent settings of items, as returned by the zabbix_item data source.
+// ABOUTME: Converts the HTTP fields of an item from the Zabbix API to the http_agent attribute.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// itemHTTPFieldType is the object type of the query fields and headers of the http_agent attribute.
+var itemHTTPFieldType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":  types.StringType,
+		"value": types.StringType,
+	},
+}
+
+// itemHTTPAgentAttrTypes are the attribute types of the http_agent attribute of an item.
+var itemHTTPAgentAttrTypes = map[string]attr.Type{
+	"url":              types.StringType,
+	"query_fields":     types.ListType{ElemType: itemHTTPFieldType},
+	"request_method":   types.StringType,
+	"headers":          types.ListType{ElemType: itemHTTPFieldType},
+	"body":             types.StringType,
+	"body_type":        types.StringType,
+	"auth_type":        types.StringType,
+	"username":         types.StringType,
+	"follow_redirects": types.BoolType,
+	"retrieve_mode":    types.StringType,
+	"timeout":          types.StringType,
+	"status_codes":     types.StringType,
+}
+
+// httpRequestMethods maps HTTP request methods to their API values.
+var httpRequestMethods = map[string]int{
+	"GET":  zabbix.HTTPMethodGet,
+	"POST": zabbix.HTTPMethodPost,
+	"PUT":  zabbix.HTTPMethodPut,
+	"HEAD": zabbix.HTTPMethodHead,
+}
+
+// httpBodyTypes maps the types of HTTP request bodies to their API values.
+var httpBodyTypes = map[string]int{
+	"raw":  zabbix.HTTPPostRaw,
+	"json": zabbix.HTTPPostJSON,
+	"xml":  zabbix.HTTPPostXML,
+}
+
+// httpAuthTypes maps HTTP authentication methods to their API values.
+var httpAuthTypes = map[string]int{
+	"none":     zabbix.HTTPAuthNone,
+	"basic":    zabbix.HTTPAuthBasic,
+	"ntlm":     zabbix.HTTPAuthNTLM,
+	"kerberos": zabbix.HTTPAuthKerberos,
+	"digest":   zabbix.HTTPAuthDigest,
+}
+
+// httpRetrieveModes maps the parts of HTTP responses items store to their API values.
+var httpRetrieveModes = map[string]int{
+	"body":    zabbix.HTTPRetrieveBody,
+	"headers": zabbix.HTTPRetrieveHeaders,
+	"both":    zabbix.HTTPRetrieveBoth,
+}
+
+// httpName returns the name of an API value in one of the HTTP name maps, or the value
+// itself when the name is not known.
+func httpName(names map[string]int, value int) string {
+	for name, v := range names {
+		if v == value {
+			return name
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// itemHTTPAgentAttribute returns the schema of the http_agent attribute of the item data source.
+func itemHTTPAgentAttribute() schema.SingleNestedAttribute {
+	field := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the field.",
+				Computed:    true,
+			},
+			"value": schema.StringAttribute{
+				Description: "Value of the field.",
+				Computed:    true,
+			},
+		},
+	}
+
+	return schema.SingleNestedAttribute{
+		Description: "HTTP agent settings of the item, null for items of other types. The password is not returned.",
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "URL the item requests.",
+				Computed:    true,
+			},
+			"query_fields": schema.ListNestedAttribute{
+				Description:  "Query fields added to the URL, in order.",
+				Computed:     true,
+				NestedObject: field,
+			},
+			"request_method": schema.StringAttribute{
+				Description: "Request method: GET, POST, PUT or HEAD.",
+				Computed:    true,
+			},
+			"headers": schema.ListNestedAttribute{
+				Description:  "Request headers, in order.",
+				Computed:     true,
+				NestedObject: field,
+			},
+			"body": schema.StringAttribute{
+				Description: "Request body.",
+				Computed:    true,
+			},
+			"body_type": schema.StringAttribute{
+				Description: "Type of the request body: raw, json or xml.",
+				Computed:    true,
+			},
+			"auth_type": schema.StringAttribute{
+				Description: "Authentication method: none, basic, ntlm, kerberos or digest.",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "User name for authentication.",
+				Computed:    true,
+			},
+			"follow_redirects": schema.BoolAttribute{
+				Description: "Whether redirects are followed.",
+				Computed:    true,
+			},
+			"retrieve_mode": schema.StringAttribute{
+				Description: "Part of the response the item stores: body, headers or both.",
+				Computed:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Timeout of the request, empty when the proxy or server timeout applies.",
+				Computed:    true,
+			},
+			"status_codes": schema.StringAttribute{
+				Description: "Comma-separated HTTP status codes and ranges that are accepted, for example 200,201-210.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// itemHTTPFieldsToList converts query fields or headers to a list of the http_agent attribute.
+func itemHTTPFieldsToList(fields []zabbix.ItemHTTPField) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(fields))
+	for _, field := range fields {
+		obj, d := types.ObjectValue(itemHTTPFieldType.AttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(field.Name),
+			"value": types.StringValue(field.Value),
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+
+	list, d := types.ListValue(itemHTTPFieldType, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// itemHTTPAgentToObject converts the HTTP fields of an item read from Zabbix to the
+// http_agent attribute, which is null for items that are not HTTP agent items.
+func itemHTTPAgentToObject(item zabbix.Item) (types.Object, diag.Diagnostics) {
+	if item.Type != zabbix.ItemTypeHTTPAgent {
+		return types.ObjectNull(itemHTTPAgentAttrTypes), nil
+	}
+
+	var diags diag.Diagnostics
+	queryFields, d := itemHTTPFieldsToList(item.QueryFields)
+	diags.Append(d...)
+	headers, d := itemHTTPFieldsToList(item.Headers)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.ObjectNull(itemHTTPAgentAttrTypes), diags
+	}
+
+	obj, d := types.ObjectValue(itemHTTPAgentAttrTypes, map[string]attr.Value{
+		"url":              types.StringValue(item.URL),
+		"query_fields":     queryFields,
+		"request_method":   types.StringValue(httpName(httpRequestMethods, item.RequestMethod)),
+		"headers":          headers,
+		"body":             types.StringValue(item.Posts),
+		"body_type":        types.StringValue(httpName(httpBodyTypes, item.PostType)),
+		"auth_type":        types.StringValue(httpName(httpAuthTypes, item.AuthType)),
+		"username":         types.StringValue(item.Username),
+		"follow_redirects": types.BoolValue(item.FollowRedirects == 1),
+		"retrieve_mode":    types.StringValue(httpName(httpRetrieveModes, item.RetrieveMode)),
+		"timeout":          types.StringValue(item.Timeout),
+		"status_codes":     types.StringValue(item.StatusCodes),
+	})
+	diags.Append(d...)
+	return obj, diags
+}
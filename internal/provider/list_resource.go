@@ -0,0 +1,34 @@
+// ABOUTME: Shared helpers of the list resources that enumerate Zabbix objects for terraform query.
+// ABOUTME: Applies the result limit and prepares the resource of each result for conversion.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// listLimit returns how many of the n objects found a list request wants, where a limit of
+// 0 means all of them. Terraform stops reading at the limit, so fetching more is wasted.
+func listLimit(n int, limit int64) int {
+	if limit > 0 && int64(n) > limit {
+		return int(limit)
+	}
+	return n
+}
+
+// listResultModel decodes the resource of a list result into target with only the ID set,
+// the way the state of a resource looks after an import, so that the apiToModel of the
+// resource can fill in the rest and default the attributes that only exist in Terraform.
+func listResultModel(ctx context.Context, resource *tfsdk.Resource, id string, target interface{}) diag.Diagnostics {
+	diags := resource.SetAttribute(ctx, path.Root("id"), id)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(resource.Get(ctx, target)...)
+	return diags
+}
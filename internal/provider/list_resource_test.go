@@ -0,0 +1,156 @@
+// ABOUTME: Unit tests for the host and host group list resources used by terraform query.
+// ABOUTME: Runs List against the fake Zabbix API and checks identities, resources and limits.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// listTestResults lists with the list resource implemented by r and returns the results.
+// Attributes of the list block that are not given are null.
+func listTestResults(t *testing.T, r fwresource.Resource, client ZabbixAPI, values map[string]tftypes.Value, includeResource bool, limit int64) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := configureTestResource(t, r, client)
+	identityResp := &fwresource.IdentitySchemaResponse{}
+	r.(fwresource.ResourceWithIdentity).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identityResp)
+
+	lister := r.(list.ListResource)
+	listSchemaResp := &list.ListResourceSchemaResponse{}
+	lister.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, listSchemaResp)
+
+	configType := listSchemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	configValues := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+	for name, attrType := range configType.AttributeTypes {
+		configValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		configValues[name] = value
+	}
+
+	stream := &list.ListResultsStream{}
+	lister.List(ctx, list.ListRequest{
+		Config:                 tfsdk.Config{Schema: listSchemaResp.Schema, Raw: tftypes.NewValue(configType, configValues)},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestHostGroupListResource(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.hostGroups["2"] = &zabbix.HostGroup{GroupID: "2", Name: "Linux servers", UUID: "uuid-2"}
+	client.hostGroups["3"] = &zabbix.HostGroup{GroupID: "3", Name: "Databases", UUID: "uuid-3"}
+
+	results := listTestResults(t, NewHostGroupResource(), client, map[string]tftypes.Value{
+		"search": tftypes.NewValue(tftypes.String, "Linux*"),
+	}, true, 0)
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 matching host group, got %d", len(results))
+	}
+	result := results[0]
+	if result.Diagnostics.HasError() {
+		t.Fatalf("unexpected list error: %s", result.Diagnostics.Errors())
+	}
+	if result.DisplayName != "Linux servers" {
+		t.Errorf("expected display name Linux servers, got %q", result.DisplayName)
+	}
+
+	var identity idNameIdentityModel
+	result.Identity.Get(ctx, &identity)
+	if identity.ID.ValueString() != "2" || identity.Name.ValueString() != "Linux servers" {
+		t.Errorf("expected the identity of host group 2, got %+v", identity)
+	}
+
+	var data HostGroupResourceModel
+	result.Resource.Get(ctx, &data)
+	if data.ID.ValueString() != "2" || data.UUID.ValueString() != "uuid-2" || data.DeletionProtection.ValueBool() {
+		t.Errorf("expected the resource of host group 2 as after an import, got %+v", data)
+	}
+}
+
+func TestHostGroupListResource_Error(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.errs["SearchHostGroups"] = errors.New("connection refused")
+
+	results := listTestResults(t, NewHostGroupResource(), client, nil, false, 0)
+	if len(results) != 1 || !results[0].Diagnostics.HasError() {
+		t.Fatalf("expected a single result with the error, got %+v", results)
+	}
+	if summary := results[0].Diagnostics.Errors()[0].Summary(); summary != "Error Listing Host Groups" {
+		t.Errorf("unexpected diagnostic: %s", summary)
+	}
+}
+
+func TestHostListResource(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.hosts["10"] = &zabbix.Host{HostID: "10", Host: "web01", Name: "Web 01", Groups: []zabbix.HostGroupID{{GroupID: "2"}}}
+	client.hosts["11"] = &zabbix.Host{HostID: "11", Host: "web02", Name: "Web 02", Groups: []zabbix.HostGroupID{{GroupID: "2"}}}
+	client.hosts["12"] = &zabbix.Host{HostID: "12", Host: "db01", Name: "DB 01", Groups: []zabbix.HostGroupID{{GroupID: "3"}}}
+
+	results := listTestResults(t, NewHostResource(), client, map[string]tftypes.Value{
+		"group_ids": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "2"),
+		}),
+	}, true, 0)
+
+	if len(results) != 2 {
+		t.Fatalf("expected the 2 hosts of group 2, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected list error: %s", result.Diagnostics.Errors())
+		}
+	}
+
+	var identity hostIdentityModel
+	results[1].Identity.Get(ctx, &identity)
+	if identity.HostID.ValueString() != "11" || results[1].DisplayName != "Web 02" {
+		t.Errorf("expected host 11 named Web 02, got %+v named %q", identity, results[1].DisplayName)
+	}
+
+	var data HostResourceModel
+	results[0].Resource.Get(ctx, &data)
+	if data.ID.ValueString() != "10" || data.Host.ValueString() != "web01" || data.GroupMode.ValueString() != "authoritative" {
+		t.Errorf("expected the resource of host 10 as after an import, got %+v", data)
+	}
+}
+
+func TestHostListResource_Limit(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.hosts["10"] = &zabbix.Host{HostID: "10", Host: "web01"}
+	client.hosts["11"] = &zabbix.Host{HostID: "11", Host: "web02"}
+
+	results := listTestResults(t, NewHostResource(), client, nil, false, 1)
+	if len(results) != 1 {
+		t.Fatalf("expected the list to stop at the limit, got %d results", len(results))
+	}
+	if !results[0].Resource.Raw.IsNull() {
+		t.Error("expected no resource when it is not requested")
+	}
+	for _, call := range client.calls {
+		if call == "GetHosts" {
+			t.Error("expected hosts not to be read when the resource is not requested")
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// ABOUTME: Partial state for objects that were created in Zabbix but could not be read back.
+// ABOUTME: Keeps the ID in state so the object is tracked instead of orphaned outside Terraform.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// savePartialState stores only the ID of a created object in the state of a Create that
+// fails afterwards. Terraform saves the state with the resource marked tainted, so the next
+// plan reads the object by ID and replaces it, instead of creating a duplicate next to an
+// object it has lost track of.
+func savePartialState(ctx context.Context, state *tfsdk.State, id string) diag.Diagnostics {
+	return state.SetAttribute(ctx, path.Root("id"), id)
+}
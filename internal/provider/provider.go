@@ -1,21 +1,38 @@
 // ABOUTME: Zabbix Terraform provider implementation using terraform-plugin-framework.
-// ABOUTME: Handles provider configuration (URL and API token) and resource registration.
+// ABOUTME: Handles provider configuration (credentials and connection settings) and resource registration.
 
 package provider
 
 import (
 	"context"
+	"fmt"
+	neturl "net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
-var _ provider.Provider = &ZabbixProvider{}
+var (
+	_ provider.Provider                  = &ZabbixProvider{}
+	_ provider.ProviderWithFunctions     = &ZabbixProvider{}
+	_ provider.ProviderWithListResources = &ZabbixProvider{}
+)
 
 // ZabbixProvider implements the Zabbix Terraform provider.
 type ZabbixProvider struct {
@@ -24,10 +41,41 @@ type ZabbixProvider struct {
 
 // ZabbixProviderModel describes the provider configuration data.
 type ZabbixProviderModel struct {
-	URL      types.String `tfsdk:"url"`
-	APIToken types.String `tfsdk:"api_token"`
+	URL               types.String  `tfsdk:"url"`
+	FallbackURLs      types.List    `tfsdk:"fallback_urls"`
+	APIToken          types.String  `tfsdk:"api_token"`
+	Username          types.String  `tfsdk:"username"`
+	Password          types.String  `tfsdk:"password"`
+	TLSInsecure       types.Bool    `tfsdk:"tls_insecure"`
+	CACertPEM         types.String  `tfsdk:"ca_cert_pem"`
+	CACertFile        types.String  `tfsdk:"ca_cert_file"`
+	ClientCert        types.String  `tfsdk:"client_cert_pem"`
+	ClientKey         types.String  `tfsdk:"client_key_pem"`
+	HTTPProxy         types.String  `tfsdk:"http_proxy"`
+	MaxIdleConns      types.Int64   `tfsdk:"max_idle_connections"`
+	MaxConns          types.Int64   `tfsdk:"max_connections"`
+	CompressRequests  types.Bool    `tfsdk:"compress_requests"`
+	CompressResponses types.Bool    `tfsdk:"compress_responses"`
+	Timeout           types.String  `tfsdk:"request_timeout"`
+	MaxRetries        types.Int64   `tfsdk:"max_retries"`
+	RetryBackoff      types.String  `tfsdk:"retry_backoff"`
+	RetryOnStatus     types.Set     `tfsdk:"retry_on_status"`
+	RateLimit         types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+	MaxConcurrent     types.Int64   `tfsdk:"max_concurrent_requests"`
+	HTTPUsername      types.String  `tfsdk:"http_auth_username"`
+	HTTPPassword      types.String  `tfsdk:"http_auth_password"`
+	MinVersion        types.String  `tfsdk:"min_api_version"`
+	MaxVersion        types.String  `tfsdk:"max_api_version"`
+	DefaultTags       types.Map     `tfsdk:"default_tags"`
 }
 
+// defaultRetryStatuses are the HTTP status codes retried when retry_on_status is not set.
+var defaultRetryStatuses = []int{502, 503, 504}
+
+// defaultRetryBackoff is the delay before the first retry when retry_backoff is not set.
+const defaultRetryBackoff = time.Second
+
 // New creates a new provider instance.
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -44,17 +92,146 @@ func (p *ZabbixProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 
 func (p *ZabbixProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Terraform provider for managing Zabbix monitoring infrastructure.",
+		Description: "Terraform provider for managing Zabbix monitoring infrastructure.\n\n" +
+			"To debug problems with the Zabbix API, set the ZABBIX_DEBUG_CAPTURE environment variable to a file path. " +
+			"Every API request and its response are then appended to the file as a line of JSON, with passwords, tokens and other secrets redacted, " +
+			"so that the file can be attached to bug reports.",
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				Description: "The URL of the Zabbix API endpoint (e.g., https://zabbix.example.com/api_jsonrpc.php). Can also be set via ZABBIX_URL environment variable.",
+				Description: "The URL of the Zabbix frontend (e.g., https://zabbix.example.com) or of its API endpoint (e.g., https://zabbix.example.com/api_jsonrpc.php). api_jsonrpc.php is appended when missing. Can also be set via ZABBIX_URL environment variable.",
 				Optional:    true,
 			},
+			"fallback_urls": schema.ListAttribute{
+				Description: "URLs of further frontends of the same Zabbix installation, in the same format as url. When the current frontend cannot be connected to, the request is sent to the next URL, and later requests stay with the frontend that answered. Read-only requests also move on after other connection errors, timeouts and HTTP 5xx statuses; other requests may already have been applied by the frontend, so they fail instead of being sent twice. Use it for active/passive frontends whose DNS fails over slowly.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"api_token": schema.StringAttribute{
-				Description: "The API token for authenticating with the Zabbix API. Can also be set via ZABBIX_API_TOKEN environment variable.",
+				Description: "The API token for authenticating with the Zabbix API. Conflicts with username. Can also be set via ZABBIX_API_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Name of the Zabbix user to log in as, instead of authenticating with an API token. The provider logs in with its first request and logs in again when the session expires. " +
+					"Sessions are not logged out when Terraform finishes, as providers are not told when they shut down, so each run leaves a session that ends with the session timeout of the user. " +
+					"Requires password and conflicts with api_token. Can also be set via ZABBIX_USERNAME environment variable.",
+				Optional: true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of username. Can also be set via ZABBIX_PASSWORD environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"http_auth_username": schema.StringAttribute{
+				Description: "Username for HTTP basic authentication, for API endpoints protected by a reverse proxy. Sent in addition to the API token, which then has to be sent in the request body and therefore requires Zabbix before 7.2. Requires http_auth_password. Can also be set via ZABBIX_HTTP_AUTH_USERNAME environment variable.",
+				Optional:    true,
+			},
+			"http_auth_password": schema.StringAttribute{
+				Description: "Password for HTTP basic authentication. Requires http_auth_username. Can also be set via ZABBIX_HTTP_AUTH_PASSWORD environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"min_api_version": schema.StringAttribute{
+				Description: "Oldest supported Zabbix API version, for example 7.0. When set, the provider checks the server version during configuration and fails if it is older.",
+				Optional:    true,
+			},
+			"max_api_version": schema.StringAttribute{
+				Description: "Newest supported Zabbix API version, for example 7.0 to allow any 7.0.x release. When set, the provider checks the server version during configuration and fails if it is newer.",
+				Optional:    true,
+			},
+			"tls_insecure": schema.BoolAttribute{
+				Description: "Disable verification of the Zabbix server certificate. Only use this for testing. Can also be set via ZABBIX_TLS_INSECURE environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates to trust in addition to the system certificate pool, for servers using a private CA. Conflicts with ca_cert_file.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file with PEM-encoded CA certificates to trust in addition to the system certificate pool. Conflicts with ca_cert_pem. Can also be set via ZABBIX_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded client certificate presented to the server for mutual TLS, for example when Zabbix is behind an mTLS-terminating proxy. Requires client_key_pem.",
+				Optional:    true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM-encoded private key of client_cert_pem. Requires client_cert_pem.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "URL of the proxy used to reach the Zabbix API and to download the source_url of templates, for example http://proxy.example.com:3128 or socks5://proxy.example.com:1080. When not set, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.",
+				Optional:    true,
+			},
+			"max_idle_connections": schema.Int64Attribute{
+				Description: "Number of idle connections to the Zabbix frontend kept open for reuse. Connections beyond it are closed after each request, so set it to at least the Terraform parallelism to avoid running out of ephemeral ports during large applies. Defaults to 32.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_connections": schema.Int64Attribute{
+				Description: "Maximum number of connections to the Zabbix frontend, including those in use. Further requests wait for a free connection. Not limited by default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"compress_requests": schema.BoolAttribute{
+				Description: "Compress request bodies of 1 KiB or more with gzip, which speeds up large template imports over slow links. The web server in front of the Zabbix frontend has to decompress them, for example Apache with SetInputFilter DEFLATE, as PHP does not. Defaults to false.",
+				Optional:    true,
+			},
+			"compress_responses": schema.BoolAttribute{
+				Description: "Ask the web server for gzip-compressed responses, which speeds up large template exports and get calls over slow links. Servers without compression answer uncompressed. Defaults to true.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout of a single Zabbix API request as a duration, for example 90s or 5m. Increase it for large template imports or host.get calls. Defaults to 30s. Can also be set via ZABBIX_REQUEST_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times a request failing with a transient error is retried. Requests that could not be sent because the connection failed, and requests failing because Zabbix reports that its database is down, are retried whatever their method. Timeouts, the retry_on_status HTTP status codes and connections lost after sending are only retried for read-only requests, as Zabbix may already have applied other requests. Defaults to 0, which disables retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_backoff": schema.StringAttribute{
+				Description: "Delay before the first retry as a duration, for example 2s. The delay doubles with every further retry, and each delay is randomized between half and the full value so that concurrent runs do not retry in lockstep. Defaults to 1s.",
+				Optional:    true,
+			},
+			"retry_on_status": schema.SetAttribute{
+				Description: "HTTP status codes on which read-only requests are retried. Other requests are not retried on any status, as a gateway may report an error for a request Zabbix applied. Defaults to 502, 503 and 504.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Set{
+					setvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum average number of API requests sent per second, including retries. Use it to keep large applies from overwhelming the Zabbix frontend. Not limited by default.",
+				Optional:    true,
+			},
+			"burst": schema.Int64Attribute{
+				Description: "Number of requests that may be sent at once before requests_per_second applies. Requires requests_per_second. Defaults to 1.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once, across all resources and data sources of the provider. Terraform runs up to 10 operations in parallel by default, each of which may send several requests; use this to bound the load on a small Zabbix frontend regardless of -parallelism. Not limited by default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_tags": schema.MapAttribute{
+				Description: "Tags added to every zabbix_host and zabbix_template, for example to enforce an organization-wide tagging policy. A tag of the same name set on the resource takes precedence. Default tags are not shown in the tags of the resource, only in tags_all.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -83,39 +260,422 @@ func (p *ZabbixProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			"The provider requires a URL to be set. "+
 				"Set the url attribute in the provider configuration or use the ZABBIX_URL environment variable.",
 		)
+	} else {
+		url = apiURL(url, path.Root("url"), &resp.Diagnostics)
+	}
+
+	var fallbackURLs []string
+	if !config.FallbackURLs.IsNull() {
+		resp.Diagnostics.Append(config.FallbackURLs.ElementsAs(ctx, &fallbackURLs, false)...)
+		for i, fallbackURL := range fallbackURLs {
+			fallbackURLs[i] = apiURL(fallbackURL, path.Root("fallback_urls").AtListIndex(i), &resp.Diagnostics)
+		}
 	}
 
-	if apiToken == "" {
+	username, password := userCredentials(config, &resp.Diagnostics)
+	switch {
+	case apiToken == "" && username == "":
 		resp.Diagnostics.AddError(
 			"Missing API Token Configuration",
-			"The provider requires an API token to be set. "+
-				"Set the api_token attribute in the provider configuration or use the ZABBIX_API_TOKEN environment variable.",
+			"The provider requires an API token or a username and password to be set. "+
+				"Set the api_token attribute in the provider configuration or use the ZABBIX_API_TOKEN environment variable, "+
+				"or set username and password or ZABBIX_USERNAME and ZABBIX_PASSWORD.",
+		)
+	case apiToken != "" && username != "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Conflicting Authentication Configuration",
+			"api_token and username cannot both be set, including through ZABBIX_API_TOKEN and ZABBIX_USERNAME. Authenticate with either an API token or a user.",
 		)
 	}
 
+	timeout := requestTimeout(config, &resp.Diagnostics)
+	retry := retryPolicy(ctx, config, &resp.Diagnostics)
+	limiter := rateLimiter(config, &resp.Diagnostics)
+	basicAuth := basicAuth(config, &resp.Diagnostics)
+	minVersion := apiVersionBound(config.MinVersion, "min_api_version", &resp.Diagnostics)
+	maxVersion := apiVersionBound(config.MaxVersion, "max_api_version", &resp.Diagnostics)
+	transportOpts := transportOptions(config, &resp.Diagnostics)
+
+	var defaultTags map[string]string
+	if !config.DefaultTags.IsNull() && !config.DefaultTags.IsUnknown() {
+		resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client := zabbix.NewClient(url, apiToken)
+	transport, err := zabbix.NewTransport(transportOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Transport Configuration",
+			fmt.Sprintf("Could not configure the transport of the Zabbix API client: %s", err),
+		)
+		return
+	}
+
+	opts := []zabbix.Option{
+		zabbix.WithToken(apiToken),
+		zabbix.WithTimeout(timeout),
+		zabbix.WithTransport(transport),
+		zabbix.WithFallbackURLs(fallbackURLs...),
+		zabbix.WithAuthMethod(zabbix.AuthMethodAuto),
+		zabbix.WithRetry(retry),
+		zabbix.WithRateLimiter(limiter),
+		zabbix.WithUserAgent("terraform-provider-zabbix/" + p.version),
+	}
+	if username != "" {
+		opts = append(opts, zabbix.WithCredentials(username, password))
+	}
+	if basicAuth != nil {
+		opts = append(opts, zabbix.WithBasicAuth(basicAuth.Username, basicAuth.Password))
+	}
+	if config.CompressRequests.ValueBool() {
+		opts = append(opts, zabbix.WithRequestCompression())
+	}
+	if !config.MaxConcurrent.IsNull() {
+		opts = append(opts, zabbix.WithConcurrencyLimiter(zabbix.NewConcurrencyLimiter(int(config.MaxConcurrent.ValueInt64()))))
+	}
+	if capturePath := os.Getenv("ZABBIX_DEBUG_CAPTURE"); capturePath != "" {
+		capture, err := zabbix.OpenCaptureFile(capturePath)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error Opening Debug Capture",
+				fmt.Sprintf("Could not open %s to capture API requests, requests are not captured: %s", capturePath, err),
+			)
+		} else {
+			opts = append(opts, zabbix.WithCapture(capture))
+		}
+	}
+	client := zabbix.NewClient(url, opts...)
+
+	// The detected version is cached by the client, which adapts parameters renamed between
+	// Zabbix versions to it. Without it requests are sent unchanged, so the version is only
+	// required to check the bounds.
+	version, err := client.APIVersion(ctx)
+	if err != nil {
+		if minVersion != nil || maxVersion != nil {
+			resp.Diagnostics.AddError(
+				"Error Detecting Zabbix Version",
+				fmt.Sprintf("Could not read the Zabbix API version to check min_api_version and max_api_version: %s", err),
+			)
+			return
+		}
+
+		tflog.Warn(ctx, "Could not detect the Zabbix API version, parameters are not adapted to it", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else if (minVersion != nil && version.Compare(*minVersion) < 0) || (maxVersion != nil && !version.AtMost(*maxVersion)) {
+		resp.Diagnostics.AddError(
+			"Unsupported Zabbix Version",
+			fmt.Sprintf("The Zabbix API at %s reports version %s, which is outside the range allowed by min_api_version and max_api_version.", url, version),
+		)
+		return
+	}
+
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &ProviderData{
+		Client:      client,
+		DefaultTags: defaultTags,
+		Transport:   transport,
+	}
+	// List resources are implemented by the resources they list, so they share the data
+	resp.ListResourceData = resp.ResourceData
+}
+
+// apiURL normalizes the configured URL to the API endpoint, appending api_jsonrpc.php to
+// frontend URLs. It adds an error diagnostic for URLs that are not absolute HTTP(S) URLs.
+func apiURL(raw string, attrPath path.Path, diags *diag.Diagnostics) string {
+	u, err := neturl.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid URL Configuration",
+			fmt.Sprintf("The URL must be an absolute http or https URL such as https://zabbix.example.com, got %q.", raw),
+		)
+		return raw
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	if !strings.HasSuffix(u.Path, "/api_jsonrpc.php") {
+		u.Path += "/api_jsonrpc.php"
+	}
+
+	return u.String()
+}
+
+// apiVersionBound parses an optional API version bound, adding an attribute error when it
+// is not a valid version.
+func apiVersionBound(value types.String, attribute string, diags *diag.Diagnostics) *zabbix.Version {
+	if value.IsNull() {
+		return nil
+	}
+
+	version, err := zabbix.ParseVersion(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid API Version",
+			fmt.Sprintf("%s must be a version such as 7.0 or 7.0.5: %s", attribute, err),
+		)
+		return nil
+	}
+
+	return &version
+}
+
+// userCredentials resolves the username and password of the Zabbix user from the provider
+// configuration and the environment. Both are empty when the provider uses an API token.
+func userCredentials(config ZabbixProviderModel, diags *diag.Diagnostics) (string, string) {
+	username := os.Getenv("ZABBIX_USERNAME")
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+
+	password := os.Getenv("ZABBIX_PASSWORD")
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
+	if username == "" && password == "" {
+		return "", ""
+	}
+
+	if username == "" || password == "" {
+		diags.AddError(
+			"Incomplete User Authentication",
+			"username and password must be set together to log in as a Zabbix user.",
+		)
+		return "", ""
+	}
+
+	return username, password
+}
+
+// basicAuth resolves the HTTP basic authentication credentials from the provider
+// configuration and the environment. Returns nil when basic authentication is not used.
+func basicAuth(config ZabbixProviderModel, diags *diag.Diagnostics) *zabbix.BasicAuth {
+	username := os.Getenv("ZABBIX_HTTP_AUTH_USERNAME")
+	if !config.HTTPUsername.IsNull() {
+		username = config.HTTPUsername.ValueString()
+	}
+
+	password := os.Getenv("ZABBIX_HTTP_AUTH_PASSWORD")
+	if !config.HTTPPassword.IsNull() {
+		password = config.HTTPPassword.ValueString()
+	}
+
+	if username == "" && password == "" {
+		return nil
+	}
+
+	if username == "" || password == "" {
+		diags.AddError(
+			"Incomplete HTTP Basic Authentication",
+			"http_auth_username and http_auth_password must be set together to use HTTP basic authentication.",
+		)
+		return nil
+	}
+
+	return &zabbix.BasicAuth{Username: username, Password: password}
+}
+
+// requestTimeout resolves the request timeout from the provider configuration and the
+// environment, adding an error diagnostic for invalid durations.
+func requestTimeout(config ZabbixProviderModel, diags *diag.Diagnostics) time.Duration {
+	value := os.Getenv("ZABBIX_REQUEST_TIMEOUT")
+	if !config.Timeout.IsNull() {
+		value = config.Timeout.ValueString()
+	}
+
+	if value == "" {
+		return zabbix.DefaultTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid Request Timeout",
+			fmt.Sprintf("The request timeout must be a positive duration such as 90s or 5m, got %q.", value),
+		)
+		return zabbix.DefaultTimeout
+	}
+
+	return timeout
+}
+
+// retryPolicy resolves the retry policy from the provider configuration, adding error
+// diagnostics for invalid settings.
+func retryPolicy(ctx context.Context, config ZabbixProviderModel, diags *diag.Diagnostics) zabbix.RetryPolicy {
+	policy := zabbix.RetryPolicy{
+		MaxRetries: int(config.MaxRetries.ValueInt64()),
+		Backoff:    defaultRetryBackoff,
+		OnStatus:   defaultRetryStatuses,
+	}
+
+	if !config.RetryBackoff.IsNull() {
+		backoff, err := time.ParseDuration(config.RetryBackoff.ValueString())
+		if err != nil || backoff < 0 {
+			diags.AddAttributeError(
+				path.Root("retry_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("The retry backoff must be a duration such as 1s or 500ms, got %q.", config.RetryBackoff.ValueString()),
+			)
+		}
+		policy.Backoff = backoff
+	}
+
+	if !config.RetryOnStatus.IsNull() {
+		var statuses []int64
+		diags.Append(config.RetryOnStatus.ElementsAs(ctx, &statuses, false)...)
+		policy.OnStatus = make([]int, len(statuses))
+		for i, status := range statuses {
+			policy.OnStatus[i] = int(status)
+		}
+	}
+
+	return policy
+}
+
+// rateLimiter creates the request rate limiter from the provider configuration. Returns
+// nil when requests are not limited.
+func rateLimiter(config ZabbixProviderModel, diags *diag.Diagnostics) *zabbix.RateLimiter {
+	if config.RateLimit.IsNull() {
+		if !config.Burst.IsNull() {
+			diags.AddAttributeError(
+				path.Root("burst"),
+				"Missing requests_per_second",
+				"burst can only be set together with requests_per_second.",
+			)
+		}
+		return nil
+	}
+
+	rate := config.RateLimit.ValueFloat64()
+	if rate <= 0 {
+		diags.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Rate Limit",
+			fmt.Sprintf("requests_per_second must be greater than 0, got %g.", rate),
+		)
+		return nil
+	}
+
+	burst := 1
+	if !config.Burst.IsNull() {
+		burst = int(config.Burst.ValueInt64())
+	}
+
+	return zabbix.NewRateLimiter(rate, burst)
+}
+
+// transportOptions resolves the TLS and proxy settings from the provider configuration
+// and the environment, adding error diagnostics for invalid settings.
+func transportOptions(config ZabbixProviderModel, diags *diag.Diagnostics) zabbix.TransportOptions {
+	var opts zabbix.TransportOptions
+
+	if v := os.Getenv("ZABBIX_TLS_INSECURE"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			diags.AddError(
+				"Invalid TLS Configuration",
+				fmt.Sprintf("The ZABBIX_TLS_INSECURE environment variable must be a boolean, got %q.", v),
+			)
+		}
+		opts.InsecureSkipVerify = insecure
+	}
+	if !config.TLSInsecure.IsNull() {
+		opts.InsecureSkipVerify = config.TLSInsecure.ValueBool()
+	}
+
+	caCertFile := os.Getenv("ZABBIX_CA_CERT_FILE")
+	if !config.CACertFile.IsNull() {
+		caCertFile = config.CACertFile.ValueString()
+	}
+
+	switch {
+	case !config.CACertPEM.IsNull() && !config.CACertFile.IsNull():
+		diags.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Conflicting TLS Configuration",
+			"ca_cert_file cannot be set together with ca_cert_pem.",
+		)
+	case !config.CACertPEM.IsNull():
+		opts.CACertPEM = []byte(config.CACertPEM.ValueString())
+	case caCertFile != "":
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			diags.AddError(
+				"Invalid CA Certificate",
+				fmt.Sprintf("Could not read CA certificate file %q: %s", caCertFile, err),
+			)
+		}
+		opts.CACertPEM = pem
+	}
+
+	if config.ClientCert.IsNull() != config.ClientKey.IsNull() {
+		diags.AddError(
+			"Incomplete Client Certificate",
+			"client_cert_pem and client_key_pem must be set together to use mutual TLS.",
+		)
+	}
+	opts.ClientCertPEM = []byte(config.ClientCert.ValueString())
+	opts.ClientKeyPEM = []byte(config.ClientKey.ValueString())
+
+	opts.ProxyURL = config.HTTPProxy.ValueString()
+	opts.DisableCompression = !config.CompressResponses.IsNull() && !config.CompressResponses.ValueBool()
+	opts.MaxIdleConnsPerHost = int(config.MaxIdleConns.ValueInt64())
+	opts.MaxConnsPerHost = int(config.MaxConns.ValueInt64())
+
+	return opts
 }
 
 func (p *ZabbixProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewHostGroupResource,
 		NewHostResource,
+		NewHostsBulkResource,
 		NewTemplateGroupResource,
 		NewTemplateResource,
 	}
 }
 
+// ListResources returns the list resources for terraform query, which enumerate objects
+// that Terraform may not manage yet.
+func (p *ZabbixProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewHostGroupListResource,
+		NewHostListResource,
+	}
+}
+
 func (p *ZabbixProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewHostGroupDataSource,
+		NewHostGroupsDataSource,
 		NewHostDataSource,
+		NewHostsDataSource,
+		NewHostCountDataSource,
 		NewTemplateGroupDataSource,
 		NewTemplateDataSource,
+		NewItemDataSource,
+		NewTriggerDataSource,
+		NewUsersDataSource,
+		NewTokensDataSource,
+	}
+}
+
+func (p *ZabbixProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewTriggerExprFunction,
+		NewValidateExpressionFunction,
+		NewValidateFormulaFunction,
+		NewSeverityFunction,
+		NewSeverityNameFunction,
+		NewTimeToSecondsFunction,
+		NewSecondsToTimeFunction,
+		NewNormalizeTimeFunction,
 	}
 }
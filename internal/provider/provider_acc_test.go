@@ -11,6 +11,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
+const (
+	// testAccDefaultURL and testAccDefaultToken point at the Docker test environment in docker/.
+	testAccDefaultURL   = "http://127.0.0.1:8080/api_jsonrpc.php"
+	testAccDefaultToken = "071fb9d2e8f72cf9c40128f0f5aab3def1bab0893413314b083fdcb4551eb01a"
+)
+
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"zabbix": providerserver.NewProtocol6WithError(New("test")()),
 }
@@ -23,10 +29,10 @@ func testAccPreCheck(t *testing.T) {
 	}
 
 	if os.Getenv("ZABBIX_URL") == "" {
-		t.Setenv("ZABBIX_URL", "http://127.0.0.1:8080/api_jsonrpc.php")
+		t.Setenv("ZABBIX_URL", testAccDefaultURL)
 	}
 
 	if os.Getenv("ZABBIX_API_TOKEN") == "" {
-		t.Setenv("ZABBIX_API_TOKEN", "071fb9d2e8f72cf9c40128f0f5aab3def1bab0893413314b083fdcb4551eb01a")
+		t.Setenv("ZABBIX_API_TOKEN", testAccDefaultToken)
 	}
 }
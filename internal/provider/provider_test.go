@@ -1,107 +1,669 @@
 // ABOUTME: Tests for the Zabbix Terraform provider configuration.
-// ABOUTME: Verifies environment variable fallback and schema validation.
+// ABOUTME: Verifies environment variable fallback, schema validation and connection settings.
 
 package provider
 
 import (
 	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 func TestProvider_Configure_EnvironmentVariableFallback(t *testing.T) {
 	t.Setenv("ZABBIX_URL", "https://env.example.com/api_jsonrpc.php")
 	t.Setenv("ZABBIX_API_TOKEN", "env-token")
 
-	p := New("test")()
-	schemaResp := &provider.SchemaResponse{}
-	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+	resp := testProviderConfigure(t, nil)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+}
+
+func TestProvider_Configure_MissingRequiredConfig(t *testing.T) {
+	t.Setenv("ZABBIX_URL", "")
+	t.Setenv("ZABBIX_API_TOKEN", "")
+
+	resp := testProviderConfigure(t, nil)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when URL and API token are not configured")
+	}
+}
+
+func TestProvider_Configure_ConfigOverridesEnvironment(t *testing.T) {
+	t.Setenv("ZABBIX_URL", "https://env.example.com/api_jsonrpc.php")
+	t.Setenv("ZABBIX_API_TOKEN", "env-token")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+}
+
+func TestProvider_Configure_RequestTimeout(t *testing.T) {
+	t.Setenv("ZABBIX_REQUEST_TIMEOUT", "45s")
+
+	tests := map[string]struct {
+		timeout   tftypes.Value
+		expected  time.Duration
+		expectErr bool
+	}{
+		"from config":      {timeout: tftypes.NewValue(tftypes.String, "5m"), expected: 5 * time.Minute},
+		"from environment": {timeout: tftypes.NewValue(tftypes.String, nil), expected: 45 * time.Second},
+		"invalid duration": {timeout: tftypes.NewValue(tftypes.String, "fast"), expectErr: true},
+		"zero duration":    {timeout: tftypes.NewValue(tftypes.String, "0s"), expectErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := testProviderConfigure(t, map[string]tftypes.Value{
+				"url":             tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+				"api_token":       tftypes.NewValue(tftypes.String, "config-token"),
+				"request_timeout": tc.timeout,
+			})
+
+			if tc.expectErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error for invalid request timeout")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+			}
 
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"url":       tftypes.String,
-			"api_token": tftypes.String,
+			client := resp.DataSourceData.(*zabbix.Client)
+			if client.HTTPClient.Timeout != tc.expected {
+				t.Errorf("expected timeout %v, got %v", tc.expected, client.HTTPClient.Timeout)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_RetryPolicy(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":           tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token":     tftypes.NewValue(tftypes.String, "config-token"),
+		"max_retries":   tftypes.NewValue(tftypes.Number, 4),
+		"retry_backoff": tftypes.NewValue(tftypes.String, "250ms"),
+		"retry_on_status": tftypes.NewValue(tftypes.Set{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, 503),
+		}),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	retry := resp.DataSourceData.(*zabbix.Client).Retry
+	if retry.MaxRetries != 4 {
+		t.Errorf("expected 4 retries, got %d", retry.MaxRetries)
+	}
+	if retry.Backoff != 250*time.Millisecond {
+		t.Errorf("expected backoff 250ms, got %v", retry.Backoff)
+	}
+	if len(retry.OnStatus) != 1 || retry.OnStatus[0] != 503 {
+		t.Errorf("expected retry on status [503], got %v", retry.OnStatus)
+	}
+}
+
+func TestProvider_Configure_RetryPolicyDefaults(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	retry := resp.DataSourceData.(*zabbix.Client).Retry
+	if retry.MaxRetries != 0 {
+		t.Errorf("expected retries to be disabled, got %d", retry.MaxRetries)
+	}
+	if retry.Backoff != time.Second {
+		t.Errorf("expected backoff 1s, got %v", retry.Backoff)
+	}
+	if len(retry.OnStatus) != 3 {
+		t.Errorf("expected default retry statuses, got %v", retry.OnStatus)
+	}
+}
+
+func TestProvider_Configure_InvalidRetryBackoff(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":           tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token":     tftypes.NewValue(tftypes.String, "config-token"),
+		"retry_backoff": tftypes.NewValue(tftypes.String, "soon"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid retry backoff")
+	}
+}
+
+func TestProvider_Configure_RateLimit(t *testing.T) {
+	tests := map[string]struct {
+		values      map[string]tftypes.Value
+		expectLimit bool
+		expectErr   bool
+	}{
+		"not limited": {
+			values: map[string]tftypes.Value{},
+		},
+		"limited": {
+			values: map[string]tftypes.Value{
+				"requests_per_second": tftypes.NewValue(tftypes.Number, 5),
+				"burst":               tftypes.NewValue(tftypes.Number, 10),
+			},
+			expectLimit: true,
+		},
+		"zero rate": {
+			values: map[string]tftypes.Value{
+				"requests_per_second": tftypes.NewValue(tftypes.Number, 0),
+			},
+			expectErr: true,
+		},
+		"burst without rate": {
+			values: map[string]tftypes.Value{
+				"burst": tftypes.NewValue(tftypes.Number, 10),
+			},
+			expectErr: true,
 		},
-	}, map[string]tftypes.Value{
-		"url":       tftypes.NewValue(tftypes.String, nil),
-		"api_token": tftypes.NewValue(tftypes.String, nil),
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.values["url"] = tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php")
+			tc.values["api_token"] = tftypes.NewValue(tftypes.String, "config-token")
+
+			resp := testProviderConfigure(t, tc.values)
+
+			if tc.expectErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error for invalid rate limit")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+			}
+
+			client := resp.DataSourceData.(*zabbix.Client)
+			if (client.Limiter != nil) != tc.expectLimit {
+				t.Errorf("expected limiter %t, got %v", tc.expectLimit, client.Limiter)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_MaxConcurrentRequests(t *testing.T) {
+	tests := map[string]struct {
+		value       tftypes.Value
+		expectLimit bool
+	}{
+		"not limited": {value: tftypes.NewValue(tftypes.Number, nil)},
+		"limited":     {value: tftypes.NewValue(tftypes.Number, 4), expectLimit: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := testProviderConfigure(t, map[string]tftypes.Value{
+				"url":                     tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+				"api_token":               tftypes.NewValue(tftypes.String, "config-token"),
+				"max_concurrent_requests": tc.value,
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+			}
+
+			client := resp.DataSourceData.(*zabbix.Client)
+			if (client.Concurrency != nil) != tc.expectLimit {
+				t.Errorf("expected concurrency limiter %t, got %v", tc.expectLimit, client.Concurrency)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_BasicAuth(t *testing.T) {
+	t.Setenv("ZABBIX_HTTP_AUTH_PASSWORD", "env-pass")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":                tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token":          tftypes.NewValue(tftypes.String, "config-token"),
+		"http_auth_username": tftypes.NewValue(tftypes.String, "proxy-user"),
 	})
 
-	config, err := tfsdk.Config{
-		Schema: schemaResp.Schema,
-		Raw:    configValue,
-	}, error(nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	auth := resp.DataSourceData.(*zabbix.Client).BasicAuth
+	if auth == nil || auth.Username != "proxy-user" || auth.Password != "env-pass" {
+		t.Errorf("expected basic auth proxy-user/env-pass, got %+v", auth)
+	}
+}
+
+func TestProvider_Configure_IncompleteBasicAuth(t *testing.T) {
+	t.Setenv("ZABBIX_HTTP_AUTH_USERNAME", "")
+	t.Setenv("ZABBIX_HTTP_AUTH_PASSWORD", "")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":                tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token":          tftypes.NewValue(tftypes.String, "config-token"),
+		"http_auth_username": tftypes.NewValue(tftypes.String, "proxy-user"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when http_auth_password is missing")
+	}
+}
+
+func TestProvider_Configure_UserCredentials(t *testing.T) {
+	t.Setenv("ZABBIX_API_TOKEN", "")
+	t.Setenv("ZABBIX_PASSWORD", "env-pass")
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":      tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"username": tftypes.NewValue(tftypes.String, "terraform"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	client := resp.DataSourceData.(*zabbix.Client)
+	if client.Username != "terraform" || client.Password != "env-pass" || client.Token != "" {
+		t.Errorf("expected to log in as terraform/env-pass without a token, got %q/%q and token %q", client.Username, client.Password, client.Token)
+	}
+}
+
+func TestProvider_Configure_InvalidUserCredentials(t *testing.T) {
+	t.Setenv("ZABBIX_USERNAME", "")
+	t.Setenv("ZABBIX_PASSWORD", "")
+
+	tests := map[string]map[string]tftypes.Value{
+		"incomplete": {
+			"url":      tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+			"username": tftypes.NewValue(tftypes.String, "terraform"),
+		},
+		"with api token": {
+			"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+			"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+			"username":  tftypes.NewValue(tftypes.String, "terraform"),
+			"password":  tftypes.NewValue(tftypes.String, "secret"),
+		},
+	}
+	for name, values := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := testProviderConfigure(t, values)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error for the user credentials")
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_APIVersionBounds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "result": "7.0.5", "id": 1}`))
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		min       string
+		max       string
+		expectErr bool
+	}{
+		"within range":     {min: "7.0", max: "7.0"},
+		"only minimum":     {min: "6.4"},
+		"too old":          {min: "7.2", expectErr: true},
+		"too new":          {max: "6.4", expectErr: true},
+		"patch too new":    {max: "7.0.4", expectErr: true},
+		"invalid bound":    {min: "seven", expectErr: true},
+		"unchecked bounds": {},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"url":       tftypes.NewValue(tftypes.String, server.URL),
+				"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+			}
+			if tc.min != "" {
+				values["min_api_version"] = tftypes.NewValue(tftypes.String, tc.min)
+			}
+			if tc.max != "" {
+				values["max_api_version"] = tftypes.NewValue(tftypes.String, tc.max)
+			}
+
+			resp := testProviderConfigure(t, values)
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Fatalf("expected error %t, got: %s", tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_APIVersionUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":             tftypes.NewValue(tftypes.String, server.URL),
+		"api_token":       tftypes.NewValue(tftypes.String, "config-token"),
+		"min_api_version": tftypes.NewValue(tftypes.String, "7.0"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the version cannot be detected")
+	}
+}
+
+func TestProvider_Configure_APIVersionDetected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "terraform-provider-zabbix/test" {
+			t.Errorf("expected the provider version in the User-Agent, got %q", ua)
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "result": "7.0.5", "id": 1}`))
+	}))
+	defer server.Close()
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, server.URL),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	// The version is detected without bounds so that requests are adapted to it
+	server.Close()
+	version, err := resp.DataSourceData.(*zabbix.Client).APIVersion(context.Background())
+	if err != nil || version.String() != "7.0.5" {
+		t.Errorf("expected the cached version 7.0.5, got %s (%v)", version, err)
+	}
+}
+
+func TestProvider_Configure_DebugCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "result": "7.0.5", "id": 1}`))
+	}))
+	defer server.Close()
+
+	capturePath := filepath.Join(t.TempDir(), "capture.jsonl")
+	t.Setenv("ZABBIX_DEBUG_CAPTURE", capturePath)
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, server.URL),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	data, err := os.ReadFile(capturePath)
 	if err != nil {
-		t.Fatalf("failed to create config: %s", err)
+		t.Fatalf("expected the capture file to be written: %v", err)
+	}
+	if !strings.Contains(string(data), `"apiinfo.version"`) {
+		t.Errorf("expected the version request to be captured, got %s", data)
 	}
+}
 
-	req := provider.ConfigureRequest{Config: config}
-	resp := &provider.ConfigureResponse{}
+func TestProvider_Configure_DebugCaptureInvalidPath(t *testing.T) {
+	t.Setenv("ZABBIX_DEBUG_CAPTURE", filepath.Join(t.TempDir(), "missing", "capture.jsonl"))
 
-	p.Configure(context.Background(), req, resp)
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected an unusable capture path not to fail the provider, got %s", resp.Diagnostics.Errors())
+	}
+	if resp.Diagnostics.WarningsCount() == 0 {
+		t.Error("expected a warning for the unusable capture path")
+	}
+}
+
+func TestProvider_Configure_APIVersionUnreachableWithoutBounds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, server.URL),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected an undetected version to be ignored without bounds, got %s", resp.Diagnostics.Errors())
+	}
+}
+
+func TestProvider_Configure_CACertPEM(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":         tftypes.NewValue(tftypes.String, server.URL),
+		"api_token":   tftypes.NewValue(tftypes.String, "config-token"),
+		"ca_cert_pem": tftypes.NewValue(tftypes.String, string(caPEM)),
+	})
 
 	if resp.Diagnostics.HasError() {
 		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
 	}
 }
 
-func TestProvider_Configure_MissingRequiredConfig(t *testing.T) {
-	t.Setenv("ZABBIX_URL", "")
-	t.Setenv("ZABBIX_API_TOKEN", "")
+func TestProvider_Configure_CACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
 
-	p := New("test")()
-	schemaResp := &provider.SchemaResponse{}
-	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %s", err)
+	}
+	t.Setenv("ZABBIX_CA_CERT_FILE", caFile)
+
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, server.URL),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
 
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"url":       tftypes.String,
-			"api_token": tftypes.String,
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+}
+
+func TestProvider_Configure_InvalidTLSConfig(t *testing.T) {
+	tests := map[string]map[string]tftypes.Value{
+		"conflicting CA attributes": {
+			"ca_cert_pem":  tftypes.NewValue(tftypes.String, "pem"),
+			"ca_cert_file": tftypes.NewValue(tftypes.String, "/tmp/ca.pem"),
+		},
+		"missing CA file": {
+			"ca_cert_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing.pem")),
+		},
+		"invalid CA PEM": {
+			"ca_cert_pem": tftypes.NewValue(tftypes.String, "not a certificate"),
+		},
+		"client certificate without key": {
+			"client_cert_pem": tftypes.NewValue(tftypes.String, "certificate"),
 		},
-	}, map[string]tftypes.Value{
-		"url":       tftypes.NewValue(tftypes.String, nil),
-		"api_token": tftypes.NewValue(tftypes.String, nil),
+		"invalid client certificate": {
+			"client_cert_pem": tftypes.NewValue(tftypes.String, "not a certificate"),
+			"client_key_pem":  tftypes.NewValue(tftypes.String, "not a key"),
+		},
+	}
+
+	for name, values := range tests {
+		t.Run(name, func(t *testing.T) {
+			values["url"] = tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php")
+			values["api_token"] = tftypes.NewValue(tftypes.String, "config-token")
+
+			resp := testProviderConfigure(t, values)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error for invalid TLS configuration")
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_HTTPProxy(t *testing.T) {
+	tests := map[string]struct {
+		proxy     string
+		expectErr bool
+	}{
+		"http proxy":   {proxy: "http://proxy.example.com:3128"},
+		"socks5 proxy": {proxy: "socks5://proxy.example.com:1080"},
+		"bad scheme":   {proxy: "ftp://proxy.example.com", expectErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := testProviderConfigure(t, map[string]tftypes.Value{
+				"url":        tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+				"api_token":  tftypes.NewValue(tftypes.String, "config-token"),
+				"http_proxy": tftypes.NewValue(tftypes.String, tc.proxy),
+			})
+
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Fatalf("expected error %t, got: %s", tc.expectErr, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_ConnectionLimits(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":                  tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token":            tftypes.NewValue(tftypes.String, "config-token"),
+		"max_idle_connections": tftypes.NewValue(tftypes.Number, 64),
+		"max_connections":      tftypes.NewValue(tftypes.Number, 100),
 	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
 
-	config := tfsdk.Config{
-		Schema: schemaResp.Schema,
-		Raw:    configValue,
+	transport := resp.DataSourceData.(*zabbix.Client).HTTPClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("expected 64 idle connections, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 100 {
+		t.Errorf("expected 100 connections, got %d", transport.MaxConnsPerHost)
 	}
+}
 
-	req := provider.ConfigureRequest{Config: config}
-	resp := &provider.ConfigureResponse{}
+func TestProvider_Configure_Compression(t *testing.T) {
+	tests := map[string]struct {
+		requests          *bool
+		responses         *bool
+		expectRequests    bool
+		expectNoResponses bool
+	}{
+		"defaults":               {},
+		"compress requests":      {requests: boolPtr(true), expectRequests: true},
+		"uncompressed responses": {responses: boolPtr(false), expectNoResponses: true},
+	}
 
-	p.Configure(context.Background(), req, resp)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+				"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+			}
+			if tc.requests != nil {
+				values["compress_requests"] = tftypes.NewValue(tftypes.Bool, *tc.requests)
+			}
+			if tc.responses != nil {
+				values["compress_responses"] = tftypes.NewValue(tftypes.Bool, *tc.responses)
+			}
 
-	if !resp.Diagnostics.HasError() {
-		t.Fatal("expected error when URL and API token are not configured")
+			resp := testProviderConfigure(t, values)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+			}
+
+			client := resp.DataSourceData.(*zabbix.Client)
+			if client.CompressRequests != tc.expectRequests {
+				t.Errorf("expected request compression %t, got %t", tc.expectRequests, client.CompressRequests)
+			}
+			transport := client.HTTPClient.Transport.(*http.Transport)
+			if transport.DisableCompression != tc.expectNoResponses {
+				t.Errorf("expected response compression disabled %t, got %t", tc.expectNoResponses, transport.DisableCompression)
+			}
+		})
 	}
 }
 
-func TestProvider_Configure_ConfigOverridesEnvironment(t *testing.T) {
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestProvider_Configure_InvalidTLSInsecureEnvironment(t *testing.T) {
 	t.Setenv("ZABBIX_URL", "https://env.example.com/api_jsonrpc.php")
 	t.Setenv("ZABBIX_API_TOKEN", "env-token")
+	t.Setenv("ZABBIX_TLS_INSECURE", "sometimes")
+
+	resp := testProviderConfigure(t, nil)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a non-boolean ZABBIX_TLS_INSECURE")
+	}
+}
+
+// testProviderConfigure configures a new provider with the given attribute values. All
+// other attributes of the provider schema are null.
+func testProviderConfigure(t *testing.T, values map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
 
 	p := New("test")()
 	schemaResp := &provider.SchemaResponse{}
 	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
 
-	configValue := tftypes.NewValue(tftypes.Object{
-		AttributeTypes: map[string]tftypes.Type{
-			"url":       tftypes.String,
-			"api_token": tftypes.String,
-		},
-	}, map[string]tftypes.Value{
-		"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
-		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
-	})
+	objectType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the provider schema to be an object")
+	}
+
+	attrValues := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		attrValues[name] = value
+	}
 
 	config := tfsdk.Config{
 		Schema: schemaResp.Schema,
-		Raw:    configValue,
+		Raw:    tftypes.NewValue(objectType, attrValues),
 	}
 
 	req := provider.ConfigureRequest{Config: config}
@@ -109,7 +671,118 @@ func TestProvider_Configure_ConfigOverridesEnvironment(t *testing.T) {
 
 	p.Configure(context.Background(), req, resp)
 
+	return resp
+}
+
+func TestProvider_Configure_DefaultTags(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+		"default_tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"owner": tftypes.NewValue(tftypes.String, "platform"),
+		}),
+	})
+
 	if resp.Diagnostics.HasError() {
 		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
 	}
+
+	data := resp.ResourceData.(*ProviderData)
+	if data.Client != resp.DataSourceData {
+		t.Error("expected resources and data sources to share the client")
+	}
+	if !reflect.DeepEqual(data.DefaultTags, map[string]string{"owner": "platform"}) {
+		t.Errorf("expected default tags owner=platform, got %v", data.DefaultTags)
+	}
+}
+
+func TestProvider_Configure_URL(t *testing.T) {
+	tests := map[string]struct {
+		url       string
+		expected  string
+		expectErr bool
+	}{
+		"endpoint":           {url: "https://zabbix.example.com/api_jsonrpc.php", expected: "https://zabbix.example.com/api_jsonrpc.php"},
+		"frontend":           {url: "https://zabbix.example.com", expected: "https://zabbix.example.com/api_jsonrpc.php"},
+		"trailing slash":     {url: "https://zabbix.example.com/", expected: "https://zabbix.example.com/api_jsonrpc.php"},
+		"frontend subpath":   {url: "http://monitoring.example.com:8080/zabbix//", expected: "http://monitoring.example.com:8080/zabbix/api_jsonrpc.php"},
+		"endpoint with port": {url: "HTTPS://zabbix.example.com:8443/api_jsonrpc.php/", expected: "https://zabbix.example.com:8443/api_jsonrpc.php"},
+		"missing scheme":     {url: "zabbix.example.com", expectErr: true},
+		"unsupported scheme": {url: "ftp://zabbix.example.com", expectErr: true},
+		"missing host":       {url: "https:///api_jsonrpc.php", expectErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := testProviderConfigure(t, map[string]tftypes.Value{
+				"url":       tftypes.NewValue(tftypes.String, tc.url),
+				"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+			})
+
+			if tc.expectErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected error for URL %q", tc.url)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+			}
+
+			client := resp.DataSourceData.(*zabbix.Client)
+			if client.URL != tc.expected {
+				t.Errorf("expected URL %s, got %s", tc.expected, client.URL)
+			}
+		})
+	}
+}
+
+func TestProvider_Configure_AuthMethod(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url":       tftypes.NewValue(tftypes.String, "https://config.example.com/api_jsonrpc.php"),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	if method := resp.DataSourceData.(*zabbix.Client).AuthMethod; method != zabbix.AuthMethodAuto {
+		t.Errorf("expected the token to be sent depending on the detected version, got auth method %d", method)
+	}
+}
+
+func TestProvider_Configure_FallbackURLs(t *testing.T) {
+	resp := testProviderConfigure(t, map[string]tftypes.Value{
+		"url": tftypes.NewValue(tftypes.String, "https://zabbix-a.example.com"),
+		"fallback_urls": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "https://zabbix-b.example.com/"),
+			tftypes.NewValue(tftypes.String, "https://zabbix-c.example.com/api_jsonrpc.php"),
+		}),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %s", resp.Diagnostics.Errors())
+	}
+
+	expected := []string{
+		"https://zabbix-b.example.com/api_jsonrpc.php",
+		"https://zabbix-c.example.com/api_jsonrpc.php",
+	}
+	if client := resp.DataSourceData.(*zabbix.Client); !reflect.DeepEqual(client.FallbackURLs, expected) {
+		t.Errorf("expected fallback URLs %v, got %v", expected, client.FallbackURLs)
+	}
+
+	resp = testProviderConfigure(t, map[string]tftypes.Value{
+		"url": tftypes.NewValue(tftypes.String, "https://zabbix-a.example.com"),
+		"fallback_urls": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "zabbix-b.example.com"),
+		}),
+		"api_token": tftypes.NewValue(tftypes.String, "config-token"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a fallback URL without scheme")
+	}
 }
@@ -0,0 +1,212 @@
+// ABOUTME: Harness running the CRUD methods of a resource against the fake Zabbix API.
+// ABOUTME: Carries state, private state and identity between operations the way Terraform does.
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceHarness runs the operations of a configured resource one after another. The
+// values passed to create and update are both the configuration and the plan; attributes
+// that are not given are null, so tests give unknown values for computed attributes.
+type resourceHarness struct {
+	t        *testing.T
+	resource resource.Resource
+	schema   resource.SchemaResponse
+
+	// state is the state after the last operation, null before create and after delete.
+	state tfsdk.State
+	// private is the private state after the last operation, of the unexported type the
+	// framework uses, which is only reachable through the Private fields.
+	private reflect.Value
+	// identity is the identity after the last operation, nil for resources without identity.
+	identity *tfsdk.ResourceIdentity
+}
+
+func newResourceHarness(t *testing.T, r resource.Resource, client ZabbixAPI) *resourceHarness {
+	t.Helper()
+
+	h := &resourceHarness{t: t, resource: r}
+	h.schema = configureTestResource(t, r, client)
+	h.state = emptyState(t, h.schema)
+	h.private = reflect.New(reflect.TypeOf(resource.CreateResponse{}.Private).Elem())
+
+	if withIdentity, ok := r.(resource.ResourceWithIdentity); ok {
+		identityResp := &resource.IdentitySchemaResponse{}
+		withIdentity.IdentitySchema(context.Background(), resource.IdentitySchemaRequest{}, identityResp)
+		h.identity = &tfsdk.ResourceIdentity{
+			Schema: identityResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(context.Background()), nil),
+		}
+	}
+	return h
+}
+
+// config builds the configuration of the values, in which unknown values become null, as
+// Terraform only knows computed values after apply.
+func (h *resourceHarness) config(values map[string]tftypes.Value) tfsdk.Config {
+	configured := make(map[string]tftypes.Value, len(values))
+	for name, value := range values {
+		if !value.IsKnown() {
+			value = tftypes.NewValue(value.Type(), nil)
+		}
+		configured[name] = value
+	}
+	return tfsdk.Config{Schema: h.schema.Schema, Raw: testObjectValue(h.t, h.schema, configured)}
+}
+
+// copyIdentity returns a copy of the identity for a request or response, as the framework passes them.
+func (h *resourceHarness) copyIdentity() *tfsdk.ResourceIdentity {
+	if h.identity == nil {
+		return nil
+	}
+	return &tfsdk.ResourceIdentity{Schema: h.identity.Schema, Raw: h.identity.Raw.Copy()}
+}
+
+// setPrivate sets the Private field of a request or response to the private state.
+func (h *resourceHarness) setPrivate(target interface{}) {
+	reflect.ValueOf(target).Elem().FieldByName("Private").Set(h.private)
+}
+
+// keepPrivate keeps the Private field of a response as the private state.
+func (h *resourceHarness) keepPrivate(resp interface{}) {
+	h.private = reflect.ValueOf(resp).Elem().FieldByName("Private")
+}
+
+func (h *resourceHarness) create(values map[string]tftypes.Value) diag.Diagnostics {
+	h.t.Helper()
+
+	req := resource.CreateRequest{Config: h.config(values), Plan: testPlan(h.t, h.schema, values), Identity: h.copyIdentity()}
+	resp := &resource.CreateResponse{State: emptyState(h.t, h.schema), Identity: h.copyIdentity()}
+	h.setPrivate(resp)
+	h.resource.Create(context.Background(), req, resp)
+
+	// Terraform keeps the state of a failed create, with the resource tainted, unless it is null
+	if !resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+		h.state = resp.State
+		h.keepPrivate(resp)
+		h.identity = resp.Identity
+	}
+	return resp.Diagnostics
+}
+
+func (h *resourceHarness) read() diag.Diagnostics {
+	h.t.Helper()
+
+	req := resource.ReadRequest{State: h.state, Identity: h.copyIdentity()}
+	h.setPrivate(&req)
+	resp := &resource.ReadResponse{State: h.state, Identity: h.copyIdentity()}
+	h.setPrivate(resp)
+	h.resource.Read(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		h.state = resp.State
+		h.keepPrivate(resp)
+		h.identity = resp.Identity
+	}
+	return resp.Diagnostics
+}
+
+// importState imports the resource with the import ID and reads it, as Terraform does
+// before it plans or generates configuration for an imported resource.
+func (h *resourceHarness) importState(id string) diag.Diagnostics {
+	h.t.Helper()
+
+	req := resource.ImportStateRequest{ID: id}
+	resp := &resource.ImportStateResponse{State: emptyState(h.t, h.schema), Identity: h.copyIdentity()}
+	h.resource.(resource.ResourceWithImportState).ImportState(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		return resp.Diagnostics
+	}
+
+	h.state = resp.State
+	h.identity = resp.Identity
+	return h.read()
+}
+
+func (h *resourceHarness) update(values map[string]tftypes.Value) diag.Diagnostics {
+	h.t.Helper()
+
+	req := resource.UpdateRequest{Config: h.config(values), Plan: testPlan(h.t, h.schema, values), State: h.state, Identity: h.copyIdentity()}
+	h.setPrivate(&req)
+	resp := &resource.UpdateResponse{State: h.state, Identity: h.copyIdentity()}
+	h.setPrivate(resp)
+	h.resource.Update(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		h.state = resp.State
+		h.keepPrivate(resp)
+		h.identity = resp.Identity
+	}
+	return resp.Diagnostics
+}
+
+func (h *resourceHarness) delete() diag.Diagnostics {
+	h.t.Helper()
+
+	req := resource.DeleteRequest{State: h.state, Identity: h.copyIdentity()}
+	h.setPrivate(&req)
+	resp := &resource.DeleteResponse{State: h.state, Identity: h.copyIdentity()}
+	h.setPrivate(resp)
+	h.resource.Delete(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		h.state = emptyState(h.t, h.schema)
+	}
+	return resp.Diagnostics
+}
+
+// removed reports whether the last operation removed the resource from state.
+func (h *resourceHarness) removed() bool {
+	return h.state.Raw.IsNull()
+}
+
+// model decodes the state into the model of the resource.
+func (h *resourceHarness) model(target interface{}) {
+	h.t.Helper()
+
+	if diags := h.state.Get(context.Background(), target); diags.HasError() {
+		h.t.Fatalf("unexpected error decoding state: %s", diags.Errors())
+	}
+}
+
+// identityModel decodes the identity into the identity model of the resource.
+func (h *resourceHarness) identityModel(target interface{}) {
+	h.t.Helper()
+
+	if h.identity == nil {
+		h.t.Fatal("the resource has no identity")
+	}
+	if diags := h.identity.Get(context.Background(), target); diags.HasError() {
+		h.t.Fatalf("unexpected error decoding identity: %s", diags.Errors())
+	}
+}
+
+// mustSucceed fails the test when the diagnostics of an operation contain errors.
+func (h *resourceHarness) mustSucceed(operation string, diags diag.Diagnostics) {
+	h.t.Helper()
+
+	if diags.HasError() {
+		h.t.Fatalf("unexpected %s error: %s", operation, diags.Errors())
+	}
+}
+
+// mustFail fails the test unless the diagnostics of an operation contain an error with the summary.
+func (h *resourceHarness) mustFail(operation string, diags diag.Diagnostics, summary string) {
+	h.t.Helper()
+
+	for _, d := range diags.Errors() {
+		if d.Summary() == summary {
+			return
+		}
+	}
+	h.t.Fatalf("expected %s to fail with %q, got %v", operation, summary, diags)
+}
@@ -0,0 +1,64 @@
+// ABOUTME: Resource identities of hosts, groups and templates, used by import blocks with identity.
+// ABOUTME: Hosts are identified by host ID, groups and templates by ID and name.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hostIdentityModel is the identity of a host.
+type hostIdentityModel struct {
+	HostID types.String `tfsdk:"hostid"`
+}
+
+// idNameIdentityModel is the identity of a host group, template group or template.
+type idNameIdentityModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func hostIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"hostid": identityschema.StringAttribute{
+				Description:       "The ID of the host (hostid in Zabbix).",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// idNameIdentitySchema returns the identity schema of the named kind of object, identified by
+// its ID and the name described. Imports only need the ID. The name changes when the object is
+// renamed, so resources using this identity set MutableIdentity.
+func idNameIdentitySchema(kind, nameDescription string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       fmt.Sprintf("The ID of the %s.", kind),
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       nameDescription,
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+// setIdentity sets the identity of a Create, Read or Update response. Terraform before 1.12
+// does not support identities, in which case there is none to set. Terraform requires an
+// identity after every Read, so Read also sets it when it removes the resource from state.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, value interface{}) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, value)
+}
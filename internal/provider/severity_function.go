@@ -0,0 +1,119 @@
+// ABOUTME: Provider-defined functions converting between Zabbix severity names and numbers.
+// ABOUTME: severity("high") returns 4 and severity_name(4) returns "high".
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = &SeverityFunction{}
+	_ function.Function = &SeverityNameFunction{}
+)
+
+// severityNames are the names of the Zabbix severities, indexed by their number.
+var severityNames = []string{"not_classified", "information", "warning", "average", "high", "disaster"}
+
+// SeverityFunction defines the function implementation.
+type SeverityFunction struct{}
+
+// NewSeverityFunction creates a new function instance.
+func NewSeverityFunction() function.Function {
+	return &SeverityFunction{}
+}
+
+func (f *SeverityFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "severity"
+}
+
+func (f *SeverityFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a Zabbix severity name to its number",
+		Description: "Returns the number of a Zabbix severity: 0 = not_classified, 1 = information, 2 = warning, 3 = average, 4 = high, 5 = disaster. " +
+			"The name is case-insensitive, and spaces or dashes may be used instead of the underscore.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Name of the severity, for example high.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *SeverityFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	severity, err := parseSeverity(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, int64(severity))
+}
+
+// SeverityNameFunction defines the function implementation.
+type SeverityNameFunction struct{}
+
+// NewSeverityNameFunction creates a new function instance.
+func NewSeverityNameFunction() function.Function {
+	return &SeverityNameFunction{}
+}
+
+func (f *SeverityNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "severity_name"
+}
+
+func (f *SeverityNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a Zabbix severity number to its name",
+		Description: "Returns the name of a Zabbix severity number, the reverse of severity: 0 = not_classified, 1 = information, 2 = warning, 3 = average, 4 = high, 5 = disaster.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "severity",
+				Description: "Number of the severity, from 0 to 5.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SeverityNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var severity int64
+
+	resp.Error = req.Arguments.Get(ctx, &severity)
+	if resp.Error != nil {
+		return
+	}
+
+	if severity < 0 || severity >= int64(len(severityNames)) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid severity %d: must be between 0 and %d", severity, len(severityNames)-1))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, severityNames[severity])
+}
+
+// parseSeverity returns the number of a severity name.
+func parseSeverity(name string) (int, error) {
+	normalized := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+
+	severity := slices.Index(severityNames, normalized)
+	if severity < 0 {
+		return 0, fmt.Errorf("invalid severity %q: must be one of %s", name, strings.Join(severityNames, ", "))
+	}
+
+	return severity, nil
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Tests for the severity and severity_name provider functions.
+// ABOUTME: Covers name normalization and running both functions through Terraform.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseSeverity(t *testing.T) {
+	tests := map[string]int{
+		"not_classified": 0,
+		"Not classified": 0,
+		"not-classified": 0,
+		"information":    1,
+		"warning":        2,
+		"average":        3,
+		"HIGH":           4,
+		" disaster ":     5,
+	}
+
+	for name, expected := range tests {
+		severity, err := parseSeverity(name)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", name, err)
+			continue
+		}
+		if severity != expected {
+			t.Errorf("expected %q to be %d, got %d", name, expected, severity)
+		}
+	}
+
+	if _, err := parseSeverity("critical"); err == nil {
+		t.Error("expected error for unknown severity")
+	}
+}
+
+func TestAccSeverityFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "severity" {
+  value = provider::zabbix::severity("high")
+}
+
+output "severity_name" {
+  value = provider::zabbix::severity_name(5)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("severity", "4"),
+					resource.TestCheckOutput("severity_name", "disaster"),
+				),
+			},
+			{
+				Config: `
+output "severity" {
+  value = provider::zabbix::severity("critical")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid severity`),
+			},
+			{
+				Config: `
+output "severity_name" {
+  value = provider::zabbix::severity_name(6)
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid severity 6`),
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+// ABOUTME: Shared state upgraders for resources whose schema changed between versions.
+// ABOUTME: Re-reads prior state JSON against the current schema when its encoding did not change.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// rereadStateUpgrader returns a state upgrader that reads the raw prior state against the
+// current schema of the resource. It suits changes that keep the JSON encoding of the
+// state, such as lists that became sets, and drops attributes that no longer exist. kind
+// names the resource in diagnostics, such as "host".
+func rereadStateUpgrader(r resource.Resource, kind string) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			stateType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			rawState, err := req.RawState.UnmarshalWithOpts(stateType, tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade "+titleCase(kind)+" State",
+					fmt.Sprintf("Could not read prior %s state: %s", kind, err),
+				)
+				return
+			}
+
+			dynamicValue, err := tfprotov6.NewDynamicValue(stateType, rawState)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade "+titleCase(kind)+" State",
+					fmt.Sprintf("Could not encode upgraded %s state: %s", kind, err),
+				)
+				return
+			}
+
+			resp.DynamicValue = &dynamicValue
+		},
+	}
+}
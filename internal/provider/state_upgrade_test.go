@@ -0,0 +1,46 @@
+// ABOUTME: Unit tests for the schema versions and state upgraders of all resources.
+// ABOUTME: Ensures every prior schema version of a resource has a state upgrader.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestResources_StateUpgradersCoverPriorVersions(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+
+		var metadataResp fwresource.MetadataResponse
+		r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "zabbix"}, &metadataResp)
+
+		var schemaResp fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+		version := schemaResp.Schema.Version
+
+		upgrader, ok := r.(fwresource.ResourceWithUpgradeState)
+		if !ok {
+			if version > 0 {
+				t.Errorf("%s: schema version %d without state upgraders", metadataResp.TypeName, version)
+			}
+			continue
+		}
+
+		upgraders := upgrader.UpgradeState(ctx)
+		for prior := int64(0); prior < version; prior++ {
+			if _, ok := upgraders[prior]; !ok {
+				t.Errorf("%s: no state upgrader from version %d to %d", metadataResp.TypeName, prior, version)
+			}
+		}
+		for prior := range upgraders {
+			if prior >= version {
+				t.Errorf("%s: state upgrader from version %d, which is not before the schema version %d", metadataResp.TypeName, prior, version)
+			}
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// ABOUTME: Acceptance test sweepers deleting objects left behind by interrupted test runs.
+// ABOUTME: Run with `make sweep`; only objects named with the tf-acc-test prefix are deleted.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// testAccSweepPrefix is the prefix of the names of the objects created by acceptance tests,
+// as generated with acctest.RandomWithPrefix.
+const testAccSweepPrefix = "tf-acc-test"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("zabbix_host", &resource.Sweeper{
+		Name: "zabbix_host",
+		F:    sweepHosts,
+	})
+	// Templates and groups cannot be deleted while hosts still use them
+	resource.AddTestSweepers("zabbix_template", &resource.Sweeper{
+		Name:         "zabbix_template",
+		Dependencies: []string{"zabbix_host"},
+		F:            sweepTemplates,
+	})
+	resource.AddTestSweepers("zabbix_host_group", &resource.Sweeper{
+		Name:         "zabbix_host_group",
+		Dependencies: []string{"zabbix_host"},
+		F:            sweepHostGroups,
+	})
+	resource.AddTestSweepers("zabbix_template_group", &resource.Sweeper{
+		Name:         "zabbix_template_group",
+		Dependencies: []string{"zabbix_template"},
+		F:            sweepTemplateGroups,
+	})
+}
+
+// sweeperClient returns a client for the test Zabbix instance, configured from the same
+// environment variables and defaults as the acceptance tests. Zabbix has no regions, so the
+// region given to the sweepers is ignored.
+func sweeperClient() *zabbix.Client {
+	url := os.Getenv("ZABBIX_URL")
+	if url == "" {
+		url = testAccDefaultURL
+	}
+	token := os.Getenv("ZABBIX_API_TOKEN")
+	if token == "" {
+		token = testAccDefaultToken
+	}
+
+	return zabbix.NewClient(url, zabbix.WithToken(token))
+}
+
+// isSweepable reports whether any of the names of an object starts with the test prefix.
+// The Zabbix search is not relied upon alone, as sweeping deletes objects.
+func isSweepable(names ...string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(name, testAccSweepPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sweepHosts(region string) error {
+	return sweepHostsWith(context.Background(), sweeperClient())
+}
+
+func sweepHostsWith(ctx context.Context, client *zabbix.Client) error {
+	hosts, err := client.SearchHosts(ctx, zabbix.HostSearch{Pattern: testAccSweepPrefix + "*"})
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, host := range hosts {
+		if isSweepable(host.Host, host.Name) {
+			ids = append(ids, host.HostID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting %d hosts left by acceptance tests", len(ids))
+	return client.DeleteHosts(ctx, ids)
+}
+
+func sweepTemplates(region string) error {
+	return sweepTemplatesWith(context.Background(), sweeperClient())
+}
+
+func sweepTemplatesWith(ctx context.Context, client *zabbix.Client) error {
+	templates, err := client.SearchTemplatesByHost(ctx, testAccSweepPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, template := range templates {
+		if isSweepable(template.Host) {
+			ids = append(ids, template.TemplateID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting %d templates left by acceptance tests", len(ids))
+	return client.DeleteTemplates(ctx, ids)
+}
+
+func sweepHostGroups(region string) error {
+	return sweepHostGroupsWith(context.Background(), sweeperClient())
+}
+
+func sweepHostGroupsWith(ctx context.Context, client *zabbix.Client) error {
+	groups, err := client.SearchHostGroups(ctx, testAccSweepPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, group := range groups {
+		if isSweepable(group.Name) {
+			ids = append(ids, group.GroupID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting %d host groups left by acceptance tests", len(ids))
+	return client.DeleteHostGroups(ctx, ids)
+}
+
+func sweepTemplateGroups(region string) error {
+	return sweepTemplateGroupsWith(context.Background(), sweeperClient())
+}
+
+func sweepTemplateGroupsWith(ctx context.Context, client *zabbix.Client) error {
+	groups, err := client.SearchTemplateGroups(ctx, testAccSweepPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, group := range groups {
+		if isSweepable(group.Name) {
+			ids = append(ids, group.GroupID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting %d template groups left by acceptance tests", len(ids))
+	return client.DeleteTemplateGroups(ctx, ids)
+}
+
+func TestSweepHosts_OnlyTestObjects(t *testing.T) {
+	var deleted []string
+	server := newSweeperTestServer(t, `[
+		{"hostid": "1", "host": "tf-acc-test-abc", "name": "tf-acc-test-abc"},
+		{"hostid": "2", "host": "web01", "name": "tf-acc-test visible name"},
+		{"hostid": "3", "host": "db01", "name": "Production tf-acc-test"}
+	]`, &deleted)
+	defer server.Close()
+
+	if err := sweepHostsWith(context.Background(), zabbix.NewClient(server.URL, zabbix.WithToken("test-token"))); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if strings.Join(deleted, ",") != "1,2" {
+		t.Errorf("expected hosts 1 and 2 to be deleted, got %v", deleted)
+	}
+}
+
+func TestSweepHostGroups_NothingToDelete(t *testing.T) {
+	var deleted []string
+	server := newSweeperTestServer(t, `[{"groupid": "5", "name": "Linux servers"}]`, &deleted)
+	defer server.Close()
+
+	if err := sweepHostGroupsWith(context.Background(), zabbix.NewClient(server.URL, zabbix.WithToken("test-token"))); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if deleted != nil {
+		t.Errorf("expected nothing to be deleted, got %v", deleted)
+	}
+}
+
+// newSweeperTestServer returns a server answering get requests with the objects and delete
+// requests with the deleted IDs, which are appended to deleted.
+func newSweeperTestServer(t *testing.T, objects string, deleted *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+			ID     int             `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %s", err)
+			return
+		}
+
+		result := json.RawMessage(objects)
+		if strings.HasSuffix(req.Method, ".delete") {
+			var ids []string
+			if err := json.Unmarshal(req.Params, &ids); err != nil {
+				t.Errorf("expected %s to be called with IDs, got %s", req.Method, req.Params)
+			}
+			*deleted = append(*deleted, ids...)
+			result, _ = json.Marshal(map[string][]string{"hostids": ids, "groupids": ids, "templateids": ids})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "result": result, "id": req.ID})
+	}))
+}
@@ -0,0 +1,86 @@
+// ABOUTME: Shared tag filter schema and conversion for data sources that search by tag.
+// ABOUTME: Maps operator names to the tag filter operators of host.get and template.get.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// tagOperators maps tag filter operator names to their API values.
+var tagOperators = map[string]int{
+	"contains":     zabbix.TagOperatorContains,
+	"equals":       zabbix.TagOperatorEquals,
+	"not_contains": zabbix.TagOperatorNotContains,
+	"not_equals":   zabbix.TagOperatorNotEquals,
+	"exists":       zabbix.TagOperatorExists,
+	"not_exists":   zabbix.TagOperatorNotExists,
+}
+
+// TagFilterModel describes a tag filter of a data source.
+type TagFilterModel struct {
+	Tag      types.String `tfsdk:"tag"`
+	Value    types.String `tfsdk:"value"`
+	Operator types.String `tfsdk:"operator"`
+}
+
+// tagFilterSchema returns the schema of an optional list of tag filters.
+func tagFilterSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"tag": schema.StringAttribute{
+					Description: "Tag name.",
+					Required:    true,
+				},
+				"value": schema.StringAttribute{
+					Description: "Tag value to compare with. Not used by the exists and not_exists operators.",
+					Optional:    true,
+				},
+				"operator": schema.StringAttribute{
+					Description: "Comparison operator: equals (default), contains, not_equals, not_contains, exists, or not_exists.",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("equals", "contains", "not_equals", "not_contains", "exists", "not_exists"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// tagFiltersFromModel converts a list of tag filters to API tag filters.
+func tagFiltersFromModel(ctx context.Context, list types.List) ([]zabbix.TagFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	var tags []TagFilterModel
+	diags.Append(list.ElementsAs(ctx, &tags, false)...)
+
+	filters := make([]zabbix.TagFilter, 0, len(tags))
+	for _, tag := range tags {
+		operator := tagOperators["equals"]
+		if !tag.Operator.IsNull() {
+			operator = tagOperators[tag.Operator.ValueString()]
+		}
+		filters = append(filters, zabbix.TagFilter{
+			Tag:      tag.Tag.ValueString(),
+			Value:    tag.Value.ValueString(),
+			Operator: operator,
+		})
+	}
+
+	return filters, diags
+}
@@ -0,0 +1,125 @@
+// ABOUTME: Extracts template technical names from Zabbix configuration exports and normalizes them.
+// ABOUTME: Parses YAML, JSON and XML exports, including exports with several templates.
+
+package provider
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+	"gopkg.in/yaml.v3"
+)
+
+// extractTemplateNames returns the technical names of all templates defined in a
+// configuration export, in the order they appear.
+func extractTemplateNames(content, format string) ([]string, error) {
+	var names []string
+	var err error
+
+	switch format {
+	case "yaml":
+		names, err = extractTemplateNamesFromYAML(content)
+	case "json":
+		names, err = extractTemplateNamesFromJSON(content)
+	case "xml":
+		names, err = extractTemplateNamesFromXML(content)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no templates found in %s content", format)
+	}
+
+	return names, nil
+}
+
+func extractTemplateNamesFromJSON(content string) ([]string, error) {
+	var export struct {
+		ZabbixExport struct {
+			Templates []struct {
+				Template string `json:"template"`
+			} `json:"templates"`
+		} `json:"zabbix_export"`
+	}
+	if err := json.Unmarshal([]byte(content), &export); err != nil {
+		return nil, fmt.Errorf("invalid JSON content: %w", err)
+	}
+
+	var names []string
+	for _, t := range export.ZabbixExport.Templates {
+		if t.Template != "" {
+			names = append(names, t.Template)
+		}
+	}
+	return names, nil
+}
+
+func extractTemplateNamesFromXML(content string) ([]string, error) {
+	var export struct {
+		XMLName   xml.Name `xml:"zabbix_export"`
+		Templates []struct {
+			Template string `xml:"template"`
+		} `xml:"templates>template"`
+	}
+	if err := xml.Unmarshal([]byte(content), &export); err != nil {
+		return nil, fmt.Errorf("invalid XML content: %w", err)
+	}
+
+	var names []string
+	for _, t := range export.Templates {
+		if name := strings.TrimSpace(t.Template); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func extractTemplateNamesFromYAML(content string) ([]string, error) {
+	var export struct {
+		ZabbixExport struct {
+			Templates []struct {
+				Template string `yaml:"template"`
+			} `yaml:"templates"`
+		} `yaml:"zabbix_export"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &export); err != nil {
+		return nil, fmt.Errorf("invalid YAML content: %w", err)
+	}
+
+	var names []string
+	for _, t := range export.ZabbixExport.Templates {
+		if t.Template != "" {
+			names = append(names, t.Template)
+		}
+	}
+	return names, nil
+}
+
+// normalizedExport returns YAML exports in their canonical form, so that exported_content
+// does not change between reads of an unchanged template. Other formats are returned as is,
+// as is content that cannot be normalized, together with a warning.
+func normalizedExport(content, format string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if format != "yaml" {
+		return content, diags
+	}
+
+	normalized, err := zabbix.NormalizeExport(content)
+	if err != nil {
+		diags.AddWarning(
+			"Error Normalizing Exported Template",
+			fmt.Sprintf("Could not normalize the exported template content, keeping it as exported: %s", err),
+		)
+		return content, diags
+	}
+
+	return normalized, diags
+}
@@ -0,0 +1,228 @@
+// ABOUTME: Unit tests for extracting template names from configuration exports and normalizing them.
+// ABOUTME: Covers YAML, JSON and XML exports with one or several templates.
+
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTemplateYAML = `zabbix_export:
+  version: '7.0'
+  template_groups:
+    - uuid: a571c0d144b14fd4a87a9d9b2aa9fcd6
+      name: Templates/Applications
+  templates:
+    - uuid: d6e1ee1a35ad4a4fb9a8ef4d3a01e7b1
+      template: 'Apache by HTTP'
+      name: 'Apache by HTTP'
+      templates:
+        - name: 'Linux by Zabbix agent'
+      items:
+        - uuid: 1b8a8a8a8a8a4a8a8a8a8a8a8a8a8a8a
+          name: 'Apache: Get status'
+          key: web.page.get
+      macros:
+        - macro: '{$APACHE.STATUS.HOST}'
+          value: 127.0.0.1
+    - template: "Nginx by HTTP" # second template
+      uuid: e8f6a1c2b3d44e5f8a9b0c1d2e3f4a5b
+      name: Nginx by HTTP
+  triggers:
+    - uuid: 3c4d5e6f7a8b4c9d8e7f6a5b4c3d2e1f
+      expression: 'last(/Apache by HTTP/apache.uptime)<10m'
+`
+
+func TestExtractTemplateNames_YAML(t *testing.T) {
+	names, err := extractTemplateNames(testTemplateYAML, "yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Apache by HTTP", "Nginx by HTTP"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestExtractTemplateNames_YAMLListAtKeyIndent(t *testing.T) {
+	content := `zabbix_export:
+  version: '7.0'
+  templates:
+  - uuid: d6e1ee1a35ad4a4fb9a8ef4d3a01e7b1
+    template: 'It''s a template'
+    items:
+    - template: not-a-template
+  groups:
+  - name: Templates
+`
+
+	names, err := extractTemplateNames(content, "yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"It's a template"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestExtractTemplateNames_YAMLFlowStyle(t *testing.T) {
+	content := `zabbix_export: {version: '7.0', templates: [
+    {uuid: d6e1ee1a35ad4a4fb9a8ef4d3a01e7b1, template: Apache by HTTP, templates: [{name: Linux by Zabbix agent}]},
+    {"template": "Nginx by HTTP", items: [{template: not-a-template}]}
+  ]}
+`
+
+	names, err := extractTemplateNames(content, "yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Apache by HTTP", "Nginx by HTTP"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestExtractTemplateNames_YAMLAnchorsAndMultiline(t *testing.T) {
+	content := `zabbix_export:
+  version: '7.0'
+  templates:
+    - &base
+      template: >-
+        Linux by
+        Zabbix agent
+      name: Linux
+    - <<: *base
+      template: Linux extended
+`
+
+	names, err := extractTemplateNames(content, "yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Linux by Zabbix agent", "Linux extended"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestExtractTemplateNames_JSON(t *testing.T) {
+	content := `{"zabbix_export":{"version":"7.0","templates":[
+		{"uuid":"d6e1ee1a35ad4a4fb9a8ef4d3a01e7b1","template":"Apache by HTTP","templates":[{"name":"Linux by Zabbix agent"}]},
+		{"uuid":"e8f6a1c2b3d44e5f8a9b0c1d2e3f4a5b","template":"Nginx by HTTP"}
+	]}}`
+
+	names, err := extractTemplateNames(content, "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Apache by HTTP", "Nginx by HTTP"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestExtractTemplateNames_XML(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<zabbix_export>
+  <version>7.0</version>
+  <templates>
+    <template>
+      <uuid>d6e1ee1a35ad4a4fb9a8ef4d3a01e7b1</uuid>
+      <template>Apache by HTTP</template>
+      <name>Apache by HTTP</name>
+      <templates>
+        <template>
+          <name>Linux by Zabbix agent</name>
+        </template>
+      </templates>
+    </template>
+    <template>
+      <uuid>e8f6a1c2b3d44e5f8a9b0c1d2e3f4a5b</uuid>
+      <template>Nginx by HTTP</template>
+    </template>
+  </templates>
+</zabbix_export>`
+
+	names, err := extractTemplateNames(content, "xml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Apache by HTTP", "Nginx by HTTP"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestExtractTemplateNames_NoTemplates(t *testing.T) {
+	for format, content := range map[string]string{
+		"yaml": "zabbix_export:\n  version: '7.0'\n",
+		"json": `{"zabbix_export":{"version":"7.0"}}`,
+		"xml":  `<zabbix_export><version>7.0</version></zabbix_export>`,
+	} {
+		if _, err := extractTemplateNames(content, format); err == nil {
+			t.Errorf("expected error for %s content without templates", format)
+		}
+	}
+}
+
+func TestExtractTemplateNames_InvalidContent(t *testing.T) {
+	if _, err := extractTemplateNames("{not json", "json"); err == nil {
+		t.Error("expected error for invalid JSON content")
+	}
+	if _, err := extractTemplateNames("zabbix_export: [\n", "yaml"); err == nil {
+		t.Error("expected error for invalid YAML content")
+	}
+	if _, err := extractTemplateNames("<zabbix_export>", "xml"); err == nil {
+		t.Error("expected error for invalid XML content")
+	}
+}
+
+func TestNormalizedExport(t *testing.T) {
+	normalized, diags := normalizedExport(testTemplateYAML, "yaml")
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if strings.Contains(normalized, "version:") {
+		t.Errorf("expected the export version to be removed, got:\n%s", normalized)
+	}
+
+	names, err := extractTemplateNames(normalized, "yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"Apache by HTTP", "Nginx by HTTP"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v in the normalized export, got %v", expected, names)
+	}
+}
+
+func TestNormalizedExport_Unchanged(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		format   string
+		warnings int
+	}{
+		"json":         {content: `{"zabbix_export":{"version":"7.0"}}`, format: "json"},
+		"invalid yaml": {content: "zabbix_export: [\n", format: "yaml", warnings: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			content, diags := normalizedExport(tc.content, tc.format)
+			if content != tc.content {
+				t.Errorf("expected the content to be kept, got %q", content)
+			}
+			if diags.WarningsCount() != tc.warnings || diags.HasError() {
+				t.Errorf("expected %d warnings, got %v", tc.warnings, diags)
+			}
+		})
+	}
+}
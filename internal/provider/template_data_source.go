@@ -6,16 +6,23 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
-var _ datasource.DataSource = &TemplateDataSource{}
+var (
+	_ datasource.DataSource                   = &TemplateDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &TemplateDataSource{}
+)
 
 // TemplateDataSource defines the data source implementation.
 type TemplateDataSource struct {
@@ -26,12 +33,17 @@ type TemplateDataSource struct {
 type TemplateDataSourceModel struct {
 	ID              types.String `tfsdk:"id"`
 	Host            types.String `tfsdk:"host"`
+	TagFilters      types.List   `tfsdk:"tag_filters"`
 	Name            types.String `tfsdk:"name"`
 	Description     types.String `tfsdk:"description"`
 	UUID            types.String `tfsdk:"uuid"`
 	Groups          types.List   `tfsdk:"groups"`
 	Tags            types.List   `tfsdk:"tags"`
+	ExportFormat    types.String `tfsdk:"export_format"`
 	ExportedContent types.String `tfsdk:"exported_content"`
+	ItemsCount      types.Int64  `tfsdk:"items_count"`
+	TriggersCount   types.Int64  `tfsdk:"triggers_count"`
+	DiscoveryCount  types.Int64  `tfsdk:"discovery_rules_count"`
 }
 
 // NewTemplateDataSource creates a new data source instance.
@@ -45,16 +57,18 @@ func (d *TemplateDataSource) Metadata(ctx context.Context, req datasource.Metada
 
 func (d *TemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Use this data source to look up a Zabbix template by technical name. Returns template metadata and exported content.",
+		Description: "Use this data source to look up a Zabbix template by technical name or by tags. Returns template metadata and exported content.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the template (templateid in Zabbix).",
 				Computed:    true,
 			},
 			"host": schema.StringAttribute{
-				Description: "Technical name of the template to look up.",
-				Required:    true,
+				Description: "Technical name of the template to look up. At least one of host or tag_filters must be set.",
+				Optional:    true,
+				Computed:    true,
 			},
+			"tag_filters": tagFilterSchema("Look up the template matching all of these tag filters, for example class equals baseline. The filters must match exactly one template."),
 			"name": schema.StringAttribute{
 				Description: "Visible name of the template.",
 				Computed:    true,
@@ -88,8 +102,28 @@ func (d *TemplateDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 					},
 				},
 			},
+			"export_format": schema.StringAttribute{
+				Description: "Format of exported_content: yaml, json, or xml. Defaults to yaml.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("yaml", "json", "xml"),
+				},
+			},
 			"exported_content": schema.StringAttribute{
-				Description: "Exported template content in YAML format.",
+				Description: "Exported template content in the format selected by export_format. YAML content is normalized to a canonical form without the export date and version and with sorted keys, so that it only changes when the template does.",
+				Computed:    true,
+			},
+			"items_count": schema.Int64Attribute{
+				Description: "Number of items on the template. Useful to verify that an import produced content.",
+				Computed:    true,
+			},
+			"triggers_count": schema.Int64Attribute{
+				Description: "Number of triggers on the template.",
+				Computed:    true,
+			},
+			"discovery_rules_count": schema.Int64Attribute{
+				Description: "Number of low-level discovery rules on the template.",
 				Computed:    true,
 			},
 		},
@@ -113,7 +147,7 @@ func (d *TemplateDataSource) Configure(ctx context.Context, req datasource.Confi
 	d.client = client
 }
 
-func (d *TemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+func (d *TemplateDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
 	var data TemplateDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -121,25 +155,43 @@ func (d *TemplateDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	template, err := d.client.GetTemplateByHost(ctx, data.Host.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Template",
-			fmt.Sprintf("Could not read template with host %q: %s", data.Host.ValueString(), err),
+	if data.Host.IsUnknown() || data.TagFilters.IsUnknown() {
+		return
+	}
+
+	if data.Host.IsNull() && data.TagFilters.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Missing Template Criteria",
+			"Either host or tag_filters must be set to look up a template.",
 		)
+	}
+}
+
+func (d *TemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TemplateDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	var template *zabbix.Template
+	if data.TagFilters.IsNull() {
+		template = d.readByHost(ctx, &data, resp)
+	} else {
+		template = d.readByTags(ctx, &data, resp)
+	}
 	if template == nil {
-		resp.Diagnostics.AddError(
-			"Template Not Found",
-			fmt.Sprintf("No template found with technical name %q.", data.Host.ValueString()),
-		)
 		return
 	}
 
+	if data.ExportFormat.IsNull() {
+		data.ExportFormat = types.StringValue("yaml")
+	}
+
 	// Export the template content
-	exported, err := d.client.ExportConfiguration(ctx, "yaml", []string{template.TemplateID})
+	exported, err := d.client.ExportConfiguration(ctx, data.ExportFormat.ValueString(), []string{template.TemplateID})
 	if err != nil {
 		resp.Diagnostics.AddWarning(
 			"Error Exporting Template",
@@ -156,6 +208,70 @@ func (d *TemplateDataSource) Read(ctx context.Context, req datasource.ReadReques
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// readByHost looks up the template by technical name, adding an error diagnostic when it
+// does not exist.
+func (d *TemplateDataSource) readByHost(ctx context.Context, data *TemplateDataSourceModel, resp *datasource.ReadResponse) *zabbix.Template {
+	template, err := d.client.GetTemplateByHost(ctx, data.Host.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Template",
+			fmt.Sprintf("Could not read template with host %q: %s", data.Host.ValueString(), err),
+		)
+		return nil
+	}
+
+	if template == nil {
+		resp.Diagnostics.AddError(
+			"Template Not Found",
+			fmt.Sprintf("No template found with technical name %q.", data.Host.ValueString()),
+		)
+		return nil
+	}
+
+	return template
+}
+
+// readByTags looks up the single template matching the tag filters and, when set, the
+// technical name. Adds an error diagnostic unless exactly one template matches.
+func (d *TemplateDataSource) readByTags(ctx context.Context, data *TemplateDataSourceModel, resp *datasource.ReadResponse) *zabbix.Template {
+	tags, diags := tagFiltersFromModel(ctx, data.TagFilters)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return nil
+	}
+
+	templates, err := d.client.SearchTemplates(ctx, data.Host.ValueString(), tags)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Template",
+			fmt.Sprintf("Could not search templates by tag: %s", err),
+		)
+		return nil
+	}
+
+	if len(templates) == 0 {
+		resp.Diagnostics.AddError(
+			"Template Not Found",
+			"No template matches the given tag filters.",
+		)
+		return nil
+	}
+
+	if len(templates) > 1 {
+		names := make([]string, len(templates))
+		for i, t := range templates {
+			names[i] = t.Host
+		}
+		resp.Diagnostics.AddError(
+			"Multiple Templates Found",
+			fmt.Sprintf("Found %d templates matching the given tag filters (%s); the lookup must match exactly one template.", len(templates), strings.Join(names, ", ")),
+		)
+		return nil
+	}
+
+	return &templates[0]
+}
+
 // apiToModel converts the Zabbix API struct to Terraform model.
 func (d *TemplateDataSource) apiToModel(ctx context.Context, template *zabbix.Template, data *TemplateDataSourceModel, exportedContent string) diag.Diagnostics {
 	var diags diag.Diagnostics
@@ -165,6 +281,9 @@ func (d *TemplateDataSource) apiToModel(ctx context.Context, template *zabbix.Te
 	data.Name = types.StringValue(template.Name)
 	data.Description = types.StringValue(template.Description)
 	data.UUID = types.StringValue(template.UUID)
+	data.ItemsCount = types.Int64Value(int64(template.ItemsCount))
+	data.TriggersCount = types.Int64Value(int64(template.TriggersCount))
+	data.DiscoveryCount = types.Int64Value(int64(template.DiscoveryCount))
 
 	// Convert groups
 	groupIDs := make([]attr.Value, len(template.Groups))
@@ -201,6 +320,8 @@ func (d *TemplateDataSource) apiToModel(ctx context.Context, template *zabbix.Te
 
 	// Set exported content
 	if exportedContent != "" {
+		exportedContent, normalizeDiags := normalizedExport(exportedContent, data.ExportFormat.ValueString())
+		diags.Append(normalizeDiags...)
 		data.ExportedContent = types.StringValue(exportedContent)
 	} else {
 		data.ExportedContent = types.StringNull()
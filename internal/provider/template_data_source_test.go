@@ -1,10 +1,11 @@
 // ABOUTME: Acceptance tests for the zabbix_template data source.
-// ABOUTME: Tests looking up templates by technical name and retrieving exported content.
+// ABOUTME: Tests looking up templates by technical name or tags and retrieving exported content.
 
 package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
@@ -26,6 +27,7 @@ func TestAccTemplateDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.zabbix_template.test", "id"),
 					resource.TestCheckResourceAttrSet("data.zabbix_template.test", "uuid"),
 					resource.TestCheckResourceAttrSet("data.zabbix_template.test", "exported_content"),
+					resource.TestCheckResourceAttr("data.zabbix_template.test", "items_count", "0"),
 				),
 			},
 		},
@@ -47,6 +49,7 @@ func TestAccTemplateDataSource_withOfficialTemplate(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.zabbix_template.test", "id"),
 					resource.TestCheckResourceAttrSet("data.zabbix_template.test", "uuid"),
 					resource.TestCheckResourceAttrSet("data.zabbix_template.test", "exported_content"),
+					resource.TestMatchResourceAttr("data.zabbix_template.test", "items_count", regexp.MustCompile(`^[1-9][0-9]*$`)),
 				),
 				// The exported_content computed field causes Terraform to show a plan
 				// even when nothing has changed. This is expected behavior.
@@ -56,6 +59,61 @@ func TestAccTemplateDataSource_withOfficialTemplate(t *testing.T) {
 	})
 }
 
+func TestAccTemplateDataSource_exportFormat(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateDataSourceConfigExportFormat(rName, "json"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_template.test", "export_format", "json"),
+					resource.TestMatchResourceAttr("data.zabbix_template.test", "exported_content", regexp.MustCompile(`^\{"zabbix_export"`)),
+				),
+			},
+			{
+				Config: testAccTemplateDataSourceConfigExportFormat(rName, "xml"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_template.test", "export_format", "xml"),
+					resource.TestMatchResourceAttr("data.zabbix_template.test", "exported_content", regexp.MustCompile(`^<\?xml`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTemplateDataSource_tagFilters(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateDataSourceConfigTagFilters(rName, "equals"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_template.test", "host", rName+"-baseline"),
+					resource.TestCheckResourceAttrPair("data.zabbix_template.test", "id", "zabbix_template.baseline", "id"),
+				),
+			},
+			{
+				Config:      testAccTemplateDataSourceConfigTagFilters(rName, "exists"),
+				ExpectError: regexp.MustCompile("Multiple Templates Found"),
+			},
+			{
+				Config: `
+data "zabbix_template" "test" {
+  export_format = "json"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing Template Criteria"),
+			},
+		},
+	})
+}
+
 func testAccTemplateDataSourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "zabbix_template_group" "test" {
@@ -86,3 +144,57 @@ data "zabbix_template" "test" {
 }
 `, content)
 }
+
+func testAccTemplateDataSourceConfigExportFormat(name, format string) string {
+	return fmt.Sprintf(`
+resource "zabbix_template_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_template" "test" {
+  host   = %[1]q
+  groups = [zabbix_template_group.test.id]
+}
+
+data "zabbix_template" "test" {
+  host          = zabbix_template.test.host
+  export_format = %[2]q
+}
+`, name, format)
+}
+
+func testAccTemplateDataSourceConfigTagFilters(name, operator string) string {
+	return fmt.Sprintf(`
+resource "zabbix_template_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_template" "baseline" {
+  host   = "%[1]s-baseline"
+  groups = [zabbix_template_group.test.id]
+  tags = [{
+    tag   = %[1]q
+    value = "baseline"
+  }]
+}
+
+resource "zabbix_template" "extra" {
+  host   = "%[1]s-extra"
+  groups = [zabbix_template_group.test.id]
+  tags = [{
+    tag   = %[1]q
+    value = "extra"
+  }]
+}
+
+data "zabbix_template" "test" {
+  tag_filters = [{
+    tag      = %[1]q
+    value    = "baseline"
+    operator = %[2]q
+  }]
+
+  depends_on = [zabbix_template.baseline, zabbix_template.extra]
+}
+`, name, operator)
+}
@@ -5,9 +5,9 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,20 +17,31 @@ import (
 )
 
 var (
-	_ resource.Resource                = &TemplateGroupResource{}
-	_ resource.ResourceWithImportState = &TemplateGroupResource{}
+	_ resource.Resource                 = &TemplateGroupResource{}
+	_ resource.ResourceWithIdentity     = &TemplateGroupResource{}
+	_ resource.ResourceWithImportState  = &TemplateGroupResource{}
+	_ resource.ResourceWithUpgradeState = &TemplateGroupResource{}
 )
 
 // TemplateGroupResource defines the resource implementation.
 type TemplateGroupResource struct {
-	client *zabbix.Client
+	client ZabbixAPI
 }
 
 // TemplateGroupResourceModel describes the resource data model.
 type TemplateGroupResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
-	UUID types.String `tfsdk:"uuid"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	UUID               types.String `tfsdk:"uuid"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	Timeouts           types.Object `tfsdk:"timeouts"`
+}
+
+// templateGroupVersionRequirements are the Zabbix versions required by the resource, as
+// template groups were split from host groups in Zabbix 6.2.
+var templateGroupVersionRequirements = []versionRequirement{
+	{major: 6, minor: 2},
 }
 
 // NewTemplateGroupResource creates a new resource instance.
@@ -40,11 +51,18 @@ func NewTemplateGroupResource() resource.Resource {
 
 func (r *TemplateGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_template_group"
+	// The identity includes the name, which changes when the object is renamed
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *TemplateGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idNameIdentitySchema("template group", "The name of the template group.")
 }
 
 func (r *TemplateGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a Zabbix template group. Template groups are used to organize templates.",
+		Description: "Manages a Zabbix template group. Template groups are used to organize templates. Requires Zabbix 6.2 or later.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the template group (groupid in Zabbix).",
@@ -64,6 +82,11 @@ func (r *TemplateGroupResource) Schema(ctx context.Context, req resource.SchemaR
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("template group"),
+			"adopt_existing":      adoptExistingAttribute("template group"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
@@ -73,16 +96,16 @@ func (r *TemplateGroupResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*zabbix.Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
 }
 
 func (r *TemplateGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -93,11 +116,29 @@ func (r *TemplateGroupResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkVersionRequirements(ctx, r.client, "zabbix_template_group", req.Config, templateGroupVersionRequirements)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	groupID, err := r.client.CreateTemplateGroup(ctx, data.Name.ValueString())
-	if err != nil {
+	if errors.Is(err, zabbix.ErrAlreadyExists) && data.AdoptExisting.ValueBool() {
+		groupID, diags = adoptExisting(ctx, "template group", data.Name.ValueString(), r.client.TemplateGroupIDByName)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Template Group",
-			fmt.Sprintf("Could not create template group: %s", err),
+			fmt.Sprintf("Could not create template group: %s%s", err, alreadyExistsHint(err, "template group", true)),
 		)
 		return
 	}
@@ -108,6 +149,16 @@ func (r *TemplateGroupResource) Create(ctx context.Context, req resource.CreateR
 			"Error Reading Template Group",
 			fmt.Sprintf("Could not read template group after creation: %s", err),
 		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, groupID)...)
+		return
+	}
+
+	if group == nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Template Group",
+			fmt.Sprintf("Template group %s was created but could not be found", groupID),
+		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, groupID)...)
 		return
 	}
 
@@ -116,6 +167,7 @@ func (r *TemplateGroupResource) Create(ctx context.Context, req resource.CreateR
 	data.UUID = types.StringValue(group.UUID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 }
 
 func (r *TemplateGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -126,8 +178,15 @@ func (r *TemplateGroupResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	group, err := r.client.GetTemplateGroup(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Reading Template Group",
 			fmt.Sprintf("Could not read template group ID %s: %s", data.ID.ValueString(), err),
@@ -136,6 +195,7 @@ func (r *TemplateGroupResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	if group == nil {
+		resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -144,7 +204,16 @@ func (r *TemplateGroupResource) Read(ctx context.Context, req resource.ReadReque
 	data.Name = types.StringValue(group.Name)
 	data.UUID = types.StringValue(group.UUID)
 
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 }
 
 func (r *TemplateGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -155,6 +224,18 @@ func (r *TemplateGroupResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkVersionRequirements(ctx, r.client, "zabbix_template_group", req.Config, templateGroupVersionRequirements)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state TemplateGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -184,6 +265,7 @@ func (r *TemplateGroupResource) Update(ctx context.Context, req resource.UpdateR
 	data.UUID = types.StringValue(group.UUID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Name})...)
 }
 
 func (r *TemplateGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -194,8 +276,21 @@ func (r *TemplateGroupResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "template group", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A template group deleted outside of Terraform is already gone
 	err := r.client.DeleteTemplateGroup(ctx, data.ID.ValueString())
-	if err != nil {
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Deleting Template Group",
 			fmt.Sprintf("Could not delete template group ID %s: %s", data.ID.ValueString(), err),
@@ -205,5 +300,12 @@ func (r *TemplateGroupResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *TemplateGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "template group", "id", r.client.TemplateGroupIDByName)
+}
+
+// UpgradeState has no upgraders while the schema is at its first version. Changes of the
+// schema that alter the state must increase the version and add an upgrader from the
+// previous one here.
+func (r *TemplateGroupResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
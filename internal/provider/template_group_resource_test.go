@@ -1,14 +1,20 @@
-// ABOUTME: Acceptance tests for the zabbix_template_group resource.
-// ABOUTME: Tests full CRUD lifecycle and import functionality.
+// ABOUTME: Acceptance and unit tests for the zabbix_template_group resource.
+// ABOUTME: Tests full CRUD lifecycle and import functionality, and CRUD logic against a fake client.
 
 package provider
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 func TestAccTemplateGroupResource_basic(t *testing.T) {
@@ -66,3 +72,146 @@ resource "zabbix_template_group" "test" {
 }
 `, name)
 }
+
+// templateGroupValues returns the planned values of a template group with the name.
+func templateGroupValues(name string) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":                tftypes.NewValue(tftypes.String, name),
+		"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"adopt_existing":      tftypes.NewValue(tftypes.Bool, false),
+	}
+}
+
+func TestTemplateGroupResource_CRUD(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateGroupResource(), client)
+
+	h.mustSucceed("create", h.create(templateGroupValues("Templates/Custom")))
+	var created TemplateGroupResourceModel
+	h.model(&created)
+	if created.ID.ValueString() != "101" || created.UUID.ValueString() != "uuid-101" {
+		t.Errorf("expected ID 101 and UUID uuid-101, got %s and %s", created.ID, created.UUID)
+	}
+
+	// A rename outside of Terraform is picked up by read
+	client.templateGroups["101"].Name = "Templates/Renamed"
+	h.mustSucceed("read", h.read())
+	var read TemplateGroupResourceModel
+	h.model(&read)
+	if read.Name.ValueString() != "Templates/Renamed" {
+		t.Errorf("expected the name to be refreshed, got %s", read.Name)
+	}
+
+	h.mustSucceed("update", h.update(templateGroupValues("Templates/Applications")))
+	if name := client.templateGroups["101"].Name; name != "Templates/Applications" {
+		t.Errorf("expected the template group to be renamed, got %s", name)
+	}
+
+	h.mustSucceed("delete", h.delete())
+	if len(client.templateGroups) != 0 {
+		t.Errorf("expected the template group to be deleted, got %v", client.templateGroups)
+	}
+
+	expected := []string{"CreateTemplateGroup", "GetTemplateGroup", "GetTemplateGroup", "UpdateTemplateGroup", "GetTemplateGroup", "DeleteTemplateGroup"}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+}
+
+func TestTemplateGroupResource_ReadRemoved(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateGroupResource(), client)
+	h.mustSucceed("create", h.create(templateGroupValues("Templates/Custom")))
+
+	delete(client.templateGroups, "101")
+	h.mustSucceed("read", h.read())
+	if !h.removed() {
+		t.Error("expected the template group deleted outside of Terraform to be removed from state")
+	}
+}
+
+func TestTemplateGroupResource_AlreadyExists(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.templateGroups["42"] = &zabbix.TemplateGroup{GroupID: "42", Name: "Templates/Custom", UUID: "uuid-42"}
+	h := newResourceHarness(t, NewTemplateGroupResource(), client)
+
+	h.mustFail("create", h.create(templateGroupValues("Templates/Custom")), "Error Creating Template Group")
+
+	values := templateGroupValues("Templates/Custom")
+	values["adopt_existing"] = tftypes.NewValue(tftypes.Bool, true)
+	h.mustSucceed("create", h.create(values))
+	var adopted TemplateGroupResourceModel
+	h.model(&adopted)
+	if adopted.ID.ValueString() != "42" {
+		t.Errorf("expected the existing template group to be adopted, got ID %s", adopted.ID)
+	}
+}
+
+func TestTemplateGroupResource_ReadAfterCreateFails(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.errs["GetTemplateGroup"] = errors.New("connection reset by peer")
+	h := newResourceHarness(t, NewTemplateGroupResource(), client)
+
+	h.mustFail("create", h.create(templateGroupValues("Templates/Custom")), "Error Reading Template Group")
+	var partial TemplateGroupResourceModel
+	h.model(&partial)
+	if partial.ID.ValueString() != "101" || !partial.Name.IsNull() {
+		t.Errorf("expected only the ID of the created template group in state, got %+v", partial)
+	}
+
+	// The next refresh reconciles the partial state with the created template group
+	delete(client.errs, "GetTemplateGroup")
+	h.mustSucceed("read", h.read())
+	var read TemplateGroupResourceModel
+	h.model(&read)
+	if read.Name.ValueString() != "Templates/Custom" || len(client.templateGroups) != 1 {
+		t.Errorf("expected the created template group to be read into state without a duplicate, got %+v", read)
+	}
+}
+
+func TestTemplateGroupResource_APIErrors(t *testing.T) {
+	tests := map[string]struct {
+		method  string
+		run     func(h *resourceHarness) diag.Diagnostics
+		summary string
+	}{
+		"create": {
+			method:  "CreateTemplateGroup",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.create(templateGroupValues("Templates/Other")) },
+			summary: "Error Creating Template Group",
+		},
+		"read": {
+			method:  "GetTemplateGroup",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.read() },
+			summary: "Error Reading Template Group",
+		},
+		"update": {
+			method:  "UpdateTemplateGroup",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.update(templateGroupValues("Templates/Other")) },
+			summary: "Error Updating Template Group",
+		},
+		"read after update": {
+			method:  "GetTemplateGroup",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.update(templateGroupValues("Templates/Other")) },
+			summary: "Error Reading Template Group",
+		},
+		"delete": {
+			method:  "DeleteTemplateGroup",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.delete() },
+			summary: "Error Deleting Template Group",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeZabbixAPI()
+			h := newResourceHarness(t, NewTemplateGroupResource(), client)
+			h.mustSucceed("create", h.create(templateGroupValues("Templates/Custom")))
+
+			client.errs[test.method] = errors.New("connection reset by peer")
+			h.mustFail(name, test.run(h), test.summary)
+		})
+	}
+}
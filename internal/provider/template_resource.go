@@ -5,29 +5,46 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/validators"
 	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 var (
-	_ resource.Resource                = &TemplateResource{}
-	_ resource.ResourceWithImportState = &TemplateResource{}
+	_ resource.Resource                   = &TemplateResource{}
+	_ resource.ResourceWithIdentity       = &TemplateResource{}
+	_ resource.ResourceWithImportState    = &TemplateResource{}
+	_ resource.ResourceWithModifyPlan     = &TemplateResource{}
+	_ resource.ResourceWithUpgradeState   = &TemplateResource{}
+	_ resource.ResourceWithValidateConfig = &TemplateResource{}
 )
 
 // TemplateResource defines the resource implementation.
 type TemplateResource struct {
-	client *zabbix.Client
+	client      ZabbixAPI
+	defaultTags map[string]string
+	httpClient  *http.Client
 }
 
 // TemplateResourceModel describes the resource data model.
@@ -37,17 +54,26 @@ type TemplateResourceModel struct {
 	Name            types.String `tfsdk:"name"`
 	Description     types.String `tfsdk:"description"`
 	UUID            types.String `tfsdk:"uuid"`
-	Groups          types.List   `tfsdk:"groups"`
-	Tags            types.List   `tfsdk:"tags"`
+	Groups          types.Set    `tfsdk:"groups"`
+	Tags            types.Set    `tfsdk:"tags"`
+	TagsAll         types.Set    `tfsdk:"tags_all"`
+	LinkedTemplates types.Set    `tfsdk:"linked_templates"`
 	SourceFormat    types.String `tfsdk:"source_format"`
 	SourceContent   types.String `tfsdk:"source_content"`
+	SourceURL       types.String `tfsdk:"source_url"`
+	SourceChecksum  types.String `tfsdk:"source_checksum"`
+	Prune           types.Bool   `tfsdk:"prune"`
+	ExportFormat    types.String `tfsdk:"export_format"`
 	ExportedContent types.String `tfsdk:"exported_content"`
-}
-
-// TemplateTagModel describes a template tag.
-type TemplateTagModel struct {
-	Tag   types.String `tfsdk:"tag"`
-	Value types.String `tfsdk:"value"`
+	ItemsCount      types.Int64  `tfsdk:"items_count"`
+	TriggersCount   types.Int64  `tfsdk:"triggers_count"`
+	DiscoveryCount  types.Int64  `tfsdk:"discovery_rules_count"`
+
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	ForceDelete        types.Bool   `tfsdk:"force_delete"`
+	UnlinkMode         types.String `tfsdk:"unlink_mode"`
+	Timeouts           types.Object `tfsdk:"timeouts"`
 }
 
 // NewTemplateResource creates a new resource instance.
@@ -57,11 +83,18 @@ func NewTemplateResource() resource.Resource {
 
 func (r *TemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_template"
+	// The identity includes the name, which changes when the object is renamed
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *TemplateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idNameIdentitySchema("template", "The technical name of the template (host in Zabbix).")
 }
 
 func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Zabbix template. Can create templates from YAML/JSON/XML content or manage template metadata directly.",
+		Version:     2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the template (templateid in Zabbix).",
@@ -92,19 +125,23 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"groups": schema.ListAttribute{
-				Description: "List of host group IDs the template belongs to. Required when not using source_content.",
+			"groups": schema.SetAttribute{
+				Description: "Set of template group IDs the template belongs to. Required when not using source_content.",
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
-				Validators: []validator.List{
-					listvalidator.SizeAtLeast(1),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					validators.UniqueIDs(),
 				},
 			},
-			"tags": schema.ListNestedAttribute{
+			"tags": schema.SetNestedAttribute{
 				Description: "Template tags.",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.Set{
+					validators.UniqueTags(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"tag": schema.StringAttribute{
@@ -119,25 +156,117 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"tags_all": schema.SetNestedAttribute{
+				Description: "All tags of the template, including the default_tags of the provider. Default tags are not added to templates imported from source_content or source_url.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tag": schema.StringAttribute{
+							Description: "Tag name.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Tag value.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"linked_templates": schema.SetAttribute{
+				Description: "Set of template IDs linked to the template, so that it inherits their items, triggers and other entities. Not used with source_content, where links are defined by the imported content.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					validators.UniqueIDs(),
+				},
+			},
 			"source_format": schema.StringAttribute{
 				Description: "Format of source_content: yaml, xml, or json. Required when source_content is provided.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("yaml", "xml", "json"),
+				},
 			},
 			"source_content": schema.StringAttribute{
-				Description: "Template content in YAML, XML, or JSON format. When provided, the template is imported using configuration.import.",
+				Description: "Template content in YAML, XML, or JSON format. Conflicts with source_url. When provided, the template is imported using configuration.import, and drift is detected with configuration.importcompare so that a re-import is only planned when the live template differs from this content. When the content defines several templates, the resource tracks the first one and deletes the others created by the import along with it; templates that existed before the import are left in place.",
 				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"source_url": schema.StringAttribute{
+				Description: "HTTPS URL to download the template content from, for example an official Zabbix template. Conflicts with source_content. The content is downloaded again on every refresh to detect drift, " +
+					"through the http_proxy and with the TLS settings of the provider, and may be at most 16 MiB.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https://`), "must be an HTTPS URL"),
+				},
+			},
+			"source_checksum": schema.StringAttribute{
+				Description: "Expected SHA-256 hex digest of the content downloaded from source_url. When set, content with a different digest is rejected.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-fA-F]{64}$`), "must be a SHA-256 hex digest"),
+				},
+			},
+			"prune": schema.BoolAttribute{
+				Description: "Whether items, triggers, discovery rules and value maps removed from the source content are deleted from the template when it is re-imported. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"export_format": schema.StringAttribute{
+				Description: "Format of exported_content: yaml, json, or xml. Defaults to yaml.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("yaml"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("yaml", "json", "xml"),
+				},
+			},
 			"exported_content": schema.StringAttribute{
-				Description: "Exported template content in YAML format. Used for drift detection.",
+				Description: "Exported template content in the format selected by export_format. YAML content is normalized to a canonical form without the export date and version and with sorted keys, so that it only changes when the template does.",
+				Computed:    true,
+			},
+			"items_count": schema.Int64Attribute{
+				Description: "Number of items on the template. Useful to verify that an import produced content.",
+				Computed:    true,
+			},
+			"triggers_count": schema.Int64Attribute{
+				Description: "Number of triggers on the template.",
+				Computed:    true,
+			},
+			"discovery_rules_count": schema.Int64Attribute{
+				Description: "Number of low-level discovery rules on the template.",
+				Computed:    true,
+			},
+			"deletion_protection": deletionProtectionAttribute("template"),
+			"adopt_existing":      adoptExistingAttribute("template"),
+			"force_delete": schema.BoolAttribute{
+				Description: "Whether the template is unlinked from all hosts before it is deleted. Without it, deleting a template that is still linked to hosts fails before anything is changed, naming the linked hosts. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"unlink_mode": schema.StringAttribute{
+				Description: "How the template is detached from hosts when force_delete is set: unlink (default) keeps inherited items and triggers on the hosts, unlink_and_clear removes them as well.",
+				Optional:    true,
 				Computed:    true,
+				Default:     stringdefault.StaticString("unlink"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("unlink", "unlink_and_clear"),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -146,68 +275,189 @@ func (r *TemplateResource) Configure(ctx context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*zabbix.Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.defaultTags = data.DefaultTags
+	r.httpClient = templateSourceHTTPClient(data.Transport)
 }
 
-func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *TemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data TemplateResourceModel
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var templateID string
-	var err error
+	if data.SourceContent.IsUnknown() || data.SourceURL.IsUnknown() || data.SourceFormat.IsUnknown() {
+		return
+	}
 
-	if !data.SourceContent.IsNull() && !data.SourceContent.IsUnknown() {
-		// Import from source content
-		format := data.SourceFormat.ValueString()
-		if format == "" {
-			resp.Diagnostics.AddError(
-				"Missing source_format",
-				"source_format is required when source_content is provided",
+	if !data.SourceContent.IsNull() && !data.SourceURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_url"),
+			"Conflicting Template Attributes",
+			"source_url cannot be set together with source_content.",
+		)
+		return
+	}
+
+	if !data.SourceChecksum.IsNull() && data.SourceURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_checksum"),
+			"Missing source_url",
+			"source_checksum only applies to templates imported from source_url.",
+		)
+	}
+
+	source := "source_content"
+	if !data.SourceURL.IsNull() {
+		source = "source_url"
+	}
+
+	if data.SourceContent.IsNull() && data.SourceURL.IsNull() {
+		if data.Prune.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("prune"),
+				"Missing source_content",
+				"prune only applies to templates imported from source_content or source_url.",
 			)
-			return
 		}
-
-		err = r.client.ImportConfiguration(ctx, format, data.SourceContent.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Importing Template",
-				fmt.Sprintf("Could not import template: %s", err),
+		if !data.SourceFormat.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_content"),
+				"Missing source_content",
+				"source_content or source_url is required when source_format is provided.",
+			)
+		}
+		if data.Host.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("host"),
+				"Missing host",
+				"host is required when neither source_content nor source_url is provided.",
+			)
+		}
+		if data.Groups.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("groups"),
+				"Missing groups",
+				"groups is required when neither source_content nor source_url is provided.",
 			)
-			return
 		}
+		return
+	}
 
-		// Extract host name from the content to find the imported template
-		host := r.extractHostFromContent(data.SourceContent.ValueString(), format)
-		if host == "" {
-			resp.Diagnostics.AddError(
-				"Error Finding Template",
-				"Could not determine template host name from source content. Please ensure the content contains a valid template definition.",
+	if data.SourceFormat.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_format"),
+			"Missing source_format",
+			fmt.Sprintf("source_format is required when %s is provided.", source),
+		)
+	}
+
+	// The imported content defines the template, so these attributes would be silently ignored
+	for _, attribute := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"host", data.Host},
+		{"groups", data.Groups},
+		{"linked_templates", data.LinkedTemplates},
+	} {
+		if !attribute.value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Conflicting Template Attributes",
+				fmt.Sprintf("%[1]s cannot be set together with %[2]s; the template definition in %[2]s is used instead.", attribute.name, source),
 			)
+		}
+	}
+}
+
+func (r *TemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan TemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported templates get their tags from the source, without default tags
+	if !plan.SourceContent.IsNull() || !plan.SourceURL.IsNull() {
+		return
+	}
+
+	// Tags that are not configured keep their current value instead of becoming unknown,
+	// so that the default tags can be merged into them
+	if plan.Tags.IsUnknown() {
+		var configTags types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &configTags)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		template, err := r.client.GetTemplateByHost(ctx, host)
-		if err != nil || template == nil {
-			resp.Diagnostics.AddError(
-				"Error Finding Imported Template",
-				fmt.Sprintf("Could not find template with host %q after import: %v", host, err),
-			)
+		if configTags.IsNull() {
+			plan.Tags = types.SetNull(tagObjectType)
+			if !req.State.Raw.IsNull() {
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tags"), &plan.Tags)...)
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags"), plan.Tags)...)
+		}
+	}
+
+	// Default tags are not part of the resource configuration, so tags_all is planned here
+	tagsAll, diags := plannedTagsAll(ctx, plan.Tags, r.defaultTags)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TemplateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var templateID string
+
+	content, imported, err := templateSource(ctx, r.httpClient, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Fetching Template Source",
+			fmt.Sprintf("Could not fetch template from source_url: %s", err),
+		)
+		return
+	}
+
+	if imported {
+		// Import from source content
+		var owned []ownedTemplate
+		templateID, owned, diags = r.importSource(ctx, data.SourceFormat.ValueString(), content, false, nil)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		templateID = template.TemplateID
+		resp.Diagnostics.Append(writeOwnedTemplates(ctx, resp.Private, owned)...)
 	} else {
 		// Create template directly
 		template, diags := r.modelToAPI(ctx, &data)
@@ -217,10 +467,27 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		}
 
 		templateID, err = r.client.CreateTemplate(ctx, template)
-		if err != nil {
+		if errors.Is(err, zabbix.ErrAlreadyExists) && data.AdoptExisting.ValueBool() {
+			templateID, diags = adoptExisting(ctx, "template", template.Host, r.client.TemplateIDByHost)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Bring the adopted template in line with the configuration
+			template.TemplateID = templateID
+			err = r.client.UpdateTemplate(ctx, template)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Updating Template",
+					fmt.Sprintf("Could not update adopted template ID %s: %s", templateID, err),
+				)
+				return
+			}
+		} else if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Creating Template",
-				fmt.Sprintf("Could not create template: %s", err),
+				fmt.Sprintf("Could not create template: %s%s", err, alreadyExistsHint(err, "template", true)),
 			)
 			return
 		}
@@ -233,6 +500,7 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 			"Error Reading Template",
 			fmt.Sprintf("Could not read template after creation: %s", err),
 		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, templateID)...)
 		return
 	}
 
@@ -241,11 +509,12 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 			"Error Reading Template",
 			fmt.Sprintf("Template %s was created but could not be found", templateID),
 		)
+		resp.Diagnostics.Append(savePartialState(ctx, &resp.State, templateID)...)
 		return
 	}
 
 	// Export the template content for drift detection
-	exported, err := r.client.ExportConfiguration(ctx, "yaml", []string{templateID})
+	exported, err := r.client.ExportConfiguration(ctx, data.ExportFormat.ValueString(), []string{templateID})
 	if err != nil {
 		resp.Diagnostics.AddWarning(
 			"Error Exporting Template",
@@ -253,13 +522,14 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		)
 	}
 
-	diags := r.apiToModel(ctx, apiTemplate, &data, exported)
+	diags = r.apiToModel(ctx, apiTemplate, &data, exported)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Host})...)
 }
 
 func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -270,8 +540,20 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	template, err := r.client.GetTemplate(ctx, data.ID.ValueString())
-	if err != nil {
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ExportFormat.IsNull() {
+		data.ExportFormat = types.StringValue("yaml")
+	}
+
+	// Read and export the template in a single round trip
+	result, err := r.client.GetTemplateWithExport(ctx, data.ID.ValueString(), data.ExportFormat.ValueString())
+	if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Reading Template",
 			fmt.Sprintf("Could not read template ID %s: %s", data.ID.ValueString(), err),
@@ -279,27 +561,62 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	if template == nil {
+	if result == nil {
+		resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Host})...)
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	// Export the template content
-	exported, err := r.client.ExportConfiguration(ctx, "yaml", []string{data.ID.ValueString()})
-	if err != nil {
+	if result.ExportErr != nil {
 		resp.Diagnostics.AddWarning(
 			"Error Exporting Template",
-			fmt.Sprintf("Could not export template content: %s", err),
+			fmt.Sprintf("Could not export template content: %s", result.ExportErr),
 		)
 	}
 
-	diags := r.apiToModel(ctx, template, &data, exported)
+	diags = r.apiToModel(ctx, result.Template, &data, result.Exported)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Ask Zabbix whether re-importing the source would change anything. Clearing
+	// the source attribute on drift makes the next plan re-import the configured source.
+	content, imported, err := templateSource(ctx, r.httpClient, &data)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error Fetching Template Source",
+			fmt.Sprintf("Could not fetch template from source_url: %s", err),
+		)
+	} else if imported {
+		changed, err := r.client.CompareConfiguration(ctx, data.SourceFormat.ValueString(), content, data.Prune.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error Comparing Template",
+				fmt.Sprintf("Could not compare template with source content: %s", err),
+			)
+		} else if changed {
+			data.SourceContent = types.StringNull()
+			data.SourceURL = types.StringNull()
+		}
+	}
+
+	// Forget owned templates that were deleted or replaced outside of Terraform
+	owned, diags := readOwnedTemplates(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if len(owned) > 0 {
+		remaining, diags := r.existingTemplates(ctx, owned)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(remaining) != len(owned) {
+			resp.Diagnostics.Append(writeOwnedTemplates(ctx, resp.Private, remaining)...)
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Host})...)
 }
 
 func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -310,31 +627,42 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state TemplateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !data.SourceContent.IsNull() && !data.SourceContent.IsUnknown() {
-		// Re-import from source content
-		format := data.SourceFormat.ValueString()
-		if format == "" {
-			resp.Diagnostics.AddError(
-				"Missing source_format",
-				"source_format is required when source_content is provided",
-			)
+	content, imported, err := templateSource(ctx, r.httpClient, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Fetching Template Source",
+			fmt.Sprintf("Could not fetch template from source_url: %s", err),
+		)
+		return
+	}
+
+	if imported {
+		// Re-import from source content; templates the source adds are owned from now on
+		owned, diags := readOwnedTemplates(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		err := r.client.ImportConfiguration(ctx, format, data.SourceContent.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Importing Template",
-				fmt.Sprintf("Could not import template: %s", err),
-			)
+		_, owned, diags = r.importSource(ctx, data.SourceFormat.ValueString(), content, data.Prune.ValueBool(), owned)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+		resp.Diagnostics.Append(writeOwnedTemplates(ctx, resp.Private, owned)...)
 	} else {
 		// Update template directly
 		template, diags := r.modelToAPI(ctx, &data)
@@ -345,6 +673,11 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 
 		template.TemplateID = state.ID.ValueString()
 
+		// An empty template list unlinks every template that is currently linked
+		if template.Templates == nil {
+			template.Templates = []zabbix.TemplateID{}
+		}
+
 		err := r.client.UpdateTemplate(ctx, template)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -374,7 +707,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Export the template content
-	exported, err := r.client.ExportConfiguration(ctx, "yaml", []string{state.ID.ValueString()})
+	exported, err := r.client.ExportConfiguration(ctx, data.ExportFormat.ValueString(), []string{state.ID.ValueString()})
 	if err != nil {
 		resp.Diagnostics.AddWarning(
 			"Error Exporting Template",
@@ -382,13 +715,14 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		)
 	}
 
-	diags := r.apiToModel(ctx, apiTemplate, &data, exported)
+	diags = r.apiToModel(ctx, apiTemplate, &data, exported)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, idNameIdentityModel{ID: data.ID, Name: data.Host})...)
 }
 
 func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -399,18 +733,88 @@ func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteTemplate(ctx, data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Template",
-			fmt.Sprintf("Could not delete template ID %s: %s", data.ID.ValueString(), err),
-		)
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "template", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Templates created along with the template by importing its source are deleted with it
+	owned, diags := readOwnedTemplates(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	owned, diags = r.existingTemplates(ctx, owned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	templateIDs := []string{data.ID.ValueString()}
+	for _, template := range owned {
+		templateIDs = append(templateIDs, template.ID)
+	}
+
+	// Linked hosts are checked up front, as template.delete fails midway through a destroy otherwise
+	linkedHosts := make(map[string][]zabbix.Host, len(templateIDs))
+	for _, templateID := range templateIDs {
+		hosts, err := r.client.GetHostsByTemplate(ctx, templateID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Linked Hosts",
+				fmt.Sprintf("Could not read hosts linked to template ID %s: %s", templateID, err),
+			)
+			return
+		}
+
+		if len(hosts) > 0 && !data.ForceDelete.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Template Linked to Hosts",
+				fmt.Sprintf("Template ID %s is still linked to %d host(s): %s. Unlink it from these hosts, or set force_delete = true to unlink it automatically before deletion.", templateID, len(hosts), strings.Join(sortedHostNames(hosts), ", ")),
+			)
+			return
+		}
+		linkedHosts[templateID] = hosts
+	}
+
+	for _, templateID := range templateIDs {
+		if hosts := linkedHosts[templateID]; len(hosts) > 0 {
+			resp.Diagnostics.Append(r.unlinkFromHosts(ctx, templateID, hosts, data.UnlinkMode.ValueString() == "unlink_and_clear")...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		// A template deleted outside of Terraform is already gone
+		err := r.client.DeleteTemplate(ctx, templateID)
+		if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Template",
+				fmt.Sprintf("Could not delete template ID %s: %s", templateID, err),
+			)
+			return
+		}
+	}
 }
 
+// ImportState imports a template by ID or, with name=<host>, by its technical name.
 func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "template", "id", r.client.TemplateIDByHost)
+}
+
+func (r *TemplateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored groups and tags as lists and version 1 groups. Their JSON
+		// encoding in state is identical to sets
+		0: rereadStateUpgrader(r, "template"),
+		1: rereadStateUpgrader(r, "template"),
+	}
 }
 
 // modelToAPI converts the Terraform model to Zabbix API struct.
@@ -435,172 +839,325 @@ func (r *TemplateResource) modelToAPI(ctx context.Context, data *TemplateResourc
 		}
 	}
 
-	// Convert tags
-	if !data.Tags.IsNull() && !data.Tags.IsUnknown() {
-		var tags []TemplateTagModel
-		diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	// Convert tags, adding the default tags of the provider
+	tags, d := tagValuesFromModel(ctx, data.Tags)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for _, tag := range mergeDefaultTags(r.defaultTags, tags) {
+		template.Tags = append(template.Tags, zabbix.TemplateTag{
+			Tag:   tag.Tag,
+			Value: tag.Value,
+		})
+	}
+
+	// Convert linked templates
+	if !data.LinkedTemplates.IsNull() && !data.LinkedTemplates.IsUnknown() {
+		var templateIDs []string
+		diags.Append(data.LinkedTemplates.ElementsAs(ctx, &templateIDs, false)...)
 		if diags.HasError() {
 			return nil, diags
 		}
-		for _, tag := range tags {
-			template.Tags = append(template.Tags, zabbix.TemplateTag{
-				Tag:   tag.Tag.ValueString(),
-				Value: tag.Value.ValueString(),
-			})
+		for _, id := range templateIDs {
+			template.Templates = append(template.Templates, zabbix.TemplateID{TemplateID: id})
 		}
 	}
 
 	return template, diags
 }
 
-// apiToModel converts the Zabbix API struct to Terraform model.
-func (r *TemplateResource) apiToModel(ctx context.Context, template *zabbix.Template, data *TemplateResourceModel, exportedContent string) diag.Diagnostics {
+// unlinkFromHosts detaches the template from the hosts it is linked to. host.massupdate
+// replaces the complete template list, so hosts are grouped by the templates they keep.
+func (r *TemplateResource) unlinkFromHosts(ctx context.Context, templateID string, hosts []zabbix.Host, clearEntities bool) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	data.ID = types.StringValue(template.TemplateID)
-	data.Host = types.StringValue(template.Host)
-	data.Name = types.StringValue(template.Name)
-	data.Description = types.StringValue(template.Description)
-	data.UUID = types.StringValue(template.UUID)
+	var clearIDs []string
+	if clearEntities {
+		clearIDs = []string{templateID}
+	}
 
-	// Convert groups
-	groupIDs := make([]attr.Value, len(template.Groups))
-	for i, g := range template.Groups {
-		groupIDs[i] = types.StringValue(g.GroupID)
+	hostsByRemaining := make(map[string][]string)
+	remainingByKey := make(map[string][]string)
+	for _, host := range hosts {
+		var remaining []string
+		for _, t := range host.ParentTemplates {
+			if t.TemplateID != templateID {
+				remaining = append(remaining, t.TemplateID)
+			}
+		}
+		sort.Strings(remaining)
+
+		key := strings.Join(remaining, ",")
+		hostsByRemaining[key] = append(hostsByRemaining[key], host.HostID)
+		remainingByKey[key] = remaining
 	}
-	groupsList, d := types.ListValue(types.StringType, groupIDs)
-	diags.Append(d...)
-	data.Groups = groupsList
 
-	// Convert tags
-	tagType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"tag":   types.StringType,
-			"value": types.StringType,
-		},
+	for key, hostIDs := range hostsByRemaining {
+		if err := r.client.MassUpdateHostTemplates(ctx, hostIDs, remainingByKey[key], clearIDs); err != nil {
+			diags.AddError(
+				"Error Unlinking Template",
+				fmt.Sprintf("Could not unlink template ID %s from hosts %v: %s", templateID, hostIDs, err),
+			)
+			return diags
+		}
 	}
-	if len(template.Tags) > 0 {
-		tagValues := make([]attr.Value, len(template.Tags))
-		for i, tag := range template.Tags {
-			obj, d := types.ObjectValue(tagType.AttrTypes, map[string]attr.Value{
-				"tag":   types.StringValue(tag.Tag),
-				"value": types.StringValue(tag.Value),
-			})
-			diags.Append(d...)
-			tagValues[i] = obj
-		}
-		tagsList, d := types.ListValue(tagType, tagValues)
-		diags.Append(d...)
-		data.Tags = tagsList
-	} else {
-		data.Tags = types.ListNull(tagType)
+
+	diags.AddWarning(
+		"Template Unlinked From Hosts",
+		fmt.Sprintf("Template ID %s was unlinked from %d host(s) before deletion because force_delete is set: %s", templateID, len(hosts), strings.Join(sortedHostNames(hosts), ", ")),
+	)
+
+	return diags
+}
+
+// sortedHostNames returns the technical names of the hosts in alphabetical order.
+func sortedHostNames(hosts []zabbix.Host) []string {
+	names := make([]string, len(hosts))
+	for i, host := range hosts {
+		names[i] = host.Host
 	}
+	sort.Strings(names)
+	return names
+}
 
-	// Set exported content
-	if exportedContent != "" {
-		data.ExportedContent = types.StringValue(exportedContent)
-	} else {
-		data.ExportedContent = types.StringNull()
+// ownedTemplatesKey is the private state key holding the ownedTemplates of a resource.
+const ownedTemplatesKey = "owned_templates"
+
+// ownedTemplate is a template that was created by importing the source of the resource
+// besides the template the resource tracks. The UUID tells it apart from a template that
+// replaced it outside of Terraform.
+type ownedTemplate struct {
+	ID   string `json:"templateid"`
+	UUID string `json:"uuid"`
+}
+
+// readOwnedTemplates returns the owned templates stored in private state.
+func readOwnedTemplates(ctx context.Context, private privateState) ([]ownedTemplate, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, ownedTemplatesKey)
+	if diags.HasError() || len(raw) == 0 {
+		return nil, diags
 	}
 
-	return diags
+	var owned []ownedTemplate
+	if err := json.Unmarshal(raw, &owned); err != nil {
+		diags.AddError(
+			"Error Reading Private State",
+			fmt.Sprintf("Could not decode stored owned templates: %s", err),
+		)
+	}
+
+	return owned, diags
 }
 
-// extractHostFromContent extracts the template host name from YAML/JSON/XML content.
-func (r *TemplateResource) extractHostFromContent(content, format string) string {
-	// Simple extraction for YAML - look for "template:" or "host:" patterns
-	// This is a basic implementation that works for standard Zabbix template exports
-	switch format {
-	case "yaml":
-		return r.extractHostFromYAML(content)
-	case "json":
-		return r.extractHostFromJSON(content)
-	case "xml":
-		return r.extractHostFromXML(content)
-	}
-	return ""
+// writeOwnedTemplates stores the owned templates in private state.
+func writeOwnedTemplates(ctx context.Context, private privateState, owned []ownedTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	raw, err := json.Marshal(owned)
+	if err != nil {
+		diags.AddError(
+			"Error Writing Private State",
+			fmt.Sprintf("Could not encode owned templates: %s", err),
+		)
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, ownedTemplatesKey, raw)...)
+	return diags
 }
 
-func (r *TemplateResource) extractHostFromYAML(content string) string {
-	// Look for patterns like:
-	// templates:
-	//   - template: "Template Name"
-	// or
-	//   - uuid: ...
-	//     template: "Template Name"
-	lines := splitLines(content)
-	inTemplates := false
-	for _, line := range lines {
-		trimmed := trimSpace(line)
-		if trimmed == "templates:" || trimmed == "zabbix_export:" {
-			inTemplates = true
+// importSource imports the source content and returns the ID of the first template it
+// defines, which the resource tracks, along with the owned templates extended by the
+// other templates the import created. Templates that existed before the import are
+// updated by it, but left to whoever created them.
+func (r *TemplateResource) importSource(ctx context.Context, format, content string, prune bool, owned []ownedTemplate) (string, []ownedTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	hosts, err := extractTemplateNames(content, format)
+	if err != nil {
+		diags.AddError(
+			"Error Finding Template",
+			fmt.Sprintf("Could not determine template host name from source content: %s. Please ensure the content contains a valid template definition.", err),
+		)
+		return "", owned, diags
+	}
+
+	existed := make(map[string]bool, len(hosts))
+	for _, host := range hosts[1:] {
+		id, err := r.client.TemplateIDByHost(ctx, host)
+		if err != nil {
+			diags.AddError(
+				"Error Reading Template",
+				fmt.Sprintf("Could not look up template %q before import: %s", host, err),
+			)
+			return "", owned, diags
+		}
+		existed[host] = id != ""
+	}
+
+	if err := r.client.ImportConfiguration(ctx, format, content, prune); err != nil {
+		diags.AddError(
+			"Error Importing Template",
+			fmt.Sprintf("Could not import template: %s", err),
+		)
+		return "", owned, diags
+	}
+
+	var templateID string
+	var unowned []string
+	for i, host := range hosts {
+		id, err := r.client.TemplateIDByHost(ctx, host)
+		if err != nil || id == "" {
+			diags.AddError(
+				"Error Finding Imported Template",
+				fmt.Sprintf("Could not find template with host %q after import: %v", host, err),
+			)
+			return "", owned, diags
+		}
+		if i == 0 {
+			templateID = id
 			continue
 		}
-		if inTemplates && hasPrefix(trimmed, "template:") {
-			value := trimSpace(trimPrefix(trimmed, "template:"))
-			// Remove quotes if present
-			value = trimQuotes(value)
-			return value
+
+		if existed[host] {
+			if !slices.ContainsFunc(owned, func(t ownedTemplate) bool { return t.ID == id }) {
+				unowned = append(unowned, host)
+			}
+			continue
 		}
+
+		template, err := r.client.GetTemplate(ctx, id)
+		if err != nil || template == nil {
+			diags.AddError(
+				"Error Reading Template",
+				fmt.Sprintf("Could not read template %q created by the import: %v", host, err),
+			)
+			return "", owned, diags
+		}
+		owned = append(owned, ownedTemplate{ID: id, UUID: template.UUID})
 	}
-	return ""
-}
 
-func (r *TemplateResource) extractHostFromJSON(content string) string {
-	// Basic JSON extraction - look for "template" or "host" field
-	// For a proper implementation, we'd use encoding/json
-	return ""
-}
+	if len(unowned) > 0 {
+		diags.AddWarning(
+			"Existing Templates Not Owned",
+			fmt.Sprintf("The source also defines %s, which existed before it was imported. They were updated by the import, but are not deleted with this resource.", strings.Join(unowned, ", ")),
+		)
+	}
 
-func (r *TemplateResource) extractHostFromXML(content string) string {
-	// Basic XML extraction
-	return ""
+	return templateID, owned, diags
 }
 
-// Helper functions to avoid importing strings package for simple operations
-func splitLines(s string) []string {
-	var lines []string
-	start := 0
-	for i := 0; i < len(s); i++ {
-		if s[i] == '\n' {
-			lines = append(lines, s[start:i])
-			start = i + 1
+// existingTemplates returns the owned templates that still exist with their recorded UUID.
+func (r *TemplateResource) existingTemplates(ctx context.Context, owned []ownedTemplate) ([]ownedTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var remaining []ownedTemplate
+	for _, t := range owned {
+		template, err := r.client.GetTemplate(ctx, t.ID)
+		if err != nil && !errors.Is(err, zabbix.ErrNotFound) {
+			diags.AddError(
+				"Error Reading Template",
+				fmt.Sprintf("Could not read template ID %s imported with the source: %s", t.ID, err),
+			)
+			return owned, diags
+		}
+		if template != nil && template.UUID == t.UUID {
+			remaining = append(remaining, t)
 		}
 	}
-	if start < len(s) {
-		lines = append(lines, s[start:])
-	}
-	return lines
+
+	return remaining, diags
 }
 
-func trimSpace(s string) string {
-	start := 0
-	end := len(s)
-	for start < end && (s[start] == ' ' || s[start] == '\t' || s[start] == '\r') {
-		start++
+// apiToModel converts the Zabbix API struct to Terraform model.
+func (r *TemplateResource) apiToModel(ctx context.Context, template *zabbix.Template, data *TemplateResourceModel, exportedContent string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(template.TemplateID)
+	data.Host = types.StringValue(template.Host)
+	data.Name = types.StringValue(template.Name)
+	data.Description = types.StringValue(template.Description)
+	data.UUID = types.StringValue(template.UUID)
+	data.ItemsCount = types.Int64Value(int64(template.ItemsCount))
+	data.TriggersCount = types.Int64Value(int64(template.TriggersCount))
+	data.DiscoveryCount = types.Int64Value(int64(template.DiscoveryCount))
+
+	if data.DeletionProtection.IsNull() || data.DeletionProtection.IsUnknown() {
+		data.DeletionProtection = types.BoolValue(false)
 	}
-	for end > start && (s[end-1] == ' ' || s[end-1] == '\t' || s[end-1] == '\r') {
-		end--
+
+	if data.Prune.IsNull() || data.Prune.IsUnknown() {
+		data.Prune = types.BoolValue(false)
 	}
-	return s[start:end]
-}
 
-func hasPrefix(s, prefix string) bool {
-	return len(s) >= len(prefix) && s[:len(prefix)] == prefix
-}
+	if data.ForceDelete.IsNull() || data.ForceDelete.IsUnknown() {
+		data.ForceDelete = types.BoolValue(false)
+	}
 
-func trimPrefix(s, prefix string) string {
-	if hasPrefix(s, prefix) {
-		return s[len(prefix):]
+	if data.AdoptExisting.IsNull() || data.AdoptExisting.IsUnknown() {
+		data.AdoptExisting = types.BoolValue(false)
 	}
-	return s
-}
 
-func trimQuotes(s string) string {
-	if len(s) >= 2 {
-		if (s[0] == '"' && s[len(s)-1] == '"') || (s[0] == '\'' && s[len(s)-1] == '\'') {
-			return s[1 : len(s)-1]
+	if data.UnlinkMode.IsNull() || data.UnlinkMode.IsUnknown() {
+		data.UnlinkMode = types.StringValue("unlink")
+	}
+
+	// Convert groups
+	groupIDs := make([]attr.Value, len(template.Groups))
+	for i, g := range template.Groups {
+		groupIDs[i] = types.StringValue(g.GroupID)
+	}
+	groupsSet, d := types.SetValue(types.StringType, groupIDs)
+	diags.Append(d...)
+	data.Groups = groupsSet
+
+	// Convert tags; tags added from the default tags are only shown in tags_all
+	allTags := make([]tagValue, len(template.Tags))
+	for i, tag := range template.Tags {
+		allTags[i] = tagValue{Tag: tag.Tag, Value: tag.Value}
+	}
+	tagsAll, d := tagsAllValue(allTags)
+	diags.Append(d...)
+	data.TagsAll = tagsAll
+
+	tags := allTags
+	if data.SourceContent.IsNull() && data.SourceURL.IsNull() {
+		configured, d := tagValuesFromModel(ctx, data.Tags)
+		diags.Append(d...)
+		tags = withoutDefaultTags(r.defaultTags, allTags, configured)
+	}
+	if len(tags) > 0 {
+		tagValues, d := tagObjects(tags)
+		diags.Append(d...)
+		tagsSet, d := types.SetValue(tagObjectType, tagValues)
+		diags.Append(d...)
+		data.Tags = tagsSet
+	} else {
+		data.Tags = types.SetNull(tagObjectType)
+	}
+
+	// Convert linked templates; links created by imported content are left to that content
+	if len(template.ParentTemplates) > 0 && ((data.SourceContent.IsNull() && data.SourceURL.IsNull()) || !data.LinkedTemplates.IsNull()) {
+		linkedIDs := make([]attr.Value, len(template.ParentTemplates))
+		for i, t := range template.ParentTemplates {
+			linkedIDs[i] = types.StringValue(t.TemplateID)
 		}
+		linkedSet, d := types.SetValue(types.StringType, linkedIDs)
+		diags.Append(d...)
+		data.LinkedTemplates = linkedSet
+	} else {
+		data.LinkedTemplates = types.SetNull(types.StringType)
 	}
-	return s
+
+	// Set exported content
+	if exportedContent != "" {
+		exportedContent, d := normalizedExport(exportedContent, data.ExportFormat.ValueString())
+		diags.Append(d...)
+		data.ExportedContent = types.StringValue(exportedContent)
+	} else {
+		data.ExportedContent = types.StringNull()
+	}
+
+	return diags
 }
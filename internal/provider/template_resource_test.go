@@ -1,16 +1,29 @@
-// ABOUTME: Acceptance tests for the zabbix_template resource.
-// ABOUTME: Tests full CRUD lifecycle including import of official Zabbix templates.
+// ABOUTME: Acceptance and unit tests for the zabbix_template resource.
+// ABOUTME: Tests full CRUD lifecycle including import of official Zabbix templates, and CRUD logic against a fake client.
 
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
 )
 
 const apacheTemplateURL = "https://raw.githubusercontent.com/zabbix/zabbix/refs/tags/7.0.22/templates/app/apache_http/template_app_apache_http.yaml"
@@ -29,6 +42,10 @@ func TestAccTemplateResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("zabbix_template.test", "name", rName+"-display"),
 					resource.TestCheckResourceAttrSet("zabbix_template.test", "id"),
 					resource.TestCheckResourceAttrSet("zabbix_template.test", "uuid"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "export_format", "yaml"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "items_count", "0"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "triggers_count", "0"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "discovery_rules_count", "0"),
 				),
 			},
 			{
@@ -41,6 +58,31 @@ func TestAccTemplateResource_basic(t *testing.T) {
 	})
 }
 
+func TestAccTemplateResource_exportFormat(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateResourceConfigExportFormat(rName, "json"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template.test", "export_format", "json"),
+					resource.TestMatchResourceAttr("zabbix_template.test", "exported_content", regexp.MustCompile(`^\{"zabbix_export"`)),
+				),
+			},
+			{
+				Config: testAccTemplateResourceConfigExportFormat(rName, "xml"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template.test", "export_format", "xml"),
+					resource.TestMatchResourceAttr("zabbix_template.test", "exported_content", regexp.MustCompile(`^<\?xml`)),
+				),
+			},
+		},
+	})
+}
+
 func TestAccTemplateResource_withOfficialTemplate(t *testing.T) {
 	// Fetch the template content at test time
 	templateContent := fetchTemplateContent(t, apacheTemplateURL)
@@ -56,11 +98,58 @@ func TestAccTemplateResource_withOfficialTemplate(t *testing.T) {
 					resource.TestCheckResourceAttrSet("zabbix_template.test", "id"),
 					resource.TestCheckResourceAttrSet("zabbix_template.test", "uuid"),
 					resource.TestCheckResourceAttrSet("zabbix_template.test", "exported_content"),
+					resource.TestMatchResourceAttr("zabbix_template.test", "items_count", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestMatchResourceAttr("zabbix_template.test", "triggers_count", regexp.MustCompile(`^[1-9][0-9]*$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTemplateResource_sourceURL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTemplateResourceConfigWithURL(apacheTemplateURL, strings.Repeat("0", 64)),
+				ExpectError: regexp.MustCompile("checksum mismatch"),
+			},
+			{
+				Config: testAccTemplateResourceConfigWithURL(apacheTemplateURL, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template.test", "host", "Apache by HTTP"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "source_url", apacheTemplateURL),
+					resource.TestCheckResourceAttrSet("zabbix_template.test", "id"),
 				),
-				// The exported_content computed field causes Terraform to show a plan
-				// even when nothing has changed. This is expected behavior.
+			},
+		},
+	})
+}
+
+func TestAccTemplateResource_forceDelete(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateResourceConfigForceDelete(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template.test", "force_delete", "true"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "unlink_mode", "unlink_and_clear"),
+					testAccLinkTemplateToHost("zabbix_host.test", "zabbix_template.test"),
+				),
+				// The template is linked outside of Terraform, so the host shows drift
 				ExpectNonEmptyPlan: true,
 			},
+			{
+				Config: testAccTemplateResourceConfigForceDelete(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zabbix_host.test", "templates.#"),
+				),
+			},
 		},
 	})
 }
@@ -115,6 +204,224 @@ func TestAccTemplateResource_withTags(t *testing.T) {
 	})
 }
 
+func TestTemplateResource_UpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r := &TemplateResource{}
+
+	rawState := `{
+		"id": "10001",
+		"host": "my_custom_template",
+		"name": "My Custom Template",
+		"groups": ["12", "13"],
+		"tags": [{"tag": "team", "value": "platform"}, {"tag": "environment", "value": "test"}],
+		"tags_all": [{"tag": "team", "value": "platform"}, {"tag": "environment", "value": "test"}]
+	}`
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	for version, upgrader := range r.UpgradeState(ctx) {
+		req := fwresource.UpgradeStateRequest{
+			RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+		}
+		resp := &fwresource.UpgradeStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema},
+		}
+
+		upgrader.StateUpgrader(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("version %d: unexpected error: %s", version, resp.Diagnostics.Errors())
+		}
+		if resp.DynamicValue == nil {
+			t.Fatalf("version %d: expected upgraded state, got nil", version)
+		}
+
+		value, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			t.Fatalf("version %d: failed to unmarshal upgraded state: %s", version, err)
+		}
+
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			t.Fatalf("version %d: failed to read upgraded state: %s", version, err)
+		}
+		for _, name := range []string{"groups", "tags"} {
+			if !attrs[name].Type().Is(tftypes.Set{}) {
+				t.Errorf("version %d: expected %s to be a set, got %s", version, name, attrs[name].Type())
+			}
+			var elements []tftypes.Value
+			if err := attrs[name].As(&elements); err != nil || len(elements) != 2 {
+				t.Errorf("version %d: expected 2 %s, got %v (%v)", version, name, elements, err)
+			}
+		}
+	}
+}
+
+func TestAccTemplateResource_defaultTags(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateResourceConfigDefaultTags(rName, "platform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zabbix_template.test", "tags.#"),
+					resource.TestCheckResourceAttr("zabbix_template.test", "tags_all.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_template.test", "tags_all.*", map[string]string{
+						"tag":   "owner",
+						"value": "platform",
+					}),
+				),
+			},
+			{
+				Config: testAccTemplateResourceConfigDefaultTags(rName, "sre"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zabbix_template.test", "tags.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_template.test", "tags_all.*", map[string]string{
+						"tag":   "owner",
+						"value": "sre",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTemplateResource_linkedTemplates(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemplateResourceConfigLinked(rName, "[zabbix_template.base.id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template.test", "linked_templates.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("zabbix_template.test", "linked_templates.*", "zabbix_template.base", "id"),
+				),
+			},
+			{
+				ResourceName:      "zabbix_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccTemplateResourceConfigLinked(rName, "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zabbix_template.test", "linked_templates.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTemplateResource_invalidSourceConfig(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "zabbix_template" "test" {
+  source_content = "zabbix_export: {}"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing source_format"),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "zabbix_template" "test" {
+  host          = %q
+  groups        = ["1"]
+  source_format = "yaml"
+}
+`, rName),
+				ExpectError: regexp.MustCompile("Missing source_content"),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "zabbix_template" "test" {
+  host           = %q
+  source_format  = "yaml"
+  source_content = "zabbix_export: {}"
+}
+`, rName),
+				ExpectError: regexp.MustCompile("Conflicting Template Attributes"),
+			},
+			{
+				Config: `
+resource "zabbix_template" "test" {
+  description = "no host"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing host"),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "zabbix_template" "test" {
+  host   = %q
+  groups = ["1"]
+  prune  = true
+}
+`, rName),
+				ExpectError: regexp.MustCompile("prune only applies to templates imported"),
+			},
+			{
+				Config: `
+resource "zabbix_template" "test" {
+  source_format  = "yaml"
+  source_content = "zabbix_export: {}"
+  source_url     = "https://example.com/template.yaml"
+}
+`,
+				ExpectError: regexp.MustCompile("Conflicting Template Attributes"),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "zabbix_template" "test" {
+  host            = %q
+  groups          = ["1"]
+  source_checksum = "%s"
+}
+`, rName, strings.Repeat("0", 64)),
+				ExpectError: regexp.MustCompile("Missing source_url"),
+			},
+			{
+				Config: `
+resource "zabbix_template" "test" {
+  source_format = "yaml"
+  source_url    = "http://example.com/template.yaml"
+}
+`,
+				ExpectError: regexp.MustCompile("must be an HTTPS URL"),
+			},
+		},
+	})
+}
+
+// testAccLinkTemplateToHost links the template to the host outside of Terraform, as other tooling would.
+func testAccLinkTemplateToHost(hostResource, templateResource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		host, ok := s.RootModule().Resources[hostResource]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", hostResource)
+		}
+		template, ok := s.RootModule().Resources[templateResource]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", templateResource)
+		}
+
+		client := zabbix.NewClient(os.Getenv("ZABBIX_URL"), zabbix.WithToken(os.Getenv("ZABBIX_API_TOKEN")))
+		return client.MassUpdateHostTemplates(context.Background(), []string{host.Primary.ID}, []string{template.Primary.ID}, nil)
+	}
+}
+
 // fetchTemplateContent fetches template YAML from a URL at test time.
 func fetchTemplateContent(t *testing.T, url string) string {
 	t.Helper()
@@ -191,6 +498,97 @@ resource "zabbix_template" "test" {
 `, name)
 }
 
+func testAccTemplateResourceConfigDefaultTags(name, owner string) string {
+	return fmt.Sprintf(`
+provider "zabbix" {
+  default_tags = {
+    owner = %[2]q
+  }
+}
+
+resource "zabbix_template_group" "test" {
+  name = "%[1]s-group"
+}
+
+resource "zabbix_template" "test" {
+  host   = %[1]q
+  groups = [zabbix_template_group.test.id]
+}
+`, name, owner)
+}
+
+func testAccTemplateResourceConfigExportFormat(name, format string) string {
+	return fmt.Sprintf(`
+resource "zabbix_template_group" "test" {
+  name = "%[1]s-group"
+}
+
+resource "zabbix_template" "test" {
+  host          = %[1]q
+  groups        = [zabbix_template_group.test.id]
+  export_format = %[2]q
+}
+`, name, format)
+}
+
+func testAccTemplateResourceConfigWithURL(url, checksum string) string {
+	if checksum == "" {
+		return fmt.Sprintf(`
+resource "zabbix_template" "test" {
+  source_format = "yaml"
+  source_url    = %q
+}
+`, url)
+	}
+
+	return fmt.Sprintf(`
+resource "zabbix_template" "test" {
+  source_format   = "yaml"
+  source_url      = %q
+  source_checksum = %q
+}
+`, url, checksum)
+}
+
+func testAccTemplateResourceConfigForceDelete(name string, withTemplate bool) string {
+	config := fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host   = %[1]q
+  groups = [zabbix_host_group.test.id]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+`, name)
+
+	if !withTemplate {
+		return config
+	}
+
+	return config + fmt.Sprintf(`
+resource "zabbix_template_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_template" "test" {
+  host         = "%[1]s-template"
+  groups       = [zabbix_template_group.test.id]
+  force_delete = true
+  unlink_mode  = "unlink_and_clear"
+}
+`, name)
+}
+
 func testAccTemplateResourceConfigWithContent(content string) string {
 	return fmt.Sprintf(`
 resource "zabbix_template" "test" {
@@ -199,3 +597,347 @@ resource "zabbix_template" "test" {
 }
 `, content)
 }
+
+func testAccTemplateResourceConfigLinked(name, linked string) string {
+	return fmt.Sprintf(`
+resource "zabbix_template_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_template" "base" {
+  host   = "%[1]s-base"
+  groups = [zabbix_template_group.test.id]
+}
+
+resource "zabbix_template" "test" {
+  host             = %[1]q
+  groups           = [zabbix_template_group.test.id]
+  linked_templates = %[2]s
+}
+`, name, linked)
+}
+
+// templateValues returns the planned values of a template created directly, without a source.
+func templateValues(host, name string, groupIDs ...string) map[string]tftypes.Value {
+	groups := make([]tftypes.Value, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = tftypes.NewValue(tftypes.String, id)
+	}
+
+	unknown := func(typ tftypes.Type) tftypes.Value { return tftypes.NewValue(typ, tftypes.UnknownValue) }
+	tagType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tag": tftypes.String, "value": tftypes.String}}
+
+	return map[string]tftypes.Value{
+		"id":                    unknown(tftypes.String),
+		"host":                  tftypes.NewValue(tftypes.String, host),
+		"name":                  tftypes.NewValue(tftypes.String, name),
+		"description":           unknown(tftypes.String),
+		"uuid":                  unknown(tftypes.String),
+		"groups":                tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, groups),
+		"tags":                  unknown(tftypes.Set{ElementType: tagType}),
+		"tags_all":              unknown(tftypes.Set{ElementType: tagType}),
+		"prune":                 tftypes.NewValue(tftypes.Bool, false),
+		"export_format":         tftypes.NewValue(tftypes.String, "yaml"),
+		"exported_content":      unknown(tftypes.String),
+		"items_count":           unknown(tftypes.Number),
+		"triggers_count":        unknown(tftypes.Number),
+		"discovery_rules_count": unknown(tftypes.Number),
+		"deletion_protection":   tftypes.NewValue(tftypes.Bool, false),
+		"adopt_existing":        tftypes.NewValue(tftypes.Bool, false),
+		"force_delete":          tftypes.NewValue(tftypes.Bool, false),
+		"unlink_mode":           tftypes.NewValue(tftypes.String, "unlink"),
+	}
+}
+
+// templateSourceValues returns the planned values of a template imported from YAML content.
+func templateSourceValues(content string) map[string]tftypes.Value {
+	values := templateValues("", "")
+	for _, name := range []string{"host", "name", "groups"} {
+		values[name] = tftypes.NewValue(values[name].Type(), tftypes.UnknownValue)
+	}
+	values["source_format"] = tftypes.NewValue(tftypes.String, "yaml")
+	values["source_content"] = tftypes.NewValue(tftypes.String, content)
+	return values
+}
+
+// sourceTemplates is YAML content defining the app template, a template it links and a
+// template shared with other sources.
+const sourceTemplates = `zabbix_export:
+  version: '7.0'
+  templates:
+    - template: App
+      name: App
+      templates:
+        - name: App extras
+    - template: App extras
+      name: App extras
+    - template: Shared base
+      name: Shared base
+`
+
+func TestTemplateResource_SourceOwnership(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.templates["50"] = &zabbix.Template{TemplateID: "50", Host: "Shared base", Name: "Shared base", UUID: "uuid-50"}
+	h := newResourceHarness(t, NewTemplateResource(), client)
+
+	diags := h.create(templateSourceValues(sourceTemplates))
+	h.mustSucceed("create", diags)
+	if len(diags.Warnings()) != 1 || diags.Warnings()[0].Summary() != "Existing Templates Not Owned" ||
+		!strings.Contains(diags.Warnings()[0].Detail(), "Shared base") {
+		t.Errorf("expected a warning that the existing template is not owned, got %v", diags)
+	}
+
+	var data TemplateResourceModel
+	h.model(&data)
+	if data.Host.ValueString() != "App" {
+		t.Errorf("expected the resource to track the first template of the source, got %s", data.Host.ValueString())
+	}
+
+	h.mustSucceed("read", h.read())
+	h.mustSucceed("delete", h.delete())
+
+	if expected := []string{"50"}; !reflect.DeepEqual(sortedIDs(client.templates), expected) {
+		t.Errorf("expected only the template that existed before the import to be kept, got %v", sortedIDs(client.templates))
+	}
+}
+
+func TestTemplateResource_SourceOwnershipReplaced(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), client)
+	h.mustSucceed("create", h.create(templateSourceValues(sourceTemplates)))
+
+	// Another import replaced App extras, so it is no longer owned by the resource
+	extras := sortedIDs(client.templates)[1]
+	client.templates[extras].UUID = "uuid-other"
+	h.mustSucceed("read", h.read())
+	client.templates[extras].UUID = "uuid-" + extras
+
+	h.mustSucceed("delete", h.delete())
+	if _, ok := client.templates[extras]; !ok {
+		t.Error("expected the replaced template to be kept")
+	}
+	if len(client.templates) != 1 {
+		t.Errorf("expected the other templates of the source to be deleted, got %v", sortedIDs(client.templates))
+	}
+}
+
+func TestTemplateResource_SourceOwnershipUpdate(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), client)
+	h.mustSucceed("create", h.create(templateSourceValues(sourceTemplates)))
+
+	// Shared base is recreated outside of Terraform, while a template added to the source
+	// is created by the next import and owned from then on
+	delete(client.templates, "103")
+	client.templates["50"] = &zabbix.Template{TemplateID: "50", Host: "Shared base", Name: "Shared base", UUID: "uuid-50"}
+	h.mustSucceed("update", h.update(templateSourceValues(sourceTemplates+"    - template: App reports\n      name: App reports\n")))
+
+	h.mustSucceed("delete", h.delete())
+	if len(client.templates) != 1 || client.templates["50"] == nil {
+		t.Errorf("expected every template but the unowned one to be deleted, got %v", sortedIDs(client.templates))
+	}
+}
+
+func TestTemplateResource_CRUD(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), client)
+
+	h.mustSucceed("create", h.create(templateValues("custom_linux", "Custom Linux", "12")))
+	var created TemplateResourceModel
+	h.model(&created)
+	if created.ID.ValueString() != "101" || created.UUID.ValueString() != "uuid-101" {
+		t.Errorf("expected ID 101 and UUID uuid-101, got %s and %s", created.ID, created.UUID)
+	}
+	if !strings.Contains(created.ExportedContent.ValueString(), "template: custom_linux") {
+		t.Errorf("expected the exported content of the template, got %q", created.ExportedContent.ValueString())
+	}
+
+	h.mustSucceed("read", h.read())
+
+	h.mustSucceed("update", h.update(templateValues("custom_linux", "Custom Linux servers", "12", "13")))
+	updated := client.templates["101"]
+	if updated.Name != "Custom Linux servers" || len(updated.Groups) != 2 {
+		t.Errorf("expected the template to be renamed and moved to 2 groups, got %+v", updated)
+	}
+
+	h.mustSucceed("delete", h.delete())
+	if len(client.templates) != 0 {
+		t.Errorf("expected the template to be deleted, got %v", client.templates)
+	}
+
+	expected := []string{
+		"CreateTemplate", "GetTemplate", "ExportConfiguration",
+		"GetTemplateWithExport",
+		"UpdateTemplate", "GetTemplate", "ExportConfiguration",
+		"GetHostsByTemplate", "DeleteTemplate",
+	}
+	if !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, client.calls)
+	}
+}
+
+func TestTemplateResource_ReadRemoved(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), client)
+	h.mustSucceed("create", h.create(templateValues("custom_linux", "Custom Linux", "12")))
+
+	delete(client.templates, "101")
+	h.mustSucceed("read", h.read())
+	if !h.removed() {
+		t.Error("expected the template deleted outside of Terraform to be removed from state")
+	}
+}
+
+func TestTemplateResource_ExportFailureWarns(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.errs["ExportConfiguration"] = errors.New("permission denied")
+	h := newResourceHarness(t, NewTemplateResource(), client)
+
+	// the operations run in order, as read needs the state of create
+	for _, op := range []struct {
+		name string
+		run  func() diag.Diagnostics
+	}{
+		{"create", func() diag.Diagnostics { return h.create(templateValues("custom_linux", "Custom Linux", "12")) }},
+		{"read", h.read},
+	} {
+		d := op.run()
+		h.mustSucceed(op.name, d)
+		if len(d.Warnings()) != 1 || d.Warnings()[0].Summary() != "Error Exporting Template" {
+			t.Errorf("%s: expected a warning about the failed export, got %v", op.name, d)
+		}
+	}
+}
+
+// lostTemplateAPI is a fake whose templates cannot be read back after they are written,
+// as when they are deleted concurrently or hidden by the permissions of the API user.
+type lostTemplateAPI struct {
+	*fakeZabbixAPI
+}
+
+func (f lostTemplateAPI) GetTemplate(ctx context.Context, templateID string) (*zabbix.Template, error) {
+	return nil, f.call(ctx, "GetTemplate")
+}
+
+func TestTemplateResource_CreatedButNotFound(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), lostTemplateAPI{client})
+
+	h.mustFail("create", h.create(templateValues("custom_linux", "Custom Linux", "12")), "Error Reading Template")
+
+	var data TemplateResourceModel
+	h.model(&data)
+	if data.ID.ValueString() != "101" || !data.Host.IsNull() {
+		t.Errorf("expected only the ID of the created template in state, got %+v", data)
+	}
+}
+
+func TestTemplateResource_ReadAfterCreateFails(t *testing.T) {
+	client := newFakeZabbixAPI()
+	client.errs["GetTemplate"] = errors.New("connection reset by peer")
+	h := newResourceHarness(t, NewTemplateResource(), client)
+
+	h.mustFail("create", h.create(templateValues("custom_linux", "Custom Linux", "12")), "Error Reading Template")
+
+	// The next refresh reconciles the partial state with the created template
+	delete(client.errs, "GetTemplate")
+	h.mustSucceed("read", h.read())
+
+	var data TemplateResourceModel
+	h.model(&data)
+	if data.ID.ValueString() != "101" || data.Host.ValueString() != "custom_linux" {
+		t.Errorf("expected the created template to be read into state, got %+v", data)
+	}
+	if len(client.templates) != 1 {
+		t.Errorf("expected no duplicate template, got %v", client.templates)
+	}
+}
+
+func TestTemplateResource_ForceDelete(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), client)
+
+	values := templateValues("custom_linux", "Custom Linux", "12")
+	h.mustSucceed("create", h.create(values))
+	client.hosts["201"] = &zabbix.Host{HostID: "201", Host: "web01", Templates: []zabbix.TemplateID{{TemplateID: "101"}}}
+
+	values["force_delete"] = tftypes.NewValue(tftypes.Bool, true)
+	h.mustSucceed("update", h.update(values))
+	h.mustSucceed("delete", h.delete())
+
+	if len(client.hosts["201"].Templates) != 0 {
+		t.Errorf("expected the template to be unlinked from the host, got %v", client.hosts["201"].Templates)
+	}
+	if len(client.templates) != 0 {
+		t.Errorf("expected the template to be deleted, got %v", client.templates)
+	}
+}
+
+func TestTemplateResource_DeleteLinkedToHosts(t *testing.T) {
+	client := newFakeZabbixAPI()
+	h := newResourceHarness(t, NewTemplateResource(), client)
+
+	h.mustSucceed("create", h.create(templateValues("custom_linux", "Custom Linux", "12")))
+	client.hosts["201"] = &zabbix.Host{HostID: "201", Host: "web02", Templates: []zabbix.TemplateID{{TemplateID: "101"}}}
+	client.hosts["202"] = &zabbix.Host{HostID: "202", Host: "web01", Templates: []zabbix.TemplateID{{TemplateID: "101"}}}
+	client.calls = nil
+
+	diags := h.delete()
+	h.mustFail("delete", diags, "Template Linked to Hosts")
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "linked to 2 host(s): web01, web02") || !strings.Contains(detail, "force_delete") {
+		t.Errorf("expected the error to name the linked hosts and suggest force_delete, got %q", detail)
+	}
+	if expected := []string{"GetHostsByTemplate"}; !reflect.DeepEqual(client.calls, expected) {
+		t.Errorf("expected only the linked hosts to be read, got calls %v", client.calls)
+	}
+	if _, ok := client.templates["101"]; !ok {
+		t.Error("expected the linked template to be kept")
+	}
+}
+
+func TestTemplateResource_APIErrors(t *testing.T) {
+	tests := map[string]struct {
+		method  string
+		run     func(h *resourceHarness) diag.Diagnostics
+		summary string
+	}{
+		"create": {
+			method:  "CreateTemplate",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.create(templateValues("other", "Other", "12")) },
+			summary: "Error Creating Template",
+		},
+		"read": {
+			method:  "GetTemplateWithExport",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.read() },
+			summary: "Error Reading Template",
+		},
+		"update": {
+			method: "UpdateTemplate",
+			run: func(h *resourceHarness) diag.Diagnostics {
+				return h.update(templateValues("custom_linux", "Other", "12"))
+			},
+			summary: "Error Updating Template",
+		},
+		"delete linked hosts": {
+			method:  "GetHostsByTemplate",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.delete() },
+			summary: "Error Reading Linked Hosts",
+		},
+		"delete": {
+			method:  "DeleteTemplate",
+			run:     func(h *resourceHarness) diag.Diagnostics { return h.delete() },
+			summary: "Error Deleting Template",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeZabbixAPI()
+			h := newResourceHarness(t, NewTemplateResource(), client)
+			h.mustSucceed("create", h.create(templateValues("custom_linux", "Custom Linux", "12")))
+
+			client.errs[test.method] = errors.New("connection reset by peer")
+			h.mustFail(name, test.run(h), test.summary)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Resolves zabbix_template source content from source_content or source_url.
+// ABOUTME: Downloads remote templates and verifies them against an optional SHA-256 checksum.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// templateSourceTimeout is the time a download of a template referenced by source_url may take.
+const templateSourceTimeout = 30 * time.Second
+
+// maxTemplateSourceSize is the largest template source that is downloaded. The official
+// templates are well below 1 MiB, so larger responses are most likely not templates.
+const maxTemplateSourceSize = 16 << 20
+
+// templateSourceHTTPClient returns the client used to download templates referenced by
+// source_url. It sends requests through the transport of the provider, so http_proxy and
+// the CA certificates and TLS settings apply as for the Zabbix API; without a transport
+// the default transport is used.
+func templateSourceHTTPClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{Timeout: templateSourceTimeout, Transport: transport}
+}
+
+// templateSource returns the template content to import and whether the template is
+// imported at all. Content referenced by source_url is downloaded with client on every call.
+func templateSource(ctx context.Context, client *http.Client, data *TemplateResourceModel) (string, bool, error) {
+	if !data.SourceContent.IsNull() && !data.SourceContent.IsUnknown() {
+		return data.SourceContent.ValueString(), true, nil
+	}
+
+	if !data.SourceURL.IsNull() && !data.SourceURL.IsUnknown() {
+		content, err := fetchTemplateSource(ctx, client, data.SourceURL.ValueString(), data.SourceChecksum.ValueString())
+		return content, true, err
+	}
+
+	return "", false, nil
+}
+
+// fetchTemplateSource downloads a template from url. When checksum is non-empty the
+// SHA-256 digest of the downloaded content must match it. Content larger than
+// maxTemplateSourceSize is rejected.
+func fetchTemplateSource(ctx context.Context, client *http.Client, url, checksum string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s: HTTP %d", url, resp.StatusCode)
+	}
+
+	// One byte more than the maximum is read to tell content of the maximum size from larger content
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTemplateSourceSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if len(body) > maxTemplateSourceSize {
+		return "", fmt.Errorf("content of %s exceeds the maximum template size of %d MiB", url, maxTemplateSourceSize>>20)
+	}
+
+	if checksum != "" {
+		sum := sha256.Sum256(body)
+		if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, checksum) {
+			return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, checksum, actual)
+		}
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,107 @@
+// ABOUTME: Unit tests for downloading template source content.
+// ABOUTME: Covers successful downloads, HTTP errors, checksum verification, size limits and the provider transport.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+const testTemplateSource = "zabbix_export:\n  version: '7.0'\n"
+
+func TestFetchTemplateSource_Success(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testTemplateSource))
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte(testTemplateSource))
+	checksum := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	content, err := fetchTemplateSource(context.Background(), server.Client(), server.URL, checksum)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != testTemplateSource {
+		t.Errorf("expected content %q, got %q", testTemplateSource, content)
+	}
+}
+
+func TestFetchTemplateSource_ChecksumMismatch(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testTemplateSource))
+	}))
+	defer server.Close()
+
+	_, err := fetchTemplateSource(context.Background(), server.Client(), server.URL, strings.Repeat("0", 64))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch error, got: %v", err)
+	}
+}
+
+func TestFetchTemplateSource_HTTPError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := fetchTemplateSource(context.Background(), server.Client(), server.URL, "")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "HTTP 404") {
+		t.Errorf("expected HTTP 404 error, got: %v", err)
+	}
+}
+
+func TestFetchTemplateSource_TooLarge(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("#", maxTemplateSourceSize+1)))
+	}))
+	defer server.Close()
+
+	_, err := fetchTemplateSource(context.Background(), server.Client(), server.URL, "")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "exceeds the maximum template size of 16 MiB") {
+		t.Errorf("expected size error, got: %v", err)
+	}
+}
+
+func TestFetchTemplateSource_ProviderTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testTemplateSource))
+	}))
+	defer server.Close()
+
+	// The certificate of the test server is only trusted through the CA certificates of the provider
+	if _, err := fetchTemplateSource(context.Background(), templateSourceHTTPClient(nil), server.URL, ""); err == nil {
+		t.Fatal("expected the default transport to reject the certificate of the test server")
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err := zabbix.NewTransport(zabbix.TransportOptions{CACertPEM: caPEM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := fetchTemplateSource(context.Background(), templateSourceHTTPClient(transport), server.URL, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != testTemplateSource {
+		t.Errorf("expected content %q, got %q", testTemplateSource, content)
+	}
+}
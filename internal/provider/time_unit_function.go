@@ -0,0 +1,148 @@
+// ABOUTME: Provider-defined functions validating and converting Zabbix time units.
+// ABOUTME: Converts between suffixed values such as 30s, 5m, 2h or 1d and seconds.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/p3l1/terraform-provider-zabbix/internal/validators"
+)
+
+var (
+	_ function.Function = &TimeToSecondsFunction{}
+	_ function.Function = &SecondsToTimeFunction{}
+	_ function.Function = &NormalizeTimeFunction{}
+)
+
+// TimeToSecondsFunction defines the function implementation.
+type TimeToSecondsFunction struct{}
+
+// NewTimeToSecondsFunction creates a new function instance.
+func NewTimeToSecondsFunction() function.Function {
+	return &TimeToSecondsFunction{}
+}
+
+func (f *TimeToSecondsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "time_to_seconds"
+}
+
+func (f *TimeToSecondsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a Zabbix time unit to seconds",
+		Description: "Returns the number of seconds of a Zabbix time value such as 30s, 5m, 2h, 1d or 1w, and fails if the value is not a valid time. " +
+			"A value without suffix is in seconds. User macros cannot be resolved and are rejected.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "time",
+				Description: "Time value with an optional s, m, h, d or w suffix.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *TimeToSecondsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	seconds, err := validators.ParseTimeUnit(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, seconds)
+}
+
+// SecondsToTimeFunction defines the function implementation.
+type SecondsToTimeFunction struct{}
+
+// NewSecondsToTimeFunction creates a new function instance.
+func NewSecondsToTimeFunction() function.Function {
+	return &SecondsToTimeFunction{}
+}
+
+func (f *SecondsToTimeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "seconds_to_time"
+}
+
+func (f *SecondsToTimeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert seconds to a Zabbix time unit",
+		Description: "Returns a number of seconds as a Zabbix time value with the largest suffix that represents it exactly, for example 90 becomes 90s, 3600 becomes 1h and 5400 becomes 90m.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "seconds",
+				Description: "Number of seconds, zero or more.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SecondsToTimeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seconds int64
+
+	resp.Error = req.Arguments.Get(ctx, &seconds)
+	if resp.Error != nil {
+		return
+	}
+
+	if seconds < 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid number of seconds %d: must not be negative", seconds))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, validators.FormatTimeUnit(seconds))
+}
+
+// NormalizeTimeFunction defines the function implementation.
+type NormalizeTimeFunction struct{}
+
+// NewNormalizeTimeFunction creates a new function instance.
+func NewNormalizeTimeFunction() function.Function {
+	return &NormalizeTimeFunction{}
+}
+
+func (f *NormalizeTimeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_time"
+}
+
+func (f *NormalizeTimeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a Zabbix time unit",
+		Description: "Returns a Zabbix time value with the largest suffix that represents it exactly, for example 3600 or 60m become 1h, and fails if the value is not a valid time. " +
+			"Equal durations written differently normalize to the same value.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "time",
+				Description: "Time value with an optional s, m, h, d or w suffix.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeTimeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	seconds, err := validators.ParseTimeUnit(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, validators.FormatTimeUnit(seconds))
+}
@@ -0,0 +1,52 @@
+// ABOUTME: Tests for the time_to_seconds, seconds_to_time and normalize_time provider functions.
+// ABOUTME: Covers conversions in both directions, normalization and errors for invalid values.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccTimeUnitFunctions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "seconds" {
+  value = provider::zabbix::time_to_seconds("2h")
+}
+
+output "time" {
+  value = provider::zabbix::seconds_to_time(5400)
+}
+
+output "normalized" {
+  value = provider::zabbix::normalize_time("60m")
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("seconds", "7200"),
+					resource.TestCheckOutput("time", "90m"),
+					resource.TestCheckOutput("normalized", "1h"),
+				),
+			},
+			{
+				Config: `
+output "seconds" {
+  value = provider::zabbix::time_to_seconds("1h30m")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid time`),
+			},
+		},
+	})
+}
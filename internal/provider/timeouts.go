@@ -0,0 +1,68 @@
+// ABOUTME: Optional timeouts block of resources, limiting how long each operation may take.
+// ABOUTME: Turns the configured create, read, update and delete durations into context deadlines.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/validators"
+)
+
+// timeoutOperations are the operations that can be given a timeout.
+var timeoutOperations = []string{"create", "read", "update", "delete"}
+
+// timeoutsBlock returns the schema of the timeouts block shared by all resources.
+func timeoutsBlock() schema.SingleNestedBlock {
+	attributes := make(map[string]schema.Attribute, len(timeoutOperations))
+	for _, operation := range timeoutOperations {
+		attributes[operation] = schema.StringAttribute{
+			Description: fmt.Sprintf("The time the %s operation may take, such as 90s or 5m. Without a timeout, operations only end when Zabbix responds or the request timeout of the provider is reached.", operation),
+			Optional:    true,
+			Validators: []validator.String{
+				validators.Duration(),
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		Description: "Timeouts of the operations on the resource. Each Zabbix API call made during an operation is cancelled once its timeout is reached.",
+		Attributes:  attributes,
+	}
+}
+
+// withTimeout returns a context whose deadline is the timeout configured for the operation
+// in the timeouts block. The context has no deadline when the block or the operation is not
+// set. The returned cancel function must always be called.
+func withTimeout(ctx context.Context, timeouts types.Object, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return ctx, func() {}, diags
+	}
+
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return ctx, func() {}, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("timeouts").AtName(operation),
+			"Invalid Timeout",
+			fmt.Sprintf("The %s timeout must be a positive duration such as 90s or 5m, got %q.", operation, value.ValueString()),
+		)
+		return ctx, func() {}, diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, diags
+}
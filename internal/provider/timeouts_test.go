@@ -0,0 +1,151 @@
+// ABOUTME: Unit tests for the timeouts block of resources.
+// ABOUTME: Covers the deadlines derived from the block and their use around client calls.
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testTimeouts builds a timeouts block with the given operations set.
+func testTimeouts(t *testing.T, values map[string]string) types.Object {
+	t.Helper()
+
+	attrTypes := map[string]attr.Type{}
+	attrValues := map[string]attr.Value{}
+	for _, operation := range timeoutOperations {
+		attrTypes[operation] = types.StringType
+		attrValues[operation] = types.StringNull()
+		if value, ok := values[operation]; ok {
+			attrValues[operation] = types.StringValue(value)
+		}
+	}
+
+	object, diags := types.ObjectValue(attrTypes, attrValues)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %s", diags.Errors())
+	}
+	return object
+}
+
+func TestWithTimeout(t *testing.T) {
+	timeouts := testTimeouts(t, map[string]string{"create": "5m"})
+
+	ctx, cancel, diags := withTimeout(context.Background(), timeouts, "create")
+	defer cancel()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %s", diags.Errors())
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected a deadline for the create operation")
+	}
+	if remaining := time.Until(deadline); remaining <= 4*time.Minute || remaining > 5*time.Minute {
+		t.Errorf("expected the deadline to be in 5m, got %s", remaining)
+	}
+
+	for name, timeouts := range map[string]types.Object{
+		"null block":    types.ObjectNull(testTimeouts(t, nil).AttributeTypes(context.Background())),
+		"unset timeout": timeouts,
+	} {
+		ctx, cancel, diags := withTimeout(context.Background(), timeouts, "delete")
+		cancel()
+		if diags.HasError() {
+			t.Errorf("%s: unexpected error: %s", name, diags.Errors())
+		}
+		if _, ok := ctx.Deadline(); ok {
+			t.Errorf("%s: expected no deadline", name)
+		}
+	}
+}
+
+func TestWithTimeout_Invalid(t *testing.T) {
+	_, cancel, diags := withTimeout(context.Background(), testTimeouts(t, map[string]string{"read": "1d"}), "read")
+	cancel()
+	if !diags.HasError() {
+		t.Fatal("expected an error for an invalid timeout")
+	}
+}
+
+func TestResources_TimeoutsBlock(t *testing.T) {
+	for _, newResource := range []func() fwresource.Resource{
+		NewHostGroupResource,
+		NewTemplateGroupResource,
+		NewTemplateResource,
+		NewHostResource,
+		NewHostsBulkResource,
+	} {
+		r := newResource()
+		metadataResp := &fwresource.MetadataResponse{}
+		r.Metadata(context.Background(), fwresource.MetadataRequest{ProviderTypeName: "zabbix"}, metadataResp)
+
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+		if _, ok := schemaResp.Schema.Blocks["timeouts"]; !ok {
+			t.Errorf("%s: expected a timeouts block", metadataResp.TypeName)
+		}
+	}
+}
+
+func TestHostGroupResource_Timeouts(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	r := NewHostGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	timeoutsType := schemaResp.Schema.Blocks["timeouts"].Type().TerraformType(ctx)
+	createResp := &fwresource.CreateResponse{State: emptyState(t, schemaResp)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Plan: testPlan(t, schemaResp, map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+			"uuid":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+				"create": tftypes.NewValue(tftypes.String, "2m"),
+				"read":   tftypes.NewValue(tftypes.String, nil),
+				"update": tftypes.NewValue(tftypes.String, nil),
+				"delete": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}),
+	}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create error: %s", createResp.Diagnostics.Errors())
+	}
+
+	for _, method := range []string{"CreateHostGroup", "GetHostGroup"} {
+		deadline, ok := client.deadlines[method]
+		if !ok {
+			t.Errorf("expected %s to be called with a deadline", method)
+			continue
+		}
+		if remaining := time.Until(deadline); remaining > 2*time.Minute {
+			t.Errorf("expected %s to be called with a deadline within 2m, got %s", method, remaining)
+		}
+	}
+
+	// The timeouts are kept in state for later operations
+	var created HostGroupResourceModel
+	createResp.State.Get(ctx, &created)
+	if got := created.Timeouts.Attributes()["create"]; !got.Equal(types.StringValue("2m")) {
+		t.Errorf("expected the create timeout to be kept in state, got %s", got)
+	}
+
+	// Read has no timeout and its calls no deadline
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	delete(client.deadlines, "GetHostGroup")
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %s", readResp.Diagnostics.Errors())
+	}
+	if _, ok := client.deadlines["GetHostGroup"]; ok {
+		t.Error("expected GetHostGroup to be called without a deadline during read")
+	}
+}
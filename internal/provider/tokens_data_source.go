@@ -0,0 +1,189 @@
+// ABOUTME: Terraform data source for listing Zabbix API token metadata per user.
+// ABOUTME: Exposes names, expiry and last access for credential hygiene; secrets are never read.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &TokensDataSource{}
+
+// TokensDataSource defines the data source implementation.
+type TokensDataSource struct {
+	client *zabbix.Client
+}
+
+// TokensDataSourceModel describes the data source data model.
+type TokensDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	UserIDs types.Set    `tfsdk:"user_ids"`
+	Tokens  types.List   `tfsdk:"tokens"`
+}
+
+// NewTokensDataSource creates a new data source instance.
+func NewTokensDataSource() datasource.DataSource {
+	return &TokensDataSource{}
+}
+
+func (d *TokensDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tokens"
+}
+
+func (d *TokensDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list Zabbix API tokens, for example to report on expiring or unused credentials. Only token metadata is returned, never the token secret.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the result, derived from the IDs of the matching tokens.",
+				Computed:    true,
+			},
+			"user_ids": schema.SetAttribute{
+				Description: "Only return tokens owned by these user IDs. Without this filter, all tokens visible to the provider's user are returned.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tokens": schema.ListNestedAttribute{
+				Description: "Matching tokens, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the token (tokenid in Zabbix).",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the token.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the token.",
+							Computed:    true,
+						},
+						"user_id": schema.StringAttribute{
+							Description: "The ID of the user the token belongs to.",
+							Computed:    true,
+						},
+						"status": schema.Int64Attribute{
+							Description: "Status of the token. 0 = enabled, 1 = disabled.",
+							Computed:    true,
+						},
+						"expires_at": schema.Int64Attribute{
+							Description: "Expiry time of the token as a Unix timestamp. 0 means the token never expires.",
+							Computed:    true,
+						},
+						"last_access": schema.Int64Attribute{
+							Description: "Time the token was last used as a Unix timestamp. 0 means the token has never been used.",
+							Computed:    true,
+						},
+						"created_at": schema.Int64Attribute{
+							Description: "Creation time of the token as a Unix timestamp.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TokensDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TokensDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var userIDs []string
+	if !data.UserIDs.IsNull() {
+		resp.Diagnostics.Append(data.UserIDs.ElementsAs(ctx, &userIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tokens, err := d.client.GetTokens(ctx, userIDs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Tokens",
+			fmt.Sprintf("Could not list API tokens: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(d.apiToModel(tokens, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apiToModel converts the matching tokens to the Terraform model.
+func (d *TokensDataSource) apiToModel(tokens []zabbix.Token, data *TokensDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tokenType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":          types.StringType,
+			"name":        types.StringType,
+			"description": types.StringType,
+			"user_id":     types.StringType,
+			"status":      types.Int64Type,
+			"expires_at":  types.Int64Type,
+			"last_access": types.Int64Type,
+			"created_at":  types.Int64Type,
+		},
+	}
+
+	ids := make([]string, len(tokens))
+	tokenValues := make([]attr.Value, len(tokens))
+	for i, token := range tokens {
+		obj, diagsToken := types.ObjectValue(tokenType.AttrTypes, map[string]attr.Value{
+			"id":          types.StringValue(token.TokenID),
+			"name":        types.StringValue(token.Name),
+			"description": types.StringValue(token.Description),
+			"user_id":     types.StringValue(token.UserID),
+			"status":      types.Int64Value(int64(token.Status)),
+			"expires_at":  types.Int64Value(token.ExpiresAt),
+			"last_access": types.Int64Value(token.LastAccess),
+			"created_at":  types.Int64Value(token.CreatedAt),
+		})
+		diags.Append(diagsToken...)
+		tokenValues[i] = obj
+		ids[i] = token.TokenID
+	}
+	tokensList, diagsTokens := types.ListValue(tokenType, tokenValues)
+	diags.Append(diagsTokens...)
+	data.Tokens = tokensList
+
+	data.ID = types.StringValue(listDataSourceID(ids))
+
+	return diags
+}
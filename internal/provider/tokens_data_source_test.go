@@ -0,0 +1,32 @@
+// ABOUTME: Acceptance tests for the zabbix_tokens data source.
+// ABOUTME: Lists the API tokens of the built-in Admin user.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTokensDataSource_byUser(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// User 1 is the built-in Admin user
+				Config: `
+data "zabbix_tokens" "test" {
+  user_ids = ["1"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.zabbix_tokens.test", "id"),
+					resource.TestCheckResourceAttrSet("data.zabbix_tokens.test", "tokens.#"),
+					resource.TestCheckNoResourceAttr("data.zabbix_tokens.test", "tokens.0.token"),
+				),
+			},
+		},
+	})
+}
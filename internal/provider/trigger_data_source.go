@@ -0,0 +1,144 @@
+// ABOUTME: Terraform data source for looking up a single Zabbix trigger.
+// ABOUTME: Resolves exactly one trigger by host technical name and trigger name.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &TriggerDataSource{}
+
+// TriggerDataSource defines the data source implementation.
+type TriggerDataSource struct {
+	client *zabbix.Client
+}
+
+// TriggerDataSourceModel describes the data source data model.
+type TriggerDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Host        types.String `tfsdk:"host"`
+	Description types.String `tfsdk:"description"`
+	Expression  types.String `tfsdk:"expression"`
+	Priority    types.Int64  `tfsdk:"priority"`
+	Status      types.Int64  `tfsdk:"status"`
+	Comments    types.String `tfsdk:"comments"`
+	URL         types.String `tfsdk:"url"`
+}
+
+// NewTriggerDataSource creates a new data source instance.
+func NewTriggerDataSource() datasource.DataSource {
+	return &TriggerDataSource{}
+}
+
+func (d *TriggerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (d *TriggerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to look up a single Zabbix trigger by host technical name and trigger name, for example to reference it in trigger dependencies or service problem tags.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the trigger (triggerid in Zabbix).",
+				Computed:    true,
+			},
+			"host": schema.StringAttribute{
+				Description: "Technical name of the host or template the trigger belongs to.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Name of the trigger to look up, exactly as defined including unexpanded macros.",
+				Required:    true,
+			},
+			"expression": schema.StringAttribute{
+				Description: "Trigger expression with item references expanded.",
+				Computed:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Severity of the trigger. 0 = not classified, 1 = information, 2 = warning, 3 = average, 4 = high, 5 = disaster.",
+				Computed:    true,
+			},
+			"status": schema.Int64Attribute{
+				Description: "Status of the trigger. 0 = enabled, 1 = disabled.",
+				Computed:    true,
+			},
+			"comments": schema.StringAttribute{
+				Description: "Additional description of the trigger.",
+				Computed:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "URL associated with the trigger.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *TriggerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TriggerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TriggerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	triggers, err := d.client.GetTriggersByHostDescription(ctx, data.Host.ValueString(), data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Trigger",
+			fmt.Sprintf("Could not read trigger %q on host %q: %s", data.Description.ValueString(), data.Host.ValueString(), err),
+		)
+		return
+	}
+
+	if len(triggers) == 0 {
+		resp.Diagnostics.AddError(
+			"Trigger Not Found",
+			fmt.Sprintf("No trigger found with name %q on host %q.", data.Description.ValueString(), data.Host.ValueString()),
+		)
+		return
+	}
+
+	if len(triggers) > 1 {
+		resp.Diagnostics.AddError(
+			"Multiple Triggers Found",
+			fmt.Sprintf("Found %d triggers with name %q on host %q; the lookup must match exactly one trigger.", len(triggers), data.Description.ValueString(), data.Host.ValueString()),
+		)
+		return
+	}
+
+	trigger := triggers[0]
+	data.ID = types.StringValue(trigger.TriggerID)
+	data.Description = types.StringValue(trigger.Description)
+	data.Expression = types.StringValue(trigger.Expression)
+	data.Priority = types.Int64Value(int64(trigger.Priority))
+	data.Status = types.Int64Value(int64(trigger.Status))
+	data.Comments = types.StringValue(trigger.Comments)
+	data.URL = types.StringValue(trigger.URL)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
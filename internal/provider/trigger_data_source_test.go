@@ -0,0 +1,65 @@
+// ABOUTME: Acceptance tests for the zabbix_trigger data source.
+// ABOUTME: Tests resolving triggers inherited from a linked template by host and name.
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTriggerDataSource_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTriggerDataSourceConfig(rName, "Linux: /etc/passwd has been changed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.zabbix_trigger.test", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_trigger.test", "description", "Linux: /etc/passwd has been changed"),
+					resource.TestCheckResourceAttr("data.zabbix_trigger.test", "status", "0"),
+					resource.TestMatchResourceAttr("data.zabbix_trigger.test", "expression", regexp.MustCompile(rName)),
+				),
+			},
+			{
+				Config:      testAccTriggerDataSourceConfig(rName, "No such trigger"),
+				ExpectError: regexp.MustCompile("Trigger Not Found"),
+			},
+		},
+	})
+}
+
+func testAccTriggerDataSourceConfig(name, description string) string {
+	return fmt.Sprintf(`
+resource "zabbix_host_group" "test" {
+  name = %[1]q
+}
+
+resource "zabbix_host" "test" {
+  host           = %[1]q
+  groups         = [zabbix_host_group.test.id]
+  template_names = ["Linux by Zabbix agent"]
+
+  interfaces = [{
+    type   = "agent"
+    ip     = "192.168.1.100"
+    dns    = ""
+    port   = "10050"
+    main   = true
+    use_ip = true
+  }]
+}
+
+data "zabbix_trigger" "test" {
+  host        = zabbix_host.test.host
+  description = %[2]q
+}
+`, name, description)
+}
@@ -0,0 +1,116 @@
+// ABOUTME: Provider-defined function that builds a Zabbix trigger function call.
+// ABOUTME: Validates the host, item key and function name and quotes parameters where needed.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &TriggerExprFunction{}
+
+// TriggerExprFunction defines the function implementation.
+type TriggerExprFunction struct{}
+
+// NewTriggerExprFunction creates a new function instance.
+func NewTriggerExprFunction() function.Function {
+	return &TriggerExprFunction{}
+}
+
+var (
+	// triggerFunctionName matches the name of a Zabbix trigger function such as last or count.
+	triggerFunctionName = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+	// triggerItemKey matches an item key with optional parameters in brackets.
+	triggerItemKey = regexp.MustCompile(`^[0-9A-Za-z._-]+(\[.*\])?$`)
+)
+
+func (f *TriggerExprFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "trigger_expr"
+}
+
+func (f *TriggerExprFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a Zabbix trigger function call",
+		Description: "Returns the trigger function call func(/host/item_key,args...) in the Zabbix 7.0 expression syntax, for example avg(/web01/system.cpu.load,5m). " +
+			"Parameters containing commas, quotes, closing parentheses or surrounding spaces are quoted and escaped, unless they are already enclosed in double quotes. " +
+			"Combine the result with operators and constants to form the complete trigger expression.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "host",
+				Description: "Technical name of the host or template the item belongs to.",
+			},
+			function.StringParameter{
+				Name:        "item_key",
+				Description: "Key of the item, including its parameters, for example vfs.fs.size[/,pfree].",
+			},
+			function.StringParameter{
+				Name:        "func",
+				Description: "Name of the trigger function, for example last, avg or nodata.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "args",
+			Description: "Parameters of the trigger function following the item reference, for example an evaluation period such as 5m or #3. An empty string skips an optional parameter.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TriggerExprFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var host, itemKey, name string
+	var args []string
+
+	resp.Error = req.Arguments.Get(ctx, &host, &itemKey, &name, &args)
+	if resp.Error != nil {
+		return
+	}
+
+	if host == "" || strings.Contains(host, "/") {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid host %q: must be non-empty and must not contain /", host))
+		return
+	}
+
+	if !triggerItemKey.MatchString(itemKey) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid item key %q", itemKey))
+		return
+	}
+
+	if !triggerFunctionName.MatchString(name) {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("invalid function name %q: must consist of lowercase letters and underscores", name))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, buildTriggerFunction(host, itemKey, name, args))
+}
+
+// buildTriggerFunction formats a trigger function call, quoting the parameters that need it.
+func buildTriggerFunction(host, itemKey, name string, args []string) string {
+	params := make([]string, 0, len(args)+1)
+	params = append(params, "/"+host+"/"+itemKey)
+	for _, arg := range args {
+		params = append(params, quoteTriggerParam(arg))
+	}
+
+	return name + "(" + strings.Join(params, ",") + ")"
+}
+
+// quoteTriggerParam quotes a trigger function parameter if Zabbix would not read it as
+// a single unquoted parameter. Backslashes and double quotes are escaped in quoted parameters,
+// and parameters that are already quoted are left as they are.
+func quoteTriggerParam(param string) string {
+	if len(param) >= 2 && strings.HasPrefix(param, `"`) && strings.HasSuffix(param, `"`) {
+		return param
+	}
+
+	if !strings.ContainsAny(param, `,")\`) && strings.TrimSpace(param) == param {
+		return param
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(param) + `"`
+}
@@ -0,0 +1,87 @@
+// ABOUTME: Tests for the trigger_expr provider function.
+// ABOUTME: Covers parameter quoting and running the function through Terraform.
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestBuildTriggerFunction(t *testing.T) {
+	tests := map[string]struct {
+		host     string
+		itemKey  string
+		name     string
+		args     []string
+		expected string
+	}{
+		"no parameters": {
+			host: "web01", itemKey: "agent.ping", name: "nodata",
+			args:     []string{"5m"},
+			expected: "nodata(/web01/agent.ping,5m)",
+		},
+		"key parameters": {
+			host: "web01", itemKey: "vfs.fs.size[/,pfree]", name: "last",
+			expected: "last(/web01/vfs.fs.size[/,pfree])",
+		},
+		"skipped parameter": {
+			host: "web01", itemKey: "log[/var/log/syslog]", name: "find",
+			args:     []string{"", "like", "error"},
+			expected: "find(/web01/log[/var/log/syslog],,like,error)",
+		},
+		"quoted parameter": {
+			host: "web01", itemKey: "log[/var/log/syslog]", name: "find",
+			args:     []string{"5m", "regexp", `fail(ed|ure), "fatal"`},
+			expected: `find(/web01/log[/var/log/syslog],5m,regexp,"fail(ed|ure), \"fatal\"")`,
+		},
+		"already quoted parameter": {
+			host: "web01", itemKey: "log[/var/log/syslog]", name: "find",
+			args:     []string{"5m", `"like"`, `"error"`},
+			expected: `find(/web01/log[/var/log/syslog],5m,"like","error")`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr := buildTriggerFunction(tc.host, tc.itemKey, tc.name, tc.args)
+			if expr != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, expr)
+			}
+			if err := validateTriggerExpression(expr); err != nil {
+				t.Errorf("built expression is not valid: %s", err)
+			}
+		})
+	}
+}
+
+func TestAccTriggerExprFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "expression" {
+  value = "${provider::zabbix::trigger_expr("web01", "system.cpu.load[all,avg1]", "avg", "5m")}>5"
+}
+`,
+				Check: resource.TestCheckOutput("expression", "avg(/web01/system.cpu.load[all,avg1],5m)>5"),
+			},
+			{
+				Config: `
+output "expression" {
+  value = provider::zabbix::trigger_expr("web/01", "agent.ping", "last")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid host`),
+			},
+		},
+	})
+}
@@ -0,0 +1,180 @@
+// ABOUTME: Terraform data source for listing Zabbix users filtered by role or user group.
+// ABOUTME: Supports bulk operations such as attaching media or building recipient lists.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ datasource.DataSource = &UsersDataSource{}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client *zabbix.Client
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	RoleIDs      types.Set    `tfsdk:"role_ids"`
+	UserGroupIDs types.Set    `tfsdk:"user_group_ids"`
+	Users        types.List   `tfsdk:"users"`
+}
+
+// NewUsersDataSource creates a new data source instance.
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to list the Zabbix users matching all of the given filters. Without filters, every user is returned.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the result, derived from the IDs of the matching users.",
+				Computed:    true,
+			},
+			"role_ids": schema.SetAttribute{
+				Description: "Only return users that have any of these role IDs.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"user_group_ids": schema.SetAttribute{
+				Description: "Only return users that belong to any of these user group IDs.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "Matching users, sorted by username.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the user (userid in Zabbix).",
+							Computed:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "Username used to log in.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "First name of the user.",
+							Computed:    true,
+						},
+						"surname": schema.StringAttribute{
+							Description: "Surname of the user.",
+							Computed:    true,
+						},
+						"role_id": schema.StringAttribute{
+							Description: "The ID of the role assigned to the user.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*zabbix.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *zabbix.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var search zabbix.UserSearch
+	if !data.RoleIDs.IsNull() {
+		resp.Diagnostics.Append(data.RoleIDs.ElementsAs(ctx, &search.RoleIDs, false)...)
+	}
+	if !data.UserGroupIDs.IsNull() {
+		resp.Diagnostics.Append(data.UserGroupIDs.ElementsAs(ctx, &search.UserGroupIDs, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.SearchUsers(ctx, search)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Users",
+			fmt.Sprintf("Could not list users: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(d.apiToModel(users, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// apiToModel converts the matching users to the Terraform model.
+func (d *UsersDataSource) apiToModel(users []zabbix.User, data *UsersDataSourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	userType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":       types.StringType,
+			"username": types.StringType,
+			"name":     types.StringType,
+			"surname":  types.StringType,
+			"role_id":  types.StringType,
+		},
+	}
+
+	ids := make([]string, len(users))
+	userValues := make([]attr.Value, len(users))
+	for i, user := range users {
+		obj, diagsUser := types.ObjectValue(userType.AttrTypes, map[string]attr.Value{
+			"id":       types.StringValue(user.UserID),
+			"username": types.StringValue(user.Username),
+			"name":     types.StringValue(user.Name),
+			"surname":  types.StringValue(user.Surname),
+			"role_id":  types.StringValue(user.RoleID),
+		})
+		diags.Append(diagsUser...)
+		userValues[i] = obj
+		ids[i] = user.UserID
+	}
+	usersList, diagsUsers := types.ListValue(userType, userValues)
+	diags.Append(diagsUsers...)
+	data.Users = usersList
+
+	data.ID = types.StringValue(listDataSourceID(ids))
+
+	return diags
+}
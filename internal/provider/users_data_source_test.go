@@ -0,0 +1,43 @@
+// ABOUTME: Acceptance tests for the zabbix_users data source.
+// ABOUTME: Lists the built-in Admin user by role and by user group.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUsersDataSource_filters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Role 3 is the built-in Super admin role, user group 7 is Zabbix administrators
+				Config: `
+data "zabbix_users" "test" {
+  role_ids       = ["3"]
+  user_group_ids = ["7"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.zabbix_users.test", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.zabbix_users.test", "users.*", map[string]string{
+						"username": "Admin",
+						"role_id":  "3",
+					}),
+				),
+			},
+			{
+				Config: `
+data "zabbix_users" "test" {
+  role_ids = ["999999"]
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.zabbix_users.test", "users.#", "0"),
+			},
+		},
+	})
+}
@@ -0,0 +1,232 @@
+// ABOUTME: Provider-defined function that checks the syntax of a Zabbix trigger expression.
+// ABOUTME: Catches unbalanced parentheses, unterminated strings and malformed item references at plan time.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ function.Function = &ValidateExpressionFunction{}
+
+// ValidateExpressionFunction defines the function implementation.
+type ValidateExpressionFunction struct{}
+
+// NewValidateExpressionFunction creates a new function instance.
+func NewValidateExpressionFunction() function.Function {
+	return &ValidateExpressionFunction{}
+}
+
+func (f *ValidateExpressionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_expression"
+}
+
+func (f *ValidateExpressionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check the syntax of a Zabbix trigger expression",
+		Description: "Returns the expression unchanged if it is a syntactically valid Zabbix 7.0 trigger expression, and fails otherwise. " +
+			"Parentheses must be balanced, strings terminated, and every item reference must be the first parameter of a function, such as last(/host/key). " +
+			"Provider functions run without a connection to Zabbix, so whether the referenced hosts and items exist is checked by Zabbix when the trigger is created.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "expression",
+				Description: "Trigger expression to check.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ValidateExpressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression string
+
+	resp.Error = req.Arguments.Get(ctx, &expression)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateTriggerExpression(expression); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid trigger expression: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, expression)
+}
+
+// validateTriggerExpression checks the structure of a trigger expression. Positions in
+// errors are 1-based character offsets.
+func validateTriggerExpression(expression string) error {
+	_, err := triggerExpressionItems(expression)
+	return err
+}
+
+// triggerExpressionItems checks the structure of a trigger expression like
+// validateTriggerExpression and returns the items it references, in order.
+func triggerExpressionItems(expression string) ([]zabbix.ItemReference, error) {
+	return expressionItems(expression, false)
+}
+
+// expressionItems checks the structure of a trigger expression or, with formula, of the
+// formula of a calculated item, and returns the items it references, in order. Formulas
+// may leave out the host to reference the host of the item, and may filter the items of
+// aggregate functions with ?[...] after the key.
+func expressionItems(expression string, formula bool) ([]zabbix.ItemReference, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, errors.New("expression is empty")
+	}
+
+	depth := 0
+	var references []zabbix.ItemReference
+	for i := 0; i < len(expression); i++ {
+		switch expression[i] {
+		case '"':
+			end, err := skipQuotedString(expression, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case '(':
+			depth++
+			if i+1 < len(expression) && expression[i+1] == '/' {
+				if !precededByFunctionName(expression, i) {
+					return nil, fmt.Errorf("item reference at position %d is not the parameter of a function", i+2)
+				}
+				ref, end, err := parseItemReference(expression, i+1, formula)
+				if err != nil {
+					return nil, err
+				}
+				references = append(references, ref)
+				i = end - 1
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unexpected ) at position %d", i+1)
+			}
+		}
+	}
+
+	if depth > 0 {
+		return nil, errors.New("missing closing parenthesis")
+	}
+
+	if len(references) == 0 {
+		return nil, errors.New("expression must reference at least one item, for example last(/host/key)")
+	}
+
+	return references, nil
+}
+
+// skipQuotedString returns the position of the double quote closing the string that
+// starts at start. Backslashes escape the following character.
+func skipQuotedString(expression string, start int) (int, error) {
+	for i := start + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case '"':
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated string starting at position %d", start+1)
+}
+
+// precededByFunctionName reports whether the parenthesis at pos directly follows a function name.
+func precededByFunctionName(expression string, pos int) bool {
+	start := pos
+	for start > 0 && (expression[start-1] == '_' || (expression[start-1] >= 'a' && expression[start-1] <= 'z')) {
+		start--
+	}
+
+	return triggerFunctionName.MatchString(expression[start:pos])
+}
+
+// parseItemReference parses the item reference /host/key starting at start and returns
+// it with the position of the comma or parenthesis that follows it. With formula, the
+// host may be empty and the key may be followed by a filter.
+func parseItemReference(expression string, start int, formula bool) (zabbix.ItemReference, int, error) {
+	var ref zabbix.ItemReference
+
+	rest := expression[start+1:]
+	slash := strings.IndexAny(rest, "/,()")
+	if slash < 0 || (slash == 0 && !formula) || rest[slash] != '/' {
+		return ref, 0, fmt.Errorf("item reference at position %d must have the form /host/key", start+1)
+	}
+
+	keyStart := start + 1 + slash + 1
+	i := keyStart
+	for i < len(expression) && isItemKeyChar(expression[i]) {
+		i++
+	}
+	if i == keyStart {
+		return ref, 0, fmt.Errorf("item reference at position %d has no item key", start+1)
+	}
+
+	if i < len(expression) && expression[i] == '[' {
+		end, err := skipKeyParameters(expression, i)
+		if err != nil {
+			return ref, 0, err
+		}
+		i = end + 1
+	}
+	keyEnd := i
+
+	if formula && i < len(expression) && expression[i] == '?' {
+		if i+1 >= len(expression) || expression[i+1] != '[' {
+			return ref, 0, fmt.Errorf("item filter at position %d must have the form ?[...]", i+1)
+		}
+		end, err := skipKeyParameters(expression, i+1)
+		if err != nil {
+			return ref, 0, err
+		}
+		i = end + 1
+	}
+
+	if i >= len(expression) {
+		return ref, 0, errors.New("missing closing parenthesis")
+	}
+	if expression[i] != ',' && expression[i] != ')' {
+		return ref, 0, fmt.Errorf("unexpected character after item key at position %d", i+1)
+	}
+
+	ref.Host = rest[:slash]
+	ref.Key = expression[keyStart:keyEnd]
+	return ref, i, nil
+}
+
+// skipKeyParameters returns the position of the bracket closing the item key parameters
+// that start at start. Brackets may be nested and parameters may be quoted.
+func skipKeyParameters(expression string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(expression); i++ {
+		switch expression[i] {
+		case '"':
+			end, err := skipQuotedString(expression, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated item key parameters starting at position %d", start+1)
+}
+
+func isItemKeyChar(c byte) bool {
+	return c == '.' || c == '_' || c == '-' ||
+		(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
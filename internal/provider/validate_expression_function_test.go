@@ -0,0 +1,80 @@
+// ABOUTME: Tests for the validate_expression provider function.
+// ABOUTME: Covers valid expressions and the syntax errors reported for invalid ones.
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestValidateTriggerExpression(t *testing.T) {
+	valid := []string{
+		"last(/web01/agent.ping)=0",
+		"avg(/web01/system.cpu.load[all,avg1],5m)>{$CPU.LOAD.MAX}",
+		`find(/web01/log["/var/log/app.log",,,"skip"],5m,"regexp","fail(ed)?")=1`,
+		"min(/web01/vfs.fs.size[/,pfree],5m)<10 and nodata(/web01/agent.ping,10m)=0",
+		"(last(/web01/a)+last(/web02/b))/2>1",
+	}
+	for _, expression := range valid {
+		if err := validateTriggerExpression(expression); err != nil {
+			t.Errorf("expected %q to be valid, got: %s", expression, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                              "expression is empty",
+		"1>0":                           "at least one item",
+		"last(/web01/agent.ping":        "missing closing parenthesis",
+		"last(/web01/agent.ping))=0":    "unexpected )",
+		`find(/web01/log,5m,"like,err)`: "unterminated string",
+		"last(/web01)=0":                "must have the form /host/key",
+		"last(//agent.ping)=0":          "must have the form /host/key",
+		"last(/web01/)=0":               "has no item key",
+		"last(/web01/key[a,b)=0":        "unterminated item key parameters",
+		"last(/web01/key x)=0":          "unexpected character after item key",
+		"(/web01/agent.ping)=0":         "not the parameter of a function",
+	}
+	for expression, message := range invalid {
+		err := validateTriggerExpression(expression)
+		if err == nil {
+			t.Errorf("expected %q to be invalid", expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("expected error for %q to contain %q, got: %s", expression, message, err)
+		}
+	}
+}
+
+func TestAccValidateExpressionFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "expression" {
+  value = provider::zabbix::validate_expression("last(/web01/agent.ping)=0")
+}
+`,
+				Check: resource.TestCheckOutput("expression", "last(/web01/agent.ping)=0"),
+			},
+			{
+				Config: `
+output "expression" {
+  value = provider::zabbix::validate_expression("last(/web01/agent.ping=0")
+}
+`,
+				ExpectError: regexp.MustCompile(`missing closing parenthesis`),
+			},
+		},
+	})
+}
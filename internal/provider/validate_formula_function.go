@@ -0,0 +1,65 @@
+// ABOUTME: Provider-defined function that checks the syntax of the formula of a calculated item.
+// ABOUTME: Accepts references to the own host and filtered aggregate references on top of trigger syntax.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ValidateFormulaFunction{}
+
+// ValidateFormulaFunction defines the function implementation.
+type ValidateFormulaFunction struct{}
+
+// NewValidateFormulaFunction creates a new function instance.
+func NewValidateFormulaFunction() function.Function {
+	return &ValidateFormulaFunction{}
+}
+
+func (f *ValidateFormulaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_formula"
+}
+
+func (f *ValidateFormulaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check the syntax of the formula of a Zabbix calculated item",
+		Description: "Returns the formula unchanged if it is a syntactically valid Zabbix 7.0 calculated item formula, and fails otherwise. " +
+			"The rules of validate_expression apply, and in addition the host may be left out to reference an item of the same host, as in last(//key), " +
+			"and the items of aggregate functions may be filtered, as in sum(last_foreach(/*/vfs.fs.size[/,used]?[group=\"Databases\"])). " +
+			"Use trigger_expr to build function calls with correctly quoted parameters.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "formula",
+				Description: "Formula to check.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ValidateFormulaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var formula string
+
+	resp.Error = req.Arguments.Get(ctx, &formula)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateCalculatedFormula(formula); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid formula: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, formula)
+}
+
+// validateCalculatedFormula checks the structure of the formula of a calculated item.
+// Positions in errors are 1-based character offsets.
+func validateCalculatedFormula(formula string) error {
+	_, err := expressionItems(formula, true)
+	return err
+}
@@ -0,0 +1,77 @@
+// ABOUTME: Tests for the validate_formula provider function.
+// ABOUTME: Covers calculated item formulas and the syntax errors reported for invalid ones.
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestValidateCalculatedFormula(t *testing.T) {
+	valid := []string{
+		"last(//net.if.in[eth0])+last(//net.if.out[eth0])",
+		"100*last(//vfs.fs.size[/,free])/last(//vfs.fs.size[/,total])",
+		`sum(last_foreach(/*/vfs.fs.size[/,used]?[group="Databases"]))`,
+		`avg(last_foreach(/*/system.cpu.load?[group="Web" and tag="role:frontend"]))`,
+		"last(/web01/agent.ping)",
+	}
+	for _, formula := range valid {
+		if err := validateCalculatedFormula(formula); err != nil {
+			t.Errorf("expected %q to be valid, got: %s", formula, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"":                                     "expression is empty",
+		"1+1":                                  "at least one item",
+		"last(//net.if.in[eth0]":               "missing closing parenthesis",
+		"last(/web01)":                         "must have the form /host/key",
+		"last(//)":                             "has no item key",
+		`sum(last_foreach(/*/key?group="DB"))`: "must have the form ?[...]",
+		`sum(last_foreach(/*/key?[group="DB))`: "unterminated string",
+		`sum(last_foreach(/*/key?[group="DB"]x))`: "unexpected character after item key",
+	}
+	for formula, message := range invalid {
+		err := validateCalculatedFormula(formula)
+		if err == nil {
+			t.Errorf("expected %q to be invalid", formula)
+			continue
+		}
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("expected error for %q to contain %q, got: %s", formula, message, err)
+		}
+	}
+}
+
+func TestAccValidateFormulaFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "formula" {
+  value = provider::zabbix::validate_formula("last(//net.if.in[eth0])*8")
+}
+`,
+				Check: resource.TestCheckOutput("formula", "last(//net.if.in[eth0])*8"),
+			},
+			{
+				Config: `
+output "formula" {
+  value = provider::zabbix::validate_formula("last(/web01)")
+}
+`,
+				ExpectError: regexp.MustCompile(`must have the form /host/key`),
+			},
+		},
+	})
+}
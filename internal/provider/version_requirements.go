@@ -0,0 +1,73 @@
+// ABOUTME: Minimum Zabbix versions of resources and attributes, checked in Create and Update.
+// ABOUTME: Reports unsupported attributes by name instead of the API error of an older server.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// versionRequirement is the minimum Zabbix version of a resource or of one of its attributes.
+type versionRequirement struct {
+	// attribute is the top-level attribute that requires the version, or empty when the
+	// resource as a whole requires it.
+	attribute    string
+	major, minor int
+}
+
+// checkVersionRequirements returns an error for each requirement that the Zabbix server does
+// not meet, to be called from Create and Update with the configuration of the resource named
+// typeName. Attribute requirements only apply when the attribute is set in the configuration.
+// Nothing is checked when the server version cannot be detected, leaving it to Zabbix.
+func checkVersionRequirements(ctx context.Context, client ZabbixAPI, typeName string, config tfsdk.Config, requirements []versionRequirement) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(requirements) == 0 {
+		return diags
+	}
+
+	version, err := client.APIVersion(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Zabbix API version not detected, skipping version requirements", map[string]interface{}{
+			"resource": typeName,
+			"error":    err.Error(),
+		})
+		return diags
+	}
+
+	for _, requirement := range requirements {
+		if version.AtLeast(requirement.major, requirement.minor) {
+			continue
+		}
+
+		if requirement.attribute == "" {
+			diags.AddError(
+				"Unsupported Zabbix Version",
+				fmt.Sprintf("The %s resource requires Zabbix >= %d.%d, but the server runs %s.", typeName, requirement.major, requirement.minor, version),
+			)
+			continue
+		}
+
+		attrPath := path.Root(requirement.attribute)
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, attrPath, &value)...)
+		if value == nil || value.IsNull() {
+			continue
+		}
+
+		diags.AddAttributeError(
+			attrPath,
+			"Unsupported Attribute",
+			fmt.Sprintf("Attribute %s requires Zabbix >= %d.%d, but the server runs %s. Remove it from the configuration or upgrade Zabbix.", requirement.attribute, requirement.major, requirement.minor, version),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,92 @@
+// ABOUTME: Unit tests for the minimum Zabbix versions of resources and attributes.
+// ABOUTME: Covers attributes set or unset in the configuration, old and new servers and unknown versions.
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+func TestCheckVersionRequirements(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := configureTestResource(t, NewHostGroupResource(), newFakeZabbixAPI())
+	requirements := []versionRequirement{{attribute: "deletion_protection", major: 7, minor: 0}}
+
+	set := tfsdk.Config{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "Linux servers"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, true),
+	})}
+	unset := tfsdk.Config{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Linux servers"),
+	})}
+
+	tests := map[string]struct {
+		version   *zabbix.Version
+		config    tfsdk.Config
+		expectErr bool
+	}{
+		"set on older server":   {version: &zabbix.Version{Major: 6, Minor: 4, Patch: 12}, config: set, expectErr: true},
+		"unset on older server": {version: &zabbix.Version{Major: 6, Minor: 4, Patch: 12}, config: unset},
+		"set on newer server":   {version: &zabbix.Version{Major: 7, Minor: 0, Patch: 3}, config: set},
+		"unknown version":       {config: set},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeZabbixAPI()
+			client.version = test.version
+
+			diags := checkVersionRequirements(ctx, client, "zabbix_host_group", test.config, requirements)
+			if diags.HasError() != test.expectErr {
+				t.Fatalf("expected error %v, got %s", test.expectErr, diags)
+			}
+			if !test.expectErr {
+				return
+			}
+
+			err := diags.Errors()[0]
+			if !strings.Contains(err.Detail(), "requires Zabbix >= 7.0, but the server runs 6.4.12") {
+				t.Errorf("expected the error to name the required and running versions, got %q", err.Detail())
+			}
+			withPath, ok := err.(interface{ Path() path.Path })
+			if !ok || !withPath.Path().Equal(path.Root("deletion_protection")) {
+				t.Errorf("expected the error to point at deletion_protection, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplateGroupResource_UnsupportedVersion(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeZabbixAPI()
+	client.version = &zabbix.Version{Major: 6, Minor: 0, Patch: 30}
+	r := NewTemplateGroupResource()
+	schemaResp := configureTestResource(t, r, client)
+
+	values := map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Custom Templates"),
+	}
+	createResp := &fwresource.CreateResponse{State: emptyState(t, schemaResp)}
+	r.Create(ctx, fwresource.CreateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, values)},
+		Plan:   testPlan(t, schemaResp, values),
+	}, createResp)
+
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a server older than 6.2")
+	}
+	if summary := createResp.Diagnostics.Errors()[0].Summary(); summary != "Unsupported Zabbix Version" {
+		t.Errorf("expected an unsupported version error, got %q", summary)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", client.calls)
+	}
+}
@@ -0,0 +1,72 @@
+// ABOUTME: Interface of the Zabbix API operations used by the resources.
+// ABOUTME: Implemented by *zabbix.Client and by fakes in the resource unit tests, plus shared error advice.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+var _ ZabbixAPI = &zabbix.Client{}
+
+// ZabbixAPI is the part of the Zabbix API client the resources depend on.
+type ZabbixAPI interface {
+	APIVersion(ctx context.Context) (zabbix.Version, error)
+
+	CreateHost(ctx context.Context, host *zabbix.Host) (string, error)
+	CreateHosts(ctx context.Context, hosts []*zabbix.Host) ([]string, error)
+	GetHost(ctx context.Context, hostID string) (*zabbix.Host, error)
+	GetHosts(ctx context.Context, hostIDs []string) ([]zabbix.Host, error)
+	GetHostsByTemplate(ctx context.Context, templateID string) ([]zabbix.Host, error)
+	GetHostsByGroup(ctx context.Context, groupID string) ([]zabbix.Host, error)
+	GetHostByName(ctx context.Context, hostname string) (*zabbix.Host, error)
+	SearchHosts(ctx context.Context, search zabbix.HostSearch) ([]zabbix.Host, error)
+	UpdateHost(ctx context.Context, host *zabbix.Host) error
+	UpdateHosts(ctx context.Context, hosts []*zabbix.Host) error
+	DeleteHost(ctx context.Context, hostID string) error
+	DeleteHosts(ctx context.Context, hostIDs []string) error
+	MassAddHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error
+	MassRemoveHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error
+	MassUpdateHostTemplates(ctx context.Context, hostIDs []string, templateIDs []string, clearTemplateIDs []string) error
+
+	CreateHostGroup(ctx context.Context, name string) (string, error)
+	GetHostGroup(ctx context.Context, groupID string) (*zabbix.HostGroup, error)
+	HostGroupIDByName(ctx context.Context, name string) (string, error)
+	SearchHostGroups(ctx context.Context, pattern string) ([]zabbix.HostGroup, error)
+	UpdateHostGroup(ctx context.Context, groupID, name string) error
+	DeleteHostGroup(ctx context.Context, groupID string) error
+
+	CreateTemplate(ctx context.Context, template *zabbix.Template) (string, error)
+	GetTemplate(ctx context.Context, templateID string) (*zabbix.Template, error)
+	GetTemplateWithExport(ctx context.Context, templateID, format string) (*zabbix.TemplateWithExport, error)
+	TemplateIDByHost(ctx context.Context, host string) (string, error)
+	UpdateTemplate(ctx context.Context, template *zabbix.Template) error
+	DeleteTemplate(ctx context.Context, templateID string) error
+	ImportConfiguration(ctx context.Context, format, source string, deleteMissing bool) error
+	CompareConfiguration(ctx context.Context, format, source string, deleteMissing bool) (bool, error)
+	ExportConfiguration(ctx context.Context, format string, templateIDs []string) (string, error)
+
+	CreateTemplateGroup(ctx context.Context, name string) (string, error)
+	GetTemplateGroup(ctx context.Context, groupID string) (*zabbix.TemplateGroup, error)
+	TemplateGroupIDByName(ctx context.Context, name string) (string, error)
+	UpdateTemplateGroup(ctx context.Context, groupID, name string) error
+	DeleteTemplateGroup(ctx context.Context, groupID string) error
+}
+
+// alreadyExistsHint returns advice to append to the error of creating an object of the
+// named kind when Zabbix reports that it already exists, and an empty string otherwise.
+// adoptable tells whether the resource supports adopt_existing.
+func alreadyExistsHint(err error, kind string, adoptable bool) string {
+	if !errors.Is(err, zabbix.ErrAlreadyExists) {
+		return ""
+	}
+
+	if adoptable {
+		return fmt.Sprintf(". To manage the existing %s with Terraform, import it or set adopt_existing = true", kind)
+	}
+	return fmt.Sprintf(". To manage the existing %s with Terraform, import it instead of creating it", kind)
+}
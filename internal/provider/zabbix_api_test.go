@@ -0,0 +1,683 @@
+// ABOUTME: In-memory fake of the ZabbixAPI interface and helpers for resource unit tests.
+// ABOUTME: Lets resource CRUD logic run without a Zabbix server or Terraform binary.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/p3l1/terraform-provider-zabbix/internal/zabbix"
+)
+
+// fakeZabbixAPI keeps host groups, template groups, templates and hosts in memory. Methods that are not overridden panic through
+// the nil embedded interface, so a test fails loudly when a resource calls an unexpected method.
+type fakeZabbixAPI struct {
+	ZabbixAPI
+
+	hostGroups     map[string]*zabbix.HostGroup
+	templateGroups map[string]*zabbix.TemplateGroup
+	templates      map[string]*zabbix.Template
+	hosts          map[string]*zabbix.Host
+	nextID         int
+	calls          []string
+	// deadlines are the context deadlines of the calls, by method name.
+	deadlines map[string]time.Time
+	// version is the Zabbix version reported by APIVersion, which fails when it is not set.
+	version *zabbix.Version
+	// errs are returned by the methods of the given name instead of calling them.
+	errs map[string]error
+}
+
+func newFakeZabbixAPI() *fakeZabbixAPI {
+	return &fakeZabbixAPI{
+		hostGroups:     map[string]*zabbix.HostGroup{},
+		templateGroups: map[string]*zabbix.TemplateGroup{},
+		templates:      map[string]*zabbix.Template{},
+		hosts:          map[string]*zabbix.Host{},
+		nextID:         100,
+		errs:           map[string]error{},
+		deadlines:      map[string]time.Time{},
+	}
+}
+
+// call records a call of the named method with its deadline and returns the error configured for it.
+func (f *fakeZabbixAPI) call(ctx context.Context, method string) error {
+	f.calls = append(f.calls, method)
+	if deadline, ok := ctx.Deadline(); ok {
+		f.deadlines[method] = deadline
+	}
+	return f.errs[method]
+}
+
+// APIVersion is not recorded in calls, as resources may ask for the version at any time.
+func (f *fakeZabbixAPI) APIVersion(ctx context.Context) (zabbix.Version, error) {
+	if f.version == nil {
+		return zabbix.Version{}, fmt.Errorf("version not detected")
+	}
+	return *f.version, nil
+}
+
+func (f *fakeZabbixAPI) CreateHostGroup(ctx context.Context, name string) (string, error) {
+	if err := f.call(ctx, "CreateHostGroup"); err != nil {
+		return "", err
+	}
+	f.nextID++
+	id := fmt.Sprint(f.nextID)
+	f.hostGroups[id] = &zabbix.HostGroup{GroupID: id, Name: name, UUID: "uuid-" + id}
+	return id, nil
+}
+
+func (f *fakeZabbixAPI) GetHostGroup(ctx context.Context, groupID string) (*zabbix.HostGroup, error) {
+	if err := f.call(ctx, "GetHostGroup"); err != nil {
+		return nil, err
+	}
+	group, ok := f.hostGroups[groupID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *group
+	return &copied, nil
+}
+
+func (f *fakeZabbixAPI) HostGroupIDByName(ctx context.Context, name string) (string, error) {
+	if err := f.call(ctx, "HostGroupIDByName"); err != nil {
+		return "", err
+	}
+	for id, group := range f.hostGroups {
+		if group.Name == name {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// SearchHostGroups matches the pattern like path.Match, which suffices for patterns without /.
+func (f *fakeZabbixAPI) SearchHostGroups(ctx context.Context, pattern string) ([]zabbix.HostGroup, error) {
+	if err := f.call(ctx, "SearchHostGroups"); err != nil {
+		return nil, err
+	}
+	var groups []zabbix.HostGroup
+	for _, id := range sortedIDs(f.hostGroups) {
+		group := f.hostGroups[id]
+		if matched, _ := path.Match(pattern, group.Name); pattern == "" || matched {
+			groups = append(groups, *group)
+		}
+	}
+	return groups, nil
+}
+
+func (f *fakeZabbixAPI) UpdateHostGroup(ctx context.Context, groupID, name string) error {
+	if err := f.call(ctx, "UpdateHostGroup"); err != nil {
+		return err
+	}
+	group, ok := f.hostGroups[groupID]
+	if !ok {
+		return fmt.Errorf("host group %s does not exist", groupID)
+	}
+	group.Name = name
+	return nil
+}
+
+// DeleteHostGroup fails like Zabbix when the group is the only group of a host.
+func (f *fakeZabbixAPI) DeleteHostGroup(ctx context.Context, groupID string) error {
+	if err := f.call(ctx, "DeleteHostGroup"); err != nil {
+		return err
+	}
+	for _, id := range sortedIDs(f.hosts) {
+		if groups := f.hosts[id].Groups; len(groups) == 1 && groups[0].GroupID == groupID {
+			return fmt.Errorf("host %q cannot be without host group", f.hosts[id].Host)
+		}
+	}
+	delete(f.hostGroups, groupID)
+	return nil
+}
+
+func (f *fakeZabbixAPI) GetHostsByGroup(ctx context.Context, groupID string) ([]zabbix.Host, error) {
+	if err := f.call(ctx, "GetHostsByGroup"); err != nil {
+		return nil, err
+	}
+	var hosts []zabbix.Host
+	for _, id := range sortedIDs(f.hosts) {
+		host := f.readHost(id)
+		if slices.ContainsFunc(host.Groups, func(group zabbix.HostGroupID) bool { return group.GroupID == groupID }) {
+			hosts = append(hosts, *host)
+		}
+	}
+	return hosts, nil
+}
+
+// newID returns the next object ID.
+func (f *fakeZabbixAPI) newID() string {
+	f.nextID++
+	return fmt.Sprint(f.nextID)
+}
+
+// alreadyExists returns the error Zabbix reports when creating an object whose name is taken.
+func alreadyExists(method, kind, name string) error {
+	return &zabbix.APIError{
+		Method: method,
+		Err:    &zabbix.Error{Code: -32602, Message: "Invalid params.", Data: fmt.Sprintf("%s %q already exists.", kind, name)},
+	}
+}
+
+func (f *fakeZabbixAPI) CreateTemplateGroup(ctx context.Context, name string) (string, error) {
+	if err := f.call(ctx, "CreateTemplateGroup"); err != nil {
+		return "", err
+	}
+	for _, group := range f.templateGroups {
+		if group.Name == name {
+			return "", alreadyExists("templategroup.create", "Template group", name)
+		}
+	}
+	id := f.newID()
+	f.templateGroups[id] = &zabbix.TemplateGroup{GroupID: id, Name: name, UUID: "uuid-" + id}
+	return id, nil
+}
+
+func (f *fakeZabbixAPI) GetTemplateGroup(ctx context.Context, groupID string) (*zabbix.TemplateGroup, error) {
+	if err := f.call(ctx, "GetTemplateGroup"); err != nil {
+		return nil, err
+	}
+	group, ok := f.templateGroups[groupID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *group
+	return &copied, nil
+}
+
+func (f *fakeZabbixAPI) TemplateGroupIDByName(ctx context.Context, name string) (string, error) {
+	if err := f.call(ctx, "TemplateGroupIDByName"); err != nil {
+		return "", err
+	}
+	for id, group := range f.templateGroups {
+		if group.Name == name {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+func (f *fakeZabbixAPI) UpdateTemplateGroup(ctx context.Context, groupID, name string) error {
+	if err := f.call(ctx, "UpdateTemplateGroup"); err != nil {
+		return err
+	}
+	group, ok := f.templateGroups[groupID]
+	if !ok {
+		return fmt.Errorf("template group %s does not exist", groupID)
+	}
+	group.Name = name
+	return nil
+}
+
+func (f *fakeZabbixAPI) DeleteTemplateGroup(ctx context.Context, groupID string) error {
+	if err := f.call(ctx, "DeleteTemplateGroup"); err != nil {
+		return err
+	}
+	delete(f.templateGroups, groupID)
+	return nil
+}
+
+func (f *fakeZabbixAPI) CreateTemplate(ctx context.Context, template *zabbix.Template) (string, error) {
+	if err := f.call(ctx, "CreateTemplate"); err != nil {
+		return "", err
+	}
+	for _, existing := range f.templates {
+		if existing.Host == template.Host {
+			return "", alreadyExists("template.create", "Template", template.Host)
+		}
+	}
+	id := f.newID()
+	created := *template
+	created.TemplateID = id
+	created.UUID = "uuid-" + id
+	if created.Name == "" {
+		created.Name = created.Host
+	}
+	f.templates[id] = &created
+	return id, nil
+}
+
+func (f *fakeZabbixAPI) GetTemplate(ctx context.Context, templateID string) (*zabbix.Template, error) {
+	if err := f.call(ctx, "GetTemplate"); err != nil {
+		return nil, err
+	}
+	template, ok := f.templates[templateID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *template
+	return &copied, nil
+}
+
+func (f *fakeZabbixAPI) GetTemplateWithExport(ctx context.Context, templateID, format string) (*zabbix.TemplateWithExport, error) {
+	if err := f.call(ctx, "GetTemplateWithExport"); err != nil {
+		return nil, err
+	}
+	template, ok := f.templates[templateID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *template
+	result := &zabbix.TemplateWithExport{Template: &copied}
+	if err := f.errs["ExportConfiguration"]; err != nil {
+		result.ExportErr = err
+	} else {
+		result.Exported = fakeExport(template)
+	}
+	return result, nil
+}
+
+func (f *fakeZabbixAPI) TemplateIDByHost(ctx context.Context, host string) (string, error) {
+	if err := f.call(ctx, "TemplateIDByHost"); err != nil {
+		return "", err
+	}
+	for id, template := range f.templates {
+		if template.Host == host {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+func (f *fakeZabbixAPI) UpdateTemplate(ctx context.Context, template *zabbix.Template) error {
+	if err := f.call(ctx, "UpdateTemplate"); err != nil {
+		return err
+	}
+	existing, ok := f.templates[template.TemplateID]
+	if !ok {
+		return fmt.Errorf("template %s does not exist", template.TemplateID)
+	}
+	updated := *template
+	updated.UUID = existing.UUID
+	f.templates[template.TemplateID] = &updated
+	return nil
+}
+
+func (f *fakeZabbixAPI) DeleteTemplate(ctx context.Context, templateID string) error {
+	if err := f.call(ctx, "DeleteTemplate"); err != nil {
+		return err
+	}
+	delete(f.templates, templateID)
+	return nil
+}
+
+func (f *fakeZabbixAPI) ExportConfiguration(ctx context.Context, format string, templateIDs []string) (string, error) {
+	if err := f.call(ctx, "ExportConfiguration"); err != nil {
+		return "", err
+	}
+	var exported string
+	for _, id := range templateIDs {
+		if template, ok := f.templates[id]; ok {
+			exported += fakeExport(template)
+		}
+	}
+	return exported, nil
+}
+
+// fakeExport returns a minimal YAML export of the template.
+func fakeExport(template *zabbix.Template) string {
+	return fmt.Sprintf("zabbix_export:\n  templates:\n    - template: %s\n      name: %s\n", template.Host, template.Name)
+}
+
+// ImportConfiguration creates the templates defined by the source that do not exist yet.
+func (f *fakeZabbixAPI) ImportConfiguration(ctx context.Context, format, source string, deleteMissing bool) error {
+	if err := f.call(ctx, "ImportConfiguration"); err != nil {
+		return err
+	}
+	hosts, err := extractTemplateNames(source, format)
+	if err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		if slices.ContainsFunc(sortedIDs(f.templates), func(id string) bool { return f.templates[id].Host == host }) {
+			continue
+		}
+		id := f.newID()
+		f.templates[id] = &zabbix.Template{TemplateID: id, Host: host, Name: host, UUID: "uuid-" + id}
+	}
+	return nil
+}
+
+// CompareConfiguration reports the source as unchanged.
+func (f *fakeZabbixAPI) CompareConfiguration(ctx context.Context, format, source string, deleteMissing bool) (bool, error) {
+	return false, f.call(ctx, "CompareConfiguration")
+}
+
+func (f *fakeZabbixAPI) GetHostsByTemplate(ctx context.Context, templateID string) ([]zabbix.Host, error) {
+	if err := f.call(ctx, "GetHostsByTemplate"); err != nil {
+		return nil, err
+	}
+	var hosts []zabbix.Host
+	for _, id := range sortedIDs(f.hosts) {
+		host := f.readHost(id)
+		for _, parent := range host.ParentTemplates {
+			if parent.TemplateID == templateID {
+				hosts = append(hosts, *host)
+				break
+			}
+		}
+	}
+	return hosts, nil
+}
+
+func (f *fakeZabbixAPI) MassUpdateHostTemplates(ctx context.Context, hostIDs []string, templateIDs []string, clearTemplateIDs []string) error {
+	if err := f.call(ctx, "MassUpdateHostTemplates"); err != nil {
+		return err
+	}
+	for _, hostID := range hostIDs {
+		host, ok := f.hosts[hostID]
+		if !ok {
+			return fmt.Errorf("host %s does not exist", hostID)
+		}
+		host.Templates = nil
+		for _, id := range templateIDs {
+			host.Templates = append(host.Templates, zabbix.TemplateID{TemplateID: id})
+		}
+	}
+	return nil
+}
+
+// storeHost stores a copy of the host, assigning IDs to the host and its new interfaces.
+func (f *fakeZabbixAPI) storeHost(host *zabbix.Host) string {
+	stored := *host
+	if stored.HostID == "" {
+		stored.HostID = f.newID()
+	}
+	if stored.Name == "" {
+		stored.Name = stored.Host
+	}
+	stored.Interfaces = append([]zabbix.HostInterface(nil), host.Interfaces...)
+	for i := range stored.Interfaces {
+		if stored.Interfaces[i].InterfaceID == "" {
+			stored.Interfaces[i].InterfaceID = f.newID()
+		}
+	}
+	stored.TemplatesClear = nil
+	f.hosts[stored.HostID] = &stored
+	return stored.HostID
+}
+
+// readHost returns a copy of the host as host.get returns it, with its linked templates.
+func (f *fakeZabbixAPI) readHost(hostID string) *zabbix.Host {
+	host, ok := f.hosts[hostID]
+	if !ok {
+		return nil
+	}
+	copied := *host
+	copied.Templates = nil
+	copied.ParentTemplates = nil
+	for _, linked := range host.Templates {
+		parent := zabbix.ParentTemplate{TemplateID: linked.TemplateID}
+		if template, ok := f.templates[linked.TemplateID]; ok {
+			parent.Host, parent.Name = template.Host, template.Name
+		}
+		copied.ParentTemplates = append(copied.ParentTemplates, parent)
+	}
+	return &copied
+}
+
+func (f *fakeZabbixAPI) hostNameTaken(host *zabbix.Host) bool {
+	for id, existing := range f.hosts {
+		if id != host.HostID && existing.Host == host.Host {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeZabbixAPI) CreateHost(ctx context.Context, host *zabbix.Host) (string, error) {
+	if err := f.call(ctx, "CreateHost"); err != nil {
+		return "", err
+	}
+	if f.hostNameTaken(host) {
+		return "", alreadyExists("host.create", "Host", host.Host)
+	}
+	return f.storeHost(host), nil
+}
+
+func (f *fakeZabbixAPI) CreateHosts(ctx context.Context, hosts []*zabbix.Host) ([]string, error) {
+	if err := f.call(ctx, "CreateHosts"); err != nil {
+		return nil, err
+	}
+	for _, host := range hosts {
+		if f.hostNameTaken(host) {
+			return nil, alreadyExists("host.create", "Host", host.Host)
+		}
+	}
+	ids := make([]string, len(hosts))
+	for i, host := range hosts {
+		ids[i] = f.storeHost(host)
+	}
+	return ids, nil
+}
+
+func (f *fakeZabbixAPI) GetHost(ctx context.Context, hostID string) (*zabbix.Host, error) {
+	if err := f.call(ctx, "GetHost"); err != nil {
+		return nil, err
+	}
+	return f.readHost(hostID), nil
+}
+
+func (f *fakeZabbixAPI) GetHosts(ctx context.Context, hostIDs []string) ([]zabbix.Host, error) {
+	if err := f.call(ctx, "GetHosts"); err != nil {
+		return nil, err
+	}
+	var hosts []zabbix.Host
+	for _, id := range hostIDs {
+		if host := f.readHost(id); host != nil {
+			hosts = append(hosts, *host)
+		}
+	}
+	return hosts, nil
+}
+
+func (f *fakeZabbixAPI) GetHostByName(ctx context.Context, hostname string) (*zabbix.Host, error) {
+	if err := f.call(ctx, "GetHostByName"); err != nil {
+		return nil, err
+	}
+	for id, host := range f.hosts {
+		if host.Host == hostname {
+			return f.readHost(id), nil
+		}
+	}
+	return nil, nil
+}
+
+// SearchHosts filters by group and by technical name, returning only the fields host.get
+// returns for a search. The pattern is matched like path.Match.
+func (f *fakeZabbixAPI) SearchHosts(ctx context.Context, search zabbix.HostSearch) ([]zabbix.Host, error) {
+	if err := f.call(ctx, "SearchHosts"); err != nil {
+		return nil, err
+	}
+	var hosts []zabbix.Host
+	for _, id := range sortedIDs(f.hosts) {
+		host := f.hosts[id]
+		if matched, _ := path.Match(search.Pattern, host.Host); search.Pattern != "" && !matched {
+			continue
+		}
+		if len(search.GroupIDs) > 0 && !slices.ContainsFunc(host.Groups, func(group zabbix.HostGroupID) bool {
+			return slices.Contains(search.GroupIDs, group.GroupID)
+		}) {
+			continue
+		}
+		hosts = append(hosts, zabbix.Host{HostID: host.HostID, Host: host.Host, Name: host.Name, Status: host.Status})
+	}
+	return hosts, nil
+}
+
+// updateHost applies the fields set in host.update parameters to the stored host.
+func (f *fakeZabbixAPI) updateHost(host *zabbix.Host) error {
+	existing, ok := f.hosts[host.HostID]
+	if !ok {
+		return fmt.Errorf("host %s does not exist", host.HostID)
+	}
+	updated := *existing
+	if host.Host != "" {
+		updated.Host = host.Host
+	}
+	if host.Name != "" {
+		updated.Name = host.Name
+	}
+	if host.Groups != nil {
+		updated.Groups = host.Groups
+	}
+	if host.Templates != nil {
+		updated.Templates = host.Templates
+	}
+	if host.Interfaces != nil {
+		updated.Interfaces = host.Interfaces
+	}
+	updated.Tags = host.Tags
+	if !host.KeepStatus {
+		updated.Status = host.Status
+	}
+	updated.TLSConnect, updated.TLSAccept = host.TLSConnect, host.TLSAccept
+	f.storeHost(&updated)
+	return nil
+}
+
+func (f *fakeZabbixAPI) UpdateHost(ctx context.Context, host *zabbix.Host) error {
+	if err := f.call(ctx, "UpdateHost"); err != nil {
+		return err
+	}
+	return f.updateHost(host)
+}
+
+func (f *fakeZabbixAPI) UpdateHosts(ctx context.Context, hosts []*zabbix.Host) error {
+	if err := f.call(ctx, "UpdateHosts"); err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		if err := f.updateHost(host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeZabbixAPI) DeleteHost(ctx context.Context, hostID string) error {
+	if err := f.call(ctx, "DeleteHost"); err != nil {
+		return err
+	}
+	delete(f.hosts, hostID)
+	return nil
+}
+
+func (f *fakeZabbixAPI) DeleteHosts(ctx context.Context, hostIDs []string) error {
+	if err := f.call(ctx, "DeleteHosts"); err != nil {
+		return err
+	}
+	for _, id := range hostIDs {
+		delete(f.hosts, id)
+	}
+	return nil
+}
+
+func (f *fakeZabbixAPI) MassAddHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error {
+	if err := f.call(ctx, "MassAddHostGroups"); err != nil {
+		return err
+	}
+	for _, hostID := range hostIDs {
+		host, ok := f.hosts[hostID]
+		if !ok {
+			return fmt.Errorf("host %s does not exist", hostID)
+		}
+		for _, id := range groupIDs {
+			host.Groups = append(host.Groups, zabbix.HostGroupID{GroupID: id})
+		}
+	}
+	return nil
+}
+
+func (f *fakeZabbixAPI) MassRemoveHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error {
+	if err := f.call(ctx, "MassRemoveHostGroups"); err != nil {
+		return err
+	}
+	for _, hostID := range hostIDs {
+		host, ok := f.hosts[hostID]
+		if !ok {
+			return fmt.Errorf("host %s does not exist", hostID)
+		}
+		var kept []zabbix.HostGroupID
+		for _, group := range host.Groups {
+			if !slices.Contains(groupIDs, group.GroupID) {
+				kept = append(kept, group)
+			}
+		}
+		host.Groups = kept
+	}
+	return nil
+}
+
+// sortedIDs returns the IDs of the objects in a stable order.
+func sortedIDs[T any](objects map[string]T) []string {
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// configureTestResource configures a resource with the fake client and returns its schema.
+func configureTestResource(t *testing.T, r resource.Resource, client ZabbixAPI) resource.SchemaResponse {
+	t.Helper()
+
+	configureResp := &resource.ConfigureResponse{}
+	r.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ProviderData{Client: client},
+	}, configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure error: %s", configureResp.Diagnostics.Errors())
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	return schemaResp
+}
+
+// testObjectValue builds a value of the schema with the given attributes, leaving all others null.
+func testObjectValue(t *testing.T, schemaResp resource.SchemaResponse, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the resource schema to be an object")
+	}
+
+	attrValues := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range values {
+		attrValues[name] = value
+	}
+
+	return tftypes.NewValue(objectType, attrValues)
+}
+
+// testPlan builds a plan of the schema with the given attributes.
+func testPlan(t *testing.T, schemaResp resource.SchemaResponse, values map[string]tftypes.Value) tfsdk.Plan {
+	t.Helper()
+	return tfsdk.Plan{Schema: schemaResp.Schema, Raw: testObjectValue(t, schemaResp, values)}
+}
+
+// emptyState returns a null state of the schema, as passed to Create.
+func emptyState(t *testing.T, schemaResp resource.SchemaResponse) tfsdk.State {
+	t.Helper()
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+}
@@ -0,0 +1,47 @@
+// ABOUTME: Schema validator for Go durations such as 90s, 5m or 1h30m.
+// ABOUTME: Checks provider-side durations, such as operation timeouts, at plan time.
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates positive Go durations.
+type durationValidator struct{}
+
+// Duration returns a validator accepting positive durations in the format of
+// time.ParseDuration, such as 90s, 5m or 1h30m. Unlike Zabbix time values, these
+// durations are used by the provider itself and do not accept user macros.
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 90s, 5m or 1h30m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
@@ -0,0 +1,28 @@
+// ABOUTME: Unit tests for the Go duration validator.
+// ABOUTME: Covers accepted and rejected durations and null or unknown values.
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDuration(t *testing.T) {
+	for _, value := range []string{"1s", "90s", "5m", "1h30m", "250ms"} {
+		if !validate(Duration(), types.StringValue(value)) {
+			t.Errorf("expected %q to be valid", value)
+		}
+	}
+
+	for _, value := range []string{"", "0", "0s", "-5m", "5", "1d", "{$TIMEOUT}"} {
+		if validate(Duration(), types.StringValue(value)) {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+
+	if !validate(Duration(), types.StringNull()) || !validate(Duration(), types.StringUnknown()) {
+		t.Error("expected null and unknown values to be valid")
+	}
+}
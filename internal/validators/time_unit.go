@@ -0,0 +1,137 @@
+// ABOUTME: Schema validators for Zabbix time values with s, m, h, d or w suffixes.
+// ABOUTME: Checks history, trends, lifetime and similar durations against their limits at plan time.
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// timeUnits are the Zabbix time suffixes and their length in seconds, from the largest.
+var timeUnits = []struct {
+	suffix  byte
+	seconds int64
+}{
+	{'w', 7 * 24 * 60 * 60},
+	{'d', 24 * 60 * 60},
+	{'h', 60 * 60},
+	{'m', 60},
+	{'s', 1},
+}
+
+// userMacro matches a Zabbix user macro with optional context, such as {$DELAY} or
+// {$DELAY:"fast"}. Values that are user macros are resolved by Zabbix and not validated.
+var userMacro = regexp.MustCompile(`^\{\$[A-Z0-9_.]+(:.*)?\}$`)
+
+// ParseTimeUnit returns the number of seconds of a time value with an optional suffix.
+func ParseTimeUnit(value string) (int64, error) {
+	number, multiplier := value, int64(1)
+	if value != "" {
+		for _, unit := range timeUnits {
+			if value[len(value)-1] == unit.suffix {
+				number, multiplier = value[:len(value)-1], unit.seconds
+				break
+			}
+		}
+	}
+
+	// ParseUint rejects signs, so only plain digits are accepted
+	n, err := strconv.ParseUint(number, 10, 63)
+	if err != nil || int64(n) > (1<<63-1)/multiplier {
+		return 0, fmt.Errorf("invalid time %q: must be a non-negative number with an optional s, m, h, d or w suffix", value)
+	}
+
+	return int64(n) * multiplier, nil
+}
+
+// FormatTimeUnit formats seconds with the largest suffix that represents them exactly.
+func FormatTimeUnit(seconds int64) string {
+	for _, unit := range timeUnits {
+		if seconds != 0 && seconds%unit.seconds == 0 {
+			return strconv.FormatInt(seconds/unit.seconds, 10) + string(unit.suffix)
+		}
+	}
+
+	return "0s"
+}
+
+var _ validator.String = timeUnitValidator{}
+
+// timeUnitValidator validates time values between min and max seconds, where a max of
+// zero means no upper limit. Zero is accepted regardless of min when allowZero is set.
+type timeUnitValidator struct {
+	min, max  int64
+	allowZero bool
+}
+
+// TimeUnit returns a validator accepting any Zabbix time value, such as 0, 30, 30s, 5m, 2h,
+// 1d or 1w, or a user macro.
+func TimeUnit() validator.String {
+	return timeUnitValidator{}
+}
+
+// TimeUnitBetween returns a validator accepting Zabbix time values from min to max, or a
+// user macro.
+func TimeUnitBetween(min, max time.Duration) validator.String {
+	return timeUnitValidator{min: int64(min.Seconds()), max: int64(max.Seconds())}
+}
+
+// ZeroOrTimeUnitBetween returns a validator accepting Zabbix time values from min to max,
+// zero, or a user macro. It suits values where zero disables a feature, such as history
+// and trends storage periods.
+func ZeroOrTimeUnitBetween(min, max time.Duration) validator.String {
+	return timeUnitValidator{min: int64(min.Seconds()), max: int64(max.Seconds()), allowZero: true}
+}
+
+func (v timeUnitValidator) Description(ctx context.Context) string {
+	var limits string
+	switch {
+	case v.max > 0:
+		limits = fmt.Sprintf(" from %s to %s", FormatTimeUnit(v.min), FormatTimeUnit(v.max))
+	case v.min > 0:
+		limits = fmt.Sprintf(" of at least %s", FormatTimeUnit(v.min))
+	}
+	if v.allowZero && limits != "" {
+		limits += " or 0"
+	}
+
+	return "value must be a time with an optional s, m, h, d or w suffix" + limits + ", or a user macro"
+}
+
+func (v timeUnitValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeUnitValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if userMacro.MatchString(value) {
+		return
+	}
+
+	seconds, err := ParseTimeUnit(value)
+	if err != nil || !v.accepts(seconds) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Time Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// accepts reports whether the number of seconds is within the limits of the validator.
+func (v timeUnitValidator) accepts(seconds int64) bool {
+	if seconds == 0 && v.allowZero {
+		return true
+	}
+	return seconds >= v.min && (v.max == 0 || seconds <= v.max)
+}
@@ -0,0 +1,175 @@
+// ABOUTME: Unit tests for the Zabbix time value and update interval validators.
+// ABOUTME: Covers parsing and formatting of time values, limits, user macros and custom intervals.
+
+package validators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validate runs a string validator on the value and reports whether it passed.
+func validate(v validator.String, value types.String) bool {
+	resp := &validator.StringResponse{}
+	v.ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("test"),
+		ConfigValue: value,
+	}, resp)
+	return !resp.Diagnostics.HasError()
+}
+
+func TestParseTimeUnit(t *testing.T) {
+	tests := map[string]int64{
+		"0":   0,
+		"30":  30,
+		"30s": 30,
+		"5m":  300,
+		"2h":  7200,
+		"1d":  86400,
+		"1w":  604800,
+	}
+
+	for value, expected := range tests {
+		seconds, err := ParseTimeUnit(value)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", value, err)
+			continue
+		}
+		if seconds != expected {
+			t.Errorf("expected %q to be %d seconds, got %d", value, expected, seconds)
+		}
+	}
+
+	for _, value := range []string{"", "s", "-5m", "+5m", "1.5h", "5y", "{$DELAY}", "1h30m", "99999999999999999w"} {
+		if _, err := ParseTimeUnit(value); err == nil {
+			t.Errorf("expected error for %q", value)
+		}
+	}
+}
+
+func TestFormatTimeUnit(t *testing.T) {
+	tests := map[int64]string{
+		0:       "0s",
+		45:      "45s",
+		90:      "90s",
+		3600:    "1h",
+		5400:    "90m",
+		86400:   "1d",
+		1209600: "2w",
+	}
+
+	for seconds, expected := range tests {
+		if value := FormatTimeUnit(seconds); value != expected {
+			t.Errorf("expected %d seconds to be %s, got %s", seconds, expected, value)
+		}
+	}
+}
+
+func TestTimeUnitValidators(t *testing.T) {
+	day := 24 * time.Hour
+	tests := map[string]struct {
+		validator validator.String
+		valid     []string
+		invalid   []string
+	}{
+		"any": {
+			validator: TimeUnit(),
+			valid:     []string{"0", "30", "30s", "1m", "2h", "1d", "1w", "{$HISTORY}", `{$HISTORY:"db"}`},
+			invalid:   []string{"", "1y", "-1h", "1h30m", "{$history}", "{HISTORY}"},
+		},
+		"between": {
+			validator: TimeUnitBetween(time.Hour, 25*365*day),
+			valid:     []string{"1h", "3600", "90d", "9125d", "{$LIFETIME}"},
+			invalid:   []string{"0", "59m", "9126d"},
+		},
+		"zero or between": {
+			validator: ZeroOrTimeUnitBetween(day, 25*365*day),
+			valid:     []string{"0", "0s", "1d", "365d"},
+			invalid:   []string{"1h", "23h", "9126d"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, value := range tc.valid {
+				if !validate(tc.validator, types.StringValue(value)) {
+					t.Errorf("expected %q to be valid", value)
+				}
+			}
+			for _, value := range tc.invalid {
+				if validate(tc.validator, types.StringValue(value)) {
+					t.Errorf("expected %q to be invalid", value)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeUnitValidator_NullAndUnknown(t *testing.T) {
+	v := TimeUnitBetween(time.Hour, 24*time.Hour)
+	if !validate(v, types.StringNull()) || !validate(v, types.StringUnknown()) {
+		t.Error("expected null and unknown values to be valid")
+	}
+}
+
+func TestTimeUnitValidator_Description(t *testing.T) {
+	tests := map[string]struct {
+		validator validator.String
+		expected  string
+	}{
+		"any":             {TimeUnit(), "value must be a time with an optional s, m, h, d or w suffix, or a user macro"},
+		"between":         {TimeUnitBetween(time.Hour, 24*time.Hour), "value must be a time with an optional s, m, h, d or w suffix from 1h to 1d, or a user macro"},
+		"zero or between": {ZeroOrTimeUnitBetween(24*time.Hour, 0), "value must be a time with an optional s, m, h, d or w suffix of at least 1d or 0, or a user macro"},
+	}
+
+	for name, tc := range tests {
+		if description := tc.validator.Description(context.Background()); description != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, description)
+		}
+	}
+}
+
+func TestUpdateInterval(t *testing.T) {
+	valid := []string{
+		"30s",
+		"1d",
+		"{$DELAY}",
+		"1m;50s/1-5,09:00-18:00",
+		"0;10s/1-7,00:00-24:00",
+		"5m;wd1-5h9",
+		"5m;md1-31/2;h0-23/4m30",
+		"5m;h/2",
+		"5m;{$SCHEDULE}",
+		"{$DELAY};{$FLEX}/1-5,09:00-18:00",
+	}
+	invalid := []string{
+		"",
+		"0",
+		"2d",
+		"1m;",
+		"1m;50s/8,09:00-18:00",
+		"1m;50s/5-1,09:00-18:00",
+		"1m;50s/1-5,18:00-09:00",
+		"1m;50s/1-5,09:00-24:30",
+		"1m;2d/1-5,09:00-18:00",
+		"1m;wd1-5x",
+		"1m;hwd1-5",
+	}
+
+	v := UpdateInterval()
+	for _, value := range valid {
+		if !validate(v, types.StringValue(value)) {
+			t.Errorf("expected %q to be valid", value)
+		}
+	}
+	for _, value := range invalid {
+		if validate(v, types.StringValue(value)) {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// ABOUTME: Schema validators rejecting duplicate IDs and tags within a single attribute.
+// ABOUTME: Catches duplicates that sets do not remove at plan time instead of at apply time.
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ validator.Set = uniqueIDsValidator{}
+	_ validator.Set = uniqueTagsValidator{}
+)
+
+// uniqueIDsValidator validates that a set of Zabbix IDs has no duplicates.
+type uniqueIDsValidator struct{}
+
+// UniqueIDs returns a validator rejecting sets of Zabbix IDs that refer to the same object
+// more than once. Sets only remove identical strings, but Zabbix compares IDs as numbers,
+// so 10 and 010 are the same host group and the API rejects the duplicate at apply time.
+func UniqueIDs() validator.Set {
+	return uniqueIDsValidator{}
+}
+
+func (v uniqueIDsValidator) Description(ctx context.Context) string {
+	return "values must refer to different objects"
+}
+
+func (v uniqueIDsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueIDsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[string]string)
+	for _, element := range req.ConfigValue.Elements() {
+		id, ok := element.(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			continue
+		}
+
+		key := normalizeID(id.ValueString())
+		if first, found := seen[key]; found {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Duplicate ID",
+				fmt.Sprintf("Attribute %s %s, but %q and %q are the same ID.", req.Path, v.Description(ctx), first, id.ValueString()),
+			)
+			continue
+		}
+		seen[key] = id.ValueString()
+	}
+}
+
+// normalizeID returns the canonical form of a numeric ID without leading zeros. Other
+// values are returned unchanged and left for the API to reject.
+func normalizeID(id string) string {
+	if id == "" || strings.Trim(id, "0123456789") != "" {
+		return id
+	}
+	if trimmed := strings.TrimLeft(id, "0"); trimmed != "" {
+		return trimmed
+	}
+	return "0"
+}
+
+// uniqueTagsValidator validates that a set of tags has no duplicate tag and value pairs.
+type uniqueTagsValidator struct{}
+
+// UniqueTags returns a validator rejecting sets of tag objects, with tag and value
+// attributes, that contain the same pair twice. An omitted value is the empty value, so
+// { tag = "env" } and { tag = "env", value = "" } are different set elements in the
+// configuration but the same tag in Zabbix, which rejects the duplicate at apply time.
+func UniqueTags() validator.Set {
+	return uniqueTagsValidator{}
+}
+
+func (v uniqueTagsValidator) Description(ctx context.Context) string {
+	return "tag and value pairs must be unique"
+}
+
+func (v uniqueTagsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueTagsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[[2]string]bool)
+	for _, element := range req.ConfigValue.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+
+		tag, tagOK := object.Attributes()["tag"].(types.String)
+		value, valueOK := object.Attributes()["value"].(types.String)
+		if !tagOK || !valueOK || tag.IsNull() || tag.IsUnknown() || value.IsUnknown() {
+			continue
+		}
+
+		pair := [2]string{tag.ValueString(), value.ValueString()}
+		if seen[pair] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Duplicate Tag",
+				fmt.Sprintf("Attribute %s %s, but tag %q with value %q is set more than once. An omitted value is the same as an empty value.", req.Path, v.Description(ctx), pair[0], pair[1]),
+			)
+			continue
+		}
+		seen[pair] = true
+	}
+}
@@ -0,0 +1,88 @@
+// ABOUTME: Unit tests for the duplicate ID and duplicate tag validators.
+// ABOUTME: Covers numeric ID spellings, omitted tag values and unknown elements.
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var tagAttrTypes = map[string]attr.Type{
+	"tag":   types.StringType,
+	"value": types.StringType,
+}
+
+// validateSet runs a set validator and reports whether the value is valid.
+func validateSet(v validator.Set, value types.Set) bool {
+	resp := &validator.SetResponse{}
+	v.ValidateSet(context.Background(), validator.SetRequest{
+		Path:        path.Root("test"),
+		ConfigValue: value,
+	}, resp)
+	return !resp.Diagnostics.HasError()
+}
+
+func idSet(ids ...attr.Value) types.Set {
+	return types.SetValueMust(types.StringType, ids)
+}
+
+func tagSet(tags ...attr.Value) types.Set {
+	return types.SetValueMust(types.ObjectType{AttrTypes: tagAttrTypes}, tags)
+}
+
+func tag(name string, value types.String) attr.Value {
+	return types.ObjectValueMust(tagAttrTypes, map[string]attr.Value{
+		"tag":   types.StringValue(name),
+		"value": value,
+	})
+}
+
+func TestUniqueIDs(t *testing.T) {
+	valid := []types.Set{
+		idSet(types.StringValue("10"), types.StringValue("11")),
+		idSet(types.StringValue("10"), types.StringValue("100")),
+		idSet(types.StringValue("0"), types.StringValue("abc")),
+		idSet(types.StringValue("10"), types.StringUnknown()),
+		types.SetNull(types.StringType),
+		types.SetUnknown(types.StringType),
+	}
+	for _, value := range valid {
+		if !validateSet(UniqueIDs(), value) {
+			t.Errorf("expected %s to be valid", value)
+		}
+	}
+
+	invalid := []types.Set{
+		idSet(types.StringValue("10"), types.StringValue("010")),
+		idSet(types.StringValue("0"), types.StringValue("00")),
+	}
+	for _, value := range invalid {
+		if validateSet(UniqueIDs(), value) {
+			t.Errorf("expected %s to be invalid", value)
+		}
+	}
+}
+
+func TestUniqueTags(t *testing.T) {
+	valid := []types.Set{
+		tagSet(tag("env", types.StringValue("prod")), tag("env", types.StringValue("test"))),
+		tagSet(tag("env", types.StringNull()), tag("role", types.StringNull())),
+		tagSet(tag("env", types.StringNull()), tag("env", types.StringUnknown())),
+		types.SetNull(types.ObjectType{AttrTypes: tagAttrTypes}),
+	}
+	for _, value := range valid {
+		if !validateSet(UniqueTags(), value) {
+			t.Errorf("expected %s to be valid", value)
+		}
+	}
+
+	if validateSet(UniqueTags(), tagSet(tag("env", types.StringNull()), tag("env", types.StringValue("")))) {
+		t.Error("expected an omitted and an empty value of the same tag to be a duplicate")
+	}
+}
@@ -0,0 +1,157 @@
+// ABOUTME: Schema validator for Zabbix item update intervals with custom intervals.
+// ABOUTME: Checks the delay and its flexible and scheduling intervals at plan time.
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// maxUpdateInterval is the longest update interval of items, one day.
+const maxUpdateInterval = 24 * 60 * 60
+
+var (
+	// flexiblePeriod matches the period of a flexible interval, such as 1-5,09:00-18:00,
+	// capturing the days and the hours and minutes of the start and end.
+	flexiblePeriod = regexp.MustCompile(`^([1-7])(?:-([1-7]))?,(\d{1,2}):(\d{2})-(\d{1,2}):(\d{2})$`)
+	// schedulingInterval matches a scheduling interval, such as wd1-5h9 or m0-59/10, made
+	// of month days, week days, hours, minutes and seconds filters in this order.
+	schedulingInterval = regexp.MustCompile(`^(md[\d,/-]+)?(wd[\d,/-]+)?(h[\d,/-]+)?(m[\d,/-]+)?(s[\d,/-]+)?$`)
+)
+
+var _ validator.String = updateIntervalValidator{}
+
+// updateIntervalValidator validates item update intervals.
+type updateIntervalValidator struct{}
+
+// UpdateInterval returns a validator for item update intervals: a time value of up to 1d,
+// optionally followed by custom intervals separated by semicolons. Custom intervals are
+// either flexible, such as 10s/1-5,09:00-18:00, or scheduling, such as wd1-5h9. A zero
+// update interval requires a custom interval. User macros are accepted in place of the
+// update interval or of a custom interval.
+func UpdateInterval() validator.String {
+	return updateIntervalValidator{}
+}
+
+func (v updateIntervalValidator) Description(ctx context.Context) string {
+	return "value must be an update interval of up to 1d with optional flexible (10s/1-5,09:00-18:00) or scheduling (wd1-5h9) intervals separated by semicolons"
+}
+
+func (v updateIntervalValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v updateIntervalValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if err := validateUpdateInterval(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Update Interval",
+			fmt.Sprintf("Attribute %s %s, got: %q: %s", req.Path, v.Description(ctx), value, err),
+		)
+	}
+}
+
+// validateUpdateInterval checks an update interval with its custom intervals.
+func validateUpdateInterval(value string) error {
+	parts := strings.Split(value, ";")
+
+	delay, custom := parts[0], parts[1:]
+	if !userMacro.MatchString(delay) {
+		seconds, err := ParseTimeUnit(delay)
+		if err != nil {
+			return err
+		}
+		if seconds > maxUpdateInterval {
+			return fmt.Errorf("update interval %q is longer than 1d", delay)
+		}
+		if seconds == 0 && len(custom) == 0 {
+			return fmt.Errorf("an update interval of 0 requires a custom interval")
+		}
+	}
+
+	for _, interval := range custom {
+		if err := validateCustomInterval(interval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCustomInterval checks a flexible or scheduling interval.
+func validateCustomInterval(interval string) error {
+	if userMacro.MatchString(interval) {
+		return nil
+	}
+
+	// Scheduling intervals may contain steps such as h/2, but never start with a time
+	delay, period, found := strings.Cut(interval, "/")
+	if found && (isTimeUnit(delay) || userMacro.MatchString(delay)) {
+		return validateFlexibleInterval(delay, period)
+	}
+
+	if interval == "" || !schedulingInterval.MatchString(interval) {
+		return fmt.Errorf("invalid custom interval %q", interval)
+	}
+	return nil
+}
+
+// validateFlexibleInterval checks the delay and period of a flexible interval.
+func validateFlexibleInterval(delay, period string) error {
+	if !userMacro.MatchString(delay) {
+		seconds, err := ParseTimeUnit(delay)
+		if err != nil {
+			return err
+		}
+		if seconds > maxUpdateInterval {
+			return fmt.Errorf("flexible interval %q is longer than 1d", delay)
+		}
+	}
+
+	if userMacro.MatchString(period) {
+		return nil
+	}
+
+	m := flexiblePeriod.FindStringSubmatch(period)
+	if m == nil {
+		return fmt.Errorf("invalid period %q: must be days and times such as 1-5,09:00-18:00", period)
+	}
+	if m[2] != "" && m[2] < m[1] {
+		return fmt.Errorf("invalid period %q: the first day is after the last day", period)
+	}
+
+	start, end := minuteOfDay(m[3], m[4]), minuteOfDay(m[5], m[6])
+	if start < 0 || end < 0 || start >= end {
+		return fmt.Errorf("invalid period %q: times must be from 00:00 to 24:00, with the start before the end", period)
+	}
+
+	return nil
+}
+
+// isTimeUnit reports whether the value is a valid time value.
+func isTimeUnit(value string) bool {
+	_, err := ParseTimeUnit(value)
+	return err == nil
+}
+
+// minuteOfDay returns the minute of the day of a time, or -1 if the time is not between
+// 00:00 and 24:00.
+func minuteOfDay(hours, minutes string) int {
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+	if h > 24 || m > 59 || (h == 24 && m != 0) {
+		return -1
+	}
+	return h*60 + m
+}
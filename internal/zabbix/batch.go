@@ -0,0 +1,258 @@
+// ABOUTME: JSON-RPC 2.0 batch requests sending several API calls in one HTTP round trip.
+// ABOUTME: Correlates the responses with the requests by ID and reports errors per request.
+
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// BatchResult is the outcome of one request of a batch. Err is an *APIError when Zabbix
+// rejected the request.
+type BatchResult struct {
+	Result json.RawMessage
+	Err    error
+}
+
+// Batch sends the requests as a single JSON-RPC batch and returns their results in the
+// order of the requests. Only Method and Params of the requests are used; the ID and token
+// are set by the client. The error is only set when the batch as a whole failed, errors of
+// single requests are returned in their result. Like Request, the batch is sent again
+// after logging in when the session of the client expired. With middleware set, the
+// requests are sent one by one instead.
+func (c *Client) Batch(ctx context.Context, requests []Request) ([]BatchResult, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	if len(c.Middleware) > 0 {
+		return c.batchSequentially(ctx, requests), nil
+	}
+
+	authenticated := false
+	for _, r := range requests {
+		authenticated = authenticated || !noAuthMethods[r.Method]
+	}
+
+	var token string
+	if authenticated {
+		var err error
+		if token, err = c.authToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := c.batchWithToken(ctx, requests, token)
+	if c.usesSession() && authenticated && batchSessionExpired(results, err) {
+		// An expired session fails every authenticated request, so none of them was applied
+		c.expireSession(ctx, token)
+		if token, err = c.authToken(ctx); err != nil {
+			return nil, err
+		}
+		return c.batchWithToken(ctx, requests, token)
+	}
+
+	return results, err
+}
+
+// batchSessionExpired reports whether the batch or one of its requests failed because the
+// session expired.
+func batchSessionExpired(results []BatchResult, err error) bool {
+	if err != nil {
+		return errors.Is(err, ErrAuthExpired)
+	}
+
+	for _, result := range results {
+		if errors.Is(result.Err, ErrAuthExpired) {
+			return true
+		}
+	}
+	return false
+}
+
+// batchWithToken sends the batch with the token or session ID in the requests that need
+// authentication.
+func (c *Client) batchWithToken(ctx context.Context, requests []Request, token string) ([]BatchResult, error) {
+	batch := make([]Request, len(requests))
+	methods := make([]string, len(requests))
+	fieldRenames := make([][]rename, len(requests))
+	index := make(map[int]int, len(requests))
+	for i, r := range requests {
+		params := r.Params
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+
+		var paramRenames []rename
+		paramRenames, fieldRenames[i] = c.compatRenames(ctx, r.Method)
+		params, err := adaptParams(params, paramRenames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+
+		batch[i] = Request{
+			JSONRPC: "2.0",
+			Method:  r.Method,
+			Params:  params,
+			ID:      int(c.requestID.Add(1)),
+		}
+		methods[i] = r.Method
+		index[batch[i].ID] = i
+	}
+
+	// The header carries the token of the whole batch, the body the token of each request
+	var bearer string
+	if token != "" {
+		if c.useBearerAuth(ctx) {
+			bearer = token
+		} else {
+			for i := range batch {
+				if !noAuthMethods[batch[i].Method] {
+					batch[i].Auth = token
+				}
+			}
+		}
+	}
+
+	logFields := map[string]interface{}{
+		"methods": methods,
+	}
+	tflog.Debug(ctx, "Sending Zabbix API batch request", logFields)
+	for _, r := range batch {
+		tflog.Trace(ctx, "Zabbix API request parameters", map[string]interface{}{
+			"method":     r.Method,
+			"request_id": r.ID,
+			"params":     redactParams(r.Params),
+		})
+	}
+
+	start := time.Now()
+	responses, err := c.batchRoundTrip(ctx, batch, bearer)
+	duration := time.Since(start)
+	for _, method := range methods {
+		c.lookups.invalidateAfter(method)
+	}
+	failBatch := func(err error) ([]BatchResult, error) {
+		for _, method := range methods {
+			c.recordCall(ctx, method, duration, err, true)
+		}
+		return nil, err
+	}
+
+	logFields["duration_ms"] = duration.Milliseconds()
+	if err != nil {
+		logFields["error"] = err.Error()
+		tflog.Debug(ctx, "Zabbix API batch request failed", logFields)
+		return failBatch(err)
+	}
+	tflog.Debug(ctx, "Received Zabbix API batch response", logFields)
+
+	results := make([]BatchResult, len(batch))
+	answered := make([]bool, len(batch))
+	for _, resp := range responses {
+		i, ok := index[resp.ID]
+		if !ok || answered[i] {
+			return failBatch(fmt.Errorf("unexpected response id %d in batch response", resp.ID))
+		}
+		answered[i] = true
+
+		if resp.Error != nil {
+			results[i].Err = &APIError{Method: batch[i].Method, Err: resp.Error}
+		} else {
+			results[i].Result, results[i].Err = adaptResult(resp.Result, fieldRenames[i])
+		}
+	}
+
+	for i, ok := range answered {
+		if !ok {
+			results[i].Err = fmt.Errorf("method %s: no response in batch for request id %d", batch[i].Method, batch[i].ID)
+		}
+		c.recordCall(ctx, methods[i], duration, results[i].Err, true)
+	}
+
+	return results, nil
+}
+
+// batchRoundTrip sends the batch and decodes the responses, retrying failures of the whole
+// batch according to the retry policy.
+func (c *Client) batchRoundTrip(ctx context.Context, batch []Request, bearer string) ([]Response, error) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch request: %w", err)
+	}
+
+	methods := make([]string, len(batch))
+	for i, req := range batch {
+		methods[i] = req.Method
+	}
+
+	var responses []Response
+	err = c.retry(ctx, c.isIdempotent(methods...), func() error {
+		responses, err = c.batchExchange(ctx, body, bearer, methods)
+		return err
+	})
+
+	return responses, err
+}
+
+// batchExchange sends the marshaled batch once. Zabbix answers a batch it cannot process
+// at all with a single error response instead of an array.
+func (c *Client) batchExchange(ctx context.Context, body []byte, bearer string, methods []string) ([]Response, error) {
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	capture := c.startCapture(body)
+	httpResp, err := c.send(ctx, body, bearer, methods)
+	if err != nil {
+		capture.finish(err)
+		return nil, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(capture.body(httpResp.Body)).Decode(&raw); err != nil {
+		err = fmt.Errorf("failed to decode batch response: %w", err)
+		capture.finish(err)
+		return nil, err
+	}
+	capture.finish(nil)
+
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var resp Response
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode batch response: %w", err)
+		}
+		if resp.Error != nil {
+			return nil, &APIError{Method: "batch", Err: resp.Error}
+		}
+		return nil, fmt.Errorf("unexpected single response to batch request")
+	}
+
+	var responses []Response
+	if err := json.Unmarshal(raw, &responses); err != nil {
+		return nil, fmt.Errorf("failed to decode batch response: %w", err)
+	}
+
+	return responses, nil
+}
+
+// firstBatchError returns the error of the first failed request of a batch, or nil.
+func firstBatchError(results []BatchResult) error {
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+
+	return nil
+}
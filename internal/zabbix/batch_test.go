@@ -0,0 +1,209 @@
+// ABOUTME: Unit tests for JSON-RPC batch requests.
+// ABOUTME: Uses httptest to mock Zabbix API batch responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newBatchTestServer returns a server answering each request of a batch with the result
+// or error of handle. It counts the HTTP requests it receives in batches.
+func newBatchTestServer(t *testing.T, batches *int, handle func(req Request) (json.RawMessage, *Error)) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var reqs []Request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			t.Errorf("expected a batch request, got %s", body)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*batches++
+
+		resps := make([]Response, len(reqs))
+		for i, req := range reqs {
+			result, apiErr := handle(req)
+			resps[i] = Response{JSONRPC: "2.0", Result: result, Error: apiErr, ID: req.ID}
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+}
+
+func TestBatch_Success(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		if req.Auth != "test-token" {
+			t.Errorf("%s: expected auth token, got '%s'", req.Method, req.Auth)
+		}
+		result, _ := json.Marshal(req.Method)
+		return result, nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	results, err := client.Batch(context.Background(), []Request{
+		{Method: "host.get"},
+		{Method: "template.get"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batches != 1 {
+		t.Errorf("expected 1 batch, got %d", batches)
+	}
+	if len(results) != 2 || string(results[0].Result) != `"host.get"` || string(results[1].Result) != `"template.get"` {
+		t.Errorf("expected results in request order, got %+v", results)
+	}
+}
+
+func TestBatch_CorrelatesResponsesByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []Request
+		_ = json.NewDecoder(r.Body).Decode(&reqs)
+
+		// Answer in reverse order, as JSON-RPC allows
+		resps := make([]Response, 0, len(reqs))
+		for i := len(reqs) - 1; i >= 0; i-- {
+			result, _ := json.Marshal(reqs[i].Method)
+			resps = append(resps, Response{JSONRPC: "2.0", Result: result, ID: reqs[i].ID})
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	results, err := client.Batch(context.Background(), []Request{
+		{Method: "host.get"},
+		{Method: "item.get"},
+		{Method: "trigger.get"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, method := range []string{"host.get", "item.get", "trigger.get"} {
+		if string(results[i].Result) != `"`+method+`"` {
+			t.Errorf("expected result %d to be %s, got %s", i, method, results[i].Result)
+		}
+	}
+}
+
+func TestBatch_PerRequestError(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		if req.Method == "configuration.export" {
+			return nil, &Error{Code: -32500, Message: "Application error.", Data: "No permissions."}
+		}
+		return json.RawMessage(`[]`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	results, err := client.Batch(context.Background(), []Request{
+		{Method: "template.get"},
+		{Method: "configuration.export"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("expected template.get to succeed, got %v", results[0].Err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(results[1].Err, &apiErr) || apiErr.Method != "configuration.export" {
+		t.Errorf("expected APIError for configuration.export, got %v", results[1].Err)
+	}
+}
+
+func TestBatch_MissingResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []Request
+		_ = json.NewDecoder(r.Body).Decode(&reqs)
+		_ = json.NewEncoder(w).Encode([]Response{{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: reqs[0].ID}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	results, err := client.Batch(context.Background(), []Request{
+		{Method: "host.get"},
+		{Method: "item.get"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("expected host.get to succeed, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "no response") {
+		t.Errorf("expected missing response error for item.get, got %v", results[1].Err)
+	}
+}
+
+func TestBatch_WholeBatchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Response{
+			JSONRPC: "2.0",
+			Error:   &Error{Code: -32600, Message: "Invalid request.", Data: "Invalid JSON-RPC batch."},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.Batch(context.Background(), []Request{{Method: "host.get"}})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Err.Code != -32600 {
+		t.Fatalf("expected APIError -32600, got %v", err)
+	}
+}
+
+func TestBatch_BearerAuth(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Authorization")
+
+		var reqs []Request
+		_ = json.NewDecoder(r.Body).Decode(&reqs)
+		resps := make([]Response, len(reqs))
+		for i, req := range reqs {
+			if req.Auth != "" {
+				t.Errorf("%s: expected no token in the body, got '%s'", req.Method, req.Auth)
+			}
+			resps[i] = Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID}
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.AuthMethod = AuthMethodHeader
+
+	if _, err := client.Batch(context.Background(), []Request{{Method: "host.get"}, {Method: "item.get"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header != "Bearer test-token" {
+		t.Errorf("expected bearer token header, got '%s'", header)
+	}
+}
+
+func TestBatch_Empty(t *testing.T) {
+	client := NewClient("http://localhost:1", WithToken("test-token"))
+
+	results, err := client.Batch(context.Background(), nil)
+	if err != nil || results != nil {
+		t.Errorf("expected no results and no request for an empty batch, got %v, %v", results, err)
+	}
+}
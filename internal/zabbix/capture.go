@@ -0,0 +1,163 @@
+// ABOUTME: Optional capture of sanitized JSON-RPC request and response pairs for debugging.
+// ABOUTME: Writes one JSON line per HTTP exchange, suitable for attaching to bug reports.
+
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// sensitiveResultMethods are the methods whose results are secrets as a whole.
+var sensitiveResultMethods = map[string]bool{
+	"user.login": true,
+}
+
+// Capture writes every request sent by a client together with its response or error as a
+// line of JSON. Secrets are redacted as in the request logs, and results of methods such
+// as user.login are replaced. Every attempt of a retried request is captured. It is safe
+// for concurrent use and may be shared by several clients.
+type Capture struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// captureRecord is a line written by a Capture.
+type captureRecord struct {
+	Time       time.Time   `json:"time"`
+	DurationMS int64       `json:"duration_ms"`
+	Request    interface{} `json:"request"`
+	Response   interface{} `json:"response,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// NewCapture creates a capture writing to w.
+func NewCapture(w io.Writer) *Capture {
+	return &Capture{w: w}
+}
+
+// OpenCaptureFile creates a capture appending to the file at path, which is created with
+// permissions for the current user only if it does not exist.
+func OpenCaptureFile(path string) (*Capture, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewCapture(f), nil
+}
+
+// write writes a record as a single line. Write errors are ignored, since the capture
+// must never fail the request it describes.
+func (c *Capture) write(record captureRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = c.w.Write(append(line, '\n'))
+}
+
+// capturedExchange collects a request body and what is read of its response until the
+// exchange is finished. All methods do nothing on a nil exchange, which is returned when
+// the client has no capture.
+type capturedExchange struct {
+	capture  *Capture
+	start    time.Time
+	request  []byte
+	response bytes.Buffer
+}
+
+// startCapture starts capturing the exchange of the marshaled request, if the client has
+// a capture.
+func (c *Client) startCapture(body []byte) *capturedExchange {
+	if c.Capture == nil {
+		return nil
+	}
+	return &capturedExchange{capture: c.Capture, start: time.Now(), request: body}
+}
+
+// body returns the reader to decode the response from, copying what is read.
+func (e *capturedExchange) body(r io.Reader) io.Reader {
+	if e == nil {
+		return r
+	}
+	return io.TeeReader(r, &e.response)
+}
+
+// finish writes the sanitized request with the response read so far and the error of the
+// exchange, if any.
+func (e *capturedExchange) finish(err error) {
+	if e == nil {
+		return
+	}
+
+	record := captureRecord{
+		Time:       e.start.UTC(),
+		DurationMS: time.Since(e.start).Milliseconds(),
+	}
+
+	var request interface{}
+	if json.Unmarshal(e.request, &request) == nil {
+		record.Request = redactValue(request)
+	}
+
+	var response interface{}
+	if e.response.Len() > 0 {
+		if json.Unmarshal(bytes.TrimSpace(e.response.Bytes()), &response) == nil {
+			record.Response = redactResults(redactValue(response), sensitiveRequestIDs(request))
+		} else {
+			// Responses that are not JSON, such as HTML error pages, are kept as text
+			record.Response = e.response.String()
+		}
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	e.capture.write(record)
+}
+
+// sensitiveRequestIDs returns the IDs of the requests of a single or batch request whose
+// results are secrets.
+func sensitiveRequestIDs(request interface{}) map[float64]bool {
+	ids := map[float64]bool{}
+
+	requests, ok := request.([]interface{})
+	if !ok {
+		requests = []interface{}{request}
+	}
+	for _, r := range requests {
+		fields, _ := r.(map[string]interface{})
+		method, _ := fields["method"].(string)
+		if id, ok := fields["id"].(float64); ok && sensitiveResultMethods[method] {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+// redactResults replaces the results of the responses with the given IDs.
+func redactResults(response interface{}, ids map[float64]bool) interface{} {
+	responses, ok := response.([]interface{})
+	if !ok {
+		responses = []interface{}{response}
+	}
+	for _, r := range responses {
+		fields, _ := r.(map[string]interface{})
+		if id, ok := fields["id"].(float64); ok && ids[id] {
+			if _, ok := fields["result"]; ok {
+				fields["result"] = redactedValue
+			}
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,149 @@
+// ABOUTME: Unit tests for the capture of sanitized request and response pairs.
+// ABOUTME: Tests cover redacted secrets, batches, failed requests and capture files.
+
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureLines decodes the records written to a capture.
+func captureLines(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+
+	var records []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var record map[string]interface{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("expected a JSON line, got %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestCapture_RedactsSecrets(t *testing.T) {
+	server := newSessionTestServer(t)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := newSessionClient(server.URL)
+	client.Capture = NewCapture(&buf)
+
+	if _, err := client.Request(context.Background(), "host.update", map[string]interface{}{"hostid": "10084", "tls_psk": "secret-psk"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, secret := range []string{"secret", "secret-psk", "session-1"} {
+		if strings.Contains(output, `"`+secret+`"`) {
+			t.Errorf("expected %q to be redacted, got:\n%s", secret, output)
+		}
+	}
+
+	records := captureLines(t, buf.Bytes())
+	if len(records) != 2 {
+		t.Fatalf("expected the login and the request to be captured, got %d records", len(records))
+	}
+
+	login := records[0]
+	if login["request"].(map[string]interface{})["method"] != "user.login" {
+		t.Errorf("expected the login first, got %v", login["request"])
+	}
+	if result := login["response"].(map[string]interface{})["result"]; result != redactedValue {
+		t.Errorf("expected the session ID to be redacted, got %v", result)
+	}
+
+	update := records[1]
+	params := update["request"].(map[string]interface{})["params"].(map[string]interface{})
+	if params["hostid"] != "10084" || params["tls_psk"] != redactedValue {
+		t.Errorf("expected only the PSK to be redacted, got %v", params)
+	}
+	if update["request"].(map[string]interface{})["auth"] != redactedValue {
+		t.Errorf("expected the session in auth to be redacted, got %v", update["request"])
+	}
+	if _, ok := update["duration_ms"]; !ok || update["time"] == "" {
+		t.Errorf("expected time and duration, got %v", update)
+	}
+}
+
+func TestCapture_Batch(t *testing.T) {
+	server := newBatchTestServer(t, new(int), func(req Request) (json.RawMessage, *Error) {
+		return json.RawMessage(`[]`), nil
+	})
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(server.URL, WithToken("test-token"), WithCapture(NewCapture(&buf)))
+
+	if _, err := client.Batch(context.Background(), []Request{{Method: "host.get"}, {Method: "item.get"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := captureLines(t, buf.Bytes())
+	if len(records) != 1 {
+		t.Fatalf("expected the batch to be captured as one exchange, got %d records", len(records))
+	}
+	requests, ok := records[0]["request"].([]interface{})
+	if !ok || len(requests) != 2 {
+		t.Errorf("expected the 2 requests of the batch, got %v", records[0]["request"])
+	}
+	responses, ok := records[0]["response"].([]interface{})
+	if !ok || len(responses) != 2 {
+		t.Errorf("expected the 2 responses of the batch, got %v", records[0]["response"])
+	}
+}
+
+func TestCapture_FailedRequest(t *testing.T) {
+	var buf bytes.Buffer
+	client := NewClient("http://localhost:1", WithToken("test-token"), WithCapture(NewCapture(&buf)))
+
+	if _, err := client.Request(context.Background(), "host.get", nil); err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+
+	records := captureLines(t, buf.Bytes())
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	if records[0]["error"] == nil || records[0]["response"] != nil {
+		t.Errorf("expected an error without response, got %v", records[0])
+	}
+}
+
+func TestOpenCaptureFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+
+	for i := 0; i < 2; i++ {
+		capture, err := OpenCaptureFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _ = NewClient("http://localhost:1", WithToken("test-token"), WithCapture(capture)).Request(context.Background(), "host.get", nil)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected permissions 0600, got %o", perm)
+	}
+
+	data, _ := os.ReadFile(path)
+	if records := captureLines(t, data); len(records) != 2 {
+		t.Errorf("expected the second capture to append, got %d records", len(records))
+	}
+}
+
+func TestOpenCaptureFile_Invalid(t *testing.T) {
+	if _, err := OpenCaptureFile(filepath.Join(t.TempDir(), "missing", "capture.jsonl")); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+}
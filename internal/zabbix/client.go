@@ -1,69 +1,174 @@
 // ABOUTME: HTTP client for communicating with the Zabbix JSON-RPC 2.0 API.
-// ABOUTME: Handles authentication, request serialization, and response parsing.
+// ABOUTME: Handles authentication, request serialization, response parsing, failover and rate limits.
 
 package zabbix
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	// DefaultTimeout is the default HTTP client timeout.
 	DefaultTimeout = 30 * time.Second
+
+	// minCompressedSize is the size from which request bodies are compressed. Smaller
+	// bodies, like those of most get calls, fit into a few packets anyway.
+	minCompressedSize = 1024
+)
+
+// AuthMethod selects how the API token is sent to Zabbix.
+type AuthMethod int
+
+const (
+	// AuthMethodBody sends the token in the auth field of the request body. It is the
+	// default, but was deprecated in Zabbix 6.4 and removed in 7.2.
+	AuthMethodBody AuthMethod = iota
+	// AuthMethodHeader sends the token as an Authorization: Bearer header.
+	AuthMethodHeader
+	// AuthMethodAuto uses the header for Zabbix 6.4 and later and the body for older
+	// versions. The version is detected with the first authenticated request.
+	AuthMethodAuto
 )
 
 // Client is a Zabbix API client.
 type Client struct {
-	URL        string
-	Token      string
-	HTTPClient *http.Client
+	URL   string
+	Token string
+	// FallbackURLs are API endpoints of the same Zabbix installation, tried in order when
+	// an endpoint fails with a connection error or a 5xx status.
+	FallbackURLs []string
+	AuthMethod   AuthMethod
+	// CompressRequests gzips request bodies of 1 KiB or more. The web server in front of
+	// the Zabbix frontend has to decompress them, which PHP does not do.
+	CompressRequests bool
+	HTTPClient       *http.Client
+	// UserAgent is sent as User-Agent header when set.
+	UserAgent string
+	Retry     RetryPolicy
+	Limiter   *RateLimiter
+	// Concurrency bounds the number of requests in flight when set. A request holds its
+	// slot until the response is read, but not while waiting to be retried.
+	Concurrency *ConcurrencyLimiter
+	// Capture records every request and response, sanitized, when set.
+	Capture   *Capture
+	BasicAuth *BasicAuth
+	// Metrics receives the method, duration and error class of every API call when set.
+	Metrics MetricsRecorder
+	// Middleware wraps the round trip of every request, the first middleware outermost.
+	// Batches are sent as single requests when it is set.
+	Middleware []Middleware
+	// Username and Password authenticate with a session instead of an API token. The
+	// session is created on the first request; call Logout when done with the client.
+	Username   string
+	Password   string
 	requestID  atomic.Int64
+	versionMu  sync.Mutex
+	version    *Version
+	versionErr error
+	// activeURL is the index of the endpoint that answered last, in URL followed by
+	// FallbackURLs. Requests start with it so a failed endpoint is not tried every time.
+	activeURL atomic.Int32
+	// lookups caches IDs looked up by name for the lifetime of the client.
+	lookups lookupCache
+	// session is the session ID of Username, empty until logged in.
+	sessionMu sync.Mutex
+	session   string
 }
 
-// NewClient creates a new Zabbix API client with default settings.
-func NewClient(url, token string) *Client {
-	return &Client{
-		URL:   url,
-		Token: token,
+// BasicAuth contains HTTP basic authentication credentials sent with every request, for
+// API endpoints protected by a reverse proxy. They are independent of the API token.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// NewClient creates a new Zabbix API client for the API endpoint, with DefaultTimeout and
+// the options applied in order.
+func NewClient(url string, opts ...Option) *Client {
+	c := &Client{
+		URL: url,
 		HTTPClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // NewClientWithTimeout creates a new Zabbix API client with a custom timeout.
+//
+// Deprecated: Use NewClient with WithToken and WithTimeout.
 func NewClientWithTimeout(url, token string, timeout time.Duration) *Client {
-	return &Client{
-		URL:   url,
-		Token: token,
-		HTTPClient: &http.Client{
-			Timeout: timeout,
-		},
-	}
+	return NewClient(url, WithToken(token), WithTimeout(timeout))
 }
 
 // Methods that don't require authentication.
 var noAuthMethods = map[string]bool{
 	"apiinfo.version": true,
-}
-
-// Request sends a JSON-RPC 2.0 request to the Zabbix API using a background context.
-func (c *Client) Request(method string, params interface{}) (json.RawMessage, error) {
-	return c.RequestWithContext(context.Background(), method, params)
+	"user.login":      true,
 }
 
 // RequestWithContext sends a JSON-RPC 2.0 request to the Zabbix API with the given context.
+//
+// Deprecated: Use Request, which takes the context as first argument.
 func (c *Client) RequestWithContext(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	return c.Request(ctx, method, params)
+}
+
+// Request sends a JSON-RPC 2.0 request to the Zabbix API. The context bounds the whole
+// request, including waiting for the rate limiter and retries. Requests are logged with
+// tflog: method, duration and result size at debug level, and the parameters with secrets
+// redacted at trace level. Parameters and results renamed between Zabbix versions are
+// adapted to the version of the server. Clients authenticating with a username log in
+// before the first request and again when Zabbix reports that the session expired.
+func (c *Client) Request(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	if noAuthMethods[method] {
+		return c.requestWithToken(ctx, method, params, "")
+	}
+
+	token, err := c.authToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.requestWithToken(ctx, method, params, token)
+	if err != nil && c.usesSession() && errors.Is(err, ErrAuthExpired) {
+		c.expireSession(ctx, token)
+		if token, err = c.authToken(ctx); err != nil {
+			return nil, err
+		}
+		return c.requestWithToken(ctx, method, params, token)
+	}
+
+	return result, err
+}
+
+// requestWithToken sends the request authenticated with the token or session ID, or
+// without authentication when it is empty.
+func (c *Client) requestWithToken(ctx context.Context, method string, params interface{}, token string) (json.RawMessage, error) {
 	if params == nil {
 		params = map[string]interface{}{}
 	}
 
+	paramRenames, fieldRenames := c.compatRenames(ctx, method)
+	params, err := adaptParams(params, paramRenames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
 	req := Request{
 		JSONRPC: "2.0",
 		Method:  method,
@@ -71,43 +176,129 @@ func (c *Client) RequestWithContext(ctx context.Context, method string, params i
 		ID:      int(c.requestID.Add(1)),
 	}
 
-	if !noAuthMethods[method] {
-		req.Auth = c.Token
+	var bearer string
+	if token != "" {
+		if c.useBearerAuth(ctx) {
+			bearer = token
+		} else {
+			req.Auth = token
+		}
 	}
 
-	body, err := json.Marshal(req)
+	logFields := map[string]interface{}{
+		"method":     method,
+		"request_id": req.ID,
+	}
+	tflog.Debug(ctx, "Sending Zabbix API request", logFields)
+	tflog.Trace(ctx, "Zabbix API request parameters", map[string]interface{}{
+		"method":     method,
+		"request_id": req.ID,
+		"params":     redactParams(params),
+	})
+
+	start := time.Now()
+	roundTrip := c.chain(func(ctx context.Context, req *Request) (json.RawMessage, error) {
+		return c.roundTrip(ctx, *req, bearer)
+	})
+	result, err := roundTrip(ctx, &req)
+	duration := time.Since(start)
+	c.lookups.invalidateAfter(method)
+	c.recordCall(ctx, method, duration, err, false)
+	logFields["duration_ms"] = duration.Milliseconds()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		logFields["error"] = err.Error()
+		tflog.Debug(ctx, "Zabbix API request failed", logFields)
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	logFields["result_size"] = len(result)
+	tflog.Debug(ctx, "Received Zabbix API response", logFields)
+
+	return adaptResult(result, fieldRenames)
+}
+
+// Call sends a JSON-RPC 2.0 request like Client.Request and unmarshals its result into T.
+func Call[T any](ctx context.Context, c *Client, method string, params interface{}) (T, error) {
+	var value T
+
+	result, err := c.Request(ctx, method, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create http request: %w", err)
+		return value, err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json-rpc")
+	if err := json.Unmarshal(result, &value); err != nil {
+		return value, fmt.Errorf("failed to unmarshal %s response: %w", method, err)
+	}
+
+	return value, nil
+}
+
+// useBearerAuth reports whether the token is sent in the Authorization header. HTTP basic
+// authentication uses the same header, so the token is then always sent in the body.
+func (c *Client) useBearerAuth(ctx context.Context) bool {
+	if c.BasicAuth != nil {
+		return false
+	}
+
+	switch c.AuthMethod {
+	case AuthMethodHeader:
+		return true
+	case AuthMethodBody:
+		return false
+	}
+
+	// Servers whose version cannot be detected get the token in the body, which all
+	// versions before 7.2 accept
+	version, err := c.APIVersion(ctx)
+	return err == nil && version.AtLeast(6, 4)
+}
 
-	httpResp, err := c.HTTPClient.Do(httpReq)
+// roundTrip sends the request, with the token as bearer token when it is not empty, and
+// returns the result of the response. Transient failures are retried according to the
+// retry policy.
+func (c *Client) roundTrip(ctx context.Context, req Request, bearer string) (json.RawMessage, error) {
+	body, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
-	defer func() { _ = httpResp.Body.Close() }()
 
-	if httpResp.StatusCode != http.StatusOK {
-		return nil, &HTTPError{
-			StatusCode: httpResp.StatusCode,
-			Status:     httpResp.Status,
-		}
+	var result json.RawMessage
+	err = c.retry(ctx, c.isIdempotent(req.Method), func() error {
+		result, err = c.exchange(ctx, req, body, bearer)
+		return err
+	})
+
+	return result, err
+}
+
+// exchange sends the marshaled request once and returns the result of the response. It
+// holds a slot of the concurrency limiter until the response is read.
+func (c *Client) exchange(ctx context.Context, req Request, body []byte, bearer string) (json.RawMessage, error) {
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	capture := c.startCapture(body)
+	httpResp, err := c.send(ctx, body, bearer, []string{req.Method})
+	if err != nil {
+		capture.finish(err)
+		return nil, err
 	}
+	defer func() { _ = httpResp.Body.Close() }()
 
 	var resp Response
-	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(capture.body(httpResp.Body)).Decode(&resp); err != nil {
+		err = fmt.Errorf("failed to decode response: %w", err)
+		capture.finish(err)
+		return nil, err
 	}
+	capture.finish(nil)
 
 	if resp.Error != nil {
 		return nil, &APIError{
-			Method: method,
+			Method: req.Method,
 			Err:    resp.Error,
 		}
 	}
@@ -118,3 +309,113 @@ func (c *Client) RequestWithContext(ctx context.Context, method string, params i
 
 	return resp.Result, nil
 }
+
+// send posts the request body of the methods once. The returned response always has
+// status 200 OK; other statuses are returned as *HTTPError.
+func (c *Client) send(ctx context.Context, body []byte, bearer string, methods []string) (*http.Response, error) {
+	httpResp, err := c.post(ctx, body, bearer, methods)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		_ = httpResp.Body.Close()
+		return nil, &HTTPError{
+			StatusCode: httpResp.StatusCode,
+			Status:     httpResp.Status,
+		}
+	}
+
+	return httpResp, nil
+}
+
+// post posts the request body of the methods to the active endpoint, failing over to the
+// next endpoint when the request could not be sent. Requests of idempotent methods also
+// fail over on other connection errors, timeouts and 5xx statuses; other requests may
+// already have been applied by then, and the endpoints share the database. Every post
+// waits for the rate limiter when one is set. The response of the last endpoint tried is
+// returned whatever its status.
+func (c *Client) post(ctx context.Context, body []byte, bearer string, methods []string) (*http.Response, error) {
+	urls := append([]string{c.URL}, c.FallbackURLs...)
+	idempotent := c.isIdempotent(methods...)
+	active := int(c.activeURL.Load()) % len(urls)
+
+	compressed := c.CompressRequests && len(body) >= minCompressedSize
+	if compressed {
+		var err error
+		if body, err = gzipBody(body); err != nil {
+			return nil, fmt.Errorf("failed to compress request: %w", err)
+		}
+	}
+
+	for i := range urls {
+		index := (active + i) % len(urls)
+		last := i == len(urls)-1
+
+		if c.Limiter != nil {
+			if err := c.Limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("failed to send request: %w", err)
+			}
+		}
+
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, urls[index], bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create http request: %w", err)
+		}
+
+		httpReq.Header.Set("Content-Type", "application/json-rpc")
+		if c.UserAgent != "" {
+			httpReq.Header.Set("User-Agent", c.UserAgent)
+		}
+		if compressed {
+			httpReq.Header.Set("Content-Encoding", "gzip")
+		}
+		if bearer != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+bearer)
+		} else if c.BasicAuth != nil {
+			httpReq.SetBasicAuth(c.BasicAuth.Username, c.BasicAuth.Password)
+		}
+
+		httpResp, err := c.HTTPClient.Do(httpReq)
+		if err != nil {
+			if last || ctx.Err() != nil || !(idempotent || isNotSent(err)) {
+				return nil, fmt.Errorf("failed to send request: %w", err)
+			}
+			tflog.Warn(ctx, "Zabbix API endpoint unreachable, failing over", map[string]interface{}{
+				"url":   urls[index],
+				"error": err.Error(),
+			})
+			continue
+		}
+
+		if httpResp.StatusCode >= http.StatusInternalServerError && idempotent && !last {
+			_ = httpResp.Body.Close()
+			tflog.Warn(ctx, "Zabbix API endpoint failed, failing over", map[string]interface{}{
+				"url":    urls[index],
+				"status": httpResp.StatusCode,
+			})
+			continue
+		}
+
+		if httpResp.StatusCode < http.StatusInternalServerError {
+			c.activeURL.Store(int32(index))
+		}
+		return httpResp, nil
+	}
+
+	// Not reached, the last endpoint always returns
+	return nil, fmt.Errorf("failed to send request: no endpoint configured")
+}
+
+// gzipBody compresses a request body with gzip.
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -4,6 +4,7 @@
 package zabbix
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"testing"
@@ -31,12 +32,12 @@ func newTestClient(t *testing.T) *Client {
 		token = defaultTestToken
 	}
 
-	return NewClient(url, token)
+	return NewClient(url, WithToken(token))
 }
 
 func TestIntegration_APIVersion(t *testing.T) {
 	client := newTestClient(t)
-	result, err := client.Request("apiinfo.version", nil)
+	result, err := client.Request(context.Background(), "apiinfo.version", nil)
 
 	if err != nil {
 		t.Fatalf("Request failed: %v", err)
@@ -56,7 +57,7 @@ func TestIntegration_APIVersion(t *testing.T) {
 
 func TestIntegration_HostGet(t *testing.T) {
 	client := newTestClient(t)
-	result, err := client.Request("host.get", map[string]interface{}{
+	result, err := client.Request(context.Background(), "host.get", map[string]interface{}{
 		"output": []string{"hostid", "host"},
 	})
 
@@ -82,8 +83,8 @@ func TestIntegration_InvalidToken(t *testing.T) {
 		url = defaultTestURL
 	}
 
-	client := NewClient(url, "invalid-token")
-	_, err := client.Request("host.get", nil)
+	client := NewClient(url, WithToken("invalid-token"))
+	_, err := client.Request(context.Background(), "host.get", nil)
 
 	if err == nil {
 		t.Fatal("Expected error with invalid token")
@@ -4,18 +4,21 @@
 package zabbix
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
 func TestNewClient(t *testing.T) {
-	client := NewClient("http://example.com/api", "test-token")
+	client := NewClient("http://example.com/api", WithToken("test-token"))
 
 	if client.URL != "http://example.com/api" {
 		t.Errorf("expected URL 'http://example.com/api', got '%s'", client.URL)
@@ -71,8 +74,8 @@ func TestRequest_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	result, err := client.Request("host.get", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.Request(context.Background(), "host.get", nil)
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -105,8 +108,8 @@ func TestRequest_NoAuthForAPIInfo(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	result, err := client.Request("apiinfo.version", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.Request(context.Background(), "apiinfo.version", nil)
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -146,11 +149,11 @@ func TestRequest_WithParams(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	params := map[string]interface{}{
 		"output": "extend",
 	}
-	result, err := client.Request("host.get", params)
+	result, err := client.Request(context.Background(), "host.get", params)
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -180,8 +183,8 @@ func TestRequest_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	_, err := client.Request("host.get", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.Request(context.Background(), "host.get", nil)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -205,8 +208,8 @@ func TestRequest_HTTPError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	_, err := client.Request("apiinfo.version", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.Request(context.Background(), "apiinfo.version", nil)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -222,8 +225,8 @@ func TestRequest_HTTPError(t *testing.T) {
 }
 
 func TestRequest_ConnectionError(t *testing.T) {
-	client := NewClient("http://localhost:1", "test-token")
-	_, err := client.Request("apiinfo.version", nil)
+	client := NewClient("http://localhost:1", WithToken("test-token"))
+	_, err := client.Request(context.Background(), "apiinfo.version", nil)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -236,8 +239,8 @@ func TestRequest_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	_, err := client.Request("apiinfo.version", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.Request(context.Background(), "apiinfo.version", nil)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -263,10 +266,10 @@ func TestRequest_IncrementingID(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	_, _ = client.Request("test", nil)
-	_, _ = client.Request("test", nil)
-	_, _ = client.Request("test", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, _ = client.Request(context.Background(), "test", nil)
+	_, _ = client.Request(context.Background(), "test", nil)
+	_, _ = client.Request(context.Background(), "test", nil)
 
 	if len(receivedIDs) != 3 {
 		t.Fatalf("expected 3 requests, got %d", len(receivedIDs))
@@ -282,11 +285,11 @@ func TestRequestWithContext_Cancellation(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	_, err := client.RequestWithContext(ctx, "test", nil)
+	_, err := client.Request(ctx, "test", nil)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -307,8 +310,8 @@ func TestRequest_ResponseIDMismatch(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
-	_, err := client.Request("test", nil)
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.Request(context.Background(), "test", nil)
 
 	if err == nil {
 		t.Fatal("expected error, got nil")
@@ -317,3 +320,437 @@ func TestRequest_ResponseIDMismatch(t *testing.T) {
 		t.Errorf("expected response id mismatch error, got: %v", err)
 	}
 }
+
+func TestRequest_RetryOnStatus(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal retried request: %v", err)
+		}
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`"7.0.0"`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond, OnStatus: []int{502, 503}}
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRequest_RetryExhausted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond, OnStatus: []int{502}}
+
+	_, err := client.Request(context.Background(), "apiinfo.version", nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected HTTPError 502, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRequest_NoRetryForOtherStatus(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond, OnStatus: []int{502, 503}}
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRequest_RetryCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 5, Backoff: time.Hour, OnStatus: []int{503}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Request(ctx, "apiinfo.version", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// newAuthTestServer returns a server reporting the given API version that records how the
+// token of the last authenticated request was sent.
+func newAuthTestServer(t *testing.T, version string, header, body *string, versionRequests *int) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		result := json.RawMessage(`[]`)
+		if req.Method == "apiinfo.version" {
+			*versionRequests++
+			if r.Header.Get("Authorization") != "" || req.Auth != "" {
+				t.Error("expected apiinfo.version to be sent without token")
+			}
+			result, _ = json.Marshal(version)
+		} else {
+			*header = r.Header.Get("Authorization")
+			*body = req.Auth
+		}
+
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+}
+
+func TestRequest_AuthMethod(t *testing.T) {
+	tests := map[string]struct {
+		method          AuthMethod
+		version         string
+		basicAuth       bool
+		expectBearer    bool
+		versionRequests int
+	}{
+		"body by default":        {method: AuthMethodBody, version: "7.0.5"},
+		"header":                 {method: AuthMethodHeader, version: "6.0.20", expectBearer: true},
+		"auto on 7.0":            {method: AuthMethodAuto, version: "7.0.5", expectBearer: true, versionRequests: 1},
+		"auto on 6.4":            {method: AuthMethodAuto, version: "6.4.0", expectBearer: true, versionRequests: 1},
+		"auto on 6.0":            {method: AuthMethodAuto, version: "6.0.20", versionRequests: 1},
+		"auto with basic auth":   {method: AuthMethodAuto, version: "7.0.5", basicAuth: true},
+		"header with basic auth": {method: AuthMethodHeader, version: "7.0.5", basicAuth: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var header, body string
+			var versionRequests int
+			server := newAuthTestServer(t, tc.version, &header, &body, &versionRequests)
+			defer server.Close()
+
+			client := NewClient(server.URL, WithToken("test-token"))
+			client.AuthMethod = tc.method
+			if tc.basicAuth {
+				client.BasicAuth = &BasicAuth{Username: "proxy-user", Password: "proxy-pass"}
+			}
+
+			for i := 0; i < 2; i++ {
+				if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if tc.expectBearer {
+				if header != "Bearer test-token" || body != "" {
+					t.Errorf("expected bearer token only, got header %q and auth %q", header, body)
+				}
+			} else {
+				if body != "test-token" || strings.HasPrefix(header, "Bearer") {
+					t.Errorf("expected token in body only, got header %q and auth %q", header, body)
+				}
+			}
+
+			if versionRequests != tc.versionRequests {
+				t.Errorf("expected %d apiinfo.version requests, got %d", tc.versionRequests, versionRequests)
+			}
+		})
+	}
+}
+
+func TestRequest_BasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "proxy-user" || password != "proxy-pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		if req.Auth != "test-token" {
+			t.Errorf("expected API token to be sent alongside basic auth, got '%s'", req.Auth)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	var httpErr *HTTPError
+	if _, err := client.Request(context.Background(), "host.get", nil); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected HTTPError 401 without basic auth, got %v", err)
+	}
+
+	client.BasicAuth = &BasicAuth{Username: "proxy-user", Password: "proxy-pass"}
+	if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// newFailoverTestServer returns a server answering every request with status, or with a
+// successful response when status is 200, counting the requests it receives.
+func newFailoverTestServer(status int, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID})
+	}))
+}
+
+func TestRequest_FailoverOnServerError(t *testing.T) {
+	var primaryRequests, fallbackRequests int
+	primary := newFailoverTestServer(http.StatusBadGateway, &primaryRequests)
+	defer primary.Close()
+	fallback := newFailoverTestServer(http.StatusOK, &fallbackRequests)
+	defer fallback.Close()
+
+	client := NewClient(primary.URL, WithToken("test-token"))
+	client.FallbackURLs = []string{fallback.URL}
+
+	for range 2 {
+		if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The second request goes straight to the fallback that answered the first one
+	if primaryRequests != 1 {
+		t.Errorf("expected 1 request to the primary endpoint, got %d", primaryRequests)
+	}
+	if fallbackRequests != 2 {
+		t.Errorf("expected 2 requests to the fallback endpoint, got %d", fallbackRequests)
+	}
+}
+
+func TestRequest_FailoverOnConnectionError(t *testing.T) {
+	var fallbackRequests int
+	fallback := newFailoverTestServer(http.StatusOK, &fallbackRequests)
+	defer fallback.Close()
+
+	client := NewClient("http://localhost:1", WithToken("test-token"))
+	client.FallbackURLs = []string{fallback.URL}
+
+	if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fallbackRequests != 1 {
+		t.Errorf("expected 1 request to the fallback endpoint, got %d", fallbackRequests)
+	}
+}
+
+func TestRequest_NoFailoverOnClientError(t *testing.T) {
+	var primaryRequests, fallbackRequests int
+	primary := newFailoverTestServer(http.StatusUnauthorized, &primaryRequests)
+	defer primary.Close()
+	fallback := newFailoverTestServer(http.StatusOK, &fallbackRequests)
+	defer fallback.Close()
+
+	client := NewClient(primary.URL, WithToken("test-token"))
+	client.FallbackURLs = []string{fallback.URL}
+
+	var httpErr *HTTPError
+	if _, err := client.Request(context.Background(), "host.get", nil); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected HTTPError 401, got %v", err)
+	}
+	if fallbackRequests != 0 {
+		t.Errorf("expected no request to the fallback endpoint, got %d", fallbackRequests)
+	}
+}
+
+func TestRequest_NoFailoverOfCreate(t *testing.T) {
+	t.Run("server error", func(t *testing.T) {
+		var primaryRequests, fallbackRequests int
+		primary := newFailoverTestServer(http.StatusGatewayTimeout, &primaryRequests)
+		defer primary.Close()
+		fallback := newFailoverTestServer(http.StatusOK, &fallbackRequests)
+		defer fallback.Close()
+
+		client := NewClient(primary.URL, WithToken("test-token"))
+		client.FallbackURLs = []string{fallback.URL}
+
+		var httpErr *HTTPError
+		if _, err := client.Request(context.Background(), "host.create", nil); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusGatewayTimeout {
+			t.Fatalf("expected HTTPError 504, got %v", err)
+		}
+		if fallbackRequests != 0 {
+			t.Errorf("expected no request to the fallback endpoint, got %d", fallbackRequests)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		var primaryRequests atomic.Int32
+		primary := newSlowServer(200*time.Millisecond, &primaryRequests)
+		defer primary.Close()
+		var fallbackRequests int
+		fallback := newFailoverTestServer(http.StatusOK, &fallbackRequests)
+		defer fallback.Close()
+
+		client := NewClient(primary.URL, WithToken("test-token"), WithTimeout(50*time.Millisecond))
+		client.FallbackURLs = []string{fallback.URL}
+
+		if _, err := client.Request(context.Background(), "host.create", nil); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if fallbackRequests != 0 {
+			t.Errorf("expected no request to the fallback endpoint, got %d", fallbackRequests)
+		}
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		var fallbackRequests int
+		fallback := newFailoverTestServer(http.StatusOK, &fallbackRequests)
+		defer fallback.Close()
+
+		client := NewClient("http://localhost:1", WithToken("test-token"))
+		client.FallbackURLs = []string{fallback.URL}
+
+		// The request never reached the primary endpoint, so it is safe to send it to the fallback
+		if _, err := client.Request(context.Background(), "host.create", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fallbackRequests != 1 {
+			t.Errorf("expected 1 request to the fallback endpoint, got %d", fallbackRequests)
+		}
+	})
+}
+
+func TestRequest_FailoverExhausted(t *testing.T) {
+	var primaryRequests, fallbackRequests int
+	primary := newFailoverTestServer(http.StatusServiceUnavailable, &primaryRequests)
+	defer primary.Close()
+	fallback := newFailoverTestServer(http.StatusBadGateway, &fallbackRequests)
+	defer fallback.Close()
+
+	client := NewClient(primary.URL, WithToken("test-token"))
+	client.FallbackURLs = []string{fallback.URL}
+	client.Retry = RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond, OnStatus: []int{502, 503}}
+
+	var httpErr *HTTPError
+	if _, err := client.Request(context.Background(), "host.get", nil); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected HTTPError 502 of the last endpoint, got %v", err)
+	}
+
+	// Every attempt tries all endpoints
+	if primaryRequests != 2 || fallbackRequests != 2 {
+		t.Errorf("expected 2 requests to each endpoint, got %d and %d", primaryRequests, fallbackRequests)
+	}
+}
+
+func TestCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		result := json.RawMessage(`[{"hostid": "10084", "host": "web01"}]`)
+		if req.Method == "apiinfo.version" {
+			result = json.RawMessage(`{"unexpected": "object"}`)
+		}
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	hosts, err := Call[[]Host](context.Background(), client, "host.get", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 1 || hosts[0].HostID != "10084" || hosts[0].Host != "web01" {
+		t.Errorf("expected host 10084 web01, got %+v", hosts)
+	}
+
+	_, err = Call[string](context.Background(), client, "apiinfo.version", nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to unmarshal apiinfo.version response") {
+		t.Errorf("expected unmarshal error, got %v", err)
+	}
+}
+
+func TestRequest_CompressRequests(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("failed to decompress request: %v", err)
+				return
+			}
+			body = reader
+		}
+
+		var req Request
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			t.Errorf("failed to unmarshal request: %v", err)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`true`), ID: req.ID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.CompressRequests = true
+
+	// Only bodies of at least minCompressedSize bytes are compressed
+	if _, err := client.Request(context.Background(), "host.get", map[string]interface{}{"hostids": []string{"1"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source := strings.Repeat("zabbix_export: ", minCompressedSize)
+	if _, err := client.Request(context.Background(), "configuration.import", map[string]interface{}{"source": source}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("expected only the large request to be compressed, got encodings %q", encodings)
+	}
+}
@@ -0,0 +1,159 @@
+// ABOUTME: Adapts request parameters and results to the API version of the Zabbix server.
+// ABOUTME: Renames parameters and result fields that changed between Zabbix versions.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// compatRule describes a parameter of a method that was renamed in a Zabbix version,
+// together with the field of the result objects that was renamed with it. Requests may
+// use either name of the parameter and the name matching the server version is sent.
+// Result fields are always returned under the old name, which the result types use.
+type compatRule struct {
+	method       string
+	major, minor int
+	oldParam     string
+	newParam     string
+	oldField     string
+	newField     string
+}
+
+// compatRules lists the renamed parameters by method.
+var compatRules = []compatRule{
+	// Zabbix 6.2 split host groups and template groups
+	{method: "host.get", major: 6, minor: 2, oldParam: "selectGroups", newParam: "selectHostGroups", oldField: "groups", newField: "hostgroups"},
+	{method: "template.get", major: 6, minor: 2, oldParam: "selectGroups", newParam: "selectTemplateGroups", oldField: "groups", newField: "templategroups"},
+	// Zabbix 7.0 added proxy groups and renamed the proxy of hosts
+	{method: "host.create", major: 7, minor: 0, oldParam: "proxy_hostid", newParam: "proxyid"},
+	{method: "host.update", major: 7, minor: 0, oldParam: "proxy_hostid", newParam: "proxyid"},
+	{method: "host.get", major: 7, minor: 0, oldField: "proxy_hostid", newField: "proxyid"},
+}
+
+// rename is a resolved rename of a parameter or result field.
+type rename struct {
+	from, to string
+}
+
+// compatRenames resolves the renames of parameters and result fields for the method on the
+// server. The version is not detected for this: requests are adapted once the version was
+// detected with APIVersion, as the provider does when it is configured, and sent as they
+// are before.
+func (c *Client) compatRenames(ctx context.Context, method string) (params, fields []rename) {
+	method = strings.ToLower(method)
+
+	var rules []compatRule
+	for _, rule := range compatRules {
+		if rule.method == method {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	version, detected := c.DetectedVersion()
+	if !detected {
+		tflog.Trace(ctx, "Zabbix API version not detected, sending parameters unchanged", map[string]interface{}{
+			"method": method,
+		})
+		return nil, nil
+	}
+
+	for _, rule := range rules {
+		if !version.AtLeast(rule.major, rule.minor) {
+			if rule.newParam != "" {
+				params = append(params, rename{from: rule.newParam, to: rule.oldParam})
+			}
+			continue
+		}
+
+		if rule.oldParam != "" {
+			params = append(params, rename{from: rule.oldParam, to: rule.newParam})
+		}
+		if rule.oldField != "" {
+			fields = append(fields, rename{from: rule.newField, to: rule.oldField})
+		}
+	}
+
+	return params, fields
+}
+
+// adaptParams returns the parameters with the top-level keys renamed. Parameters that are
+// not an object, or do not contain any of the keys, are returned unchanged.
+func adaptParams(params interface{}, renames []rename) (interface{}, error) {
+	if len(renames) == 0 {
+		return params, nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return params, nil
+	}
+
+	if !renameKeys(object, renames) {
+		return params, nil
+	}
+
+	return object, nil
+}
+
+// adaptResult returns the result with the fields of the result object, or of the objects
+// of a result array, renamed. Results without any of the fields are returned unchanged.
+func adaptResult(result json.RawMessage, renames []rename) (json.RawMessage, error) {
+	if len(renames) == 0 {
+		return result, nil
+	}
+
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(result, &objects); err != nil {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(result, &object); err != nil {
+			// Scalar results such as counts have no fields
+			return result, nil
+		}
+		if !renameKeys(object, renames) {
+			return result, nil
+		}
+		return json.Marshal(object)
+	}
+
+	changed := false
+	for _, object := range objects {
+		changed = renameKeys(object, renames) || changed
+	}
+	if !changed {
+		return result, nil
+	}
+
+	return json.Marshal(objects)
+}
+
+// renameKeys renames the keys of the object and reports whether any key was renamed. Keys
+// that already exist under the new name are left alone.
+func renameKeys(object map[string]json.RawMessage, renames []rename) bool {
+	changed := false
+	for _, r := range renames {
+		value, ok := object[r.from]
+		if !ok {
+			continue
+		}
+		if _, exists := object[r.to]; exists {
+			continue
+		}
+		delete(object, r.from)
+		object[r.to] = value
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,174 @@
+// ABOUTME: Unit tests for adapting request parameters and results to the Zabbix version.
+// ABOUTME: Tests cover renamed select parameters, renamed result fields and undetected versions.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newCompatTestServer reports the version for apiinfo.version and answers host.get with a
+// host whose groups are in the field matching the version. It stores the parameters of the
+// last host.get request in params.
+func newCompatTestServer(t *testing.T, version string, params *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("failed to unmarshal request: %v", err)
+			return
+		}
+
+		result := json.RawMessage(`"` + version + `"`)
+		if req.Method == "host.get" {
+			*params, _ = req.Params.(map[string]interface{})
+			groupsField := "groups"
+			if v, _ := ParseVersion(version); v.AtLeast(6, 2) {
+				groupsField = "hostgroups"
+			}
+			result = json.RawMessage(`[{"hostid": "1", "host": "web01", "` + groupsField + `": [{"groupid": "2", "name": "Linux servers"}]}]`)
+		}
+
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+}
+
+func TestRequest_AdaptsToVersion(t *testing.T) {
+	tests := map[string]struct {
+		version       string
+		expectedParam string
+		removedParam  string
+	}{
+		"zabbix 6.0": {version: "6.0.30", expectedParam: "selectGroups", removedParam: "selectHostGroups"},
+		"zabbix 7.0": {version: "7.0.5", expectedParam: "selectHostGroups", removedParam: "selectGroups"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var params map[string]interface{}
+			server := newCompatTestServer(t, tc.version, &params)
+			defer server.Close()
+
+			client := NewClient(server.URL, WithToken("test-token"))
+			if _, err := client.APIVersion(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			host, err := client.GetHost(context.Background(), "1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, ok := params[tc.expectedParam]; !ok {
+				t.Errorf("expected parameter %s, got %v", tc.expectedParam, params)
+			}
+			if _, ok := params[tc.removedParam]; ok {
+				t.Errorf("expected no parameter %s, got %v", tc.removedParam, params)
+			}
+			if len(host.Groups) != 1 || host.Groups[0].GroupID != "2" {
+				t.Errorf("expected the groups of the host to be parsed, got %+v", host.Groups)
+			}
+		})
+	}
+}
+
+func TestRequest_UndetectedVersion(t *testing.T) {
+	var params map[string]interface{}
+	server := newCompatTestServer(t, "6.0.30", &params)
+	defer server.Close()
+
+	// Without a detected version the parameters are sent as they are
+	client := NewClient(server.URL, WithToken("test-token"))
+	if _, err := client.GetHost(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := params["selectGroups"]; !ok {
+		t.Errorf("expected parameter selectGroups, got %v", params)
+	}
+}
+
+func TestBatch_AdaptsToVersion(t *testing.T) {
+	var params map[string]interface{}
+	server := newBatchTestServer(t, new(int), func(req Request) (json.RawMessage, *Error) {
+		params, _ = req.Params.(map[string]interface{})
+		return json.RawMessage(`[{"templateid": "1", "templategroups": [{"groupid": "3"}]}]`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	version, _ := ParseVersion("7.0.5")
+	client.version = &version
+
+	results, err := client.Batch(context.Background(), []Request{{Method: "template.get", Params: getTemplateParams("1")}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := params["selectTemplateGroups"]; !ok {
+		t.Errorf("expected parameter selectTemplateGroups, got %v", params)
+	}
+
+	template, err := firstTemplate(results[0].Result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(template.Groups) != 1 || template.Groups[0].GroupID != "3" {
+		t.Errorf("expected the groups of the template to be parsed, got %+v", template.Groups)
+	}
+}
+
+func TestAdaptParams(t *testing.T) {
+	renames := []rename{{from: "proxy_hostid", to: "proxyid"}}
+
+	adapted, err := adaptParams(map[string]interface{}{"hostid": "1", "proxy_hostid": "5"}, renames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := json.Marshal(adapted)
+	if string(data) != `{"hostid":"1","proxyid":"5"}` {
+		t.Errorf("expected proxy_hostid to be renamed, got %s", data)
+	}
+
+	// Array parameters such as those of delete methods have no keys to rename
+	ids := []string{"1", "2"}
+	adapted, err = adaptParams(ids, renames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := adapted.([]string); !ok {
+		t.Errorf("expected array parameters to be unchanged, got %T", adapted)
+	}
+}
+
+func TestAdaptResult(t *testing.T) {
+	renames := []rename{{from: "proxyid", to: "proxy_hostid"}}
+
+	tests := map[string]struct {
+		result   string
+		expected string
+	}{
+		"object":      {result: `{"hostid":"1","proxyid":"5"}`, expected: `{"hostid":"1","proxy_hostid":"5"}`},
+		"array":       {result: `[{"hostid":"1","proxyid":"5"},{"hostid":"2"}]`, expected: `[{"hostid":"1","proxy_hostid":"5"},{"hostid":"2"}]`},
+		"no fields":   {result: `[{"hostid":"1"}]`, expected: `[{"hostid":"1"}]`},
+		"count":       {result: `"12"`, expected: `"12"`},
+		"keeps field": {result: `{"proxyid":"5","proxy_hostid":"6"}`, expected: `{"proxyid":"5","proxy_hostid":"6"}`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			adapted, err := adaptResult(json.RawMessage(tc.result), renames)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(adapted) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, adapted)
+			}
+		})
+	}
+}
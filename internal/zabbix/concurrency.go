@@ -0,0 +1,55 @@
+// ABOUTME: Semaphore bounding the number of requests the Zabbix API client has in flight.
+// ABOUTME: Keeps parallel Terraform operations from overloading the frontend at once.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConcurrencyLimiter bounds the number of HTTP requests in flight. Unlike RateLimiter, it
+// limits the load a slow frontend has at once rather than the rate of requests. It is
+// safe for concurrent use and may be shared by several clients.
+type ConcurrencyLimiter struct {
+	slots chan struct{}
+}
+
+// NewConcurrencyLimiter creates a limiter allowing up to max requests in flight.
+func NewConcurrencyLimiter(max int) *ConcurrencyLimiter {
+	if max < 1 {
+		max = 1
+	}
+	return &ConcurrencyLimiter{
+		slots: make(chan struct{}, max),
+	}
+}
+
+// Acquire blocks until fewer than the maximum number of requests are in flight or the
+// context is done. Every successful Acquire must be followed by a Release.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release frees the slot taken by Acquire.
+func (l *ConcurrencyLimiter) Release() {
+	<-l.slots
+}
+
+// acquireSlot takes a slot of the concurrency limiter of the client, if any, and returns
+// the function releasing it.
+func (c *Client) acquireSlot(ctx context.Context) (func(), error) {
+	if c.Concurrency == nil {
+		return func() {}, nil
+	}
+
+	if err := c.Concurrency.Acquire(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	return c.Concurrency.Release, nil
+}
@@ -0,0 +1,133 @@
+// ABOUTME: Unit tests for the concurrency limiter of the Zabbix API client.
+// ABOUTME: Tests cover the bound on requests in flight, batches and canceled waits.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newConcurrencyTestServer answers requests after a delay and records the highest number
+// of requests it handled at once.
+func newConcurrencyTestServer(t *testing.T, maxInFlight *atomic.Int32) *httptest.Server {
+	var inFlight atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			highest := maxInFlight.Load()
+			if current <= highest || maxInFlight.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			var reqs []Request
+			_ = json.Unmarshal(body, &reqs)
+			resps := make([]Response, len(reqs))
+			for i, req := range reqs {
+				resps[i] = Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID}
+			}
+			_ = json.NewEncoder(w).Encode(resps)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID})
+	}))
+}
+
+func TestConcurrencyLimiter_BoundsRequestsInFlight(t *testing.T) {
+	var maxInFlight atomic.Int32
+	server := newConcurrencyTestServer(t, &maxInFlight)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"), WithConcurrencyLimiter(NewConcurrencyLimiter(2)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				_, err = client.Request(context.Background(), "host.get", nil)
+			} else {
+				_, err = client.Batch(context.Background(), []Request{{Method: "host.get"}, {Method: "item.get"}})
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if highest := maxInFlight.Load(); highest > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", highest)
+	}
+}
+
+func TestConcurrencyLimiter_Unlimited(t *testing.T) {
+	var maxInFlight atomic.Int32
+	server := newConcurrencyTestServer(t, &maxInFlight)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = client.Request(context.Background(), "host.get", nil)
+		}()
+	}
+	wg.Wait()
+
+	if highest := maxInFlight.Load(); highest < 2 {
+		t.Errorf("expected parallel requests without a limiter, got at most %d in flight", highest)
+	}
+}
+
+func TestConcurrencyLimiter_AcquireCanceled(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1)
+	if err := limiter.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to end with the context, got %v", err)
+	}
+
+	limiter.Release()
+	if err := limiter.Acquire(context.Background()); err != nil {
+		t.Errorf("expected the released slot to be free, got %v", err)
+	}
+}
+
+func TestConcurrencyLimiter_RequestCanceled(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1)
+	_ = limiter.Acquire(context.Background())
+
+	client := NewClient("http://localhost:1", WithToken("test-token"), WithConcurrencyLimiter(limiter))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Request(ctx, "host.get", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to fail while waiting for a slot, got %v", err)
+	}
+}
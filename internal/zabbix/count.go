@@ -0,0 +1,141 @@
+// ABOUTME: Provides API methods for counting Zabbix hosts, items and triggers.
+// ABOUTME: Uses countOutput so that only the number of matching objects is transferred.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ObjectCountFilter contains the criteria for CountObjects. Empty criteria are not applied,
+// and hosts must match all criteria that are set.
+type ObjectCountFilter struct {
+	GroupIDs []string
+	// Tags filter hosts by host tag; items and triggers are counted on the matching hosts.
+	Tags []TagFilter
+}
+
+// ObjectCounts contains the number of hosts matching an ObjectCountFilter and the number
+// of items and triggers on those hosts. Template items and triggers are not counted.
+type ObjectCounts struct {
+	Hosts    int
+	Items    int
+	Triggers int
+}
+
+// CountObjects counts the hosts matching the filter and the items and triggers on them.
+// The counts are requested in batches, taking one round trip without tag filters and two
+// with them.
+func (c *Client) CountObjects(ctx context.Context, filter ObjectCountFilter) (*ObjectCounts, error) {
+	counts := &ObjectCounts{}
+
+	hostCount := Request{Method: "host.get", Params: GetHostParams{
+		GroupIDs:    filter.GroupIDs,
+		Tags:        filter.Tags,
+		CountOutput: true,
+	}}
+
+	if len(filter.Tags) == 0 {
+		values, err := c.batchCounts(ctx, append([]Request{hostCount}, objectCountRequests(filter.GroupIDs, nil)...))
+		if err != nil {
+			return nil, err
+		}
+		counts.Hosts, counts.Items, counts.Triggers = values[0], values[1], values[2]
+		return counts, nil
+	}
+
+	// item.get and trigger.get filter by their own tags, so host tags are resolved to host IDs
+	results, err := c.Batch(ctx, []Request{
+		hostCount,
+		{Method: "host.get", Params: GetHostParams{
+			GroupIDs: filter.GroupIDs,
+			Tags:     filter.Tags,
+			Output:   []string{"hostid"},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := firstBatchError(results); err != nil {
+		return nil, err
+	}
+
+	counts.Hosts, err = parseCount("host.get", results[0].Result)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []Host
+	if err := json.Unmarshal(results[1].Result, &hosts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal host.get response: %w", err)
+	}
+	if len(hosts) == 0 {
+		return counts, nil
+	}
+
+	hostIDs := make([]string, len(hosts))
+	for i, host := range hosts {
+		hostIDs[i] = host.HostID
+	}
+
+	values, err := c.batchCounts(ctx, objectCountRequests(filter.GroupIDs, hostIDs))
+	if err != nil {
+		return nil, err
+	}
+	counts.Items, counts.Triggers = values[0], values[1]
+
+	return counts, nil
+}
+
+// objectCountRequests returns the requests counting the items and triggers of hosts,
+// excluding template items and triggers.
+func objectCountRequests(groupIDs, hostIDs []string) []Request {
+	templated := false
+
+	return []Request{
+		{Method: "item.get", Params: GetItemParams{
+			GroupIDs:    groupIDs,
+			HostIDs:     hostIDs,
+			Templated:   &templated,
+			CountOutput: true,
+		}},
+		{Method: "trigger.get", Params: GetTriggerParams{
+			GroupIDs:    groupIDs,
+			HostIDs:     hostIDs,
+			Templated:   &templated,
+			CountOutput: true,
+		}},
+	}
+}
+
+// batchCounts sends get requests with countOutput set in one batch and parses the counts.
+func (c *Client) batchCounts(ctx context.Context, requests []Request) ([]int, error) {
+	results, err := c.Batch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+	if err := firstBatchError(results); err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, len(results))
+	for i, result := range results {
+		counts[i], err = parseCount(requests[i].Method, result.Result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return counts, nil
+}
+
+// parseCount parses the count returned by a get method with countOutput set.
+func parseCount(method string, result json.RawMessage) (int, error) {
+	var count FlexInt
+	if err := json.Unmarshal(result, &count); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal %s response: %w", method, err)
+	}
+	return int(count), nil
+}
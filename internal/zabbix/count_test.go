@@ -0,0 +1,166 @@
+// ABOUTME: Unit tests for Zabbix object count methods.
+// ABOUTME: Uses httptest to mock Zabbix API responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCountObjects_ByGroup(t *testing.T) {
+	counts := map[string]string{"host.get": "3", "item.get": "120", "trigger.get": "45"}
+
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		if params["countOutput"] != true {
+			t.Errorf("%s: expected countOutput true, got '%v'", req.Method, params["countOutput"])
+		}
+		groupIDs, ok := params["groupids"].([]interface{})
+		if !ok || len(groupIDs) != 1 || groupIDs[0] != "2" {
+			t.Errorf("%s: expected groupids ['2'], got '%v'", req.Method, params["groupids"])
+		}
+		if _, exists := params["hostids"]; exists {
+			t.Errorf("%s: expected hostids to be omitted, got '%v'", req.Method, params["hostids"])
+		}
+		if req.Method != "host.get" && params["templated"] != false {
+			t.Errorf("%s: expected templated false, got '%v'", req.Method, params["templated"])
+		}
+
+		count, ok := counts[req.Method]
+		if !ok {
+			t.Fatalf("unexpected method '%s'", req.Method)
+		}
+		result, _ := json.Marshal(count)
+		return result, nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.CountObjects(context.Background(), ObjectCountFilter{GroupIDs: []string{"2"}})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Hosts != 3 || result.Items != 120 || result.Triggers != 45 {
+		t.Errorf("expected counts 3/120/45, got %+v", result)
+	}
+	if batches != 1 {
+		t.Errorf("expected a single batch, got %d", batches)
+	}
+}
+
+func TestCountObjects_ByTag(t *testing.T) {
+	var methods []string
+
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		params := req.Params.(map[string]interface{})
+		methods = append(methods, req.Method)
+
+		switch {
+		case req.Method == "host.get" && params["countOutput"] == true:
+			return json.RawMessage(`"2"`), nil
+		case req.Method == "host.get":
+			if _, exists := params["tags"]; !exists {
+				t.Error("expected host tags to be passed when resolving host IDs")
+			}
+			return json.RawMessage(`[{"hostid": "10084"}, {"hostid": "10085"}]`), nil
+		default:
+			hostIDs, ok := params["hostids"].([]interface{})
+			if !ok || len(hostIDs) != 2 {
+				t.Errorf("%s: expected 2 hostids, got '%v'", req.Method, params["hostids"])
+			}
+			if _, exists := params["tags"]; exists {
+				t.Errorf("%s: expected tags to be omitted, got '%v'", req.Method, params["tags"])
+			}
+			return json.RawMessage(`"10"`), nil
+		}
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.CountObjects(context.Background(), ObjectCountFilter{
+		Tags: []TagFilter{{Tag: "env", Value: "prod", Operator: TagOperatorEquals}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Hosts != 2 || result.Items != 10 || result.Triggers != 10 {
+		t.Errorf("expected counts 2/10/10, got %+v", result)
+	}
+	if len(methods) != 4 {
+		t.Errorf("expected 4 requests, got %v", methods)
+	}
+	if batches != 2 {
+		t.Errorf("expected 2 batches, got %d", batches)
+	}
+}
+
+func TestCountObjects_ByTagNoHosts(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		if params := req.Params.(map[string]interface{}); params["countOutput"] == true {
+			return json.RawMessage(`"0"`), nil
+		}
+		return json.RawMessage(`[]`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.CountObjects(context.Background(), ObjectCountFilter{
+		Tags: []TagFilter{{Tag: "env", Operator: TagOperatorExists}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Hosts != 0 || result.Items != 0 || result.Triggers != 0 {
+		t.Errorf("expected zero counts, got %+v", result)
+	}
+	if batches != 1 {
+		t.Errorf("expected a single batch, got %d", batches)
+	}
+}
+
+func TestCountObjects_InvalidCount(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		return json.RawMessage(`"many"`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.CountObjects(context.Background(), ObjectCountFilter{})
+
+	if err == nil {
+		t.Fatal("expected error for invalid count, got nil")
+	}
+}
+
+func TestCountObjects_APIError(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		if req.Method == "trigger.get" {
+			return nil, &Error{Code: -32602, Message: "Invalid params."}
+		}
+		return json.RawMessage(`"1"`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.CountObjects(context.Background(), ObjectCountFilter{})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Method != "trigger.get" {
+		t.Fatalf("expected APIError for trigger.get, got %v", err)
+	}
+}
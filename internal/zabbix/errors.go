@@ -0,0 +1,51 @@
+// ABOUTME: Sentinel errors classifying Zabbix API errors for errors.Is checks.
+// ABOUTME: Derived from the message and data of the error, as Zabbix reuses few error codes.
+
+package zabbix
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by errors about objects that do not exist. Zabbix reports
+	// missing objects and objects the user may not see with the same error, so both match.
+	ErrNotFound = errors.New("zabbix object not found")
+	// ErrAlreadyExists is matched by errors about creating an object whose name is taken.
+	ErrAlreadyExists = errors.New("zabbix object already exists")
+	// ErrPermissionDenied is matched by errors about methods or operations the user may not use.
+	ErrPermissionDenied = errors.New("zabbix permission denied")
+	// ErrAuthExpired is matched by errors about an expired, terminated or invalid session or API token.
+	ErrAuthExpired = errors.New("zabbix session or api token expired")
+)
+
+// errorClasses maps fragments of lower-cased error messages to their sentinel error. The
+// fragments are checked in order, so the first matching class wins.
+var errorClasses = []struct {
+	fragment string
+	err      error
+}{
+	{"session terminated", ErrAuthExpired},
+	{"not authorised", ErrAuthExpired},
+	{"not authorized", ErrAuthExpired},
+	{"api token expired", ErrAuthExpired},
+	{"does not exist", ErrNotFound},
+	{"already exists", ErrAlreadyExists},
+	{"no permissions", ErrPermissionDenied},
+	{"do not have permission", ErrPermissionDenied},
+	{"permission denied", ErrPermissionDenied},
+}
+
+// Is reports whether the error belongs to the class of the sentinel error target, so that
+// errors.Is(err, ErrNotFound) matches API errors about missing objects.
+func (e *Error) Is(target error) bool {
+	message := strings.ToLower(e.Message + " " + e.Data)
+	for _, class := range errorClasses {
+		if strings.Contains(message, class.fragment) {
+			return class.err == target
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+// ABOUTME: Unit tests for the classification of Zabbix API errors.
+// ABOUTME: Checks errors.Is against the sentinel errors for typical Zabbix error messages.
+
+package zabbix
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError_Is(t *testing.T) {
+	tests := map[string]struct {
+		err      *Error
+		expected error
+	}{
+		"missing object": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: "No permissions to referred object or it does not exist!"},
+			expected: ErrNotFound,
+		},
+		"duplicate host": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: `Host with the same name "web01" already exists.`},
+			expected: ErrAlreadyExists,
+		},
+		"duplicate host group": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: `Host group "Linux servers" already exists.`},
+			expected: ErrAlreadyExists,
+		},
+		"method not permitted": {
+			err:      &Error{Code: -32500, Message: "Application error.", Data: `No permissions to call "user.create".`},
+			expected: ErrPermissionDenied,
+		},
+		"operation not permitted": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: "You do not have permission to perform this operation."},
+			expected: ErrPermissionDenied,
+		},
+		"session terminated": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: "Session terminated, re-login, please."},
+			expected: ErrAuthExpired,
+		},
+		"not authorised": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: "Not authorised."},
+			expected: ErrAuthExpired,
+		},
+		"token expired": {
+			err:      &Error{Code: -32602, Message: "Invalid params.", Data: "API token expired."},
+			expected: ErrAuthExpired,
+		},
+		"invalid parameter": {
+			err: &Error{Code: -32602, Message: "Invalid params.", Data: `Invalid parameter "/1": unexpected parameter "foo".`},
+		},
+	}
+
+	sentinels := []error{ErrNotFound, ErrAlreadyExists, ErrPermissionDenied, ErrAuthExpired}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Errors are usually wrapped by APIError and by the caller
+			err := fmt.Errorf("could not delete: %w", &APIError{Method: "host.delete", Err: tc.err})
+
+			for _, sentinel := range sentinels {
+				if matched := errors.Is(err, sentinel); matched != (sentinel == tc.expected) {
+					t.Errorf("errors.Is(%v, %v) = %t", err, sentinel, matched)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// ABOUTME: Provides API methods for updating Zabbix problem events.
+// ABOUTME: Implements acknowledging, closing and changing the severity of events with event.acknowledge.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+)
+
+// AcknowledgeAction is a bit of the action of event.acknowledge. Actions are combined with |.
+type AcknowledgeAction int
+
+// Actions of event.acknowledge.
+const (
+	AcknowledgeActionClose           AcknowledgeAction = 1
+	AcknowledgeActionAcknowledge     AcknowledgeAction = 2
+	AcknowledgeActionMessage         AcknowledgeAction = 4
+	AcknowledgeActionChangeSeverity  AcknowledgeAction = 8
+	AcknowledgeActionUnacknowledge   AcknowledgeAction = 16
+	AcknowledgeActionSuppress        AcknowledgeAction = 32
+	AcknowledgeActionUnsuppress      AcknowledgeAction = 64
+	AcknowledgeActionChangeToCause   AcknowledgeAction = 128
+	AcknowledgeActionChangeToSymptom AcknowledgeAction = 256
+)
+
+// AcknowledgeEventParams contains parameters for event.acknowledge.
+type AcknowledgeEventParams struct {
+	EventIDs []string          `json:"eventids"`
+	Action   AcknowledgeAction `json:"action"`
+	Message  string            `json:"message,omitempty"`
+	Severity *int              `json:"severity,omitempty"`
+}
+
+// AcknowledgeEventResponse contains the response from event.acknowledge.
+type AcknowledgeEventResponse struct {
+	EventIDs []FlexInt `json:"eventids"`
+}
+
+// AcknowledgeEvent applies the action to the problem events, for example acknowledging
+// and closing them with AcknowledgeActionAcknowledge|AcknowledgeActionClose. A non-empty
+// message is added to the events, AcknowledgeActionMessage does not need to be set for it.
+func (c *Client) AcknowledgeEvent(ctx context.Context, eventIDs []string, message string, action AcknowledgeAction) error {
+	return c.acknowledgeEvents(ctx, AcknowledgeEventParams{
+		EventIDs: eventIDs,
+		Action:   action,
+		Message:  message,
+	})
+}
+
+// ChangeEventSeverity changes the severity of the problem events, from 0 (not classified)
+// to 5 (disaster), adding the message to them when it is not empty.
+func (c *Client) ChangeEventSeverity(ctx context.Context, eventIDs []string, message string, severity int) error {
+	return c.acknowledgeEvents(ctx, AcknowledgeEventParams{
+		EventIDs: eventIDs,
+		Action:   AcknowledgeActionChangeSeverity,
+		Message:  message,
+		Severity: &severity,
+	})
+}
+
+// acknowledgeEvents sends event.acknowledge, setting the message action for messages.
+func (c *Client) acknowledgeEvents(ctx context.Context, params AcknowledgeEventParams) error {
+	if params.Message != "" {
+		params.Action |= AcknowledgeActionMessage
+	}
+
+	resp, err := Call[AcknowledgeEventResponse](ctx, c, "event.acknowledge", params)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.EventIDs) == 0 {
+		return fmt.Errorf("event.acknowledge returned no event IDs")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Unit tests for event API methods using mock HTTP responses.
+// ABOUTME: Tests cover the actions, messages and severities sent with event.acknowledge.
+
+package zabbix
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAcknowledgeEvent(t *testing.T) {
+	tests := map[string]struct {
+		message        string
+		action         AcknowledgeAction
+		expectedAction float64
+	}{
+		"acknowledge":             {action: AcknowledgeActionAcknowledge, expectedAction: 2},
+		"acknowledge and close":   {action: AcknowledgeActionAcknowledge | AcknowledgeActionClose, expectedAction: 3},
+		"message sets its action": {message: "Investigating", action: AcknowledgeActionAcknowledge, expectedAction: 6},
+		"message only":            {message: "Investigating", expectedAction: 4},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var params interface{}
+			server := newBulkTestServer(t, "event.acknowledge", &params, `{"eventids": [20, 21]}`)
+			defer server.Close()
+
+			client := NewClient(server.URL, WithToken("test-token"))
+			if err := client.AcknowledgeEvent(context.Background(), []string{"20", "21"}, tc.message, tc.action); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			p := params.(map[string]interface{})
+			if p["action"] != tc.expectedAction {
+				t.Errorf("expected action %v, got %v", tc.expectedAction, p["action"])
+			}
+			if eventIDs, ok := p["eventids"].([]interface{}); !ok || len(eventIDs) != 2 || eventIDs[0] != "20" {
+				t.Errorf("expected event IDs 20 and 21, got %v", p["eventids"])
+			}
+			if message, exists := p["message"]; tc.message == "" && exists {
+				t.Errorf("expected message to be omitted, got %v", message)
+			} else if tc.message != "" && message != tc.message {
+				t.Errorf("expected message '%s', got %v", tc.message, message)
+			}
+			if _, exists := p["severity"]; exists {
+				t.Error("expected severity to be omitted")
+			}
+		})
+	}
+}
+
+func TestAcknowledgeEvent_NoEventIDs(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "event.acknowledge", &params, `{"eventids": []}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.AcknowledgeEvent(context.Background(), []string{"20"}, "", AcknowledgeActionClose)
+	if err == nil || !strings.Contains(err.Error(), "no event IDs") {
+		t.Fatalf("expected no event IDs error, got %v", err)
+	}
+}
+
+func TestChangeEventSeverity(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "event.acknowledge", &params, `{"eventids": ["20"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if err := client.ChangeEventSeverity(context.Background(), []string{"20"}, "Downgraded by automation", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	if p["action"] != float64(12) {
+		t.Errorf("expected action 12, got %v", p["action"])
+	}
+	if severity, exists := p["severity"]; !exists || severity != float64(0) {
+		t.Errorf("expected severity 0 to be sent, got %v", p["severity"])
+	}
+}
@@ -0,0 +1,134 @@
+// ABOUTME: Canonical form of YAML configuration exports for comparing them across runs.
+// ABOUTME: Strips the export date and version, sorts keys and normalizes the quoting of scalars.
+
+package zabbix
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// exportMetadataKeys are the keys of zabbix_export that change with every export or
+// Zabbix version without describing the exported configuration.
+var exportMetadataKeys = map[string]bool{
+	"date":    true,
+	"version": true,
+}
+
+// yaml11NonStrings matches plain scalars that YAML 1.1 parsers, such as the one of the
+// Zabbix frontend, read as booleans, nulls or sexagesimal numbers while YAML 1.2 reads
+// them as strings.
+var yaml11NonStrings = regexp.MustCompile(`^(?:y|Y|yes|Yes|YES|n|N|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF|~|null|Null|NULL|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+(?:\.[0-9_]*)?)$`)
+
+// NormalizeExport returns the canonical form of a YAML configuration export, so that
+// exports of the same configuration are equal across runs and Zabbix patch versions.
+// The date and version of the export are removed, mapping keys are sorted, comments are
+// dropped and all scalars are written as strings, quoted only where YAML 1.1 or 1.2
+// would read them as another type. The order of list entries is kept.
+func NormalizeExport(content string) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", fmt.Errorf("invalid YAML export: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return "", fmt.Errorf("invalid YAML export: empty document")
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("invalid YAML export: expected a mapping, got %s", nodeKind(root))
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "zabbix_export" && root.Content[i+1].Kind == yaml.MappingNode {
+			removeKeys(root.Content[i+1], exportMetadataKeys)
+		}
+	}
+
+	normalizeNode(root)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return "", fmt.Errorf("failed to encode YAML export: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode YAML export: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// normalizeNode sorts the keys of all mappings below node and resets the tag, style and
+// comments of its scalars.
+func normalizeNode(node *yaml.Node) {
+	node.HeadComment, node.LineComment, node.FootComment = "", "", ""
+	node.Style = 0
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		// Zabbix reads every value as a string, so 1 and '1' are the same value
+		node.Tag = "!!str"
+		if yaml11NonStrings.MatchString(node.Value) {
+			node.Style = yaml.SingleQuotedStyle
+		}
+	case yaml.MappingNode:
+		sortMapping(node)
+		for _, child := range node.Content {
+			normalizeNode(child)
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			normalizeNode(child)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			normalizeNode(node.Alias)
+		}
+	}
+}
+
+// sortMapping sorts the key and value pairs of a mapping node by key.
+func sortMapping(node *yaml.Node) {
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i][0].Value < pairs[j][0].Value
+	})
+
+	for i, pair := range pairs {
+		node.Content[2*i], node.Content[2*i+1] = pair[0], pair[1]
+	}
+}
+
+// removeKeys removes the entries with the given keys from a mapping node.
+func removeKeys(node *yaml.Node, keys map[string]bool) {
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !keys[node.Content[i].Value] {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	node.Content = content
+}
+
+// nodeKind names the kind of a YAML node for error messages.
+func nodeKind(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a sequence"
+	case yaml.ScalarNode:
+		return "a scalar"
+	case yaml.AliasNode:
+		return "an alias"
+	default:
+		return "a document"
+	}
+}
@@ -0,0 +1,104 @@
+// ABOUTME: Unit tests for the canonical form of YAML configuration exports.
+// ABOUTME: Tests cover removed metadata, sorted keys, quoting of scalars and invalid content.
+
+package zabbix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeExport(t *testing.T) {
+	content := `zabbix_export:
+  version: '7.0'
+  date: '2024-05-01T10:00:00Z'
+  templates:
+    - uuid: 7df96b18c230490a9a0a9e2307226338
+      template: 'Linux by agent'
+      name: Linux by agent
+      # Items are collected every minute
+      items:
+        - name: CPU load
+          key: system.cpu.load
+          delay: 1m
+          history: '7d'
+          value_type: FLOAT
+        - name: Uptime
+          key: system.uptime
+          units: uptime
+          trends: 0
+          status: 'yes'
+`
+
+	expected := `zabbix_export:
+  templates:
+    - items:
+        - delay: 1m
+          history: 7d
+          key: system.cpu.load
+          name: CPU load
+          value_type: FLOAT
+        - key: system.uptime
+          name: Uptime
+          status: 'yes'
+          trends: "0"
+          units: uptime
+      name: Linux by agent
+      template: Linux by agent
+      uuid: 7df96b18c230490a9a0a9e2307226338
+`
+
+	normalized, err := NormalizeExport(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if normalized != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, normalized)
+	}
+}
+
+func TestNormalizeExport_EqualAcrossExports(t *testing.T) {
+	first := "zabbix_export:\n  version: '7.0'\n  date: '2024-05-01T10:00:00Z'\n  templates:\n    - template: Linux\n      name: Linux\n      description: |\n        Monitors Linux\n        hosts\n"
+	second := "zabbix_export:\n  date: \"2024-06-12T08:30:00Z\"\n  version: \"7.0\"\n  templates:\n  - name: \"Linux\"\n    template: Linux\n    description: \"Monitors Linux\\nhosts\\n\"\n"
+
+	a, err := NormalizeExport(first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := NormalizeExport(second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a != b {
+		t.Errorf("expected equal normalized exports, got:\n%s\nand:\n%s", a, b)
+	}
+}
+
+func TestNormalizeExport_QuotesYAML11Values(t *testing.T) {
+	normalized, err := NormalizeExport("zabbix_export:\n  templates:\n    - a: 'on'\n      b: 'NO'\n      c: '~'\n      d: '1:30'\n      e: 'enabled'\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range []string{"a: 'on'", "b: 'NO'", "c: '~'", "d: '1:30'", "e: enabled"} {
+		if !strings.Contains(normalized, line) {
+			t.Errorf("expected %q in:\n%s", line, normalized)
+		}
+	}
+}
+
+func TestNormalizeExport_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid syntax": "zabbix_export:\n  templates: [\n",
+		"empty":          "",
+		"sequence":       "- zabbix_export\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NormalizeExport(content); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// ABOUTME: Tolerant JSON types for values Zabbix returns as numbers or as strings.
+// ABOUTME: Lets API objects decode the same across Zabbix versions and methods.
+
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// FlexInt is an integer that Zabbix returns as JSON number or as numeric string, depending
+// on the method and version. Empty strings and null decode to 0. It is encoded as number.
+type FlexInt int64
+
+// UnmarshalJSON accepts numbers, numeric strings, empty strings and null.
+func (f *FlexInt) UnmarshalJSON(data []byte) error {
+	value, err := flexScalar(data)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		*f = 0
+		return nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer value: %s", data)
+	}
+	*f = FlexInt(n)
+	return nil
+}
+
+// MarshalJSON encodes the value as JSON number.
+func (f FlexInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(f), 10)), nil
+}
+
+// FlexBool is a boolean that Zabbix returns as JSON boolean, as 0 or 1, or as one of them
+// in a string. Empty strings and null decode to false. It is encoded as boolean.
+type FlexBool bool
+
+// UnmarshalJSON accepts booleans, 0 and 1 as numbers or strings, empty strings and null.
+func (f *FlexBool) UnmarshalJSON(data []byte) error {
+	value, err := flexScalar(data)
+	if err != nil {
+		return err
+	}
+
+	switch value {
+	case "1", "true":
+		*f = true
+	case "", "0", "false":
+		*f = false
+	default:
+		return fmt.Errorf("invalid boolean value: %s", data)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the value as JSON boolean.
+func (f FlexBool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(f))
+}
+
+// flexScalar returns the text of a JSON scalar, unquoting strings. Null is returned as
+// empty string.
+func flexScalar(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+
+	if len(data) > 0 && (data[0] == '{' || data[0] == '[') {
+		return "", fmt.Errorf("expected a scalar, got %s", data)
+	}
+	return string(data), nil
+}
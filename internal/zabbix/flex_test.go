@@ -0,0 +1,134 @@
+// ABOUTME: Unit tests for the tolerant JSON types of numeric and boolean values.
+// ABOUTME: Tests cover numbers, strings, null and invalid values, and API objects using them.
+
+package zabbix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexInt_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		json      string
+		expected  FlexInt
+		expectErr bool
+	}{
+		"number":         {json: `3`, expected: 3},
+		"string":         {json: `"3"`, expected: 3},
+		"negative":       {json: `"-1"`, expected: -1},
+		"timestamp":      {json: `"1735689600"`, expected: 1735689600},
+		"empty string":   {json: `""`, expected: 0},
+		"null":           {json: `null`, expected: 0},
+		"text":           {json: `"high"`, expectErr: true},
+		"float":          {json: `1.5`, expectErr: true},
+		"boolean":        {json: `true`, expectErr: true},
+		"object":         {json: `{"value": 1}`, expectErr: true},
+		"invalid string": {json: `"1`, expectErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			value := FlexInt(42)
+			err := json.Unmarshal([]byte(tc.json), &value)
+
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestFlexBool_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		json      string
+		expected  FlexBool
+		expectErr bool
+	}{
+		"true":          {json: `true`, expected: true},
+		"false":         {json: `false`, expected: false},
+		"one":           {json: `1`, expected: true},
+		"zero":          {json: `0`, expected: false},
+		"string one":    {json: `"1"`, expected: true},
+		"string zero":   {json: `"0"`, expected: false},
+		"string true":   {json: `"true"`, expected: true},
+		"empty string":  {json: `""`, expected: false},
+		"null":          {json: `null`, expected: false},
+		"other number":  {json: `2`, expectErr: true},
+		"other string":  {json: `"yes"`, expectErr: true},
+		"array of bool": {json: `[true]`, expectErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			value := FlexBool(!tc.expected)
+			err := json.Unmarshal([]byte(tc.json), &value)
+
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got %t", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestFlex_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Count   FlexInt  `json:"count"`
+		Enabled FlexBool `json:"enabled"`
+	}{Count: 7, Enabled: true})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"count":7,"enabled":true}` {
+		t.Errorf("expected a number and a boolean, got %s", data)
+	}
+}
+
+func TestFlexInt_APIObjects(t *testing.T) {
+	// Numeric fields decode the same whether Zabbix sends them as numbers or strings
+	var host Host
+	if err := json.Unmarshal([]byte(`{"hostid": "10084", "status": 1, "flags": "4", "tls_connect": 2, "interfaces": [{"type": 1, "main": "1", "useip": 1, "ip": "127.0.0.1", "dns": "", "port": "10050"}]}`), &host); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host.Status != 1 || host.Flags != HostFlagDiscovered || host.TLSConnect != 2 {
+		t.Errorf("expected status 1, flags 4 and tls_connect 2, got %+v", host)
+	}
+	if len(host.Interfaces) != 1 || host.Interfaces[0].Type != 1 || host.Interfaces[0].Main != 1 {
+		t.Errorf("expected a main agent interface, got %+v", host.Interfaces)
+	}
+
+	var item Item
+	if err := json.Unmarshal([]byte(`{"itemid": "1", "type": 0, "value_type": "3", "status": null}`), &item); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.ValueType != 3 || item.Status != 0 {
+		t.Errorf("expected value type 3 and status 0, got %+v", item)
+	}
+
+	var token Token
+	if err := json.Unmarshal([]byte(`{"tokenid": "2", "status": 1, "expires_at": 1735689600, "lastaccess": "0"}`), &token); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Status != 1 || token.ExpiresAt != 1735689600 {
+		t.Errorf("expected status 1 and expires_at 1735689600, got %+v", token)
+	}
+}
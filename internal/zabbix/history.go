@@ -0,0 +1,56 @@
+// ABOUTME: Provides API methods for Zabbix item history.
+// ABOUTME: Implements sending values to trapper and HTTP agent items with history.push.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// HistoryValue is a value for history.push. The item is identified either by ItemID or by
+// Host and Key. Clock and NS default to the time the server receives the value.
+type HistoryValue struct {
+	ItemID string `json:"itemid,omitempty"`
+	Host   string `json:"host,omitempty"`
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value"`
+	Clock  int64  `json:"clock,omitempty"`
+	NS     int64  `json:"ns,omitempty"`
+}
+
+// PushHistoryResponse contains the response from history.push.
+type PushHistoryResponse struct {
+	Response string               `json:"response"`
+	Data     []PushHistoryOutcome `json:"data"`
+}
+
+// PushHistoryOutcome is the outcome of a value sent with history.push, in the order of the
+// values. Error is set for values that were rejected.
+type PushHistoryOutcome struct {
+	ItemID string `json:"itemid"`
+	Error  string `json:"error"`
+}
+
+// PushHistory sends the values to the history of their items. The items must be trapper
+// or HTTP agent items allowing the user's host to send values. It requires Zabbix 7.0 and
+// fails if any value is rejected, after the accepted values have been stored.
+func (c *Client) PushHistory(ctx context.Context, values []HistoryValue) error {
+	resp, err := Call[PushHistoryResponse](ctx, c, "history.push", values)
+	if err != nil {
+		return err
+	}
+
+	var rejected []string
+	for i, outcome := range resp.Data {
+		if outcome.Error != "" {
+			rejected = append(rejected, fmt.Sprintf("value %d: %s", i, outcome.Error))
+		}
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("history.push rejected %d of %d values: %s", len(rejected), len(values), strings.Join(rejected, "; "))
+	}
+
+	return nil
+}
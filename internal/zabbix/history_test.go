@@ -0,0 +1,66 @@
+// ABOUTME: Unit tests for history API methods using mock HTTP responses.
+// ABOUTME: Tests cover the values sent with history.push and the handling of rejected values.
+
+package zabbix
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPushHistory_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "history.push", &params, `{"response": "success", "data": [{"itemid": "28252"}, {"itemid": "28253"}]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.PushHistory(context.Background(), []HistoryValue{
+		{ItemID: "28252", Value: "deployed v1.2.0", Clock: 1700000000},
+		{Host: "app-01", Key: "deploy.marker", Value: "1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values, ok := params.([]interface{})
+	if !ok || len(values) != 2 {
+		t.Fatalf("expected 2 values, got %v", params)
+	}
+
+	first := values[0].(map[string]interface{})
+	if first["itemid"] != "28252" || first["value"] != "deployed v1.2.0" || first["clock"] != float64(1700000000) {
+		t.Errorf("unexpected first value: %v", first)
+	}
+	if _, exists := first["host"]; exists {
+		t.Error("expected host to be omitted when itemid is set")
+	}
+
+	second := values[1].(map[string]interface{})
+	if second["host"] != "app-01" || second["key"] != "deploy.marker" {
+		t.Errorf("unexpected second value: %v", second)
+	}
+	for _, field := range []string{"itemid", "clock", "ns"} {
+		if _, exists := second[field]; exists {
+			t.Errorf("expected %s to be omitted", field)
+		}
+	}
+}
+
+func TestPushHistory_Rejected(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "history.push", &params, `{"response": "success", "data": [{"itemid": "28252"}, {"error": "No permissions to referred object or it does not exist."}]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.PushHistory(context.Background(), []HistoryValue{
+		{ItemID: "28252", Value: "1"},
+		{ItemID: "99999", Value: "1"},
+	})
+	if err == nil {
+		t.Fatal("expected error for rejected value")
+	}
+	if !strings.Contains(err.Error(), "rejected 1 of 2 values: value 1: No permissions") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
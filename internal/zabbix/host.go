@@ -7,33 +7,53 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
 )
 
+// HostFlagDiscovered marks a host created by low-level discovery from a host prototype.
+const HostFlagDiscovered = 4
+
 // Host represents a Zabbix host.
 type Host struct {
-	HostID          string           `json:"hostid,omitempty"`
-	Host            string           `json:"host,omitempty"`
-	Name            string           `json:"name,omitempty"`
-	Status          int              `json:"-"`
-	Groups          []HostGroupID    `json:"groups,omitempty"`
-	Interfaces      []HostInterface  `json:"interfaces,omitempty"`
-	Tags            []HostTag        `json:"tags,omitempty"`
-	Templates       []TemplateID     `json:"templates,omitempty"`
-	ParentTemplates []ParentTemplate `json:"parentTemplates,omitempty"`
-}
-
-// hostJSON is used for JSON marshaling/unmarshaling with string status.
+	HostID            string           `json:"hostid,omitempty"`
+	Host              string           `json:"host,omitempty"`
+	Name              string           `json:"name,omitempty"`
+	Status            int              `json:"-"`
+	Flags             int              `json:"-"`
+	MaintenanceStatus int              `json:"-"`
+	ActiveAvailable   int              `json:"-"`
+	TLSConnect        int              `json:"-"`
+	TLSAccept         int              `json:"-"`
+	TLSPSKIdentity    string           `json:"tls_psk_identity,omitempty"`
+	TLSPSK            string           `json:"tls_psk,omitempty"`
+	IPMIUsername      *string          `json:"ipmi_username,omitempty"`
+	IPMIPassword      string           `json:"ipmi_password,omitempty"`
+	Groups            []HostGroupID    `json:"groups,omitempty"`
+	Interfaces        []HostInterface  `json:"interfaces,omitempty"`
+	Tags              []HostTag        `json:"tags,omitempty"`
+	Templates         []TemplateID     `json:"templates,omitempty"`
+	TemplatesClear    []TemplateID     `json:"templates_clear,omitempty"`
+	ParentTemplates   []ParentTemplate `json:"parentTemplates,omitempty"`
+	// KeepStatus leaves the status of the host unchanged on update.
+	KeepStatus bool `json:"-"`
+}
+
+// hostJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
 type hostJSON struct {
-	HostID          string           `json:"hostid,omitempty"`
-	Host            string           `json:"host,omitempty"`
-	Name            string           `json:"name,omitempty"`
-	Status          string           `json:"status,omitempty"`
-	Groups          []HostGroupID    `json:"groups,omitempty"`
-	Interfaces      []HostInterface  `json:"interfaces,omitempty"`
-	Tags            []HostTag        `json:"tags,omitempty"`
-	Templates       []TemplateID     `json:"templates,omitempty"`
-	ParentTemplates []ParentTemplate `json:"parentTemplates,omitempty"`
+	HostID            string           `json:"hostid,omitempty"`
+	Host              string           `json:"host,omitempty"`
+	Name              string           `json:"name,omitempty"`
+	Status            FlexInt          `json:"status,omitempty"`
+	Flags             FlexInt          `json:"flags,omitempty"`
+	MaintenanceStatus FlexInt          `json:"maintenance_status,omitempty"`
+	ActiveAvailable   FlexInt          `json:"active_available,omitempty"`
+	TLSConnect        FlexInt          `json:"tls_connect,omitempty"`
+	TLSAccept         FlexInt          `json:"tls_accept,omitempty"`
+	IPMIUsername      *string          `json:"ipmi_username,omitempty"`
+	Groups            []HostGroupID    `json:"groups,omitempty"`
+	Interfaces        []HostInterface  `json:"interfaces,omitempty"`
+	Tags              []HostTag        `json:"tags,omitempty"`
+	Templates         []TemplateID     `json:"templates,omitempty"`
+	ParentTemplates   []ParentTemplate `json:"parentTemplates,omitempty"`
 }
 
 // UnmarshalJSON handles Zabbix API returning numeric values as strings.
@@ -51,14 +71,13 @@ func (h *Host) UnmarshalJSON(data []byte) error {
 	h.Tags = hj.Tags
 	h.Templates = hj.Templates
 	h.ParentTemplates = hj.ParentTemplates
-
-	if hj.Status != "" {
-		status, err := strconv.Atoi(hj.Status)
-		if err != nil {
-			return fmt.Errorf("invalid status value: %s", hj.Status)
-		}
-		h.Status = status
-	}
+	h.IPMIUsername = hj.IPMIUsername
+	h.Status = int(hj.Status)
+	h.Flags = int(hj.Flags)
+	h.MaintenanceStatus = int(hj.MaintenanceStatus)
+	h.ActiveAvailable = int(hj.ActiveAvailable)
+	h.TLSConnect = int(hj.TLSConnect)
+	h.TLSAccept = int(hj.TLSAccept)
 
 	return nil
 }
@@ -78,17 +97,85 @@ type HostInterface struct {
 	IP          string `json:"ip"`
 	DNS         string `json:"dns"`
 	Port        string `json:"port"`
+	Available   int    `json:"-"`
+	Error       string `json:"-"`
+	// Details holds the SNMP settings of SNMP interfaces, and is nil for other interfaces.
+	Details *SNMPDetails `json:"-"`
 }
 
-// hostInterfaceJSON is used for JSON unmarshaling with string numeric fields.
+// SNMP versions of SNMPDetails.
+const (
+	SNMPVersion1  = 1
+	SNMPVersion2c = 2
+	SNMPVersion3  = 3
+)
+
+// SNMPDetails holds the SNMP settings of an SNMP interface. Community applies to SNMPv1
+// and SNMPv2c, the other settings to SNMPv3. The passphrases are only sent to Zabbix.
+type SNMPDetails struct {
+	Version        int
+	Bulk           int
+	Community      string
+	SecurityName   string
+	SecurityLevel  int
+	AuthProtocol   int
+	AuthPassphrase string
+	PrivProtocol   int
+	PrivPassphrase string
+	ContextName    string
+}
+
+// snmpDetailsJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
+type snmpDetailsJSON struct {
+	Version       FlexInt `json:"version"`
+	Bulk          FlexInt `json:"bulk"`
+	Community     string  `json:"community"`
+	SecurityName  string  `json:"securityname"`
+	SecurityLevel FlexInt `json:"securitylevel"`
+	AuthProtocol  FlexInt `json:"authprotocol"`
+	PrivProtocol  FlexInt `json:"privprotocol"`
+	ContextName   string  `json:"contextname"`
+}
+
+// params returns the details parameter of host.create and host.update. The passphrases
+// are only sent when set, so Zabbix keeps the stored ones otherwise.
+func (d *SNMPDetails) params() map[string]interface{} {
+	params := map[string]interface{}{
+		"version": d.Version,
+		"bulk":    d.Bulk,
+	}
+	if d.Version != SNMPVersion3 {
+		params["community"] = d.Community
+		return params
+	}
+
+	params["securityname"] = d.SecurityName
+	params["securitylevel"] = d.SecurityLevel
+	params["authprotocol"] = d.AuthProtocol
+	params["privprotocol"] = d.PrivProtocol
+	params["contextname"] = d.ContextName
+	if d.AuthPassphrase != "" {
+		params["authpassphrase"] = d.AuthPassphrase
+	}
+	if d.PrivPassphrase != "" {
+		params["privpassphrase"] = d.PrivPassphrase
+	}
+	return params
+}
+
+// hostInterfaceJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
 type hostInterfaceJSON struct {
-	InterfaceID string `json:"interfaceid,omitempty"`
-	Type        string `json:"type"`
-	Main        string `json:"main"`
-	UseIP       string `json:"useip"`
-	IP          string `json:"ip"`
-	DNS         string `json:"dns"`
-	Port        string `json:"port"`
+	InterfaceID string  `json:"interfaceid,omitempty"`
+	Type        FlexInt `json:"type"`
+	Main        FlexInt `json:"main"`
+	UseIP       FlexInt `json:"useip"`
+	IP          string  `json:"ip"`
+	DNS         string  `json:"dns"`
+	Port        string  `json:"port"`
+	Available   FlexInt `json:"available"`
+	Error       string  `json:"error"`
+	// Details is an object for SNMP interfaces and an empty array for other interfaces.
+	Details json.RawMessage `json:"details"`
 }
 
 // UnmarshalJSON handles Zabbix API returning numeric values as strings.
@@ -102,29 +189,27 @@ func (hi *HostInterface) UnmarshalJSON(data []byte) error {
 	hi.IP = hij.IP
 	hi.DNS = hij.DNS
 	hi.Port = hij.Port
-
-	if hij.Type != "" {
-		t, err := strconv.Atoi(hij.Type)
-		if err != nil {
-			return fmt.Errorf("invalid interface type value: %s", hij.Type)
+	hi.Error = hij.Error
+	hi.Type = int(hij.Type)
+	hi.Main = int(hij.Main)
+	hi.UseIP = int(hij.UseIP)
+	hi.Available = int(hij.Available)
+
+	if len(hij.Details) > 0 && hij.Details[0] == '{' {
+		var details snmpDetailsJSON
+		if err := json.Unmarshal(hij.Details, &details); err != nil {
+			return err
 		}
-		hi.Type = t
-	}
-
-	if hij.Main != "" {
-		m, err := strconv.Atoi(hij.Main)
-		if err != nil {
-			return fmt.Errorf("invalid interface main value: %s", hij.Main)
+		hi.Details = &SNMPDetails{
+			Version:       int(details.Version),
+			Bulk:          int(details.Bulk),
+			Community:     details.Community,
+			SecurityName:  details.SecurityName,
+			SecurityLevel: int(details.SecurityLevel),
+			AuthProtocol:  int(details.AuthProtocol),
+			PrivProtocol:  int(details.PrivProtocol),
+			ContextName:   details.ContextName,
 		}
-		hi.Main = m
-	}
-
-	if hij.UseIP != "" {
-		u, err := strconv.Atoi(hij.UseIP)
-		if err != nil {
-			return fmt.Errorf("invalid interface useip value: %s", hij.UseIP)
-		}
-		hi.UseIP = u
 	}
 
 	return nil
@@ -143,6 +228,9 @@ func (hi HostInterface) MarshalJSON() ([]byte, error) {
 	if hi.InterfaceID != "" {
 		m["interfaceid"] = hi.InterfaceID
 	}
+	if hi.Details != nil {
+		m["details"] = hi.Details.params()
+	}
 	return json.Marshal(m)
 }
 
@@ -152,6 +240,33 @@ type HostTag struct {
 	Value string `json:"value"`
 }
 
+// Tag filter operators accepted by host.get.
+const (
+	TagOperatorContains    = 0
+	TagOperatorEquals      = 1
+	TagOperatorNotContains = 2
+	TagOperatorNotEquals   = 3
+	TagOperatorExists      = 4
+	TagOperatorNotExists   = 5
+)
+
+// TagFilter filters hosts or templates by tag in host.get and template.get.
+type TagFilter struct {
+	Tag      string `json:"tag"`
+	Value    string `json:"value"`
+	Operator int    `json:"operator"`
+}
+
+// HostSearch contains the criteria for SearchHosts. Empty criteria are not applied,
+// and hosts must match all criteria that are set.
+type HostSearch struct {
+	GroupIDs []string
+	ProxyIDs []string
+	// Pattern is matched against the whole technical or visible name, with * as wildcard.
+	Pattern string
+	Tags    []TagFilter
+}
+
 // TemplateID represents a template reference by ID.
 type TemplateID struct {
 	TemplateID string `json:"templateid"`
@@ -160,6 +275,7 @@ type TemplateID struct {
 // ParentTemplate represents a linked template returned from host.get.
 type ParentTemplate struct {
 	TemplateID string `json:"templateid"`
+	Host       string `json:"host,omitempty"`
 	Name       string `json:"name,omitempty"`
 }
 
@@ -171,7 +287,16 @@ type CreateHostResponse struct {
 // GetHostParams contains parameters for retrieving hosts.
 type GetHostParams struct {
 	HostIDs               []string               `json:"hostids,omitempty"`
+	TemplateIDs           []string               `json:"templateids,omitempty"`
+	GroupIDs              []string               `json:"groupids,omitempty"`
+	ProxyIDs              []string               `json:"proxyids,omitempty"`
 	Filter                map[string]interface{} `json:"filter,omitempty"`
+	Search                map[string]interface{} `json:"search,omitempty"`
+	SearchByAny           bool                   `json:"searchByAny,omitempty"`
+	SearchWildcards       bool                   `json:"searchWildcardsEnabled,omitempty"`
+	Tags                  []TagFilter            `json:"tags,omitempty"`
+	SortField             string                 `json:"sortfield,omitempty"`
+	CountOutput           bool                   `json:"countOutput,omitempty"`
 	Output                interface{}            `json:"output,omitempty"`
 	SelectGroups          interface{}            `json:"selectGroups,omitempty"`
 	SelectInterfaces      interface{}            `json:"selectInterfaces,omitempty"`
@@ -191,6 +316,39 @@ type DeleteHostResponse struct {
 
 // CreateHost creates a new host and returns the created host ID.
 func (c *Client) CreateHost(ctx context.Context, host *Host) (string, error) {
+	resp, err := Call[CreateHostResponse](ctx, c, "host.create", createHostParams(host))
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.HostIDs) == 0 {
+		return "", fmt.Errorf("host.create returned no host IDs")
+	}
+
+	return resp.HostIDs[0], nil
+}
+
+// CreateHosts creates several hosts in a single request and returns the created host IDs in input order.
+func (c *Client) CreateHosts(ctx context.Context, hosts []*Host) ([]string, error) {
+	params := make([]map[string]interface{}, len(hosts))
+	for i, host := range hosts {
+		params[i] = createHostParams(host)
+	}
+
+	resp, err := Call[CreateHostResponse](ctx, c, "host.create", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.HostIDs) != len(hosts) {
+		return nil, fmt.Errorf("host.create returned %d host IDs for %d hosts", len(resp.HostIDs), len(hosts))
+	}
+
+	return resp.HostIDs, nil
+}
+
+// createHostParams builds the host.create parameters for a single host.
+func createHostParams(host *Host) map[string]interface{} {
 	params := map[string]interface{}{
 		"host":   host.Host,
 		"status": host.Status,
@@ -200,6 +358,8 @@ func (c *Client) CreateHost(ctx context.Context, host *Host) (string, error) {
 		params["name"] = host.Name
 	}
 
+	addHostSecurityParams(params, host)
+
 	if len(host.Groups) > 0 {
 		groups := make([]map[string]string, len(host.Groups))
 		for i, g := range host.Groups {
@@ -219,6 +379,9 @@ func (c *Client) CreateHost(ctx context.Context, host *Host) (string, error) {
 				"dns":   iface.DNS,
 				"port":  iface.Port,
 			}
+			if iface.Details != nil {
+				interfaces[i]["details"] = iface.Details.params()
+			}
 		}
 		params["interfaces"] = interfaces
 	}
@@ -239,27 +402,28 @@ func (c *Client) CreateHost(ctx context.Context, host *Host) (string, error) {
 		params["tags"] = tags
 	}
 
-	result, err := c.RequestWithContext(ctx, "host.create", params)
-	if err != nil {
-		return "", err
-	}
+	return params
+}
 
-	var resp CreateHostResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal host.create response: %w", err)
+// GetHost retrieves a host by ID with all related data.
+func (c *Client) GetHost(ctx context.Context, hostID string) (*Host, error) {
+	hosts, err := c.GetHosts(ctx, []string{hostID})
+	if err != nil {
+		return nil, err
 	}
 
-	if len(resp.HostIDs) == 0 {
-		return "", fmt.Errorf("host.create returned no host IDs")
+	if len(hosts) == 0 {
+		return nil, nil
 	}
 
-	return resp.HostIDs[0], nil
+	return &hosts[0], nil
 }
 
-// GetHost retrieves a host by ID with all related data.
-func (c *Client) GetHost(ctx context.Context, hostID string) (*Host, error) {
+// GetHosts retrieves several hosts by ID with all related data in a single request.
+// Hosts that do not exist are omitted from the result.
+func (c *Client) GetHosts(ctx context.Context, hostIDs []string) ([]Host, error) {
 	params := GetHostParams{
-		HostIDs:               []string{hostID},
+		HostIDs:               hostIDs,
 		Output:                "extend",
 		SelectGroups:          "extend",
 		SelectInterfaces:      "extend",
@@ -267,21 +431,73 @@ func (c *Client) GetHost(ctx context.Context, hostID string) (*Host, error) {
 		SelectParentTemplates: "extend",
 	}
 
-	result, err := c.RequestWithContext(ctx, "host.get", params)
+	hosts, err := Call[[]Host](ctx, c, "host.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var hosts []Host
-	if err := json.Unmarshal(result, &hosts); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal host.get response: %w", err)
+	return hosts, nil
+}
+
+// GetHostsByTemplate retrieves the hosts a template is linked to, including all of their
+// linked templates.
+func (c *Client) GetHostsByTemplate(ctx context.Context, templateID string) ([]Host, error) {
+	params := GetHostParams{
+		TemplateIDs:           []string{templateID},
+		Output:                []string{"hostid", "host"},
+		SelectParentTemplates: []string{"templateid"},
 	}
 
-	if len(hosts) == 0 {
-		return nil, nil
+	hosts, err := Call[[]Host](ctx, c, "host.get", params)
+	if err != nil {
+		return nil, err
 	}
 
-	return &hosts[0], nil
+	return hosts, nil
+}
+
+// GetHostsByGroup retrieves the hosts in a host group, including all of their host groups.
+func (c *Client) GetHostsByGroup(ctx context.Context, groupID string) ([]Host, error) {
+	params := GetHostParams{
+		GroupIDs:     []string{groupID},
+		Output:       []string{"hostid", "host"},
+		SelectGroups: []string{"groupid"},
+	}
+
+	hosts, err := Call[[]Host](ctx, c, "host.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
+}
+
+// SearchHosts retrieves the hosts matching the search criteria, sorted by technical name.
+// Only the ID, names and status of each host are returned.
+func (c *Client) SearchHosts(ctx context.Context, search HostSearch) ([]Host, error) {
+	params := GetHostParams{
+		GroupIDs:  search.GroupIDs,
+		ProxyIDs:  search.ProxyIDs,
+		Tags:      search.Tags,
+		Output:    []string{"hostid", "host", "name", "status"},
+		SortField: "host",
+	}
+
+	if search.Pattern != "" {
+		params.Search = map[string]interface{}{
+			"host": search.Pattern,
+			"name": search.Pattern,
+		}
+		params.SearchByAny = true
+		params.SearchWildcards = true
+	}
+
+	hosts, err := Call[[]Host](ctx, c, "host.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return hosts, nil
 }
 
 // GetHostByName retrieves a host by technical name.
@@ -297,16 +513,11 @@ func (c *Client) GetHostByName(ctx context.Context, hostname string) (*Host, err
 		SelectParentTemplates: "extend",
 	}
 
-	result, err := c.RequestWithContext(ctx, "host.get", params)
+	hosts, err := Call[[]Host](ctx, c, "host.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var hosts []Host
-	if err := json.Unmarshal(result, &hosts); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal host.get response: %w", err)
-	}
-
 	if len(hosts) == 0 {
 		return nil, nil
 	}
@@ -316,6 +527,39 @@ func (c *Client) GetHostByName(ctx context.Context, hostname string) (*Host, err
 
 // UpdateHost updates a host.
 func (c *Client) UpdateHost(ctx context.Context, host *Host) error {
+	resp, err := Call[UpdateHostResponse](ctx, c, "host.update", updateHostParams(host))
+	if err != nil {
+		return err
+	}
+
+	if len(resp.HostIDs) == 0 {
+		return fmt.Errorf("host.update returned no host IDs")
+	}
+
+	return nil
+}
+
+// UpdateHosts updates several hosts in a single request.
+func (c *Client) UpdateHosts(ctx context.Context, hosts []*Host) error {
+	params := make([]map[string]interface{}, len(hosts))
+	for i, host := range hosts {
+		params[i] = updateHostParams(host)
+	}
+
+	resp, err := Call[UpdateHostResponse](ctx, c, "host.update", params)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.HostIDs) != len(hosts) {
+		return fmt.Errorf("host.update returned %d host IDs for %d hosts", len(resp.HostIDs), len(hosts))
+	}
+
+	return nil
+}
+
+// updateHostParams builds the host.update parameters for a single host.
+func updateHostParams(host *Host) map[string]interface{} {
 	params := map[string]interface{}{
 		"hostid": host.HostID,
 	}
@@ -328,8 +572,12 @@ func (c *Client) UpdateHost(ctx context.Context, host *Host) error {
 		params["name"] = host.Name
 	}
 
-	// Status is always included since 0 is a valid value
-	params["status"] = host.Status
+	// Status is included unless it is kept, since 0 is a valid value
+	if !host.KeepStatus {
+		params["status"] = host.Status
+	}
+
+	addHostSecurityParams(params, host)
 
 	if len(host.Groups) > 0 {
 		groups := make([]map[string]string, len(host.Groups))
@@ -339,7 +587,7 @@ func (c *Client) UpdateHost(ctx context.Context, host *Host) error {
 		params["groups"] = groups
 	}
 
-	if len(host.Interfaces) > 0 {
+	if host.Interfaces != nil {
 		interfaces := make([]map[string]interface{}, len(host.Interfaces))
 		for i, iface := range host.Interfaces {
 			ifaceMap := map[string]interface{}{
@@ -353,6 +601,9 @@ func (c *Client) UpdateHost(ctx context.Context, host *Host) error {
 			if iface.InterfaceID != "" {
 				ifaceMap["interfaceid"] = iface.InterfaceID
 			}
+			if iface.Details != nil {
+				ifaceMap["details"] = iface.Details.params()
+			}
 			interfaces[i] = ifaceMap
 		}
 		params["interfaces"] = interfaces
@@ -366,6 +617,14 @@ func (c *Client) UpdateHost(ctx context.Context, host *Host) error {
 		params["templates"] = templates
 	}
 
+	if len(host.TemplatesClear) > 0 {
+		templatesClear := make([]map[string]string, len(host.TemplatesClear))
+		for i, t := range host.TemplatesClear {
+			templatesClear[i] = map[string]string{"templateid": t.TemplateID}
+		}
+		params["templates_clear"] = templatesClear
+	}
+
 	if host.Tags != nil {
 		tags := make([]map[string]string, len(host.Tags))
 		for i, t := range host.Tags {
@@ -374,40 +633,163 @@ func (c *Client) UpdateHost(ctx context.Context, host *Host) error {
 		params["tags"] = tags
 	}
 
-	result, err := c.RequestWithContext(ctx, "host.update", params)
-	if err != nil {
-		return err
+	return params
+}
+
+// addHostSecurityParams adds the encryption and IPMI settings that are set on the host.
+// Secrets are only sent when given since Zabbix never returns them.
+func addHostSecurityParams(params map[string]interface{}, host *Host) {
+	if host.TLSConnect != 0 {
+		params["tls_connect"] = host.TLSConnect
 	}
 
-	var resp UpdateHostResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal host.update response: %w", err)
+	if host.TLSAccept != 0 {
+		params["tls_accept"] = host.TLSAccept
 	}
 
-	if len(resp.HostIDs) == 0 {
-		return fmt.Errorf("host.update returned no host IDs")
+	if host.TLSPSKIdentity != "" {
+		params["tls_psk_identity"] = host.TLSPSKIdentity
 	}
 
-	return nil
+	if host.TLSPSK != "" {
+		params["tls_psk"] = host.TLSPSK
+	}
+
+	if host.IPMIUsername != nil {
+		params["ipmi_username"] = *host.IPMIUsername
+	}
+
+	if host.IPMIPassword != "" {
+		params["ipmi_password"] = host.IPMIPassword
+	}
 }
 
 // DeleteHost deletes a host by ID.
 func (c *Client) DeleteHost(ctx context.Context, hostID string) error {
-	params := []string{hostID}
+	return c.DeleteHosts(ctx, []string{hostID})
+}
 
-	result, err := c.RequestWithContext(ctx, "host.delete", params)
+// DeleteHosts deletes several hosts by ID in a single request.
+func (c *Client) DeleteHosts(ctx context.Context, hostIDs []string) error {
+	resp, err := Call[DeleteHostResponse](ctx, c, "host.delete", hostIDs)
 	if err != nil {
 		return err
 	}
 
-	var resp DeleteHostResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal host.delete response: %w", err)
+	if len(resp.HostIDs) == 0 {
+		return fmt.Errorf("host.delete returned no host IDs")
+	}
+
+	return nil
+}
+
+// MassAddHostGroups adds the given host groups to the hosts without touching their other groups.
+func (c *Client) MassAddHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error {
+	params := map[string]interface{}{
+		"hosts":  hostRefs(hostIDs),
+		"groups": groupRefs(groupIDs),
+	}
+
+	return c.massHostCall(ctx, "host.massadd", params)
+}
+
+// MassAddHostTemplates links the given templates to the hosts without touching their other templates.
+func (c *Client) MassAddHostTemplates(ctx context.Context, hostIDs []string, templateIDs []string) error {
+	params := map[string]interface{}{
+		"hosts":     hostRefs(hostIDs),
+		"templates": templateRefs(templateIDs),
+	}
+
+	return c.massHostCall(ctx, "host.massadd", params)
+}
+
+// MassRemoveHostGroups removes the given host groups from the hosts without touching their other groups.
+func (c *Client) MassRemoveHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error {
+	params := map[string]interface{}{
+		"hostids":  hostIDs,
+		"groupids": groupIDs,
+	}
+
+	return c.massHostCall(ctx, "host.massremove", params)
+}
+
+// MassRemoveHostTemplates unlinks the given templates from the hosts without touching their
+// other templates. With clear, the entities inherited from the templates are removed as well;
+// otherwise they are kept on the hosts as unlinked copies.
+func (c *Client) MassRemoveHostTemplates(ctx context.Context, hostIDs []string, templateIDs []string, clear bool) error {
+	params := map[string]interface{}{
+		"hostids": hostIDs,
+	}
+	if clear {
+		params["templateids_clear"] = templateIDs
+	} else {
+		params["templateids"] = templateIDs
+	}
+
+	return c.massHostCall(ctx, "host.massremove", params)
+}
+
+// MassUpdateHostGroups replaces the host groups of the hosts with groupIDs.
+func (c *Client) MassUpdateHostGroups(ctx context.Context, hostIDs []string, groupIDs []string) error {
+	params := map[string]interface{}{
+		"hosts":  hostRefs(hostIDs),
+		"groups": groupRefs(groupIDs),
+	}
+
+	return c.massHostCall(ctx, "host.massupdate", params)
+}
+
+// MassUpdateHostTemplates replaces the templates linked to the hosts with templateIDs.
+// Templates in clearTemplateIDs are unlinked and their inherited entities removed.
+func (c *Client) MassUpdateHostTemplates(ctx context.Context, hostIDs []string, templateIDs []string, clearTemplateIDs []string) error {
+	params := map[string]interface{}{
+		"hosts":     hostRefs(hostIDs),
+		"templates": templateRefs(templateIDs),
+	}
+	if len(clearTemplateIDs) > 0 {
+		params["templates_clear"] = templateRefs(clearTemplateIDs)
+	}
+
+	return c.massHostCall(ctx, "host.massupdate", params)
+}
+
+// massHostCall sends one of the host.mass* methods and checks that it reports changed hosts.
+func (c *Client) massHostCall(ctx context.Context, method string, params map[string]interface{}) error {
+	resp, err := Call[UpdateHostResponse](ctx, c, method, params)
+	if err != nil {
+		return err
 	}
 
 	if len(resp.HostIDs) == 0 {
-		return fmt.Errorf("host.delete returned no host IDs")
+		return fmt.Errorf("%s returned no host IDs", method)
 	}
 
 	return nil
 }
+
+// hostRefs builds the host objects of the mass methods from host IDs.
+func hostRefs(hostIDs []string) []map[string]string {
+	hosts := make([]map[string]string, len(hostIDs))
+	for i, id := range hostIDs {
+		hosts[i] = map[string]string{"hostid": id}
+	}
+	return hosts
+}
+
+// groupRefs builds the host group objects of the mass methods from group IDs.
+func groupRefs(groupIDs []string) []HostGroupID {
+	groups := make([]HostGroupID, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = HostGroupID{GroupID: id}
+	}
+	return groups
+}
+
+// templateRefs builds the template objects of the mass methods from template IDs.
+func templateRefs(templateIDs []string) []TemplateID {
+	templates := make([]TemplateID, len(templateIDs))
+	for i, id := range templateIDs {
+		templates[i] = TemplateID{TemplateID: id}
+	}
+	return templates
+}
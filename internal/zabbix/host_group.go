@@ -5,7 +5,6 @@ package zabbix
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -28,9 +27,12 @@ type CreateHostGroupResponse struct {
 
 // GetHostGroupParams contains parameters for retrieving host groups.
 type GetHostGroupParams struct {
-	GroupIDs []string               `json:"groupids,omitempty"`
-	Filter   map[string]interface{} `json:"filter,omitempty"`
-	Output   interface{}            `json:"output,omitempty"`
+	GroupIDs        []string               `json:"groupids,omitempty"`
+	Filter          map[string]interface{} `json:"filter,omitempty"`
+	Search          map[string]interface{} `json:"search,omitempty"`
+	SearchWildcards bool                   `json:"searchWildcardsEnabled,omitempty"`
+	Output          interface{}            `json:"output,omitempty"`
+	SortField       string                 `json:"sortfield,omitempty"`
 }
 
 // UpdateHostGroupParams contains parameters for updating a host group.
@@ -55,16 +57,11 @@ func (c *Client) CreateHostGroup(ctx context.Context, name string) (string, erro
 		Name: name,
 	}
 
-	result, err := c.RequestWithContext(ctx, "hostgroup.create", params)
+	resp, err := Call[CreateHostGroupResponse](ctx, c, "hostgroup.create", params)
 	if err != nil {
 		return "", err
 	}
 
-	var resp CreateHostGroupResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal hostgroup.create response: %w", err)
-	}
-
 	if len(resp.GroupIDs) == 0 {
 		return "", fmt.Errorf("hostgroup.create returned no group IDs")
 	}
@@ -72,6 +69,25 @@ func (c *Client) CreateHostGroup(ctx context.Context, name string) (string, erro
 	return resp.GroupIDs[0], nil
 }
 
+// CreateHostGroups creates several host groups in a single request and returns the created group IDs in input order.
+func (c *Client) CreateHostGroups(ctx context.Context, names []string) ([]string, error) {
+	params := make([]CreateHostGroupParams, len(names))
+	for i, name := range names {
+		params[i] = CreateHostGroupParams{Name: name}
+	}
+
+	resp, err := Call[CreateHostGroupResponse](ctx, c, "hostgroup.create", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.GroupIDs) != len(names) {
+		return nil, fmt.Errorf("hostgroup.create returned %d group IDs for %d groups", len(resp.GroupIDs), len(names))
+	}
+
+	return resp.GroupIDs, nil
+}
+
 // GetHostGroup retrieves a host group by ID.
 func (c *Client) GetHostGroup(ctx context.Context, groupID string) (*HostGroup, error) {
 	params := GetHostGroupParams{
@@ -79,16 +95,11 @@ func (c *Client) GetHostGroup(ctx context.Context, groupID string) (*HostGroup,
 		Output:   "extend",
 	}
 
-	result, err := c.RequestWithContext(ctx, "hostgroup.get", params)
+	groups, err := Call[[]HostGroup](ctx, c, "hostgroup.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var groups []HostGroup
-	if err := json.Unmarshal(result, &groups); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal hostgroup.get response: %w", err)
-	}
-
 	if len(groups) == 0 {
 		return nil, nil
 	}
@@ -105,16 +116,11 @@ func (c *Client) GetHostGroupByName(ctx context.Context, name string) (*HostGrou
 		Output: "extend",
 	}
 
-	result, err := c.RequestWithContext(ctx, "hostgroup.get", params)
+	groups, err := Call[[]HostGroup](ctx, c, "hostgroup.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var groups []HostGroup
-	if err := json.Unmarshal(result, &groups); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal hostgroup.get response: %w", err)
-	}
-
 	if len(groups) == 0 {
 		return nil, nil
 	}
@@ -122,6 +128,30 @@ func (c *Client) GetHostGroupByName(ctx context.Context, name string) (*HostGrou
 	return &groups[0], nil
 }
 
+// SearchHostGroups retrieves the host groups whose name matches the pattern, sorted by name.
+// The pattern is matched against the whole name with * as wildcard; an empty pattern
+// returns all host groups.
+func (c *Client) SearchHostGroups(ctx context.Context, pattern string) ([]HostGroup, error) {
+	params := GetHostGroupParams{
+		Output:    "extend",
+		SortField: "name",
+	}
+
+	if pattern != "" {
+		params.Search = map[string]interface{}{
+			"name": pattern,
+		}
+		params.SearchWildcards = true
+	}
+
+	groups, err := Call[[]HostGroup](ctx, c, "hostgroup.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
 // UpdateHostGroup updates a host group's name.
 func (c *Client) UpdateHostGroup(ctx context.Context, groupID, name string) error {
 	params := UpdateHostGroupParams{
@@ -129,16 +159,11 @@ func (c *Client) UpdateHostGroup(ctx context.Context, groupID, name string) erro
 		Name:    name,
 	}
 
-	result, err := c.RequestWithContext(ctx, "hostgroup.update", params)
+	resp, err := Call[UpdateHostGroupResponse](ctx, c, "hostgroup.update", params)
 	if err != nil {
 		return err
 	}
 
-	var resp UpdateHostGroupResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal hostgroup.update response: %w", err)
-	}
-
 	if len(resp.GroupIDs) == 0 {
 		return fmt.Errorf("hostgroup.update returned no group IDs")
 	}
@@ -146,19 +171,36 @@ func (c *Client) UpdateHostGroup(ctx context.Context, groupID, name string) erro
 	return nil
 }
 
-// DeleteHostGroup deletes a host group by ID.
-func (c *Client) DeleteHostGroup(ctx context.Context, groupID string) error {
-	// hostgroup.delete takes an array of group IDs directly
-	params := []string{groupID}
+// UpdateHostGroups renames several host groups in a single request.
+func (c *Client) UpdateHostGroups(ctx context.Context, groups []HostGroup) error {
+	params := make([]UpdateHostGroupParams, len(groups))
+	for i, group := range groups {
+		params[i] = UpdateHostGroupParams{GroupID: group.GroupID, Name: group.Name}
+	}
 
-	result, err := c.RequestWithContext(ctx, "hostgroup.delete", params)
+	resp, err := Call[UpdateHostGroupResponse](ctx, c, "hostgroup.update", params)
 	if err != nil {
 		return err
 	}
 
-	var resp DeleteHostGroupResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal hostgroup.delete response: %w", err)
+	if len(resp.GroupIDs) != len(groups) {
+		return fmt.Errorf("hostgroup.update returned %d group IDs for %d groups", len(resp.GroupIDs), len(groups))
+	}
+
+	return nil
+}
+
+// DeleteHostGroup deletes a host group by ID.
+func (c *Client) DeleteHostGroup(ctx context.Context, groupID string) error {
+	return c.DeleteHostGroups(ctx, []string{groupID})
+}
+
+// DeleteHostGroups deletes several host groups by ID in a single request.
+func (c *Client) DeleteHostGroups(ctx context.Context, groupIDs []string) error {
+	// hostgroup.delete takes an array of group IDs directly
+	resp, err := Call[DeleteHostGroupResponse](ctx, c, "hostgroup.delete", groupIDs)
+	if err != nil {
+		return err
 	}
 
 	if len(resp.GroupIDs) == 0 {
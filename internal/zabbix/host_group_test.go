@@ -41,7 +41,7 @@ func TestCreateHostGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	groupID, err := client.CreateHostGroup(context.Background(), "Test Group")
 
 	if err != nil {
@@ -67,7 +67,7 @@ func TestCreateHostGroup_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	_, err := client.CreateHostGroup(context.Background(), "Test Group")
 
 	if err == nil {
@@ -90,7 +90,7 @@ func TestCreateHostGroup_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	_, err := client.CreateHostGroup(context.Background(), "Test Group")
 
 	if err == nil {
@@ -137,7 +137,7 @@ func TestGetHostGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetHostGroup(context.Background(), "123")
 
 	if err != nil {
@@ -172,7 +172,7 @@ func TestGetHostGroup_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetHostGroup(context.Background(), "999")
 
 	if err != nil {
@@ -217,7 +217,7 @@ func TestGetHostGroupByName_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetHostGroupByName(context.Background(), "Linux servers")
 
 	if err != nil {
@@ -249,7 +249,7 @@ func TestGetHostGroupByName_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetHostGroupByName(context.Background(), "Nonexistent")
 
 	if err != nil {
@@ -260,6 +260,91 @@ func TestGetHostGroupByName_NotFound(t *testing.T) {
 	}
 }
 
+func TestSearchHostGroups_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "hostgroup.get" {
+			t.Errorf("expected method 'hostgroup.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		search, ok := params["search"].(map[string]interface{})
+		if !ok || search["name"] != "prod/*" {
+			t.Errorf("expected search name 'prod/*', got '%v'", params["search"])
+		}
+		if params["searchWildcardsEnabled"] != true {
+			t.Errorf("expected searchWildcardsEnabled true, got '%v'", params["searchWildcardsEnabled"])
+		}
+		if params["sortfield"] != "name" {
+			t.Errorf("expected sortfield 'name', got '%v'", params["sortfield"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[
+				{"groupid": "10", "name": "prod/db", "uuid": "abc-1"},
+				{"groupid": "11", "name": "prod/web", "uuid": "abc-2"}
+			]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	groups, err := client.SearchHostGroups(context.Background(), "prod/*")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[1].GroupID != "11" || groups[1].Name != "prod/web" {
+		t.Errorf("expected group 11 'prod/web', got %+v", groups[1])
+	}
+}
+
+func TestSearchHostGroups_AllGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		params := req.Params.(map[string]interface{})
+		if _, exists := params["search"]; exists {
+			t.Errorf("expected search to be omitted, got '%v'", params["search"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[{"groupid": "2", "name": "Linux servers", "uuid": "xyz-123"}]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	groups, err := client.SearchHostGroups(context.Background(), "")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Errorf("expected 1 group, got %d", len(groups))
+	}
+}
+
 func TestUpdateHostGroup_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -292,7 +377,7 @@ func TestUpdateHostGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.UpdateHostGroup(context.Background(), "123", "Updated Group")
 
 	if err != nil {
@@ -315,7 +400,7 @@ func TestUpdateHostGroup_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.UpdateHostGroup(context.Background(), "123", "Updated Group")
 
 	if err == nil {
@@ -352,7 +437,7 @@ func TestDeleteHostGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteHostGroup(context.Background(), "123")
 
 	if err != nil {
@@ -375,7 +460,7 @@ func TestDeleteHostGroup_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteHostGroup(context.Background(), "123")
 
 	if err == nil {
@@ -398,7 +483,7 @@ func TestDeleteHostGroup_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteHostGroup(context.Background(), "999")
 
 	if err == nil {
@@ -413,3 +498,96 @@ func TestDeleteHostGroup_APIError(t *testing.T) {
 		t.Errorf("expected method 'hostgroup.delete', got '%s'", apiErr.Method)
 	}
 }
+
+// newBulkTestServer answers a request of the method with the result and stores its parameters.
+func newBulkTestServer(t *testing.T, method string, params *interface{}, result string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("failed to unmarshal request: %v", err)
+			return
+		}
+
+		if req.Method != method {
+			t.Errorf("expected method '%s', got '%s'", method, req.Method)
+		}
+		*params = req.Params
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(result),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestCreateHostGroups_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostgroup.create", &params, `{"groupids": ["21", "22"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	groupIDs, err := client.CreateHostGroups(context.Background(), []string{"Linux servers", "Databases"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groupIDs) != 2 || groupIDs[0] != "21" || groupIDs[1] != "22" {
+		t.Errorf("expected group IDs [21 22], got %v", groupIDs)
+	}
+
+	groups, ok := params.([]interface{})
+	if !ok || len(groups) != 2 || groups[1].(map[string]interface{})["name"] != "Databases" {
+		t.Errorf("expected an array of 2 groups, got %v", params)
+	}
+}
+
+func TestCreateHostGroups_MissingIDs(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostgroup.create", &params, `{"groupids": ["21"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if _, err := client.CreateHostGroups(context.Background(), []string{"Linux servers", "Databases"}); err == nil {
+		t.Fatal("expected error when fewer group IDs than groups are returned")
+	}
+}
+
+func TestUpdateHostGroups_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostgroup.update", &params, `{"groupids": ["21", "22"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.UpdateHostGroups(context.Background(), []HostGroup{
+		{GroupID: "21", Name: "Linux hosts"},
+		{GroupID: "22", Name: "Database hosts"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	groups, ok := params.([]interface{})
+	if !ok || len(groups) != 2 || groups[0].(map[string]interface{})["groupid"] != "21" {
+		t.Errorf("expected an array of 2 groups, got %v", params)
+	}
+}
+
+func TestDeleteHostGroups_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostgroup.delete", &params, `{"groupids": ["21", "22"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if err := client.DeleteHostGroups(context.Background(), []string{"21", "22"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, ok := params.([]interface{})
+	if !ok || len(ids) != 2 || ids[0] != "21" || ids[1] != "22" {
+		t.Errorf("expected group IDs [21 22], got %v", params)
+	}
+}
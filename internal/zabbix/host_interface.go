@@ -0,0 +1,44 @@
+// ABOUTME: Provides API methods for managing the interfaces of Zabbix hosts.
+// ABOUTME: Implements replacing all interfaces of a host with hostinterface.replacehostinterfaces.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReplaceHostInterfacesParams contains parameters for hostinterface.replacehostinterfaces.
+type ReplaceHostInterfacesParams struct {
+	HostID     string          `json:"hostid"`
+	Interfaces []HostInterface `json:"interfaces"`
+}
+
+// ReplaceHostInterfacesResponse contains the response from hostinterface.replacehostinterfaces.
+type ReplaceHostInterfacesResponse struct {
+	InterfaceIDs []string `json:"interfaceids"`
+}
+
+// ReplaceHostInterfaces replaces the interfaces of the host with interfaces in a single call,
+// so the host never lacks a main interface in between. Interfaces with an InterfaceID are
+// updated, the others are created, and existing interfaces not given are deleted. It
+// returns the IDs of the interfaces of the host.
+func (c *Client) ReplaceHostInterfaces(ctx context.Context, hostID string, interfaces []HostInterface) ([]string, error) {
+	if interfaces == nil {
+		interfaces = []HostInterface{}
+	}
+
+	resp, err := Call[ReplaceHostInterfacesResponse](ctx, c, "hostinterface.replacehostinterfaces", ReplaceHostInterfacesParams{
+		HostID:     hostID,
+		Interfaces: interfaces,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.InterfaceIDs) != len(interfaces) {
+		return nil, fmt.Errorf("hostinterface.replacehostinterfaces returned %d interface IDs for %d interfaces", len(resp.InterfaceIDs), len(interfaces))
+	}
+
+	return resp.InterfaceIDs, nil
+}
@@ -0,0 +1,80 @@
+// ABOUTME: Unit tests for host interface API methods using mock HTTP responses.
+// ABOUTME: Tests cover replacing the interfaces of a host with hostinterface.replacehostinterfaces.
+
+package zabbix
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestReplaceHostInterfaces_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostinterface.replacehostinterfaces", &params, `{"interfaceids": ["30", "31"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	interfaceIDs, err := client.ReplaceHostInterfaces(context.Background(), "10084", []HostInterface{
+		{InterfaceID: "30", Type: 1, Main: 1, UseIP: 1, IP: "192.168.1.10", Port: "10050"},
+		{Type: 2, Main: 1, UseIP: 0, DNS: "db01.example.com", Port: "161"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(interfaceIDs) != 2 || interfaceIDs[1] != "31" {
+		t.Errorf("expected interface IDs [30 31], got %v", interfaceIDs)
+	}
+
+	p := params.(map[string]interface{})
+	if p["hostid"] != "10084" {
+		t.Errorf("expected hostid 10084, got %v", p["hostid"])
+	}
+	interfaces, ok := p["interfaces"].([]interface{})
+	if !ok || len(interfaces) != 2 {
+		t.Fatalf("expected 2 interfaces, got %v", p["interfaces"])
+	}
+	first := interfaces[0].(map[string]interface{})
+	if first["interfaceid"] != "30" || first["type"] != float64(1) || first["ip"] != "192.168.1.10" {
+		t.Errorf("unexpected first interface: %v", first)
+	}
+	second := interfaces[1].(map[string]interface{})
+	if _, exists := second["interfaceid"]; exists {
+		t.Error("expected interfaceid to be omitted for a new interface")
+	}
+	if second["dns"] != "db01.example.com" || second["useip"] != float64(0) {
+		t.Errorf("unexpected second interface: %v", second)
+	}
+}
+
+func TestReplaceHostInterfaces_Empty(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostinterface.replacehostinterfaces", &params, `{"interfaceids": []}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if _, err := client.ReplaceHostInterfaces(context.Background(), "10084", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	if interfaces, ok := p["interfaces"].([]interface{}); !ok || len(interfaces) != 0 {
+		t.Errorf("expected an empty interfaces array, got %v", p["interfaces"])
+	}
+}
+
+func TestReplaceHostInterfaces_MissingIDs(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "hostinterface.replacehostinterfaces", &params, `{"interfaceids": ["30"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.ReplaceHostInterfaces(context.Background(), "10084", []HostInterface{
+		{Type: 1, Main: 1, UseIP: 1, IP: "192.168.1.10", Port: "10050"},
+		{Type: 2, Main: 1, UseIP: 1, IP: "192.168.1.10", Port: "161"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "returned 1 interface IDs for 2 interfaces") {
+		t.Fatalf("expected interface ID count error, got %v", err)
+	}
+}
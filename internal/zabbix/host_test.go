@@ -65,7 +65,7 @@ func TestCreateHost_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		Host:   "test-server",
 		Name:   "Test Server",
@@ -117,7 +117,7 @@ func TestCreateHost_WithTemplates(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		Host:   "test-server",
 		Groups: []HostGroupID{{GroupID: "2"}},
@@ -177,7 +177,7 @@ func TestCreateHost_WithTags(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		Host:   "test-server",
 		Groups: []HostGroupID{{GroupID: "2"}},
@@ -218,7 +218,7 @@ func TestCreateHost_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		Host:   "test-server",
 		Groups: []HostGroupID{{GroupID: "2"}},
@@ -252,7 +252,7 @@ func TestCreateHost_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		Host:   "test-server",
 		Groups: []HostGroupID{{GroupID: "2"}},
@@ -321,10 +321,13 @@ func TestGetHost_Success(t *testing.T) {
 				"host": "test-server",
 				"name": "Test Server",
 				"status": "0",
+				"flags": "4",
+				"maintenance_status": "1",
+				"active_available": "2",
 				"groups": [{"groupid": "2", "name": "Linux servers"}],
-				"interfaces": [{"interfaceid": "1", "type": "1", "main": "1", "useip": "1", "ip": "192.168.1.100", "dns": "", "port": "10050"}],
+				"interfaces": [{"interfaceid": "1", "type": "1", "main": "1", "useip": "1", "ip": "192.168.1.100", "dns": "", "port": "10050", "available": "2", "error": "Get value from agent failed"}],
 				"tags": [{"tag": "environment", "value": "production"}],
-				"parentTemplates": [{"templateid": "10001", "name": "Template OS Linux"}]
+				"parentTemplates": [{"templateid": "10001", "host": "Template OS Linux", "name": "Template OS Linux"}]
 			}]`),
 			ID: req.ID,
 		}
@@ -332,7 +335,7 @@ func TestGetHost_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host, err := client.GetHost(context.Background(), "10084")
 
 	if err != nil {
@@ -353,6 +356,15 @@ func TestGetHost_Success(t *testing.T) {
 	if host.Status != 0 {
 		t.Errorf("expected status 0, got %d", host.Status)
 	}
+	if host.Flags != HostFlagDiscovered {
+		t.Errorf("expected flags %d, got %d", HostFlagDiscovered, host.Flags)
+	}
+	if host.MaintenanceStatus != 1 || host.ActiveAvailable != 2 {
+		t.Errorf("expected maintenance_status 1 and active_available 2, got %d and %d", host.MaintenanceStatus, host.ActiveAvailable)
+	}
+	if len(host.Interfaces) == 1 && (host.Interfaces[0].Available != 2 || host.Interfaces[0].Error != "Get value from agent failed") {
+		t.Errorf("expected unavailable interface with error, got %v", host.Interfaces[0])
+	}
 	if len(host.Groups) != 1 || host.Groups[0].GroupID != "2" {
 		t.Errorf("expected groups with groupid '2', got %v", host.Groups)
 	}
@@ -365,6 +377,9 @@ func TestGetHost_Success(t *testing.T) {
 	if len(host.ParentTemplates) != 1 || host.ParentTemplates[0].TemplateID != "10001" {
 		t.Errorf("expected template with id '10001', got %v", host.ParentTemplates)
 	}
+	if host.ParentTemplates[0].Host != "Template OS Linux" {
+		t.Errorf("expected template host 'Template OS Linux', got '%s'", host.ParentTemplates[0].Host)
+	}
 }
 
 func TestGetHost_NotFound(t *testing.T) {
@@ -382,7 +397,7 @@ func TestGetHost_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host, err := client.GetHost(context.Background(), "99999")
 
 	if err != nil {
@@ -436,7 +451,7 @@ func TestGetHostByName_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host, err := client.GetHostByName(context.Background(), "test-server")
 
 	if err != nil {
@@ -468,7 +483,7 @@ func TestGetHostByName_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host, err := client.GetHostByName(context.Background(), "nonexistent")
 
 	if err != nil {
@@ -511,7 +526,7 @@ func TestUpdateHost_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		HostID: "10084",
 		Name:   "Updated Server",
@@ -550,7 +565,7 @@ func TestUpdateHost_WithGroups(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		HostID: "10084",
 		Groups: []HostGroupID{
@@ -565,6 +580,133 @@ func TestUpdateHost_WithGroups(t *testing.T) {
 	}
 }
 
+func TestUpdateHost_WithTemplatesClear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		templates, ok := params["templates"].([]interface{})
+		if !ok || len(templates) != 1 {
+			t.Fatalf("expected templates to be array with 1 element, got %v", params["templates"])
+		}
+
+		templatesClear, ok := params["templates_clear"].([]interface{})
+		if !ok || len(templatesClear) != 1 {
+			t.Fatalf("expected templates_clear to be array with 1 element, got %v", params["templates_clear"])
+		}
+		cleared := templatesClear[0].(map[string]interface{})
+		if cleared["templateid"] != "10002" {
+			t.Errorf("expected templates_clear templateid '10002', got '%v'", cleared["templateid"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	host := &Host{
+		HostID:         "10084",
+		Templates:      []TemplateID{{TemplateID: "10001"}},
+		TemplatesClear: []TemplateID{{TemplateID: "10002"}},
+	}
+	err := client.UpdateHost(context.Background(), host)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateHost_RemoveAllInterfaces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		interfaces, ok := params["interfaces"].([]interface{})
+		if !ok || len(interfaces) != 0 {
+			t.Fatalf("expected interfaces to be an empty array, got %v", params["interfaces"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	host := &Host{
+		HostID:     "10084",
+		Interfaces: []HostInterface{},
+	}
+	err := client.UpdateHost(context.Background(), host)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateHost_KeepStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		if _, ok := params["status"]; ok {
+			t.Errorf("expected no status when it is kept, got %v", params["status"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	host := &Host{
+		HostID:     "10084",
+		Name:       "Renamed",
+		KeepStatus: true,
+	}
+	err := client.UpdateHost(context.Background(), host)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestUpdateHost_EmptyResponse(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -580,7 +722,7 @@ func TestUpdateHost_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	host := &Host{
 		HostID: "10084",
 		Name:   "Updated Server",
@@ -621,7 +763,7 @@ func TestDeleteHost_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteHost(context.Background(), "10084")
 
 	if err != nil {
@@ -644,7 +786,7 @@ func TestDeleteHost_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteHost(context.Background(), "10084")
 
 	if err == nil {
@@ -667,7 +809,7 @@ func TestDeleteHost_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteHost(context.Background(), "99999")
 
 	if err == nil {
@@ -682,3 +824,843 @@ func TestDeleteHost_APIError(t *testing.T) {
 		t.Errorf("expected method 'host.delete', got '%s'", apiErr.Method)
 	}
 }
+
+func TestCreateHosts_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.create" {
+			t.Errorf("expected method 'host.create', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.([]interface{})
+		if !ok || len(params) != 2 {
+			t.Fatalf("expected params to be an array with 2 elements, got %v", req.Params)
+		}
+		first := params[0].(map[string]interface{})
+		if first["host"] != "node-01" {
+			t.Errorf("expected host 'node-01', got '%v'", first["host"])
+		}
+		second := params[1].(map[string]interface{})
+		if second["host"] != "node-02" {
+			t.Errorf("expected host 'node-02', got '%v'", second["host"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10101", "10102"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	hostIDs, err := client.CreateHosts(context.Background(), []*Host{
+		{Host: "node-01", Groups: []HostGroupID{{GroupID: "2"}}},
+		{Host: "node-02", Groups: []HostGroupID{{GroupID: "2"}}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hostIDs) != 2 || hostIDs[0] != "10101" || hostIDs[1] != "10102" {
+		t.Errorf("expected host IDs [10101 10102], got %v", hostIDs)
+	}
+}
+
+func TestCreateHosts_MismatchedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10101"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.CreateHosts(context.Background(), []*Host{
+		{Host: "node-01"},
+		{Host: "node-02"},
+	})
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestGetHosts_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		hostIDs, ok := params["hostids"].([]interface{})
+		if !ok || len(hostIDs) != 3 {
+			t.Errorf("expected 3 hostids, got '%v'", params["hostids"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[
+				{"hostid": "10101", "host": "node-01", "name": "node-01", "status": "0"},
+				{"hostid": "10102", "host": "node-02", "name": "node-02", "status": "1"}
+			]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	hosts, err := client.GetHosts(context.Background(), []string{"10101", "10102", "10103"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(hosts))
+	}
+	if hosts[1].HostID != "10102" || hosts[1].Status != 1 {
+		t.Errorf("expected host 10102 with status 1, got %v", hosts[1])
+	}
+}
+
+func TestUpdateHosts_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.update" {
+			t.Errorf("expected method 'host.update', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.([]interface{})
+		if !ok || len(params) != 2 {
+			t.Fatalf("expected params to be an array with 2 elements, got %v", req.Params)
+		}
+		first := params[0].(map[string]interface{})
+		if first["hostid"] != "10101" {
+			t.Errorf("expected hostid '10101', got '%v'", first["hostid"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10101", "10102"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.UpdateHosts(context.Background(), []*Host{
+		{HostID: "10101", Status: 1},
+		{HostID: "10102", Status: 1},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteHosts_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.([]interface{})
+		if !ok || len(params) != 2 {
+			t.Fatalf("expected params to be an array with 2 elements, got %v", req.Params)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10101", "10102"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.DeleteHosts(context.Background(), []string{"10101", "10102"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMassAddHostGroups_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.massadd" {
+			t.Errorf("expected method 'host.massadd', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		hosts, ok := params["hosts"].([]interface{})
+		if !ok || len(hosts) != 1 {
+			t.Fatalf("expected hosts to be array with 1 element, got %v", params["hosts"])
+		}
+		if hosts[0].(map[string]interface{})["hostid"] != "10084" {
+			t.Errorf("expected hostid '10084', got '%v'", hosts[0])
+		}
+
+		groups, ok := params["groups"].([]interface{})
+		if !ok || len(groups) != 2 {
+			t.Fatalf("expected groups to be array with 2 elements, got %v", params["groups"])
+		}
+		if groups[1].(map[string]interface{})["groupid"] != "5" {
+			t.Errorf("expected groupid '5', got '%v'", groups[1])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.MassAddHostGroups(context.Background(), []string{"10084"}, []string{"4", "5"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMassRemoveHostGroups_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.massremove" {
+			t.Errorf("expected method 'host.massremove', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		hostIDs, ok := params["hostids"].([]interface{})
+		if !ok || len(hostIDs) != 1 || hostIDs[0] != "10084" {
+			t.Errorf("expected hostids ['10084'], got '%v'", params["hostids"])
+		}
+		groupIDs, ok := params["groupids"].([]interface{})
+		if !ok || len(groupIDs) != 1 || groupIDs[0] != "4" {
+			t.Errorf("expected groupids ['4'], got '%v'", params["groupids"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.MassRemoveHostGroups(context.Background(), []string{"10084"}, []string{"4"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetHostsByTemplate_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.get" {
+			t.Errorf("expected method 'host.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		templateIDs, ok := params["templateids"].([]interface{})
+		if !ok || len(templateIDs) != 1 || templateIDs[0] != "10001" {
+			t.Errorf("expected templateids ['10001'], got '%v'", params["templateids"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"hostid": "10084",
+				"host": "server-01",
+				"parentTemplates": [{"templateid": "10001"}, {"templateid": "10002"}]
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	hosts, err := client.GetHostsByTemplate(context.Background(), "10001")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("expected 1 host, got %d", len(hosts))
+	}
+	if hosts[0].Host != "server-01" {
+		t.Errorf("expected host 'server-01', got '%s'", hosts[0].Host)
+	}
+	if len(hosts[0].ParentTemplates) != 2 {
+		t.Errorf("expected 2 parent templates, got %d", len(hosts[0].ParentTemplates))
+	}
+}
+
+func TestGetHostsByGroup_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.get" {
+			t.Errorf("expected method 'host.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		groupIDs, ok := params["groupids"].([]interface{})
+		if !ok || len(groupIDs) != 1 || groupIDs[0] != "2" {
+			t.Errorf("expected groupids ['2'], got '%v'", params["groupids"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"hostid": "10084",
+				"host": "server-01",
+				"groups": [{"groupid": "2"}, {"groupid": "4"}]
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	hosts, err := client.GetHostsByGroup(context.Background(), "2")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("expected 1 host, got %d", len(hosts))
+	}
+	if hosts[0].Host != "server-01" {
+		t.Errorf("expected host 'server-01', got '%s'", hosts[0].Host)
+	}
+	if len(hosts[0].Groups) != 2 {
+		t.Errorf("expected 2 host groups, got %d", len(hosts[0].Groups))
+	}
+}
+
+func TestMassUpdateHostTemplates_Clear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.massupdate" {
+			t.Errorf("expected method 'host.massupdate', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		hosts, ok := params["hosts"].([]interface{})
+		if !ok || len(hosts) != 2 {
+			t.Fatalf("expected hosts to be array with 2 elements, got %v", params["hosts"])
+		}
+		templates, ok := params["templates"].([]interface{})
+		if !ok || len(templates) != 1 {
+			t.Fatalf("expected templates to be array with 1 element, got %v", params["templates"])
+		}
+		if templates[0].(map[string]interface{})["templateid"] != "10002" {
+			t.Errorf("expected templates templateid '10002', got '%v'", templates[0])
+		}
+		templatesClear, ok := params["templates_clear"].([]interface{})
+		if !ok || len(templatesClear) != 1 {
+			t.Fatalf("expected templates_clear to be array with 1 element, got %v", params["templates_clear"])
+		}
+		if templatesClear[0].(map[string]interface{})["templateid"] != "10001" {
+			t.Errorf("expected templates_clear templateid '10001', got '%v'", templatesClear[0])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084", "10085"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.MassUpdateHostTemplates(context.Background(), []string{"10084", "10085"}, []string{"10002"}, []string{"10001"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMassUpdateHostTemplates_UnlinkAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		params := req.Params.(map[string]interface{})
+		templates, ok := params["templates"].([]interface{})
+		if !ok || len(templates) != 0 {
+			t.Errorf("expected templates to be an empty array, got %v", params["templates"])
+		}
+		if _, exists := params["templates_clear"]; exists {
+			t.Error("expected templates_clear to be omitted")
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.MassUpdateHostTemplates(context.Background(), []string{"10084"}, nil, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSearchHosts_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "host.get" {
+			t.Errorf("expected method 'host.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		groupIDs, ok := params["groupids"].([]interface{})
+		if !ok || len(groupIDs) != 1 || groupIDs[0] != "2" {
+			t.Errorf("expected groupids ['2'], got '%v'", params["groupids"])
+		}
+		proxyIDs, ok := params["proxyids"].([]interface{})
+		if !ok || len(proxyIDs) != 1 || proxyIDs[0] != "5" {
+			t.Errorf("expected proxyids ['5'], got '%v'", params["proxyids"])
+		}
+		search, ok := params["search"].(map[string]interface{})
+		if !ok || search["host"] != "web-*" || search["name"] != "web-*" {
+			t.Errorf("expected search on host and name for 'web-*', got '%v'", params["search"])
+		}
+		if params["searchByAny"] != true {
+			t.Errorf("expected searchByAny true, got '%v'", params["searchByAny"])
+		}
+		if params["searchWildcardsEnabled"] != true {
+			t.Errorf("expected searchWildcardsEnabled true, got '%v'", params["searchWildcardsEnabled"])
+		}
+		tags, ok := params["tags"].([]interface{})
+		if !ok || len(tags) != 1 {
+			t.Fatalf("expected tags to be array with 1 element, got %v", params["tags"])
+		}
+		tag := tags[0].(map[string]interface{})
+		if tag["tag"] != "env" || tag["value"] != "prod" || tag["operator"] != float64(TagOperatorEquals) {
+			t.Errorf("expected tag filter env=prod with operator equals, got %v", tag)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[
+				{"hostid": "10084", "host": "web-01", "name": "Web 01", "status": "0"},
+				{"hostid": "10085", "host": "web-02", "name": "Web 02", "status": "1"}
+			]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	hosts, err := client.SearchHosts(context.Background(), HostSearch{
+		GroupIDs: []string{"2"},
+		ProxyIDs: []string{"5"},
+		Pattern:  "web-*",
+		Tags:     []TagFilter{{Tag: "env", Value: "prod", Operator: TagOperatorEquals}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(hosts))
+	}
+	if hosts[1].Host != "web-02" || hosts[1].Status != 1 {
+		t.Errorf("expected disabled host 'web-02', got %+v", hosts[1])
+	}
+}
+
+func TestSearchHosts_NoCriteria(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		params := req.Params.(map[string]interface{})
+		for _, key := range []string{"groupids", "proxyids", "search", "searchByAny", "searchWildcardsEnabled", "tags"} {
+			if _, exists := params[key]; exists {
+				t.Errorf("expected %s to be omitted, got '%v'", key, params[key])
+			}
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	hosts, err := client.SearchHosts(context.Background(), HostSearch{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 0 {
+		t.Errorf("expected no hosts, got %d", len(hosts))
+	}
+}
+
+func TestCreateHost_WithEncryption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+		if params["tls_connect"] != float64(2) {
+			t.Errorf("expected tls_connect 2, got '%v'", params["tls_connect"])
+		}
+		if params["tls_accept"] != float64(3) {
+			t.Errorf("expected tls_accept 3, got '%v'", params["tls_accept"])
+		}
+		if params["tls_psk_identity"] != "psk-001" {
+			t.Errorf("expected tls_psk_identity 'psk-001', got '%v'", params["tls_psk_identity"])
+		}
+		if params["tls_psk"] != "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952" {
+			t.Errorf("expected tls_psk to be sent, got '%v'", params["tls_psk"])
+		}
+		if params["ipmi_username"] != "" {
+			t.Errorf("expected empty ipmi_username, got '%v'", params["ipmi_username"])
+		}
+		if _, ok := params["ipmi_password"]; ok {
+			t.Errorf("expected ipmi_password to be omitted, got '%v'", params["ipmi_password"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	ipmiUsername := ""
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.CreateHost(context.Background(), &Host{
+		Host:           "test-server",
+		Groups:         []HostGroupID{{GroupID: "2"}},
+		TLSConnect:     2,
+		TLSAccept:      3,
+		TLSPSKIdentity: "psk-001",
+		TLSPSK:         "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952",
+		IPMIUsername:   &ipmiUsername,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetHost_WithEncryption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[{"hostid": "10084", "host": "test-server", "status": "0", "tls_connect": "2", "tls_accept": "6", "ipmi_username": "admin"}]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	host, err := client.GetHost(context.Background(), "10084")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host.TLSConnect != 2 || host.TLSAccept != 6 {
+		t.Errorf("expected tls_connect 2 and tls_accept 6, got %d and %d", host.TLSConnect, host.TLSAccept)
+	}
+	if host.IPMIUsername == nil || *host.IPMIUsername != "admin" {
+		t.Errorf("expected ipmi_username 'admin', got %v", host.IPMIUsername)
+	}
+}
+
+func TestMassAddHostTemplates_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "host.massadd", &params, `{"hostids": ["10084"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if err := client.MassAddHostTemplates(context.Background(), []string{"10084"}, []string{"10001", "10002"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	templates, ok := p["templates"].([]interface{})
+	if !ok || len(templates) != 2 || templates[1].(map[string]interface{})["templateid"] != "10002" {
+		t.Errorf("expected templates 10001 and 10002, got %v", p["templates"])
+	}
+	if _, exists := p["groups"]; exists {
+		t.Error("expected groups to be omitted")
+	}
+}
+
+func TestMassRemoveHostTemplates(t *testing.T) {
+	tests := map[string]struct {
+		clear    bool
+		expected string
+		omitted  string
+	}{
+		"unlink":       {clear: false, expected: "templateids", omitted: "templateids_clear"},
+		"unlink clear": {clear: true, expected: "templateids_clear", omitted: "templateids"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var params interface{}
+			server := newBulkTestServer(t, "host.massremove", &params, `{"hostids": ["10084"]}`)
+			defer server.Close()
+
+			client := NewClient(server.URL, WithToken("test-token"))
+			if err := client.MassRemoveHostTemplates(context.Background(), []string{"10084"}, []string{"10001"}, tc.clear); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			p := params.(map[string]interface{})
+			ids, ok := p[tc.expected].([]interface{})
+			if !ok || len(ids) != 1 || ids[0] != "10001" {
+				t.Errorf("expected %s ['10001'], got %v", tc.expected, p[tc.expected])
+			}
+			if _, exists := p[tc.omitted]; exists {
+				t.Errorf("expected %s to be omitted", tc.omitted)
+			}
+		})
+	}
+}
+
+func TestMassUpdateHostGroups_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "host.massupdate", &params, `{"hostids": ["10084", "10085"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if err := client.MassUpdateHostGroups(context.Background(), []string{"10084", "10085"}, []string{"4"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	hosts, ok := p["hosts"].([]interface{})
+	if !ok || len(hosts) != 2 {
+		t.Errorf("expected 2 hosts, got %v", p["hosts"])
+	}
+	groups, ok := p["groups"].([]interface{})
+	if !ok || len(groups) != 1 || groups[0].(map[string]interface{})["groupid"] != "4" {
+		t.Errorf("expected groups [4], got %v", p["groups"])
+	}
+}
+
+func TestMassUpdateHostGroups_NoHostIDs(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "host.massupdate", &params, `{"hostids": []}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.MassUpdateHostGroups(context.Background(), []string{"10084"}, []string{"4"})
+	if err == nil || err.Error() != "host.massupdate returned no host IDs" {
+		t.Errorf("expected error for missing host IDs, got %v", err)
+	}
+}
+
+func TestCreateHost_WithSNMPv3Interface(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params := req.Params.(map[string]interface{})
+		interfaces := params["interfaces"].([]interface{})
+		details, ok := interfaces[0].(map[string]interface{})["details"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected SNMP details, got %v", interfaces[0])
+		}
+		if details["version"] != float64(3) || details["securityname"] != "monitor" || details["securitylevel"] != float64(2) {
+			t.Errorf("unexpected SNMPv3 details: %v", details)
+		}
+		if details["authpassphrase"] != "auth-secret" || details["privpassphrase"] != "priv-secret" {
+			t.Errorf("expected the passphrases to be sent, got %v", details)
+		}
+		if _, ok := details["community"]; ok {
+			t.Errorf("expected no community for SNMPv3, got %v", details["community"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"hostids": ["10084"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.CreateHost(context.Background(), &Host{
+		Host:   "switch01",
+		Groups: []HostGroupID{{GroupID: "2"}},
+		Interfaces: []HostInterface{{
+			Type: 2, Main: 1, UseIP: 1, IP: "192.0.2.1", Port: "161",
+			Details: &SNMPDetails{
+				Version:        SNMPVersion3,
+				Bulk:           1,
+				SecurityName:   "monitor",
+				SecurityLevel:  2,
+				AuthPassphrase: "auth-secret",
+				PrivPassphrase: "priv-secret",
+			},
+		}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetHost_WithSNMPInterface(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{"hostid": "10084", "host": "switch01", "status": "0", "interfaces": [
+				{"interfaceid": "1", "type": "1", "main": "1", "useip": "1", "ip": "192.0.2.1", "dns": "", "port": "10050", "details": []},
+				{"interfaceid": "2", "type": "2", "main": "1", "useip": "1", "ip": "192.0.2.1", "dns": "", "port": "161",
+				 "details": {"version": "2", "bulk": "1", "community": "{$SNMP_COMMUNITY}", "securityname": "", "securitylevel": "0", "authprotocol": "0", "privprotocol": "0", "contextname": ""}}
+			]}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	host, err := client.GetHost(context.Background(), "10084")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host.Interfaces[0].Details != nil {
+		t.Errorf("expected no details for the agent interface, got %+v", host.Interfaces[0].Details)
+	}
+	details := host.Interfaces[1].Details
+	if details == nil || details.Version != SNMPVersion2c || details.Bulk != 1 || details.Community != "{$SNMP_COMMUNITY}" {
+		t.Errorf("expected SNMPv2c details with the community macro, got %+v", details)
+	}
+}
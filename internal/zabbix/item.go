@@ -0,0 +1,373 @@
+// ABOUTME: Provides API methods for reading Zabbix items.
+// ABOUTME: Implements lookups and searches using the item.get JSON-RPC method.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Types of items.
+const (
+	ItemTypeZabbixAgent       = 0
+	ItemTypeTrapper           = 2
+	ItemTypeSimpleCheck       = 3
+	ItemTypeInternal          = 5
+	ItemTypeZabbixAgentActive = 7
+	ItemTypeWebItem           = 9
+	ItemTypeExternalCheck     = 10
+	ItemTypeDatabaseMonitor   = 11
+	ItemTypeIPMIAgent         = 12
+	ItemTypeSSHAgent          = 13
+	ItemTypeTelnetAgent       = 14
+	ItemTypeCalculated        = 15
+	ItemTypeJMXAgent          = 16
+	ItemTypeSNMPTrap          = 17
+	ItemTypeDependent         = 18
+	ItemTypeHTTPAgent         = 19
+	ItemTypeSNMPAgent         = 20
+	ItemTypeScript            = 21
+	ItemTypeBrowser           = 22
+)
+
+// Request methods of HTTP agent items.
+const (
+	HTTPMethodGet  = 0
+	HTTPMethodPost = 1
+	HTTPMethodPut  = 2
+	HTTPMethodHead = 3
+)
+
+// Body types of HTTP agent items. 1 is not used by Zabbix.
+const (
+	HTTPPostRaw  = 0
+	HTTPPostJSON = 2
+	HTTPPostXML  = 3
+)
+
+// Authentication methods of HTTP agent items.
+const (
+	HTTPAuthNone     = 0
+	HTTPAuthBasic    = 1
+	HTTPAuthNTLM     = 2
+	HTTPAuthKerberos = 3
+	HTTPAuthDigest   = 4
+)
+
+// Parts of the response HTTP agent items store.
+const (
+	HTTPRetrieveBody    = 0
+	HTTPRetrieveHeaders = 1
+	HTTPRetrieveBoth    = 2
+)
+
+// Item represents a Zabbix item. MasterItemID is the item a dependent item takes its
+// values from, and is empty for items of other types. Params holds the formula of
+// calculated items and the script or query of script, SSH, Telnet and database monitor
+// items. The HTTP fields apply to HTTP agent items only.
+type Item struct {
+	ItemID        string              `json:"itemid,omitempty"`
+	HostID        string              `json:"hostid,omitempty"`
+	Name          string              `json:"name,omitempty"`
+	Key           string              `json:"key_,omitempty"`
+	Type          int                 `json:"-"`
+	ValueType     int                 `json:"-"`
+	Status        int                 `json:"-"`
+	Delay         string              `json:"delay,omitempty"`
+	Units         string              `json:"units,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Tags          []ItemTag           `json:"tags,omitempty"`
+	Preprocessing []ItemPreprocessing `json:"preprocessing,omitempty"`
+	MasterItemID  string              `json:"master_itemid,omitempty"`
+	Params        string              `json:"params,omitempty"`
+
+	URL             string          `json:"url,omitempty"`
+	QueryFields     []ItemHTTPField `json:"query_fields,omitempty"`
+	RequestMethod   int             `json:"-"`
+	Headers         []ItemHTTPField `json:"headers,omitempty"`
+	Posts           string          `json:"posts,omitempty"`
+	PostType        int             `json:"-"`
+	AuthType        int             `json:"-"`
+	Username        string          `json:"username,omitempty"`
+	Password        string          `json:"password,omitempty"`
+	FollowRedirects int             `json:"-"`
+	RetrieveMode    int             `json:"-"`
+	Timeout         string          `json:"timeout,omitempty"`
+	StatusCodes     string          `json:"status_codes,omitempty"`
+}
+
+// ItemHTTPField is a query field or header of an HTTP agent item. Zabbix keeps their
+// order, and a name may occur more than once.
+type ItemHTTPField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ItemTag represents an item tag.
+type ItemTag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// PreprocessingType is the type of an item preprocessing step.
+type PreprocessingType int
+
+// Types of item preprocessing steps.
+const (
+	PreprocessingMultiplier                PreprocessingType = 1
+	PreprocessingRightTrim                 PreprocessingType = 2
+	PreprocessingLeftTrim                  PreprocessingType = 3
+	PreprocessingTrim                      PreprocessingType = 4
+	PreprocessingRegex                     PreprocessingType = 5
+	PreprocessingBoolToDecimal             PreprocessingType = 6
+	PreprocessingOctalToDecimal            PreprocessingType = 7
+	PreprocessingHexToDecimal              PreprocessingType = 8
+	PreprocessingSimpleChange              PreprocessingType = 9
+	PreprocessingChangePerSecond           PreprocessingType = 10
+	PreprocessingXPath                     PreprocessingType = 11
+	PreprocessingJSONPath                  PreprocessingType = 12
+	PreprocessingInRange                   PreprocessingType = 13
+	PreprocessingMatchesRegex              PreprocessingType = 14
+	PreprocessingNotMatchesRegex           PreprocessingType = 15
+	PreprocessingCheckJSONError            PreprocessingType = 16
+	PreprocessingCheckXMLError             PreprocessingType = 17
+	PreprocessingCheckRegexError           PreprocessingType = 18
+	PreprocessingDiscardUnchanged          PreprocessingType = 19
+	PreprocessingDiscardUnchangedHeartbeat PreprocessingType = 20
+	PreprocessingJavaScript                PreprocessingType = 21
+	PreprocessingPrometheusPattern         PreprocessingType = 22
+	PreprocessingPrometheusToJSON          PreprocessingType = 23
+	PreprocessingCSVToJSON                 PreprocessingType = 24
+	PreprocessingReplace                   PreprocessingType = 25
+	PreprocessingCheckUnsupported          PreprocessingType = 26
+	PreprocessingXMLToJSON                 PreprocessingType = 27
+	PreprocessingSNMPWalkValue             PreprocessingType = 28
+	PreprocessingSNMPWalkToJSON            PreprocessingType = 29
+	PreprocessingSNMPGetValue              PreprocessingType = 30
+)
+
+// PreprocessingErrorHandler is the action taken when a preprocessing step fails.
+type PreprocessingErrorHandler int
+
+// Error handlers of item preprocessing steps.
+const (
+	// PreprocessingErrorDefault sets the item unsupported with the error of the step.
+	PreprocessingErrorDefault      PreprocessingErrorHandler = 0
+	PreprocessingErrorDiscardValue PreprocessingErrorHandler = 1
+	PreprocessingErrorSetValue     PreprocessingErrorHandler = 2
+	PreprocessingErrorSetMessage   PreprocessingErrorHandler = 3
+)
+
+// ItemPreprocessing represents a preprocessing step of an item. Params holds the
+// parameters of the step separated by newlines, as Zabbix stores them; ErrorHandlerParams
+// is the custom value or error message of the error handler.
+type ItemPreprocessing struct {
+	Type               PreprocessingType         `json:"type"`
+	Params             string                    `json:"params"`
+	ErrorHandler       PreprocessingErrorHandler `json:"error_handler"`
+	ErrorHandlerParams string                    `json:"error_handler_params"`
+}
+
+// itemPreprocessingJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
+type itemPreprocessingJSON struct {
+	Type               FlexInt `json:"type"`
+	Params             string  `json:"params"`
+	ErrorHandler       FlexInt `json:"error_handler"`
+	ErrorHandlerParams string  `json:"error_handler_params"`
+}
+
+// UnmarshalJSON handles Zabbix API returning numeric values as strings.
+func (p *ItemPreprocessing) UnmarshalJSON(data []byte) error {
+	var pj itemPreprocessingJSON
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+
+	p.Type = PreprocessingType(pj.Type)
+	p.Params = pj.Params
+	p.ErrorHandler = PreprocessingErrorHandler(pj.ErrorHandler)
+	p.ErrorHandlerParams = pj.ErrorHandlerParams
+
+	return nil
+}
+
+// ParamList returns the parameters of the step, one element per parameter.
+func (p ItemPreprocessing) ParamList() []string {
+	if p.Params == "" {
+		return []string{}
+	}
+	return strings.Split(p.Params, "\n")
+}
+
+// itemJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
+type itemJSON struct {
+	ItemID        string              `json:"itemid,omitempty"`
+	HostID        string              `json:"hostid,omitempty"`
+	Name          string              `json:"name,omitempty"`
+	Key           string              `json:"key_,omitempty"`
+	Type          FlexInt             `json:"type,omitempty"`
+	ValueType     FlexInt             `json:"value_type,omitempty"`
+	Status        FlexInt             `json:"status,omitempty"`
+	Delay         string              `json:"delay,omitempty"`
+	Units         string              `json:"units,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Tags          []ItemTag           `json:"tags,omitempty"`
+	Preprocessing []ItemPreprocessing `json:"preprocessing,omitempty"`
+	MasterItemID  string              `json:"master_itemid,omitempty"`
+	Params        string              `json:"params,omitempty"`
+
+	URL             string          `json:"url,omitempty"`
+	QueryFields     []ItemHTTPField `json:"query_fields,omitempty"`
+	RequestMethod   FlexInt         `json:"request_method,omitempty"`
+	Headers         []ItemHTTPField `json:"headers,omitempty"`
+	Posts           string          `json:"posts,omitempty"`
+	PostType        FlexInt         `json:"post_type,omitempty"`
+	AuthType        FlexInt         `json:"authtype,omitempty"`
+	Username        string          `json:"username,omitempty"`
+	Password        string          `json:"password,omitempty"`
+	FollowRedirects FlexInt         `json:"follow_redirects,omitempty"`
+	RetrieveMode    FlexInt         `json:"retrieve_mode,omitempty"`
+	Timeout         string          `json:"timeout,omitempty"`
+	StatusCodes     string          `json:"status_codes,omitempty"`
+}
+
+// UnmarshalJSON handles Zabbix API returning numeric values as strings.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var ij itemJSON
+	if err := json.Unmarshal(data, &ij); err != nil {
+		return err
+	}
+
+	i.ItemID = ij.ItemID
+	i.HostID = ij.HostID
+	i.Name = ij.Name
+	i.Key = ij.Key
+	i.Delay = ij.Delay
+	i.Units = ij.Units
+	i.Description = ij.Description
+	i.Tags = ij.Tags
+	i.Preprocessing = ij.Preprocessing
+	// Zabbix returns master item ID 0 for items that are not dependent items
+	if ij.MasterItemID != "0" {
+		i.MasterItemID = ij.MasterItemID
+	}
+	i.Params = ij.Params
+	i.URL = ij.URL
+	i.QueryFields = ij.QueryFields
+	i.RequestMethod = int(ij.RequestMethod)
+	i.Headers = ij.Headers
+	i.Posts = ij.Posts
+	i.PostType = int(ij.PostType)
+	i.AuthType = int(ij.AuthType)
+	i.Username = ij.Username
+	i.Password = ij.Password
+	i.FollowRedirects = int(ij.FollowRedirects)
+	i.RetrieveMode = int(ij.RetrieveMode)
+	i.Timeout = ij.Timeout
+	i.StatusCodes = ij.StatusCodes
+	i.Type = int(ij.Type)
+	i.ValueType = int(ij.ValueType)
+	i.Status = int(ij.Status)
+
+	return nil
+}
+
+// GetItemParams contains parameters for retrieving items.
+type GetItemParams struct {
+	ItemIDs             []string               `json:"itemids,omitempty"`
+	HostIDs             []string               `json:"hostids,omitempty"`
+	GroupIDs            []string               `json:"groupids,omitempty"`
+	Host                string                 `json:"host,omitempty"`
+	Templated           *bool                  `json:"templated,omitempty"`
+	Filter              map[string]interface{} `json:"filter,omitempty"`
+	Search              map[string]interface{} `json:"search,omitempty"`
+	SearchWildcards     bool                   `json:"searchWildcardsEnabled,omitempty"`
+	Tags                []TagFilter            `json:"tags,omitempty"`
+	SortField           string                 `json:"sortfield,omitempty"`
+	CountOutput         bool                   `json:"countOutput,omitempty"`
+	Output              interface{}            `json:"output,omitempty"`
+	SelectTags          interface{}            `json:"selectTags,omitempty"`
+	SelectPreprocessing interface{}            `json:"selectPreprocessing,omitempty"`
+}
+
+// ItemReference identifies an item by the technical name of its host or template and its
+// key, as item references in trigger expressions do.
+type ItemReference struct {
+	Host string
+	Key  string
+}
+
+// ItemSearch contains the criteria for SearchItems. Empty criteria are not applied,
+// and items must match all criteria that are set.
+type ItemSearch struct {
+	HostIDs  []string
+	GroupIDs []string
+	// Name and Key are matched against the whole item name and key, with * as wildcard.
+	Name string
+	Key  string
+	Tags []TagFilter
+	// Templated restricts the search to items of templates when true and to items of
+	// hosts when false.
+	Templated *bool
+	// Output lists the item fields to return. All fields are returned when it is empty.
+	Output []string
+}
+
+// GetItemsByHostKey retrieves the items with the given key on the host or template with the
+// given technical name, including their preprocessing steps. Item keys are unique per host, so more than one result indicates an
+// ambiguous lookup that callers should report.
+func (c *Client) GetItemsByHostKey(ctx context.Context, host, key string) ([]Item, error) {
+	params := GetItemParams{
+		Host: host,
+		Filter: map[string]interface{}{
+			"key_": key,
+		},
+		Output:              "extend",
+		SelectPreprocessing: "extend",
+	}
+
+	items, err := Call[[]Item](ctx, c, "item.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// SearchItems retrieves the items matching the search criteria, including their tags,
+// sorted by name.
+func (c *Client) SearchItems(ctx context.Context, search ItemSearch) ([]Item, error) {
+	params := GetItemParams{
+		HostIDs:    search.HostIDs,
+		GroupIDs:   search.GroupIDs,
+		Templated:  search.Templated,
+		Tags:       search.Tags,
+		Output:     "extend",
+		SelectTags: "extend",
+		SortField:  "name",
+	}
+
+	if len(search.Output) > 0 {
+		params.Output = search.Output
+	}
+
+	if search.Name != "" || search.Key != "" {
+		params.Search = map[string]interface{}{}
+		if search.Name != "" {
+			params.Search["name"] = search.Name
+		}
+		if search.Key != "" {
+			params.Search["key_"] = search.Key
+		}
+		params.SearchWildcards = true
+	}
+
+	items, err := Call[[]Item](ctx, c, "item.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,331 @@
+// ABOUTME: Unit tests for Zabbix item API methods.
+// ABOUTME: Uses httptest to mock Zabbix API responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGetItemsByHostKey_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "item.get" {
+			t.Errorf("expected method 'item.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		if params["host"] != "web-01" {
+			t.Errorf("expected host 'web-01', got '%v'", params["host"])
+		}
+		filter, ok := params["filter"].(map[string]interface{})
+		if !ok || filter["key_"] != "system.cpu.load[all,avg1]" {
+			t.Errorf("expected filter key_ 'system.cpu.load[all,avg1]', got '%v'", params["filter"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"itemid": "23296",
+				"hostid": "10084",
+				"name": "Load average (1m avg)",
+				"key_": "system.cpu.load[all,avg1]",
+				"type": "0",
+				"value_type": "0",
+				"status": "0",
+				"delay": "1m",
+				"units": "",
+				"description": "CPU load"
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	items, err := client.GetItemsByHostKey(context.Background(), "web-01", "system.cpu.load[all,avg1]")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+	item := items[0]
+	if item.ItemID != "23296" {
+		t.Errorf("expected itemid '23296', got '%s'", item.ItemID)
+	}
+	if item.HostID != "10084" {
+		t.Errorf("expected hostid '10084', got '%s'", item.HostID)
+	}
+	if item.Key != "system.cpu.load[all,avg1]" {
+		t.Errorf("expected key 'system.cpu.load[all,avg1]', got '%s'", item.Key)
+	}
+	if item.Delay != "1m" {
+		t.Errorf("expected delay '1m', got '%s'", item.Delay)
+	}
+}
+
+func TestGetItemsByHostKey_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	items, err := client.GetItemsByHostKey(context.Background(), "web-01", "missing.key")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("expected no items, got %d", len(items))
+	}
+}
+
+func TestGetItemsByHostKey_Preprocessing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+		if params["selectPreprocessing"] != "extend" {
+			t.Errorf("expected preprocessing to be selected, got '%v'", params["selectPreprocessing"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"itemid": "23300",
+				"key_": "nginx.requests.total.rate",
+				"preprocessing": [
+					{"type": "12", "params": "$.requests", "error_handler": "0", "error_handler_params": ""},
+					{"type": "5", "params": "(\\d+) active\n\\1", "error_handler": "2", "error_handler_params": "0"},
+					{"type": "10", "params": "", "error_handler": "0", "error_handler_params": ""}
+				]
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	items, err := client.GetItemsByHostKey(context.Background(), "web-01", "nginx.requests.total.rate")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+	expected := []ItemPreprocessing{
+		{Type: PreprocessingJSONPath, Params: "$.requests"},
+		{Type: PreprocessingRegex, Params: "(\\d+) active\n\\1", ErrorHandler: PreprocessingErrorSetValue, ErrorHandlerParams: "0"},
+		{Type: PreprocessingChangePerSecond},
+	}
+	if !reflect.DeepEqual(items[0].Preprocessing, expected) {
+		t.Fatalf("expected preprocessing %+v, got %+v", expected, items[0].Preprocessing)
+	}
+
+	if params := items[0].Preprocessing[1].ParamList(); !reflect.DeepEqual(params, []string{"(\\d+) active", "\\1"}) {
+		t.Errorf("expected the regular expression and its output as parameters, got %q", params)
+	}
+	if params := items[0].Preprocessing[2].ParamList(); len(params) != 0 {
+		t.Errorf("expected no parameters for change per second, got %q", params)
+	}
+}
+
+func TestItem_UnmarshalJSON_InvalidValueType(t *testing.T) {
+	var item Item
+	err := json.Unmarshal([]byte(`{"itemid": "1", "value_type": "text"}`), &item)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestItem_UnmarshalJSON_MasterItemID(t *testing.T) {
+	tests := map[string]struct {
+		json     string
+		expected string
+	}{
+		"dependent": {json: `{"itemid": "2", "type": "18", "master_itemid": "1"}`, expected: "1"},
+		"agent":     {json: `{"itemid": "1", "type": "0", "master_itemid": "0"}`, expected: ""},
+		"omitted":   {json: `{"itemid": "1"}`, expected: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var item Item
+			if err := json.Unmarshal([]byte(tc.json), &item); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if item.MasterItemID != tc.expected {
+				t.Errorf("expected master item ID %q, got %q", tc.expected, item.MasterItemID)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_Formula(t *testing.T) {
+	var item Item
+	err := json.Unmarshal([]byte(`{"itemid": "23320", "type": "15", "params": "sum(last_foreach(/*/vfs.fs.size[/,used]?[group=\"Databases\"]))"}`), &item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Type != ItemTypeCalculated {
+		t.Errorf("expected a calculated item, got type %d", item.Type)
+	}
+	if item.Params != `sum(last_foreach(/*/vfs.fs.size[/,used]?[group="Databases"]))` {
+		t.Errorf("expected the formula as params, got %q", item.Params)
+	}
+}
+
+func TestItem_UnmarshalJSON_HTTPAgent(t *testing.T) {
+	var item Item
+	err := json.Unmarshal([]byte(`{
+		"itemid": "23310",
+		"type": "19",
+		"url": "https://api.example.com/health",
+		"query_fields": [{"name": "verbose", "value": "1"}],
+		"request_method": "1",
+		"headers": [{"name": "Accept", "value": "application/json"}, {"name": "X-Trace", "value": "on"}],
+		"posts": "{\"check\": \"all\"}",
+		"post_type": "2",
+		"authtype": "1",
+		"username": "monitor",
+		"password": "secret",
+		"follow_redirects": "1",
+		"retrieve_mode": "2",
+		"timeout": "10s",
+		"status_codes": "200,201-210"
+	}`), &item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := Item{
+		ItemID:          "23310",
+		Type:            ItemTypeHTTPAgent,
+		URL:             "https://api.example.com/health",
+		QueryFields:     []ItemHTTPField{{Name: "verbose", Value: "1"}},
+		RequestMethod:   HTTPMethodPost,
+		Headers:         []ItemHTTPField{{Name: "Accept", Value: "application/json"}, {Name: "X-Trace", Value: "on"}},
+		Posts:           `{"check": "all"}`,
+		PostType:        HTTPPostJSON,
+		AuthType:        HTTPAuthBasic,
+		Username:        "monitor",
+		Password:        "secret",
+		FollowRedirects: 1,
+		RetrieveMode:    HTTPRetrieveBoth,
+		Timeout:         "10s",
+		StatusCodes:     "200,201-210",
+	}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("expected %+v, got %+v", expected, item)
+	}
+}
+
+func TestSearchItems_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "item.get", &params, `[{
+		"itemid": "28501",
+		"hostid": "10084",
+		"name": "CPU load (1m avg)",
+		"key_": "system.cpu.load[all,avg1]",
+		"type": "0",
+		"value_type": "0",
+		"status": "0",
+		"tags": [{"tag": "component", "value": "cpu"}]
+	}]`)
+	defer server.Close()
+
+	templated := false
+	client := NewClient(server.URL, WithToken("test-token"))
+	items, err := client.SearchItems(context.Background(), ItemSearch{
+		HostIDs:   []string{"10084"},
+		Name:      "CPU*",
+		Key:       "system.cpu.load[*]",
+		Tags:      []TagFilter{{Tag: "component", Value: "cpu", Operator: TagOperatorEquals}},
+		Templated: &templated,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	search, ok := p["search"].(map[string]interface{})
+	if !ok || search["name"] != "CPU*" || search["key_"] != "system.cpu.load[*]" {
+		t.Errorf("expected search on name and key, got %v", p["search"])
+	}
+	if p["searchWildcardsEnabled"] != true || p["searchByAny"] != nil {
+		t.Errorf("expected wildcards matching all criteria, got %v", p)
+	}
+	if p["templated"] != false || p["output"] != "extend" || p["selectTags"] != "extend" || p["sortfield"] != "name" {
+		t.Errorf("unexpected parameters: %v", p)
+	}
+	tags, ok := p["tags"].([]interface{})
+	if !ok || len(tags) != 1 || tags[0].(map[string]interface{})["tag"] != "component" {
+		t.Errorf("expected the tag filter, got %v", p["tags"])
+	}
+
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+	if items[0].Key != "system.cpu.load[all,avg1]" || len(items[0].Tags) != 1 || items[0].Tags[0].Value != "cpu" {
+		t.Errorf("expected the item with its tags, got %+v", items[0])
+	}
+}
+
+func TestSearchItems_NoCriteria(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "item.get", &params, `[]`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if _, err := client.SearchItems(context.Background(), ItemSearch{Output: []string{"itemid", "name"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	for _, key := range []string{"search", "searchWildcardsEnabled", "tags", "hostids", "templated"} {
+		if _, exists := p[key]; exists {
+			t.Errorf("expected %s to be omitted, got %v", key, p[key])
+		}
+	}
+	if output, ok := p["output"].([]interface{}); !ok || len(output) != 2 {
+		t.Errorf("expected the selected output fields, got %v", p["output"])
+	}
+}
@@ -0,0 +1,61 @@
+// ABOUTME: Redaction of secrets in JSON-RPC parameters before they are logged.
+// ABOUTME: Replaces the values of credential fields such as passwords, tokens and PSKs.
+
+package zabbix
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// redactedValue replaces the value of sensitive parameters in logs.
+const redactedValue = "***"
+
+// sensitiveParamKeys are the parameter names whose values are never logged.
+var sensitiveParamKeys = map[string]bool{
+	"auth":                  true,
+	"token":                 true,
+	"sessionid":             true,
+	"passwd":                true,
+	"current_passwd":        true,
+	"password":              true,
+	"ipmi_password":         true,
+	"tls_psk":               true,
+	"snmpv3_authpassphrase": true,
+	"snmpv3_privpassphrase": true,
+}
+
+// redactParams returns a copy of the request parameters that is safe to log, with the
+// values of sensitive fields replaced at any depth.
+func redactParams(params interface{}) interface{} {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return redactedValue
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return redactedValue
+	}
+
+	return redactValue(decoded)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveParamKeys[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,132 @@
+// ABOUTME: Tests for JSON-RPC request logging and the redaction of secrets in parameters.
+// ABOUTME: Captures tflog output to check the logged fields and that no credentials leak.
+
+package zabbix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactParams(t *testing.T) {
+	params := map[string]interface{}{
+		"host":    "web01",
+		"tls_psk": "1f87b595725ac58dd977beef14b97461",
+		"interfaces": []map[string]interface{}{
+			{"ip": "192.0.2.1", "details": map[string]interface{}{"authpassphrase": "x", "snmpv3_privpassphrase": "secret"}},
+		},
+		"Password": "hunter2",
+	}
+
+	expected := map[string]interface{}{
+		"host":    "web01",
+		"tls_psk": redactedValue,
+		"interfaces": []interface{}{
+			map[string]interface{}{"ip": "192.0.2.1", "details": map[string]interface{}{"authpassphrase": "x", "snmpv3_privpassphrase": redactedValue}},
+		},
+		"Password": redactedValue,
+	}
+
+	if redacted := redactParams(params); !reflect.DeepEqual(redacted, expected) {
+		t.Errorf("expected %v, got %v", expected, redacted)
+	}
+
+	if params["tls_psk"] != "1f87b595725ac58dd977beef14b97461" {
+		t.Error("expected the original parameters to be unchanged")
+	}
+}
+
+func TestRequest_Logging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`["10084"]`), ID: req.ID})
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(server.URL, WithToken("secret-token"))
+	_, err := client.Request(ctx, "user.update", map[string]interface{}{
+		"userid": "1",
+		"passwd": "hunter2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(output.String(), "hunter2") || strings.Contains(output.String(), "secret-token") {
+		t.Fatalf("expected secrets to be redacted from the log, got: %s", output.String())
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("failed to decode log: %v", err)
+	}
+
+	messages := make(map[string]map[string]interface{})
+	for _, entry := range entries {
+		messages[entry["@message"].(string)] = entry
+	}
+
+	params, ok := messages["Zabbix API request parameters"]
+	if !ok {
+		t.Fatalf("expected the request parameters to be logged, got: %v", entries)
+	}
+	if p := params["params"].(map[string]interface{}); p["passwd"] != redactedValue || p["userid"] != "1" {
+		t.Errorf("expected passwd to be redacted and userid to be kept, got %v", p)
+	}
+
+	response, ok := messages["Received Zabbix API response"]
+	if !ok {
+		t.Fatalf("expected the response to be logged, got: %v", entries)
+	}
+	if response["method"] != "user.update" {
+		t.Errorf("expected method user.update, got %v", response["method"])
+	}
+	if response["result_size"] != float64(len(`["10084"]`)) {
+		t.Errorf("expected result_size %d, got %v", len(`["10084"]`), response["result_size"])
+	}
+	if _, ok := response["duration_ms"]; !ok {
+		t.Error("expected duration_ms to be logged")
+	}
+}
+
+func TestRequest_LoggingFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(server.URL, WithToken("secret-token"))
+	if _, err := client.Request(ctx, "host.get", nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("failed to decode log: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry["@message"] == "Zabbix API request failed" {
+			if !strings.Contains(entry["error"].(string), "502") {
+				t.Errorf("expected the HTTP status in the logged error, got %v", entry["error"])
+			}
+			return
+		}
+	}
+	t.Fatalf("expected the failure to be logged, got: %v", entries)
+}
@@ -0,0 +1,165 @@
+// ABOUTME: Read-through cache of name to ID lookups of host groups, template groups and templates.
+// ABOUTME: Deduplicates lookups within one provider instance and is invalidated by API writes.
+
+package zabbix
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// lookupKind is the kind of object whose IDs are cached by name.
+type lookupKind int
+
+const (
+	lookupHostGroup lookupKind = iota
+	lookupTemplateGroup
+	lookupTemplate
+)
+
+// lookupInvalidations maps the API methods that may change the names or IDs of cached
+// objects to the kinds they invalidate. Methods of other objects never do.
+var lookupInvalidations = map[string][]lookupKind{
+	"hostgroup.create":     {lookupHostGroup},
+	"hostgroup.update":     {lookupHostGroup},
+	"hostgroup.delete":     {lookupHostGroup},
+	"templategroup.create": {lookupTemplateGroup},
+	"templategroup.update": {lookupTemplateGroup},
+	"templategroup.delete": {lookupTemplateGroup},
+	"template.create":      {lookupTemplate},
+	"template.update":      {lookupTemplate},
+	"template.delete":      {lookupTemplate},
+	// Imports create and rename templates and the groups they reference
+	"configuration.import": {lookupHostGroup, lookupTemplateGroup, lookupTemplate},
+}
+
+// lookupKey identifies a cached lookup.
+type lookupKey struct {
+	kind lookupKind
+	name string
+}
+
+// lookupEntry is a lookup that is done or in flight. Concurrent lookups of the same name
+// wait for done instead of sending their own request.
+type lookupEntry struct {
+	done chan struct{}
+	id   string
+	err  error
+}
+
+// lookupCache caches the IDs of objects by name. The zero value is an empty cache and it is
+// safe for concurrent use. Failed lookups and objects that were not found are not cached,
+// so that objects created later are found.
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[lookupKey]*lookupEntry
+}
+
+// get returns the cached ID of the named object, calling lookup on a miss.
+func (l *lookupCache) get(ctx context.Context, kind lookupKind, name string, lookup func(context.Context) (string, error)) (string, error) {
+	key := lookupKey{kind: kind, name: name}
+
+	l.mu.Lock()
+	if entry, ok := l.entries[key]; ok {
+		l.mu.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if entry.err == nil && entry.id != "" {
+			return entry.id, nil
+		}
+		// The lookup we waited for failed, so do our own
+		return lookup(ctx)
+	}
+
+	entry := &lookupEntry{done: make(chan struct{})}
+	if l.entries == nil {
+		l.entries = map[lookupKey]*lookupEntry{}
+	}
+	l.entries[key] = entry
+	l.mu.Unlock()
+
+	entry.id, entry.err = lookup(ctx)
+	close(entry.done)
+
+	if entry.err != nil || entry.id == "" {
+		l.mu.Lock()
+		if l.entries[key] == entry {
+			delete(l.entries, key)
+		}
+		l.mu.Unlock()
+	}
+
+	return entry.id, entry.err
+}
+
+// invalidate removes the cached lookups of the given kinds.
+func (l *lookupCache) invalidate(kinds ...lookupKind) {
+	if len(kinds) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key := range l.entries {
+		for _, kind := range kinds {
+			if key.kind == kind {
+				delete(l.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// invalidateAfter removes the cached lookups that a call of the API method may have made
+// stale. It is called for failed calls as well, as they may have been applied anyway.
+func (l *lookupCache) invalidateAfter(method string) {
+	l.invalidate(lookupInvalidations[strings.ToLower(method)]...)
+}
+
+// HostGroupIDByName returns the ID of the host group with the given name, or an empty
+// string if there is none. Found IDs are cached until host groups are written.
+func (c *Client) HostGroupIDByName(ctx context.Context, name string) (string, error) {
+	return c.lookups.get(ctx, lookupHostGroup, name, func(ctx context.Context) (string, error) {
+		group, err := c.GetHostGroupByName(ctx, name)
+		if err != nil || group == nil {
+			return "", err
+		}
+		return group.GroupID, nil
+	})
+}
+
+// TemplateGroupIDByName returns the ID of the template group with the given name, or an
+// empty string if there is none. Found IDs are cached until template groups are written.
+func (c *Client) TemplateGroupIDByName(ctx context.Context, name string) (string, error) {
+	return c.lookups.get(ctx, lookupTemplateGroup, name, func(ctx context.Context) (string, error) {
+		group, err := c.GetTemplateGroupByName(ctx, name)
+		if err != nil || group == nil {
+			return "", err
+		}
+		return group.GroupID, nil
+	})
+}
+
+// TemplateIDByHost returns the ID of the template with the given technical name, or an
+// empty string if there is none. Found IDs are cached until templates are written.
+func (c *Client) TemplateIDByHost(ctx context.Context, host string) (string, error) {
+	return c.lookups.get(ctx, lookupTemplate, host, func(ctx context.Context) (string, error) {
+		params := GetTemplateParams{
+			Filter: map[string]interface{}{
+				"host": host,
+			},
+			Output: []string{"templateid"},
+		}
+
+		templates, err := Call[[]Template](ctx, c, "template.get", params)
+		if err != nil || len(templates) == 0 {
+			return "", err
+		}
+		return templates[0].TemplateID, nil
+	})
+}
@@ -0,0 +1,210 @@
+// ABOUTME: Unit tests for the cache of name to ID lookups using mock HTTP responses.
+// ABOUTME: Tests cover cache hits, invalidation by writes, uncached misses and concurrent lookups.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newLookupTestServer answers hostgroup.get with the group ID stored in groupID, or no
+// group when it is empty, and other methods with an empty result. It counts the
+// hostgroup.get requests.
+func newLookupTestServer(t *testing.T, groupID *atomic.Value, gets *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("failed to unmarshal request: %v", err)
+			return
+		}
+
+		result := json.RawMessage(`{"groupids": ["1"]}`)
+		if req.Method == "hostgroup.get" {
+			gets.Add(1)
+			result = json.RawMessage(`[]`)
+			if id := groupID.Load().(string); id != "" {
+				result = json.RawMessage(`[{"groupid": "` + id + `", "name": "Linux servers"}]`)
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+}
+
+func TestHostGroupIDByName_Cached(t *testing.T) {
+	var groupID atomic.Value
+	groupID.Store("10")
+	var gets atomic.Int32
+	server := newLookupTestServer(t, &groupID, &gets)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		id, err := client.HostGroupIDByName(ctx, "Linux servers")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if id != "10" {
+			t.Errorf("expected group ID '10', got '%s'", id)
+		}
+	}
+	if gets.Load() != 1 {
+		t.Errorf("expected 1 hostgroup.get request, got %d", gets.Load())
+	}
+
+	// Lookups of other kinds are not invalidated by writes of other objects
+	if _, err := client.Request(ctx, "host.update", map[string]interface{}{"hostid": "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.HostGroupIDByName(ctx, "Linux servers"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gets.Load() != 1 {
+		t.Errorf("expected host.update to keep the cached lookup, got %d hostgroup.get requests", gets.Load())
+	}
+}
+
+func TestHostGroupIDByName_InvalidatedByWrite(t *testing.T) {
+	var groupID atomic.Value
+	groupID.Store("10")
+	var gets atomic.Int32
+	server := newLookupTestServer(t, &groupID, &gets)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	ctx := context.Background()
+
+	if _, err := client.HostGroupIDByName(ctx, "Linux servers"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.DeleteHostGroup(ctx, "10"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	groupID.Store("11")
+
+	id, err := client.HostGroupIDByName(ctx, "Linux servers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "11" {
+		t.Errorf("expected group ID '11' after the delete, got '%s'", id)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("expected 2 hostgroup.get requests, got %d", gets.Load())
+	}
+}
+
+func TestHostGroupIDByName_NotFoundIsNotCached(t *testing.T) {
+	var groupID atomic.Value
+	groupID.Store("")
+	var gets atomic.Int32
+	server := newLookupTestServer(t, &groupID, &gets)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	ctx := context.Background()
+
+	id, err := client.HostGroupIDByName(ctx, "Linux servers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "" {
+		t.Errorf("expected no group ID, got '%s'", id)
+	}
+
+	// The group may be created by another resource of the apply
+	groupID.Store("12")
+	id, err = client.HostGroupIDByName(ctx, "Linux servers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "12" {
+		t.Errorf("expected group ID '12', got '%s'", id)
+	}
+}
+
+func TestHostGroupIDByName_Concurrent(t *testing.T) {
+	var groupID atomic.Value
+	groupID.Store("10")
+	var gets atomic.Int32
+	server := newLookupTestServer(t, &groupID, &gets)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := client.HostGroupIDByName(context.Background(), "Linux servers")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if id != "10" {
+				t.Errorf("expected group ID '10', got '%s'", id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if gets.Load() != 1 {
+		t.Errorf("expected concurrent lookups to share 1 hostgroup.get request, got %d", gets.Load())
+	}
+}
+
+func TestTemplateIDByHost(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		result := json.RawMessage(`{"imported": true}`)
+		if req.Method == "template.get" {
+			gets.Add(1)
+			result = json.RawMessage(`[{"templateid": "20"}]`)
+		}
+
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		id, err := client.TemplateIDByHost(ctx, "Linux by Zabbix agent")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if id != "20" {
+			t.Errorf("expected template ID '20', got '%s'", id)
+		}
+	}
+	if gets.Load() != 1 {
+		t.Errorf("expected 1 template.get request, got %d", gets.Load())
+	}
+
+	// Imports may create or rename templates
+	if err := client.ImportConfiguration(ctx, "yaml", "zabbix_export: {}", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.TemplateIDByHost(ctx, "Linux by Zabbix agent"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gets.Load() != 2 {
+		t.Errorf("expected the import to invalidate the cached lookup, got %d template.get requests", gets.Load())
+	}
+}
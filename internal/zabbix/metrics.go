@@ -0,0 +1,105 @@
+// ABOUTME: Optional metrics hook reporting the method, duration and error class of API calls.
+// ABOUTME: Lets embedders export API latency and error rates without parsing logs.
+
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrorClass is a coarse classification of the error of an API call, suitable as a metric label.
+type ErrorClass string
+
+// Error classes reported to a MetricsRecorder.
+const (
+	ErrorClassNone             ErrorClass = ""
+	ErrorClassNotFound         ErrorClass = "not_found"
+	ErrorClassAlreadyExists    ErrorClass = "already_exists"
+	ErrorClassPermissionDenied ErrorClass = "permission_denied"
+	ErrorClassAuthExpired      ErrorClass = "auth_expired"
+	// ErrorClassAPI is any other error reported by Zabbix.
+	ErrorClassAPI ErrorClass = "api"
+	// ErrorClassHTTP is an unexpected HTTP status.
+	ErrorClassHTTP     ErrorClass = "http"
+	ErrorClassNetwork  ErrorClass = "network"
+	ErrorClassCanceled ErrorClass = "canceled"
+	ErrorClassOther    ErrorClass = "other"
+)
+
+// CallMetrics describes a finished API call.
+type CallMetrics struct {
+	Method string
+	// Duration is the time until the response, including retries and waiting for the rate
+	// limiter. Requests of a batch report the duration of the whole batch.
+	Duration   time.Duration
+	ErrorClass ErrorClass
+	// Batch is set for requests sent as part of a batch.
+	Batch bool
+}
+
+// MetricsRecorder receives the metrics of every API call of a client. It is called
+// synchronously from concurrent requests, so it must be safe for concurrent use and fast.
+type MetricsRecorder interface {
+	RecordCall(ctx context.Context, call CallMetrics)
+}
+
+// MetricsRecorderFunc adapts a function to a MetricsRecorder.
+type MetricsRecorderFunc func(ctx context.Context, call CallMetrics)
+
+// RecordCall calls f.
+func (f MetricsRecorderFunc) RecordCall(ctx context.Context, call CallMetrics) {
+	f(ctx, call)
+}
+
+// ClassifyError returns the class of an error returned by the client.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	// The sentinel classes are more specific than the generic API error
+	for _, class := range []struct {
+		sentinel error
+		class    ErrorClass
+	}{
+		{ErrNotFound, ErrorClassNotFound},
+		{ErrAlreadyExists, ErrorClassAlreadyExists},
+		{ErrPermissionDenied, ErrorClassPermissionDenied},
+		{ErrAuthExpired, ErrorClassAuthExpired},
+	} {
+		if errors.Is(err, class.sentinel) {
+			return class.class
+		}
+	}
+
+	var apiErr *APIError
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &apiErr):
+		return ErrorClassAPI
+	case errors.As(err, &httpErr):
+		return ErrorClassHTTP
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassCanceled
+	case isTimeout(err) || isNetworkError(err):
+		return ErrorClassNetwork
+	}
+
+	return ErrorClassOther
+}
+
+// recordCall reports the call to the metrics recorder of the client, if any.
+func (c *Client) recordCall(ctx context.Context, method string, duration time.Duration, err error, batch bool) {
+	if c.Metrics == nil {
+		return
+	}
+
+	c.Metrics.RecordCall(ctx, CallMetrics{
+		Method:     method,
+		Duration:   duration,
+		ErrorClass: ClassifyError(err),
+		Batch:      batch,
+	})
+}
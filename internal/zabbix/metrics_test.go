@@ -0,0 +1,121 @@
+// ABOUTME: Unit tests for the metrics hook of the client and the classification of errors.
+// ABOUTME: Tests cover recorded calls of single and batch requests and the error classes.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordedCalls collects the calls reported to a MetricsRecorder.
+type recordedCalls struct {
+	mu    sync.Mutex
+	calls []CallMetrics
+}
+
+func (r *recordedCalls) RecordCall(ctx context.Context, call CallMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func TestMetrics_Request(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(`[]`)}
+		if req.Method == "host.delete" {
+			resp.Result = nil
+			resp.Error = &Error{Code: -32602, Message: "Invalid params.", Data: "No permissions to referred object or it does not exist!"}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	recorder := &recordedCalls{}
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Metrics = recorder
+	ctx := context.Background()
+
+	_, _ = client.Request(ctx, "host.get", nil)
+	_, _ = client.Request(ctx, "host.delete", []string{"1"})
+
+	if len(recorder.calls) != 2 {
+		t.Fatalf("expected 2 recorded calls, got %d", len(recorder.calls))
+	}
+	if call := recorder.calls[0]; call.Method != "host.get" || call.ErrorClass != ErrorClassNone || call.Batch {
+		t.Errorf("expected a successful host.get call, got %+v", call)
+	}
+	if call := recorder.calls[1]; call.Method != "host.delete" || call.ErrorClass != ErrorClassNotFound {
+		t.Errorf("expected a host.delete call failing with not_found, got %+v", call)
+	}
+	if recorder.calls[0].Duration <= 0 {
+		t.Errorf("expected a positive duration, got %s", recorder.calls[0].Duration)
+	}
+}
+
+func TestMetrics_Batch(t *testing.T) {
+	server := newBatchTestServer(t, new(int), func(req Request) (json.RawMessage, *Error) {
+		if req.Method == "user.create" {
+			return nil, &Error{Code: -32500, Message: "Application error.", Data: `No permissions to call "user.create".`}
+		}
+		return json.RawMessage(`"1"`), nil
+	})
+	defer server.Close()
+
+	var calls []CallMetrics
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Metrics = MetricsRecorderFunc(func(ctx context.Context, call CallMetrics) {
+		calls = append(calls, call)
+	})
+
+	_, err := client.Batch(context.Background(), []Request{{Method: "host.get"}, {Method: "user.create"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 recorded calls, got %d", len(calls))
+	}
+	if !calls[0].Batch || calls[0].ErrorClass != ErrorClassNone {
+		t.Errorf("expected a successful batch call, got %+v", calls[0])
+	}
+	if calls[1].Method != "user.create" || calls[1].ErrorClass != ErrorClassPermissionDenied {
+		t.Errorf("expected user.create to fail with permission_denied, got %+v", calls[1])
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected ErrorClass
+	}{
+		"no error":       {err: nil, expected: ErrorClassNone},
+		"already exists": {err: &APIError{Method: "host.create", Err: &Error{Data: `Host with the same name "web01" already exists.`}}, expected: ErrorClassAlreadyExists},
+		"expired":        {err: &APIError{Method: "host.get", Err: &Error{Data: "Session terminated, re-login, please."}}, expected: ErrorClassAuthExpired},
+		"other api":      {err: &APIError{Method: "host.get", Err: &Error{Data: `Invalid parameter "/1".`}}, expected: ErrorClassAPI},
+		"http":           {err: fmt.Errorf("request failed: %w", &HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}), expected: ErrorClassHTTP},
+		"network":        {err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: ErrorClassNetwork},
+		"canceled":       {err: fmt.Errorf("failed to send request: %w", context.Canceled), expected: ErrorClassCanceled},
+		"other":          {err: errors.New("failed to unmarshal response"), expected: ErrorClassOther},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if class := ClassifyError(tc.err); class != tc.expected {
+				t.Errorf("expected class %q, got %q", tc.expected, class)
+			}
+		})
+	}
+}
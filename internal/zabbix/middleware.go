@@ -0,0 +1,37 @@
+// ABOUTME: Middleware chain wrapping the round trips of the Zabbix API client.
+// ABOUTME: Lets users of the package log, audit or change requests and results without forking the client.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// RoundTripFunc sends a request and returns its result. The request has its ID and
+// parameters set; the token is sent by the final round trip and is not part of it.
+type RoundTripFunc func(ctx context.Context, req *Request) (json.RawMessage, error)
+
+// Middleware wraps a round trip, for example to inspect or change the request before
+// calling next and the result or error after it. A middleware may also answer without
+// calling next.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chain wraps the round trip in the middleware of the client. The first middleware is the
+// outermost and sees the request first.
+func (c *Client) chain(roundTrip RoundTripFunc) RoundTripFunc {
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		roundTrip = c.Middleware[i](roundTrip)
+	}
+	return roundTrip
+}
+
+// batchSequentially sends the requests of a batch one by one, so that each of them passes
+// the middleware, which wraps single requests only.
+func (c *Client) batchSequentially(ctx context.Context, requests []Request) []BatchResult {
+	results := make([]BatchResult, len(requests))
+	for i, r := range requests {
+		results[i].Result, results[i].Err = c.Request(ctx, r.Method, r.Params)
+	}
+	return results
+}
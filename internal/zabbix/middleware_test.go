@@ -0,0 +1,139 @@
+// ABOUTME: Unit tests for the middleware chain of the Zabbix API client.
+// ABOUTME: Tests cover the order of middleware, changed requests, short-circuited results and batches.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newMiddlewareTestServer answers every request with its method and parameters as result.
+func newMiddlewareTestServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("expected a single request, got %s", body)
+			return
+		}
+		*requests++
+
+		result, _ := json.Marshal(map[string]interface{}{"method": req.Method, "params": req.Params})
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+}
+
+func TestMiddleware_Order(t *testing.T) {
+	requests := 0
+	server := newMiddlewareTestServer(t, &requests)
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, req *Request) (json.RawMessage, error) {
+				order = append(order, name+" before")
+				result, err := next(ctx, req)
+				order = append(order, name+" after")
+				return result, err
+			}
+		}
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Middleware = []Middleware{trace("outer"), trace("inner")}
+
+	if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"outer before", "inner before", "inner after", "outer after"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestMiddleware_ChangesRequest(t *testing.T) {
+	requests := 0
+	server := newMiddlewareTestServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Middleware = []Middleware{func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (json.RawMessage, error) {
+			req.Params = map[string]interface{}{"limit": 1}
+			return next(ctx, req)
+		}
+	}}
+
+	result, err := client.Request(context.Background(), "host.get", map[string]interface{}{"output": "extend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(result) != `{"method":"host.get","params":{"limit":1}}` {
+		t.Errorf("expected the changed parameters to be sent, got %s", result)
+	}
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	requests := 0
+	server := newMiddlewareTestServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Middleware = []Middleware{func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (json.RawMessage, error) {
+			if req.Method == "host.get" {
+				return json.RawMessage(`[]`), nil
+			}
+			return next(ctx, req)
+		}
+	}}
+
+	result, err := client.Request(context.Background(), "host.get", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(result) != `[]` || requests != 0 {
+		t.Errorf("expected the middleware to answer without a request, got %s after %d requests", result, requests)
+	}
+}
+
+func TestMiddleware_Batch(t *testing.T) {
+	requests := 0
+	server := newMiddlewareTestServer(t, &requests)
+	defer server.Close()
+
+	var methods []string
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Middleware = []Middleware{func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, req *Request) (json.RawMessage, error) {
+			methods = append(methods, req.Method)
+			return next(ctx, req)
+		}
+	}}
+
+	results, err := client.Batch(context.Background(), []Request{{Method: "host.get"}, {Method: "item.get"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 2 || results[1].Err != nil || string(results[1].Result) != `{"method":"item.get","params":{}}` {
+		t.Errorf("expected the results of both requests, got %+v", results)
+	}
+	if len(methods) != 2 || requests != 2 {
+		t.Errorf("expected both requests to pass the middleware as single requests, got %v and %d requests", methods, requests)
+	}
+}
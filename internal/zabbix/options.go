@@ -0,0 +1,127 @@
+// ABOUTME: Functional options configuring a Zabbix API client created by NewClient.
+// ABOUTME: Each option sets one of the exported fields of Client, which may also be set directly.
+
+package zabbix
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a client created by NewClient. Options are applied in order, so a
+// later option overrides an earlier one setting the same field.
+type Option func(c *Client)
+
+// WithToken authenticates with an API token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.Token = token
+	}
+}
+
+// WithCredentials authenticates with a session of the user instead of an API token.
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.Username = username
+		c.Password = password
+	}
+}
+
+// WithTimeout sets the timeout of the HTTP client, DefaultTimeout by default. It changes
+// the client passed to an earlier WithHTTPClient.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = timeout
+	}
+}
+
+// WithHTTPClient sends requests with the HTTP client instead of one with DefaultTimeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sends requests through the transport, such as one created by NewTransport.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.HTTPClient.Transport = transport
+	}
+}
+
+// WithRetry retries failed requests according to the policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = policy
+	}
+}
+
+// WithRateLimiter waits for the limiter before every HTTP request.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.Limiter = limiter
+	}
+}
+
+// WithConcurrencyLimiter bounds the number of requests in flight with the limiter.
+func WithConcurrencyLimiter(limiter *ConcurrencyLimiter) Option {
+	return func(c *Client) {
+		c.Concurrency = limiter
+	}
+}
+
+// WithUserAgent sends the User-Agent header with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithFallbackURLs tries the endpoints in order when the URL of the client fails.
+func WithFallbackURLs(urls ...string) Option {
+	return func(c *Client) {
+		c.FallbackURLs = urls
+	}
+}
+
+// WithAuthMethod selects how the API token is sent.
+func WithAuthMethod(method AuthMethod) Option {
+	return func(c *Client) {
+		c.AuthMethod = method
+	}
+}
+
+// WithBasicAuth sends HTTP basic authentication credentials with every request.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.BasicAuth = &BasicAuth{Username: username, Password: password}
+	}
+}
+
+// WithRequestCompression gzips request bodies of 1 KiB or more.
+func WithRequestCompression() Option {
+	return func(c *Client) {
+		c.CompressRequests = true
+	}
+}
+
+// WithMetrics reports every API call to the recorder.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(c *Client) {
+		c.Metrics = recorder
+	}
+}
+
+// WithCapture records every request and response, sanitized, to the capture.
+func WithCapture(capture *Capture) Option {
+	return func(c *Client) {
+		c.Capture = capture
+	}
+}
+
+// WithMiddleware appends middleware wrapping the round trip of every request.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.Middleware = append(c.Middleware, middleware...)
+	}
+}
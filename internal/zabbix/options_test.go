@@ -0,0 +1,98 @@
+// ABOUTME: Unit tests for the functional options of NewClient.
+// ABOUTME: Tests cover the fields set by each option, their order and the User-Agent header.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	limiter := NewRateLimiter(10, 1)
+	recorder := MetricsRecorderFunc(func(ctx context.Context, call CallMetrics) {})
+	passThrough := func(next RoundTripFunc) RoundTripFunc { return next }
+	transport := &http.Transport{}
+
+	client := NewClient("http://example.com/api",
+		WithToken("test-token"),
+		WithCredentials("terraform", "secret"),
+		WithTimeout(time.Minute),
+		WithTransport(transport),
+		WithRetry(RetryPolicy{MaxRetries: 3}),
+		WithRateLimiter(limiter),
+		WithUserAgent("terraform-provider-zabbix/test"),
+		WithFallbackURLs("http://standby.example.com/api"),
+		WithAuthMethod(AuthMethodHeader),
+		WithBasicAuth("proxy", "proxy-secret"),
+		WithRequestCompression(),
+		WithMetrics(recorder),
+		WithMiddleware(passThrough),
+		WithMiddleware(passThrough),
+	)
+
+	if client.Token != "test-token" || client.Username != "terraform" || client.Password != "secret" {
+		t.Errorf("expected token and credentials to be set, got %q, %q and %q", client.Token, client.Username, client.Password)
+	}
+	if client.HTTPClient.Timeout != time.Minute || client.HTTPClient.Transport != transport {
+		t.Errorf("expected the timeout and transport to be set, got %v and %v", client.HTTPClient.Timeout, client.HTTPClient.Transport)
+	}
+	if client.Retry.MaxRetries != 3 || client.Limiter != limiter {
+		t.Errorf("expected retry policy and limiter to be set, got %+v and %v", client.Retry, client.Limiter)
+	}
+	if client.UserAgent != "terraform-provider-zabbix/test" {
+		t.Errorf("expected user agent to be set, got %q", client.UserAgent)
+	}
+	if len(client.FallbackURLs) != 1 || client.AuthMethod != AuthMethodHeader {
+		t.Errorf("expected fallback URLs and auth method to be set, got %v and %v", client.FallbackURLs, client.AuthMethod)
+	}
+	if client.BasicAuth == nil || client.BasicAuth.Username != "proxy" || !client.CompressRequests {
+		t.Errorf("expected basic auth and compression to be set, got %+v and %v", client.BasicAuth, client.CompressRequests)
+	}
+	if client.Metrics == nil || len(client.Middleware) != 2 {
+		t.Errorf("expected metrics and 2 middleware, got %v and %d middleware", client.Metrics, len(client.Middleware))
+	}
+}
+
+func TestNewClient_OptionOrder(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+
+	client := NewClient("http://example.com/api", WithTimeout(time.Minute), WithHTTPClient(httpClient))
+	if client.HTTPClient != httpClient || client.HTTPClient.Timeout != time.Second {
+		t.Errorf("expected the later HTTP client to replace the timeout, got %v", client.HTTPClient.Timeout)
+	}
+
+	client = NewClient("http://example.com/api", WithHTTPClient(httpClient), WithTimeout(time.Minute))
+	if client.HTTPClient != httpClient || client.HTTPClient.Timeout != time.Minute {
+		t.Errorf("expected the later timeout to apply to the HTTP client, got %v", client.HTTPClient.Timeout)
+	}
+
+	client = NewClient("http://example.com/api", WithToken("first"), WithToken("second"))
+	if client.Token != "second" {
+		t.Errorf("expected the later token to win, got %q", client.Token)
+	}
+}
+
+func TestRequest_UserAgent(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: 1})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	_, _ = NewClient(server.URL, WithToken("test-token"), WithUserAgent("terraform-provider-zabbix/1.2.3")).Request(ctx, "host.get", nil)
+	_, _ = NewClient(server.URL, WithToken("test-token")).Request(ctx, "host.get", nil)
+
+	if len(userAgents) != 2 || userAgents[0] != "terraform-provider-zabbix/1.2.3" {
+		t.Fatalf("expected the configured User-Agent, got %v", userAgents)
+	}
+	if userAgents[1] == "terraform-provider-zabbix/1.2.3" {
+		t.Errorf("expected the default User-Agent without the option, got %q", userAgents[1])
+	}
+}
@@ -0,0 +1,162 @@
+// ABOUTME: Paginator fetching the objects of large *.get calls in pages of a fixed size.
+// ABOUTME: Lists the matching IDs first and then requests the full objects page by page.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultPageSize is the number of objects requested per page when no page size is given.
+const DefaultPageSize = 1000
+
+// paginationIDFields maps the get methods that can be paginated to the ID field of their
+// objects. The parameter filtering by ID is the plural of the field, such as hostids.
+var paginationIDFields = map[string]string{
+	"host.get":          "hostid",
+	"hostgroup.get":     "groupid",
+	"template.get":      "templateid",
+	"templategroup.get": "groupid",
+	"item.get":          "itemid",
+	"trigger.get":       "triggerid",
+	"user.get":          "userid",
+	"token.get":         "tokenid",
+	"proxy.get":         "proxyid",
+}
+
+// Paginator fetches the objects returned by a get method in pages. The Zabbix API has no
+// offset parameter, so the paginator first lists only the IDs of the matching objects and
+// then requests the full objects by ID, one page at a time. Pages keep the order given by
+// the sortfield parameter, or are sorted by ID when it is not set.
+type Paginator[T any] struct {
+	client   *Client
+	method   string
+	idField  string
+	params   map[string]interface{}
+	pageSize int
+	ids      []string
+	listed   bool
+}
+
+// NewPaginator creates a paginator for a get method such as host.get. The parameters are
+// those of a single get call, and pageSize is the number of objects per page, defaulting to
+// DefaultPageSize when it is not positive.
+func NewPaginator[T any](c *Client, method string, params interface{}, pageSize int) (*Paginator[T], error) {
+	idField, ok := paginationIDFields[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s does not support pagination", method)
+	}
+
+	decoded := map[string]interface{}{}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s parameters: %w", method, err)
+		}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return nil, fmt.Errorf("%s parameters must be an object: %w", method, err)
+		}
+	}
+
+	if countOutput, _ := decoded["countOutput"].(bool); countOutput {
+		return nil, fmt.Errorf("%s with countOutput cannot be paginated", method)
+	}
+	if _, ok := decoded["sortfield"]; !ok {
+		decoded["sortfield"] = idField
+	}
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return &Paginator[T]{
+		client:   c,
+		method:   method,
+		idField:  idField,
+		params:   decoded,
+		pageSize: pageSize,
+	}, nil
+}
+
+// HasNext reports whether there may be a further page. It is true before the first page.
+func (p *Paginator[T]) HasNext() bool {
+	return !p.listed || len(p.ids) > 0
+}
+
+// Next returns the next page of objects, or nil when all pages have been returned. The
+// first call lists the IDs of all matching objects.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if !p.listed {
+		ids, err := p.listIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.ids = ids
+		p.listed = true
+	}
+
+	if len(p.ids) == 0 {
+		return nil, nil
+	}
+
+	size := min(p.pageSize, len(p.ids))
+	page := p.ids[:size]
+
+	params := make(map[string]interface{}, len(p.params)+1)
+	for key, value := range p.params {
+		params[key] = value
+	}
+	delete(params, "limit")
+	params[p.idField+"s"] = page
+
+	objects, err := Call[[]T](ctx, p.client, p.method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ids = p.ids[size:]
+	return objects, nil
+}
+
+// All returns the objects of all remaining pages.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+	}
+
+	return all, nil
+}
+
+// listIDs requests the IDs of the matching objects in the final sort order. Related
+// objects are not selected, so the response stays small even for many objects.
+func (p *Paginator[T]) listIDs(ctx context.Context) ([]string, error) {
+	params := make(map[string]interface{}, len(p.params))
+	for key, value := range p.params {
+		if !strings.HasPrefix(key, "select") {
+			params[key] = value
+		}
+	}
+	params["output"] = []string{p.idField}
+
+	objects, err := Call[[]map[string]json.RawMessage](ctx, p.client, p.method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(objects))
+	for i, object := range objects {
+		if err := json.Unmarshal(object[p.idField], &ids[i]); err != nil {
+			return nil, fmt.Errorf("failed to read %s of %s response: %w", p.idField, p.method, err)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,175 @@
+// ABOUTME: Unit tests for the paginator of large get calls.
+// ABOUTME: Uses httptest to mock a host.get endpoint that lists IDs and returns hosts by ID.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newPaginationTestServer returns a host.get server for the given host names, sorted by
+// name in descending order, recording the parameters of every request.
+func newPaginationTestServer(t *testing.T, names []string, requests *[]map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+		params := req.Params.(map[string]interface{})
+		*requests = append(*requests, params)
+
+		wanted := map[string]bool{}
+		if ids, ok := params["hostids"].([]interface{}); ok {
+			for _, id := range ids {
+				wanted[id.(string)] = true
+			}
+		}
+
+		var hosts []map[string]string
+		for i := len(names) - 1; i >= 0; i-- {
+			id := string(rune('1' + i))
+			if len(wanted) > 0 && !wanted[id] {
+				continue
+			}
+			host := map[string]string{"hostid": id}
+			if params["output"] == "extend" {
+				host["host"] = names[i]
+			}
+			hosts = append(hosts, host)
+		}
+
+		result, _ := json.Marshal(hosts)
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: result, ID: req.ID})
+	}))
+}
+
+func TestPaginator_Pages(t *testing.T) {
+	var requests []map[string]interface{}
+	server := newPaginationTestServer(t, []string{"a", "b", "c", "d", "e"}, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	paginator, err := NewPaginator[Host](client, "host.get", GetHostParams{
+		Output:       "extend",
+		SelectGroups: "extend",
+		SortField:    "host",
+	}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var pages [][]string
+	for paginator.HasNext() {
+		page, err := paginator.Next(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var names []string
+		for _, host := range page {
+			names = append(names, host.Host)
+		}
+		pages = append(pages, names)
+	}
+
+	expected := [][]string{{"e", "d"}, {"c", "b"}, {"a"}}
+	if !reflect.DeepEqual(pages, expected) {
+		t.Errorf("expected pages %v, got %v", expected, pages)
+	}
+
+	if len(requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(requests))
+	}
+
+	listing := requests[0]
+	if output, ok := listing["output"].([]interface{}); !ok || len(output) != 1 || output[0] != "hostid" {
+		t.Errorf("expected the listing to request only hostid, got '%v'", listing["output"])
+	}
+	if _, exists := listing["selectGroups"]; exists {
+		t.Error("expected the listing not to select groups")
+	}
+
+	for _, page := range requests[1:] {
+		if page["selectGroups"] != "extend" || page["sortfield"] != "host" {
+			t.Errorf("expected pages to keep the parameters, got %v", page)
+		}
+	}
+	if ids := requests[1]["hostids"].([]interface{}); len(ids) != 2 || ids[0] != "5" || ids[1] != "4" {
+		t.Errorf("expected the first page to request hosts 5 and 4, got %v", ids)
+	}
+}
+
+func TestPaginator_All(t *testing.T) {
+	var requests []map[string]interface{}
+	server := newPaginationTestServer(t, []string{"a", "b", "c"}, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	paginator, err := NewPaginator[Host](client, "host.get", map[string]interface{}{
+		"output": "extend",
+		"limit":  2,
+	}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hosts, err := paginator.All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(hosts) != 3 {
+		t.Errorf("expected 3 hosts, got %d", len(hosts))
+	}
+	if requests[0]["sortfield"] != "hostid" {
+		t.Errorf("expected hosts to be sorted by hostid without sortfield, got '%v'", requests[0]["sortfield"])
+	}
+	if requests[0]["limit"] != float64(2) {
+		t.Errorf("expected the listing to keep the limit, got '%v'", requests[0]["limit"])
+	}
+	if _, exists := requests[1]["limit"]; exists {
+		t.Error("expected the limit to be removed from pages")
+	}
+}
+
+func TestPaginator_NoObjects(t *testing.T) {
+	var requests []map[string]interface{}
+	server := newPaginationTestServer(t, nil, &requests)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	paginator, err := NewPaginator[Host](client, "host.get", nil, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hosts, err := paginator.All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hosts) != 0 || len(requests) != 1 {
+		t.Errorf("expected no hosts after a single listing request, got %d hosts and %d requests", len(hosts), len(requests))
+	}
+	if paginator.HasNext() {
+		t.Error("expected no further page")
+	}
+}
+
+func TestNewPaginator_Invalid(t *testing.T) {
+	client := NewClient("http://localhost:1", WithToken("test-token"))
+
+	if _, err := NewPaginator[Host](client, "apiinfo.version", nil, 10); err == nil {
+		t.Error("expected error for a method without pagination support")
+	}
+	if _, err := NewPaginator[Host](client, "host.get", GetHostParams{CountOutput: true}, 10); err == nil {
+		t.Error("expected error for countOutput")
+	}
+}
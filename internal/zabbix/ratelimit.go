@@ -0,0 +1,75 @@
+// ABOUTME: Token bucket rate limiter for requests sent by the Zabbix API client.
+// ABOUTME: Spreads large applies over time so that small Zabbix frontends are not overwhelmed.
+
+package zabbix
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that allows requests at a steady rate with bursts of up
+// to a fixed number of requests. It is safe for concurrent use.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter allowing requestsPerSecond requests per second on
+// average and bursts of up to burst requests. The bucket starts full.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and otherwise returns the time until the
+// next token is added to the bucket.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
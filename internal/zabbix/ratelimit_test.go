@@ -0,0 +1,94 @@
+// ABOUTME: Unit tests for the Zabbix API client rate limiter.
+// ABOUTME: Uses a fake clock to verify token bucket refill and burst behavior.
+
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+	limiter.last = now
+
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("expected request %d of the burst to pass, got delay %v", i+1, delay)
+		}
+	}
+
+	if delay := limiter.reserve(); delay != 500*time.Millisecond {
+		t.Errorf("expected delay 500ms after the burst, got %v", delay)
+	}
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := NewRateLimiter(10, 1)
+	limiter.now = func() time.Time { return now }
+	limiter.last = now
+
+	if delay := limiter.reserve(); delay != 0 {
+		t.Fatalf("expected first request to pass, got delay %v", delay)
+	}
+	if delay := limiter.reserve(); delay == 0 {
+		t.Fatal("expected second request to be delayed")
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	if delay := limiter.reserve(); delay != 0 {
+		t.Errorf("expected request to pass after refill, got delay %v", delay)
+	}
+
+	// An idle limiter does not accumulate more tokens than the burst size
+	now = now.Add(time.Hour)
+	limiter.reserve()
+	if delay := limiter.reserve(); delay == 0 {
+		t.Error("expected tokens to be capped at the burst size")
+	}
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	_ = limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRequest_RateLimited(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Limiter = NewRateLimiter(0.001, 1)
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err == nil {
+		t.Fatal("expected HTTP error, got nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Request(ctx, "apiinfo.version", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second request to wait for the limiter, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request to reach the server, got %d", requests)
+	}
+}
@@ -0,0 +1,152 @@
+// ABOUTME: Retry policy of the Zabbix API client for transient failures.
+// ABOUTME: Retries network errors, gateway statuses and an unavailable database with jittered backoff.
+
+package zabbix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy configures how requests failing with a transient error are retried. Failed
+// connections and Zabbix reporting that its database is down are always transient. Timeouts,
+// the HTTP statuses in OnStatus and connections lost after the request was sent are only
+// transient for idempotent methods, as Zabbix may have applied the request before.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// Backoff is the delay before the first retry. It doubles with every further retry,
+	// and each delay is randomized between half and the full value.
+	Backoff time.Duration
+	// OnStatus lists the HTTP status codes that are retried for idempotent methods.
+	OnStatus []int
+	// IdempotentMethods lists the methods besides *.get and apiinfo.version whose requests
+	// are retried after a timeout, because sending them twice has the same effect as once.
+	IdempotentMethods []string
+}
+
+// transientAPIErrors are fragments of Zabbix API error messages reporting a failure of the
+// database rather than of the request, matched case-insensitively.
+var transientAPIErrors = []string{
+	"database is down",
+	"error connecting to database",
+}
+
+// retry calls attempt until it succeeds, fails with an error that is not transient, or the
+// retries of the retry policy are exhausted. Errors after which the request may have been
+// applied are only retried when idempotent is set.
+func (c *Client) retry(ctx context.Context, idempotent bool, attempt func() error) error {
+	backoff := c.Retry.Backoff
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || n > c.Retry.MaxRetries || !c.isTransient(ctx, err, idempotent) {
+			return err
+		}
+
+		delay := jitter(backoff)
+		tflog.Debug(ctx, "Retrying Zabbix API request", map[string]interface{}{
+			"attempt":  n,
+			"delay_ms": delay.Milliseconds(),
+			"error":    err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (retry aborted: %w)", err, ctx.Err())
+		case <-time.After(delay):
+		}
+		backoff *= 2
+	}
+}
+
+// isTransient reports whether a request failing with err may succeed when retried.
+// Errors caused by the context are never transient. Errors after which the request may have
+// been applied are only transient for idempotent requests.
+func (c *Client) isTransient(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return idempotent && slices.Contains(c.Retry.OnStatus, httpErr.StatusCode)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		message := strings.ToLower(apiErr.Err.Message + " " + apiErr.Err.Data)
+		return slices.ContainsFunc(transientAPIErrors, func(fragment string) bool {
+			return strings.Contains(message, fragment)
+		})
+	}
+
+	if isNotSent(err) {
+		return true
+	}
+
+	return idempotent && (isTimeout(err) || isNetworkError(err))
+}
+
+// isIdempotent reports whether requests of all the methods may be sent again without
+// changing the outcome: read-only methods and the IdempotentMethods of the retry policy.
+func (c *Client) isIdempotent(methods ...string) bool {
+	for _, method := range methods {
+		readOnly := strings.HasSuffix(method, ".get") || method == "apiinfo.version"
+		if !readOnly && !slices.Contains(c.Retry.IdempotentMethods, method) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNotSent reports whether err is a failure to connect, so that the request never
+// reached Zabbix and may be sent again whatever its method.
+func isNotSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isTimeout reports whether err is a timeout, after which it is unknown whether the
+// request reached Zabbix.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isNetworkError reports whether err is a connection error. Errors of the HTTP client
+// such as invalid certificates are not network errors, and timeouts are told apart by
+// isTimeout.
+func isNetworkError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// jitter returns a random delay between half and the full backoff, so that clients failing
+// at the same time do not retry in lockstep.
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + rand.N(backoff-backoff/2+1)
+}
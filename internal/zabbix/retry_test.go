@@ -0,0 +1,266 @@
+// ABOUTME: Unit tests for retrying transient failures of Zabbix API requests.
+// ABOUTME: Covers dropped connections, statuses and timeouts of idempotent and other methods, an unavailable database and the jittered backoff.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequest_RetryOnDroppedConnection(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("failed to hijack connection: %v", err)
+			}
+			_ = conn.Close()
+			return
+		}
+
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+	if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRequest_RetryOnConnectionRefused(t *testing.T) {
+	client := NewClient("http://localhost:1", WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+	attempts := 0
+	err := client.retry(context.Background(), false, func() error {
+		attempts++
+		_, err := client.exchange(context.Background(), Request{Method: "host.get"}, []byte(`{}`), "")
+		return err
+	})
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// newSlowServer returns a server answering every request with an empty result, after
+// sleeping for delay on the first attempt. attempts counts the requests received.
+func newSlowServer(delay time.Duration, attempts *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			time.Sleep(delay)
+		}
+
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID})
+	}))
+}
+
+func TestRequest_RetryOnTimeout(t *testing.T) {
+	for _, method := range []string{"host.get", "apiinfo.version", "host.update"} {
+		t.Run(method, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := newSlowServer(200*time.Millisecond, &attempts)
+			defer server.Close()
+
+			client := NewClient(server.URL, WithToken("test-token"), WithTimeout(50*time.Millisecond))
+			client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond, IdempotentMethods: []string{"host.update"}}
+
+			if _, err := client.Request(context.Background(), method, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n := attempts.Load(); n != 2 {
+				t.Errorf("expected 2 attempts, got %d", n)
+			}
+		})
+	}
+}
+
+func TestRequest_NoRetryOnTimeoutOfCreate(t *testing.T) {
+	var attempts atomic.Int32
+	server := newSlowServer(200*time.Millisecond, &attempts)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"), WithTimeout(50*time.Millisecond))
+	client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+	// The host may have been created before the timeout, so sending the request again could
+	// fail with a duplicate or create the host twice
+	_, err := client.Request(context.Background(), "host.create", map[string]interface{}{"host": "web01"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !isTimeout(err) {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestBatch_NoRetryOnTimeoutOfCreate(t *testing.T) {
+	var attempts atomic.Int32
+	server := newSlowServer(200*time.Millisecond, &attempts)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"), WithTimeout(50*time.Millisecond))
+	client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+	if _, err := client.Batch(context.Background(), []Request{{Method: "host.get"}, {Method: "host.create"}}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestRequest_NoRetryOfCreateAfterSending(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"gateway timeout": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGatewayTimeout)
+		},
+		"dropped connection": func(w http.ResponseWriter, r *http.Request) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+		},
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				handler(w, r)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, WithToken("test-token"))
+			client.Retry = RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond, OnStatus: []int{502, 503, 504}}
+
+			if _, err := client.Request(context.Background(), "host.create", nil); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if n := attempts.Load(); n != 1 {
+				t.Errorf("expected 1 attempt, got %d", n)
+			}
+		})
+	}
+}
+
+func TestRequest_RetryOnDatabaseDown(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		resp := Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID}
+		if attempts < 3 {
+			resp = Response{JSONRPC: "2.0", Error: &Error{Code: -32500, Message: "Application error.", Data: "Database is down."}, ID: req.ID}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}
+
+	if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRequest_NoRetryForAPIError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(Response{
+			JSONRPC: "2.0",
+			Error:   &Error{Code: -32602, Message: "Invalid params.", Data: "Host with the same name already exists."},
+			ID:      req.ID,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}
+
+	var apiErr *APIError
+	if _, err := client.Request(context.Background(), "host.create", nil); !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestBatch_RetryOnStatus(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var reqs []Request
+		_ = json.NewDecoder(r.Body).Decode(&reqs)
+		resps := make([]Response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = Response{JSONRPC: "2.0", Result: json.RawMessage(`[]`), ID: req.ID}
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.Retry = RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond, OnStatus: []int{503}}
+
+	if _, err := client.Batch(context.Background(), []Request{{Method: "host.get"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	backoff := 100 * time.Millisecond
+
+	for range 100 {
+		if delay := jitter(backoff); delay < backoff/2 || delay > backoff {
+			t.Fatalf("expected delay between %v and %v, got %v", backoff/2, backoff, delay)
+		}
+	}
+
+	if delay := jitter(0); delay != 0 {
+		t.Errorf("expected no delay without backoff, got %v", delay)
+	}
+}
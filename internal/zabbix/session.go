@@ -0,0 +1,120 @@
+// ABOUTME: Session lifecycle for clients authenticating with username and password.
+// ABOUTME: Logs in lazily, shares the session between goroutines, logs in again when it expires and logs out.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LoginParams contains parameters for user.login.
+type LoginParams struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// usesSession reports whether the client authenticates with a session of Username instead
+// of an API token. An API token takes precedence when both are set.
+func (c *Client) usesSession() bool {
+	return c.Token == "" && c.Username != ""
+}
+
+// authToken returns the API token, or the session ID of Username, logging in when there is
+// no session yet. Concurrent callers share one login.
+func (c *Client) authToken(ctx context.Context) (string, error) {
+	if !c.usesSession() {
+		return c.Token, nil
+	}
+
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.session == "" {
+		session, err := c.login(ctx)
+		if err != nil {
+			return "", err
+		}
+		c.session = session
+	}
+
+	return c.session, nil
+}
+
+// expireSession forgets the session when it is still the given expired session, so that the
+// next request logs in again. Sessions of newer logins are kept.
+func (c *Client) expireSession(ctx context.Context, expired string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.session == expired {
+		tflog.Debug(ctx, "Zabbix session expired, logging in again", map[string]interface{}{
+			"username": c.Username,
+		})
+		c.session = ""
+	}
+}
+
+// Login logs in with Username and Password and keeps the session for later requests. It
+// is not required before other requests, which log in when there is no session yet.
+func (c *Client) Login(ctx context.Context) error {
+	if !c.usesSession() {
+		return fmt.Errorf("login requires a username and no API token")
+	}
+
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	session, err := c.login(ctx)
+	if err != nil {
+		return err
+	}
+	c.session = session
+
+	return nil
+}
+
+// login requests a new session with user.login.
+func (c *Client) login(ctx context.Context) (string, error) {
+	session, err := Call[string](ctx, c, "user.login", LoginParams{
+		Username: c.Username,
+		Password: c.Password,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not log in as %q: %w", c.Username, err)
+	}
+	if session == "" {
+		return "", fmt.Errorf("could not log in as %q: user.login returned no session", c.Username)
+	}
+
+	tflog.Debug(ctx, "Logged in to the Zabbix API", map[string]interface{}{
+		"username": c.Username,
+	})
+	return session, nil
+}
+
+// Logout ends the session with user.logout, so that it does not count against the
+// sessions of the user until it expires. It does nothing for API tokens or when the client
+// has not logged in. Requests after Logout log in again.
+func (c *Client) Logout(ctx context.Context) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.session == "" {
+		return nil
+	}
+
+	// user.logout is sent with the session directly, as authToken would wait for sessionMu
+	session := c.session
+	c.session = ""
+	if _, err := c.requestWithToken(ctx, "user.logout", []string{}, session); err != nil {
+		return fmt.Errorf("could not log out: %w", err)
+	}
+
+	tflog.Debug(ctx, "Logged out of the Zabbix API", map[string]interface{}{
+		"username": c.Username,
+	})
+	return nil
+}
@@ -0,0 +1,228 @@
+// ABOUTME: Unit tests for the session lifecycle of clients authenticating with a username.
+// ABOUTME: Tests cover lazy and shared login, login after an expired session, failed logins and logout.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// sessionTestServer is a Zabbix API that hands out numbered sessions and accepts only the
+// latest one.
+type sessionTestServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	logins  int
+	logouts []string
+	valid   string
+}
+
+func newSessionTestServer(t *testing.T) *sessionTestServer {
+	s := &sessionTestServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var reqs []Request
+		batch := json.Unmarshal(body, &reqs) == nil
+		if !batch {
+			var req Request
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("failed to unmarshal request: %v", err)
+				return
+			}
+			reqs = []Request{req}
+		}
+
+		resps := make([]Response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = s.handle(t, req)
+		}
+
+		if batch {
+			_ = json.NewEncoder(w).Encode(resps)
+		} else {
+			_ = json.NewEncoder(w).Encode(resps[0])
+		}
+	}))
+	return s
+}
+
+func (s *sessionTestServer) handle(t *testing.T, req Request) Response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "user.login":
+		params, _ := req.Params.(map[string]interface{})
+		if params["username"] != "terraform" || params["password"] != "secret" {
+			resp.Error = &Error{Code: -32602, Message: "Invalid params.", Data: "Incorrect user name or password or account is temporarily blocked."}
+			return resp
+		}
+		if req.Auth != "" {
+			t.Errorf("expected user.login without auth, got %q", req.Auth)
+		}
+		s.logins++
+		s.valid = fmt.Sprintf("session-%d", s.logins)
+		resp.Result = json.RawMessage(`"` + s.valid + `"`)
+	case "user.logout":
+		s.logouts = append(s.logouts, req.Auth)
+		s.valid = ""
+		resp.Result = json.RawMessage(`true`)
+	default:
+		if req.Auth == "" || req.Auth != s.valid {
+			resp.Error = &Error{Code: -32602, Message: "Invalid params.", Data: "Session terminated, re-login, please."}
+			return resp
+		}
+		resp.Result = json.RawMessage(`[]`)
+	}
+	return resp
+}
+
+// expire terminates the current session, as Zabbix does after the auto-logout time.
+func (s *sessionTestServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valid = ""
+}
+
+func newSessionClient(url string) *Client {
+	client := NewClient(url)
+	client.Username = "terraform"
+	client.Password = "secret"
+	return client
+}
+
+func TestSession_LazyLogin(t *testing.T) {
+	server := newSessionTestServer(t)
+	defer server.Close()
+
+	client := newSessionClient(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Request(context.Background(), "host.get", nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if server.logins != 1 {
+		t.Errorf("expected concurrent requests to share 1 login, got %d", server.logins)
+	}
+}
+
+func TestSession_LoginAgainAfterExpiry(t *testing.T) {
+	server := newSessionTestServer(t)
+	defer server.Close()
+
+	client := newSessionClient(server.URL)
+	ctx := context.Background()
+
+	if _, err := client.Request(ctx, "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server.expire()
+	if _, err := client.Request(ctx, "host.get", nil); err != nil {
+		t.Fatalf("expected the request to succeed after logging in again, got %v", err)
+	}
+
+	server.expire()
+	results, err := client.Batch(ctx, []Request{{Method: "host.get"}, {Method: "item.get"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("expected the batch to succeed after logging in again, got %v", result.Err)
+		}
+	}
+
+	if server.logins != 3 {
+		t.Errorf("expected 3 logins, got %d", server.logins)
+	}
+}
+
+func TestSession_LoginFailure(t *testing.T) {
+	server := newSessionTestServer(t)
+	defer server.Close()
+
+	client := newSessionClient(server.URL)
+	client.Password = "wrong"
+
+	_, err := client.Request(context.Background(), "host.get", nil)
+	if err == nil {
+		t.Fatal("expected error for wrong password")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Method != "user.login" {
+		t.Errorf("expected the user.login error, got %v", err)
+	}
+}
+
+func TestSession_Logout(t *testing.T) {
+	server := newSessionTestServer(t)
+	defer server.Close()
+
+	client := newSessionClient(server.URL)
+	ctx := context.Background()
+
+	// Logging out without a session does nothing
+	if err := client.Logout(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(server.logouts) != 0 {
+		t.Errorf("expected no logout without a session, got %v", server.logouts)
+	}
+
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Logout(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(server.logouts) != 1 || server.logouts[0] != "session-1" {
+		t.Errorf("expected a logout of session-1, got %v", server.logouts)
+	}
+
+	// Requests after the logout log in again
+	if _, err := client.Request(ctx, "host.get", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.logins != 2 {
+		t.Errorf("expected 2 logins, got %d", server.logins)
+	}
+}
+
+func TestSession_TokenTakesPrecedence(t *testing.T) {
+	server := newSessionTestServer(t)
+	defer server.Close()
+
+	client := newSessionClient(server.URL)
+	client.Token = "api-token"
+
+	if err := client.Login(context.Background()); err == nil {
+		t.Error("expected login to fail for a client with an API token")
+	}
+
+	// The test server rejects the token, without logging in
+	_, _ = client.Request(context.Background(), "host.get", nil)
+	if server.logins != 0 {
+		t.Errorf("expected no login with an API token, got %d", server.logins)
+	}
+}
@@ -0,0 +1,75 @@
+// ABOUTME: Provides API methods for Zabbix server tasks.
+// ABOUTME: Implements checking items immediately and reloading proxy configuration with task.create.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+)
+
+// Task types of task.create.
+const (
+	TaskTypeReloadProxyConfig = 2
+	TaskTypeCheckNow          = 6
+)
+
+// Task represents a task for task.create. Request holds the parameters of the task type.
+type Task struct {
+	Type    int         `json:"type"`
+	Request interface{} `json:"request"`
+}
+
+// checkNowRequest is the request of a TaskTypeCheckNow task.
+type checkNowRequest struct {
+	ItemID string `json:"itemid"`
+}
+
+// reloadProxyConfigRequest is the request of a TaskTypeReloadProxyConfig task.
+type reloadProxyConfigRequest struct {
+	ProxyIDs []string `json:"proxyids"`
+}
+
+// CreateTasksResponse contains the response from task.create.
+type CreateTasksResponse struct {
+	TaskIDs []string `json:"taskids"`
+}
+
+// CreateTasks creates the tasks in a single request and returns their IDs in order.
+func (c *Client) CreateTasks(ctx context.Context, tasks []Task) ([]string, error) {
+	resp, err := Call[CreateTasksResponse](ctx, c, "task.create", tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.TaskIDs) != len(tasks) {
+		return nil, fmt.Errorf("task.create returned %d task IDs for %d tasks", len(resp.TaskIDs), len(tasks))
+	}
+
+	return resp.TaskIDs, nil
+}
+
+// CheckItemsNow asks the server to check the items immediately instead of waiting for their
+// update interval. Items must be enabled and of a type that supports checks on demand,
+// and their hosts must be monitored.
+func (c *Client) CheckItemsNow(ctx context.Context, itemIDs []string) ([]string, error) {
+	tasks := make([]Task, len(itemIDs))
+	for i, id := range itemIDs {
+		tasks[i] = Task{Type: TaskTypeCheckNow, Request: checkNowRequest{ItemID: id}}
+	}
+
+	return c.CreateTasks(ctx, tasks)
+}
+
+// ReloadProxyConfig asks the server to send the configuration to the proxies now, so that
+// changes reach them before the next configuration sync.
+func (c *Client) ReloadProxyConfig(ctx context.Context, proxyIDs []string) (string, error) {
+	taskIDs, err := c.CreateTasks(ctx, []Task{
+		{Type: TaskTypeReloadProxyConfig, Request: reloadProxyConfigRequest{ProxyIDs: proxyIDs}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return taskIDs[0], nil
+}
@@ -0,0 +1,76 @@
+// ABOUTME: Unit tests for task API methods using mock HTTP responses.
+// ABOUTME: Tests cover the check now and proxy configuration reload tasks sent with task.create.
+
+package zabbix
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheckItemsNow_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "task.create", &params, `{"taskids": ["1", "2"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	taskIDs, err := client.CheckItemsNow(context.Background(), []string{"28252", "28253"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(taskIDs) != 2 || taskIDs[0] != "1" || taskIDs[1] != "2" {
+		t.Errorf("expected task IDs [1 2], got %v", taskIDs)
+	}
+
+	tasks, ok := params.([]interface{})
+	if !ok || len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %v", params)
+	}
+	task := tasks[1].(map[string]interface{})
+	if task["type"] != float64(TaskTypeCheckNow) {
+		t.Errorf("expected type %d, got %v", TaskTypeCheckNow, task["type"])
+	}
+	if request := task["request"].(map[string]interface{}); request["itemid"] != "28253" {
+		t.Errorf("expected itemid 28253, got %v", request["itemid"])
+	}
+}
+
+func TestCheckItemsNow_MissingIDs(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "task.create", &params, `{"taskids": ["1"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.CheckItemsNow(context.Background(), []string{"28252", "28253"})
+	if err == nil || !strings.Contains(err.Error(), "returned 1 task IDs for 2 tasks") {
+		t.Fatalf("expected task ID count error, got %v", err)
+	}
+}
+
+func TestReloadProxyConfig_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "task.create", &params, `{"taskids": ["3"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	taskID, err := client.ReloadProxyConfig(context.Background(), []string{"10459", "10460"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if taskID != "3" {
+		t.Errorf("expected task ID 3, got %s", taskID)
+	}
+
+	tasks := params.([]interface{})
+	task := tasks[0].(map[string]interface{})
+	if task["type"] != float64(TaskTypeReloadProxyConfig) {
+		t.Errorf("expected type %d, got %v", TaskTypeReloadProxyConfig, task["type"])
+	}
+	proxyIDs, ok := task["request"].(map[string]interface{})["proxyids"].([]interface{})
+	if !ok || len(proxyIDs) != 2 || proxyIDs[0] != "10459" {
+		t.Errorf("expected proxy IDs 10459 and 10460, got %v", task["request"])
+	}
+}
@@ -11,13 +11,57 @@ import (
 
 // Template represents a Zabbix template.
 type Template struct {
-	TemplateID  string            `json:"templateid,omitempty"`
-	Host        string            `json:"host,omitempty"`
-	Name        string            `json:"name,omitempty"`
-	Description string            `json:"description,omitempty"`
-	UUID        string            `json:"uuid,omitempty"`
-	Groups      []TemplateGroupID `json:"groups,omitempty"`
-	Tags        []TemplateTag     `json:"tags,omitempty"`
+	TemplateID      string            `json:"templateid,omitempty"`
+	Host            string            `json:"host,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	UUID            string            `json:"uuid,omitempty"`
+	Groups          []TemplateGroupID `json:"groups,omitempty"`
+	Tags            []TemplateTag     `json:"tags,omitempty"`
+	Templates       []TemplateID      `json:"templates,omitempty"`
+	ParentTemplates []ParentTemplate  `json:"parentTemplates,omitempty"`
+	ItemsCount      int               `json:"-"`
+	TriggersCount   int               `json:"-"`
+	DiscoveryCount  int               `json:"-"`
+}
+
+// templateJSON is used for JSON unmarshaling with entity counts sent as numbers or strings.
+type templateJSON struct {
+	TemplateID      string            `json:"templateid,omitempty"`
+	Host            string            `json:"host,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	UUID            string            `json:"uuid,omitempty"`
+	Groups          []TemplateGroupID `json:"groups,omitempty"`
+	Tags            []TemplateTag     `json:"tags,omitempty"`
+	Templates       []TemplateID      `json:"templates,omitempty"`
+	ParentTemplates []ParentTemplate  `json:"parentTemplates,omitempty"`
+	Items           FlexInt           `json:"items,omitempty"`
+	Triggers        FlexInt           `json:"triggers,omitempty"`
+	Discoveries     FlexInt           `json:"discoveries,omitempty"`
+}
+
+// UnmarshalJSON handles Zabbix API returning entity counts as strings.
+func (t *Template) UnmarshalJSON(data []byte) error {
+	var tj templateJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+
+	t.TemplateID = tj.TemplateID
+	t.Host = tj.Host
+	t.Name = tj.Name
+	t.Description = tj.Description
+	t.UUID = tj.UUID
+	t.Groups = tj.Groups
+	t.Tags = tj.Tags
+	t.Templates = tj.Templates
+	t.ParentTemplates = tj.ParentTemplates
+	t.ItemsCount = int(tj.Items)
+	t.TriggersCount = int(tj.Triggers)
+	t.DiscoveryCount = int(tj.Discoveries)
+
+	return nil
 }
 
 // TemplateGroupID represents a template group reference by ID.
@@ -39,11 +83,19 @@ type CreateTemplateResponse struct {
 
 // GetTemplateParams contains parameters for retrieving templates.
 type GetTemplateParams struct {
-	TemplateIDs  []string               `json:"templateids,omitempty"`
-	Filter       map[string]interface{} `json:"filter,omitempty"`
-	Output       interface{}            `json:"output,omitempty"`
-	SelectGroups interface{}            `json:"selectGroups,omitempty"`
-	SelectTags   interface{}            `json:"selectTags,omitempty"`
+	TemplateIDs           []string               `json:"templateids,omitempty"`
+	Filter                map[string]interface{} `json:"filter,omitempty"`
+	Search                map[string]interface{} `json:"search,omitempty"`
+	SearchWildcards       bool                   `json:"searchWildcardsEnabled,omitempty"`
+	SortField             string                 `json:"sortfield,omitempty"`
+	Tags                  []TagFilter            `json:"tags,omitempty"`
+	Output                interface{}            `json:"output,omitempty"`
+	SelectGroups          interface{}            `json:"selectGroups,omitempty"`
+	SelectTags            interface{}            `json:"selectTags,omitempty"`
+	SelectParentTemplates interface{}            `json:"selectParentTemplates,omitempty"`
+	SelectItems           interface{}            `json:"selectItems,omitempty"`
+	SelectTriggers        interface{}            `json:"selectTriggers,omitempty"`
+	SelectDiscoveries     interface{}            `json:"selectDiscoveries,omitempty"`
 }
 
 // UpdateTemplateResponse contains the response from template.update.
@@ -58,6 +110,39 @@ type DeleteTemplateResponse struct {
 
 // CreateTemplate creates a new template and returns the created template ID.
 func (c *Client) CreateTemplate(ctx context.Context, template *Template) (string, error) {
+	resp, err := Call[CreateTemplateResponse](ctx, c, "template.create", createTemplateParams(template))
+	if err != nil {
+		return "", err
+	}
+
+	if len(resp.TemplateIDs) == 0 {
+		return "", fmt.Errorf("template.create returned no template IDs")
+	}
+
+	return resp.TemplateIDs[0], nil
+}
+
+// CreateTemplates creates several templates in a single request and returns the created template IDs in input order.
+func (c *Client) CreateTemplates(ctx context.Context, templates []*Template) ([]string, error) {
+	params := make([]map[string]interface{}, len(templates))
+	for i, template := range templates {
+		params[i] = createTemplateParams(template)
+	}
+
+	resp, err := Call[CreateTemplateResponse](ctx, c, "template.create", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.TemplateIDs) != len(templates) {
+		return nil, fmt.Errorf("template.create returned %d template IDs for %d templates", len(resp.TemplateIDs), len(templates))
+	}
+
+	return resp.TemplateIDs, nil
+}
+
+// createTemplateParams builds the template.create parameters for a single template.
+func createTemplateParams(template *Template) map[string]interface{} {
 	params := map[string]interface{}{
 		"host": template.Host,
 	}
@@ -86,37 +171,81 @@ func (c *Client) CreateTemplate(ctx context.Context, template *Template) (string
 		params["tags"] = tags
 	}
 
-	result, err := c.RequestWithContext(ctx, "template.create", params)
-	if err != nil {
-		return "", err
-	}
-
-	var resp CreateTemplateResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal template.create response: %w", err)
-	}
-
-	if len(resp.TemplateIDs) == 0 {
-		return "", fmt.Errorf("template.create returned no template IDs")
+	if len(template.Templates) > 0 {
+		templates := make([]map[string]string, len(template.Templates))
+		for i, t := range template.Templates {
+			templates[i] = map[string]string{"templateid": t.TemplateID}
+		}
+		params["templates"] = templates
 	}
 
-	return resp.TemplateIDs[0], nil
+	return params
 }
 
 // GetTemplate retrieves a template by ID with all related data.
 func (c *Client) GetTemplate(ctx context.Context, templateID string) (*Template, error) {
-	params := GetTemplateParams{
-		TemplateIDs:  []string{templateID},
-		Output:       "extend",
-		SelectGroups: "extend",
-		SelectTags:   "extend",
+	result, err := c.Request(ctx, "template.get", getTemplateParams(templateID))
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := c.RequestWithContext(ctx, "template.get", params)
+	return firstTemplate(result)
+}
+
+// TemplateWithExport is a template together with its exported configuration.
+type TemplateWithExport struct {
+	Template *Template
+	// Exported is the configuration in the requested format. It is empty when the export failed.
+	Exported string
+	// ExportErr is the error of a failed export, which does not fail reading the template.
+	ExportErr error
+}
+
+// GetTemplateWithExport retrieves a template like GetTemplate and exports its configuration
+// like ExportConfiguration, sending both requests in one batch. Returns nil when the template
+// does not exist.
+func (c *Client) GetTemplateWithExport(ctx context.Context, templateID, format string) (*TemplateWithExport, error) {
+	results, err := c.Batch(ctx, []Request{
+		{Method: "template.get", Params: getTemplateParams(templateID)},
+		{Method: "configuration.export", Params: exportConfigurationParams(format, []string{templateID})},
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if results[0].Err != nil {
+		return nil, results[0].Err
+	}
+
+	template, err := firstTemplate(results[0].Result)
+	if err != nil || template == nil {
+		return nil, err
+	}
+
+	result := &TemplateWithExport{Template: template, ExportErr: results[1].Err}
+	if result.ExportErr == nil {
+		result.Exported, result.ExportErr = exportedConfiguration(results[1].Result)
+	}
+
+	return result, nil
+}
+
+// getTemplateParams returns the template.get parameters reading a template with all related data.
+func getTemplateParams(templateID string) GetTemplateParams {
+	return GetTemplateParams{
+		TemplateIDs:           []string{templateID},
+		Output:                "extend",
+		SelectGroups:          "extend",
+		SelectTags:            "extend",
+		SelectParentTemplates: "extend",
+		SelectItems:           "count",
+		SelectTriggers:        "count",
+		SelectDiscoveries:     "count",
+	}
+}
+
+// firstTemplate returns the first template of a template.get result, or nil if there is none.
+func firstTemplate(result json.RawMessage) (*Template, error) {
 	var templates []Template
 	if err := json.Unmarshal(result, &templates); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal template.get response: %w", err)
@@ -135,21 +264,20 @@ func (c *Client) GetTemplateByHost(ctx context.Context, host string) (*Template,
 		Filter: map[string]interface{}{
 			"host": host,
 		},
-		Output:       "extend",
-		SelectGroups: "extend",
-		SelectTags:   "extend",
+		Output:                "extend",
+		SelectGroups:          "extend",
+		SelectTags:            "extend",
+		SelectParentTemplates: "extend",
+		SelectItems:           "count",
+		SelectTriggers:        "count",
+		SelectDiscoveries:     "count",
 	}
 
-	result, err := c.RequestWithContext(ctx, "template.get", params)
+	templates, err := Call[[]Template](ctx, c, "template.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var templates []Template
-	if err := json.Unmarshal(result, &templates); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal template.get response: %w", err)
-	}
-
 	if len(templates) == 0 {
 		return nil, nil
 	}
@@ -157,8 +285,90 @@ func (c *Client) GetTemplateByHost(ctx context.Context, host string) (*Template,
 	return &templates[0], nil
 }
 
+// SearchTemplates retrieves the templates matching all of the given tag filters. When host
+// is non-empty, only the template with that technical name is considered.
+func (c *Client) SearchTemplates(ctx context.Context, host string, tags []TagFilter) ([]Template, error) {
+	params := GetTemplateParams{
+		Tags:                  tags,
+		Output:                "extend",
+		SelectGroups:          "extend",
+		SelectTags:            "extend",
+		SelectParentTemplates: "extend",
+		SelectItems:           "count",
+		SelectTriggers:        "count",
+		SelectDiscoveries:     "count",
+	}
+
+	if host != "" {
+		params.Filter = map[string]interface{}{
+			"host": host,
+		}
+	}
+
+	templates, err := Call[[]Template](ctx, c, "template.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// SearchTemplatesByHost retrieves the templates whose technical name matches the pattern,
+// sorted by technical name. The pattern is matched against the whole name with * as
+// wildcard. Only the ID and names of each template are returned.
+func (c *Client) SearchTemplatesByHost(ctx context.Context, pattern string) ([]Template, error) {
+	params := GetTemplateParams{
+		Search: map[string]interface{}{
+			"host": pattern,
+		},
+		SearchWildcards: true,
+		Output:          []string{"templateid", "host", "name"},
+		SortField:       "host",
+	}
+
+	templates, err := Call[[]Template](ctx, c, "template.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
 // UpdateTemplate updates a template.
 func (c *Client) UpdateTemplate(ctx context.Context, template *Template) error {
+	resp, err := Call[UpdateTemplateResponse](ctx, c, "template.update", updateTemplateParams(template))
+	if err != nil {
+		return err
+	}
+
+	if len(resp.TemplateIDs) == 0 {
+		return fmt.Errorf("template.update returned no template IDs")
+	}
+
+	return nil
+}
+
+// UpdateTemplates updates several templates in a single request.
+func (c *Client) UpdateTemplates(ctx context.Context, templates []*Template) error {
+	params := make([]map[string]interface{}, len(templates))
+	for i, template := range templates {
+		params[i] = updateTemplateParams(template)
+	}
+
+	resp, err := Call[UpdateTemplateResponse](ctx, c, "template.update", params)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.TemplateIDs) != len(templates) {
+		return fmt.Errorf("template.update returned %d template IDs for %d templates", len(resp.TemplateIDs), len(templates))
+	}
+
+	return nil
+}
+
+// updateTemplateParams builds the template.update parameters for a single template.
+func updateTemplateParams(template *Template) map[string]interface{} {
 	params := map[string]interface{}{
 		"templateid": template.TemplateID,
 	}
@@ -191,37 +401,29 @@ func (c *Client) UpdateTemplate(ctx context.Context, template *Template) error {
 		params["tags"] = tags
 	}
 
-	result, err := c.RequestWithContext(ctx, "template.update", params)
-	if err != nil {
-		return err
-	}
-
-	var resp UpdateTemplateResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal template.update response: %w", err)
-	}
-
-	if len(resp.TemplateIDs) == 0 {
-		return fmt.Errorf("template.update returned no template IDs")
+	if template.Templates != nil {
+		templates := make([]map[string]string, len(template.Templates))
+		for i, t := range template.Templates {
+			templates[i] = map[string]string{"templateid": t.TemplateID}
+		}
+		params["templates"] = templates
 	}
 
-	return nil
+	return params
 }
 
 // DeleteTemplate deletes a template by ID.
 func (c *Client) DeleteTemplate(ctx context.Context, templateID string) error {
-	params := []string{templateID}
+	return c.DeleteTemplates(ctx, []string{templateID})
+}
 
-	result, err := c.RequestWithContext(ctx, "template.delete", params)
+// DeleteTemplates deletes several templates by ID in a single request.
+func (c *Client) DeleteTemplates(ctx context.Context, templateIDs []string) error {
+	resp, err := Call[DeleteTemplateResponse](ctx, c, "template.delete", templateIDs)
 	if err != nil {
 		return err
 	}
 
-	var resp DeleteTemplateResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal template.delete response: %w", err)
-	}
-
 	if len(resp.TemplateIDs) == 0 {
 		return fmt.Errorf("template.delete returned no template IDs")
 	}
@@ -237,61 +439,184 @@ type ImportConfigurationParams struct {
 }
 
 // ImportConfiguration imports configuration from YAML/XML/JSON.
-func (c *Client) ImportConfiguration(ctx context.Context, format, source string) error {
+// With deleteMissing set, items, triggers, discovery rules and value maps that are
+// not part of the source are removed from existing templates.
+func (c *Client) ImportConfiguration(ctx context.Context, format, source string, deleteMissing bool) error {
 	params := ImportConfigurationParams{
 		Format: format,
 		Source: source,
-		Rules: map[string]interface{}{
-			"templates": map[string]interface{}{
-				"createMissing":  true,
-				"updateExisting": true,
-			},
-			"template_groups": map[string]interface{}{
-				"createMissing": true,
-			},
-			"items": map[string]interface{}{
-				"createMissing":  true,
-				"updateExisting": true,
-			},
-			"triggers": map[string]interface{}{
-				"createMissing":  true,
-				"updateExisting": true,
-			},
-			"discoveryRules": map[string]interface{}{
-				"createMissing":  true,
-				"updateExisting": true,
-			},
-			"valueMaps": map[string]interface{}{
-				"createMissing":  true,
-				"updateExisting": true,
-			},
-		},
+		Rules:  TemplateImportRules(deleteMissing),
 	}
 
-	_, err := c.RequestWithContext(ctx, "configuration.import", params)
+	_, err := c.Request(ctx, "configuration.import", params)
 	return err
 }
 
+// ImportChanges is the diff returned by configuration.importcompare, keyed by entity type
+// such as "templates", "items" or "triggers".
+type ImportChanges map[string]ImportChangeSet
+
+// ImportChangeSet lists the added, removed and updated entities of one type.
+type ImportChangeSet struct {
+	Added   []ImportChange `json:"added,omitempty"`
+	Removed []ImportChange `json:"removed,omitempty"`
+	Updated []ImportChange `json:"updated,omitempty"`
+}
+
+// ImportChange is a single changed entity. Added entities have only After, removed entities
+// only Before. Changes holds the changes of child entities, such as the items of an updated
+// template.
+type ImportChange struct {
+	Before  map[string]interface{}
+	After   map[string]interface{}
+	Changes ImportChanges
+}
+
+// UnmarshalJSON collects the entity type keys next to before and after as child changes.
+func (ic *ImportChange) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		var err error
+		switch key {
+		case "before":
+			err = json.Unmarshal(value, &ic.Before)
+		case "after":
+			err = json.Unmarshal(value, &ic.After)
+		default:
+			var set ImportChangeSet
+			if err = json.Unmarshal(value, &set); err == nil {
+				if ic.Changes == nil {
+					ic.Changes = ImportChanges{}
+				}
+				ic.Changes[key] = set
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// ImportCompare returns what importing the source with the rules would change in Zabbix,
+// without importing it. The result is empty when nothing would change.
+func (c *Client) ImportCompare(ctx context.Context, format, source string, rules map[string]interface{}) (ImportChanges, error) {
+	params := ImportConfigurationParams{
+		Format: format,
+		Source: source,
+		Rules:  rules,
+	}
+
+	result, err := c.Request(ctx, "configuration.importcompare", params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Zabbix returns an empty array when there are no changes and an object keyed by entity type otherwise
+	var empty []json.RawMessage
+	if err := json.Unmarshal(result, &empty); err == nil && len(empty) == 0 {
+		return ImportChanges{}, nil
+	}
+
+	var changes ImportChanges
+	if err := json.Unmarshal(result, &changes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration.importcompare response: %w", err)
+	}
+
+	return changes, nil
+}
+
+// CompareConfiguration reports whether importing the source with the same rules as
+// ImportConfiguration would change anything in Zabbix.
+func (c *Client) CompareConfiguration(ctx context.Context, format, source string, deleteMissing bool) (bool, error) {
+	changes, err := c.ImportCompare(ctx, format, source, TemplateImportRules(deleteMissing))
+	if err != nil {
+		return false, err
+	}
+
+	return len(changes) > 0, nil
+}
+
+// TemplateImportRules returns the configuration.import rules used for templates.
+// With deleteMissing set, template entities missing from the source are removed.
+func TemplateImportRules(deleteMissing bool) map[string]interface{} {
+	entityRule := func() map[string]interface{} {
+		rule := map[string]interface{}{
+			"createMissing":  true,
+			"updateExisting": true,
+		}
+		if deleteMissing {
+			rule["deleteMissing"] = true
+		}
+		return rule
+	}
+
+	return map[string]interface{}{
+		"templates": map[string]interface{}{
+			"createMissing":  true,
+			"updateExisting": true,
+		},
+		"template_groups": map[string]interface{}{
+			"createMissing": true,
+		},
+		"items":          entityRule(),
+		"triggers":       entityRule(),
+		"discoveryRules": entityRule(),
+		"valueMaps":      entityRule(),
+	}
+}
+
 // ExportConfigurationParams contains parameters for configuration.export.
 type ExportConfigurationParams struct {
-	Format  string                 `json:"format"`
-	Options map[string]interface{} `json:"options"`
+	Format  string        `json:"format"`
+	Options ExportOptions `json:"options"`
+}
+
+// ExportOptions selects the objects exported by configuration.export by their IDs. Objects
+// of several types can be exported together; types without IDs are not exported.
+type ExportOptions struct {
+	HostGroups     []string `json:"host_groups,omitempty"`
+	TemplateGroups []string `json:"template_groups,omitempty"`
+	Hosts          []string `json:"hosts,omitempty"`
+	Templates      []string `json:"templates,omitempty"`
+	Maps           []string `json:"maps,omitempty"`
+	Images         []string `json:"images,omitempty"`
+	MediaTypes     []string `json:"mediaTypes,omitempty"`
+}
+
+// empty reports whether the options select no objects.
+func (o ExportOptions) empty() bool {
+	return len(o.HostGroups) == 0 && len(o.TemplateGroups) == 0 && len(o.Hosts) == 0 &&
+		len(o.Templates) == 0 && len(o.Maps) == 0 && len(o.Images) == 0 && len(o.MediaTypes) == 0
 }
 
 // ExportConfiguration exports a template configuration as YAML/XML/JSON.
 func (c *Client) ExportConfiguration(ctx context.Context, format string, templateIDs []string) (string, error) {
-	params := ExportConfigurationParams{
-		Format: format,
-		Options: map[string]interface{}{
-			"templates": templateIDs,
-		},
+	return c.ExportObjects(ctx, format, ExportOptions{Templates: templateIDs})
+}
+
+// ExportObjects exports the configuration of the objects selected by options as
+// YAML/XML/JSON, in a single document.
+func (c *Client) ExportObjects(ctx context.Context, format string, options ExportOptions) (string, error) {
+	if options.empty() {
+		return "", fmt.Errorf("configuration.export requires at least one object to export")
 	}
 
-	result, err := c.RequestWithContext(ctx, "configuration.export", params)
+	result, err := c.Request(ctx, "configuration.export", ExportConfigurationParams{Format: format, Options: options})
 	if err != nil {
 		return "", err
 	}
 
+	return exportedConfiguration(result)
+}
+
+// exportedConfiguration decodes the configuration returned by configuration.export.
+func exportedConfiguration(result json.RawMessage) (string, error) {
 	var exported string
 	if err := json.Unmarshal(result, &exported); err != nil {
 		return "", fmt.Errorf("failed to unmarshal configuration.export response: %w", err)
@@ -299,3 +624,11 @@ func (c *Client) ExportConfiguration(ctx context.Context, format string, templat
 
 	return exported, nil
 }
+
+// exportConfigurationParams returns the configuration.export parameters exporting templates.
+func exportConfigurationParams(format string, templateIDs []string) ExportConfigurationParams {
+	return ExportConfigurationParams{
+		Format:  format,
+		Options: ExportOptions{Templates: templateIDs},
+	}
+}
@@ -5,7 +5,6 @@ package zabbix
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -28,9 +27,12 @@ type CreateTemplateGroupResponse struct {
 
 // GetTemplateGroupParams contains parameters for retrieving template groups.
 type GetTemplateGroupParams struct {
-	GroupIDs []string               `json:"groupids,omitempty"`
-	Filter   map[string]interface{} `json:"filter,omitempty"`
-	Output   interface{}            `json:"output,omitempty"`
+	GroupIDs        []string               `json:"groupids,omitempty"`
+	Filter          map[string]interface{} `json:"filter,omitempty"`
+	Search          map[string]interface{} `json:"search,omitempty"`
+	SearchWildcards bool                   `json:"searchWildcardsEnabled,omitempty"`
+	SortField       string                 `json:"sortfield,omitempty"`
+	Output          interface{}            `json:"output,omitempty"`
 }
 
 // UpdateTemplateGroupParams contains parameters for updating a template group.
@@ -55,16 +57,11 @@ func (c *Client) CreateTemplateGroup(ctx context.Context, name string) (string,
 		Name: name,
 	}
 
-	result, err := c.RequestWithContext(ctx, "templategroup.create", params)
+	resp, err := Call[CreateTemplateGroupResponse](ctx, c, "templategroup.create", params)
 	if err != nil {
 		return "", err
 	}
 
-	var resp CreateTemplateGroupResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return "", fmt.Errorf("failed to unmarshal templategroup.create response: %w", err)
-	}
-
 	if len(resp.GroupIDs) == 0 {
 		return "", fmt.Errorf("templategroup.create returned no group IDs")
 	}
@@ -72,6 +69,25 @@ func (c *Client) CreateTemplateGroup(ctx context.Context, name string) (string,
 	return resp.GroupIDs[0], nil
 }
 
+// CreateTemplateGroups creates several template groups in a single request and returns the created group IDs in input order.
+func (c *Client) CreateTemplateGroups(ctx context.Context, names []string) ([]string, error) {
+	params := make([]CreateTemplateGroupParams, len(names))
+	for i, name := range names {
+		params[i] = CreateTemplateGroupParams{Name: name}
+	}
+
+	resp, err := Call[CreateTemplateGroupResponse](ctx, c, "templategroup.create", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.GroupIDs) != len(names) {
+		return nil, fmt.Errorf("templategroup.create returned %d group IDs for %d groups", len(resp.GroupIDs), len(names))
+	}
+
+	return resp.GroupIDs, nil
+}
+
 // GetTemplateGroup retrieves a template group by ID.
 func (c *Client) GetTemplateGroup(ctx context.Context, groupID string) (*TemplateGroup, error) {
 	params := GetTemplateGroupParams{
@@ -79,16 +95,11 @@ func (c *Client) GetTemplateGroup(ctx context.Context, groupID string) (*Templat
 		Output:   "extend",
 	}
 
-	result, err := c.RequestWithContext(ctx, "templategroup.get", params)
+	groups, err := Call[[]TemplateGroup](ctx, c, "templategroup.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var groups []TemplateGroup
-	if err := json.Unmarshal(result, &groups); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal templategroup.get response: %w", err)
-	}
-
 	if len(groups) == 0 {
 		return nil, nil
 	}
@@ -105,16 +116,11 @@ func (c *Client) GetTemplateGroupByName(ctx context.Context, name string) (*Temp
 		Output: "extend",
 	}
 
-	result, err := c.RequestWithContext(ctx, "templategroup.get", params)
+	groups, err := Call[[]TemplateGroup](ctx, c, "templategroup.get", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var groups []TemplateGroup
-	if err := json.Unmarshal(result, &groups); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal templategroup.get response: %w", err)
-	}
-
 	if len(groups) == 0 {
 		return nil, nil
 	}
@@ -122,6 +128,30 @@ func (c *Client) GetTemplateGroupByName(ctx context.Context, name string) (*Temp
 	return &groups[0], nil
 }
 
+// SearchTemplateGroups retrieves the template groups whose name matches the pattern, sorted
+// by name. The pattern is matched against the whole name with * as wildcard; an empty
+// pattern returns all template groups.
+func (c *Client) SearchTemplateGroups(ctx context.Context, pattern string) ([]TemplateGroup, error) {
+	params := GetTemplateGroupParams{
+		Output:    "extend",
+		SortField: "name",
+	}
+
+	if pattern != "" {
+		params.Search = map[string]interface{}{
+			"name": pattern,
+		}
+		params.SearchWildcards = true
+	}
+
+	groups, err := Call[[]TemplateGroup](ctx, c, "templategroup.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
 // UpdateTemplateGroup updates a template group's name.
 func (c *Client) UpdateTemplateGroup(ctx context.Context, groupID, name string) error {
 	params := UpdateTemplateGroupParams{
@@ -129,16 +159,11 @@ func (c *Client) UpdateTemplateGroup(ctx context.Context, groupID, name string)
 		Name:    name,
 	}
 
-	result, err := c.RequestWithContext(ctx, "templategroup.update", params)
+	resp, err := Call[UpdateTemplateGroupResponse](ctx, c, "templategroup.update", params)
 	if err != nil {
 		return err
 	}
 
-	var resp UpdateTemplateGroupResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal templategroup.update response: %w", err)
-	}
-
 	if len(resp.GroupIDs) == 0 {
 		return fmt.Errorf("templategroup.update returned no group IDs")
 	}
@@ -146,18 +171,35 @@ func (c *Client) UpdateTemplateGroup(ctx context.Context, groupID, name string)
 	return nil
 }
 
-// DeleteTemplateGroup deletes a template group by ID.
-func (c *Client) DeleteTemplateGroup(ctx context.Context, groupID string) error {
-	params := []string{groupID}
+// UpdateTemplateGroups renames several template groups in a single request.
+func (c *Client) UpdateTemplateGroups(ctx context.Context, groups []TemplateGroup) error {
+	params := make([]UpdateTemplateGroupParams, len(groups))
+	for i, group := range groups {
+		params[i] = UpdateTemplateGroupParams{GroupID: group.GroupID, Name: group.Name}
+	}
 
-	result, err := c.RequestWithContext(ctx, "templategroup.delete", params)
+	resp, err := Call[UpdateTemplateGroupResponse](ctx, c, "templategroup.update", params)
 	if err != nil {
 		return err
 	}
 
-	var resp DeleteTemplateGroupResponse
-	if err := json.Unmarshal(result, &resp); err != nil {
-		return fmt.Errorf("failed to unmarshal templategroup.delete response: %w", err)
+	if len(resp.GroupIDs) != len(groups) {
+		return fmt.Errorf("templategroup.update returned %d group IDs for %d groups", len(resp.GroupIDs), len(groups))
+	}
+
+	return nil
+}
+
+// DeleteTemplateGroup deletes a template group by ID.
+func (c *Client) DeleteTemplateGroup(ctx context.Context, groupID string) error {
+	return c.DeleteTemplateGroups(ctx, []string{groupID})
+}
+
+// DeleteTemplateGroups deletes several template groups by ID in a single request.
+func (c *Client) DeleteTemplateGroups(ctx context.Context, groupIDs []string) error {
+	resp, err := Call[DeleteTemplateGroupResponse](ctx, c, "templategroup.delete", groupIDs)
+	if err != nil {
+		return err
 	}
 
 	if len(resp.GroupIDs) == 0 {
@@ -41,7 +41,7 @@ func TestCreateTemplateGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	groupID, err := client.CreateTemplateGroup(context.Background(), "Test Templates")
 
 	if err != nil {
@@ -67,7 +67,7 @@ func TestCreateTemplateGroup_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	_, err := client.CreateTemplateGroup(context.Background(), "Test Templates")
 
 	if err == nil {
@@ -90,7 +90,7 @@ func TestCreateTemplateGroup_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	_, err := client.CreateTemplateGroup(context.Background(), "Test Templates")
 
 	if err == nil {
@@ -137,7 +137,7 @@ func TestGetTemplateGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetTemplateGroup(context.Background(), "100")
 
 	if err != nil {
@@ -172,7 +172,7 @@ func TestGetTemplateGroup_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetTemplateGroup(context.Background(), "99999")
 
 	if err != nil {
@@ -213,7 +213,7 @@ func TestGetTemplateGroupByName_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	group, err := client.GetTemplateGroupByName(context.Background(), "Test Templates")
 
 	if err != nil {
@@ -227,6 +227,57 @@ func TestGetTemplateGroupByName_Success(t *testing.T) {
 	}
 }
 
+func TestSearchTemplateGroups_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "templategroup.get" {
+			t.Errorf("expected method 'templategroup.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		search, ok := params["search"].(map[string]interface{})
+		if !ok || search["name"] != "Templates/*" {
+			t.Errorf("expected search name 'Templates/*', got '%v'", params["search"])
+		}
+		if params["searchWildcardsEnabled"] != true {
+			t.Errorf("expected searchWildcardsEnabled true, got '%v'", params["searchWildcardsEnabled"])
+		}
+		if params["sortfield"] != "name" {
+			t.Errorf("expected sortfield 'name', got '%v'", params["sortfield"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[{"groupid": "12", "name": "Templates/Applications"}, {"groupid": "13", "name": "Templates/Databases"}]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	groups, err := client.SearchTemplateGroups(context.Background(), "Templates/*")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[1].GroupID != "13" || groups[1].Name != "Templates/Databases" {
+		t.Errorf("expected group 13 'Templates/Databases', got %+v", groups[1])
+	}
+}
+
 func TestUpdateTemplateGroup_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -259,7 +310,7 @@ func TestUpdateTemplateGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.UpdateTemplateGroup(context.Background(), "100", "Updated Templates")
 
 	if err != nil {
@@ -282,7 +333,7 @@ func TestUpdateTemplateGroup_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.UpdateTemplateGroup(context.Background(), "100", "Updated Templates")
 
 	if err == nil {
@@ -319,7 +370,7 @@ func TestDeleteTemplateGroup_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteTemplateGroup(context.Background(), "100")
 
 	if err != nil {
@@ -342,7 +393,7 @@ func TestDeleteTemplateGroup_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteTemplateGroup(context.Background(), "100")
 
 	if err == nil {
@@ -365,7 +416,7 @@ func TestDeleteTemplateGroup_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteTemplateGroup(context.Background(), "99999")
 
 	if err == nil {
@@ -380,3 +431,50 @@ func TestDeleteTemplateGroup_APIError(t *testing.T) {
 		t.Errorf("expected method 'templategroup.delete', got '%s'", apiErr.Method)
 	}
 }
+
+func TestCreateTemplateGroups_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "templategroup.create", &params, `{"groupids": ["31", "32"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	groupIDs, err := client.CreateTemplateGroups(context.Background(), []string{"Templates/Linux", "Templates/Databases"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groupIDs) != 2 || groupIDs[0] != "31" || groupIDs[1] != "32" {
+		t.Errorf("expected group IDs [31 32], got %v", groupIDs)
+	}
+}
+
+func TestUpdateTemplateGroups_MissingIDs(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "templategroup.update", &params, `{"groupids": ["31"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.UpdateTemplateGroups(context.Background(), []TemplateGroup{
+		{GroupID: "31", Name: "Templates/Linux hosts"},
+		{GroupID: "32", Name: "Templates/Database hosts"},
+	})
+	if err == nil {
+		t.Fatal("expected error when fewer group IDs than groups are returned")
+	}
+}
+
+func TestDeleteTemplateGroups_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "templategroup.delete", &params, `{"groupids": ["31", "32"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if err := client.DeleteTemplateGroups(context.Background(), []string{"31", "32"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, ok := params.([]interface{})
+	if !ok || len(ids) != 2 {
+		t.Errorf("expected 2 group IDs, got %v", params)
+	}
+}
@@ -53,7 +53,7 @@ func TestCreateTemplate_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		Host:   "my_template",
 		Name:   "My Template",
@@ -94,7 +94,7 @@ func TestCreateTemplate_WithDescription(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		Host:        "my_template",
 		Name:        "My Template",
@@ -145,7 +145,7 @@ func TestCreateTemplate_WithTags(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		Host:   "my_template",
 		Groups: []TemplateGroupID{{GroupID: "1"}},
@@ -179,7 +179,7 @@ func TestCreateTemplate_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		Host:   "my_template",
 		Groups: []TemplateGroupID{{GroupID: "1"}},
@@ -206,7 +206,7 @@ func TestCreateTemplate_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		Host:   "my_template",
 		Groups: []TemplateGroupID{{GroupID: "1"}},
@@ -254,6 +254,14 @@ func TestGetTemplate_Success(t *testing.T) {
 		if params["selectTags"] != "extend" {
 			t.Errorf("expected selectTags 'extend', got '%v'", params["selectTags"])
 		}
+		if params["selectParentTemplates"] != "extend" {
+			t.Errorf("expected selectParentTemplates 'extend', got '%v'", params["selectParentTemplates"])
+		}
+		for _, selectParam := range []string{"selectItems", "selectTriggers", "selectDiscoveries"} {
+			if params[selectParam] != "count" {
+				t.Errorf("expected %s 'count', got '%v'", selectParam, params[selectParam])
+			}
+		}
 
 		resp := Response{
 			JSONRPC: "2.0",
@@ -264,7 +272,11 @@ func TestGetTemplate_Success(t *testing.T) {
 				"description": "Template description",
 				"uuid": "abc123",
 				"groups": [{"groupid": "1", "name": "Templates"}],
-				"tags": [{"tag": "environment", "value": "production"}]
+				"tags": [{"tag": "environment", "value": "production"}],
+				"parentTemplates": [{"templateid": "10050", "host": "Linux by Zabbix agent", "name": "Linux by Zabbix agent"}],
+				"items": "42",
+				"triggers": "7",
+				"discoveries": "3"
 			}]`),
 			ID: req.ID,
 		}
@@ -272,7 +284,7 @@ func TestGetTemplate_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template, err := client.GetTemplate(context.Background(), "10001")
 
 	if err != nil {
@@ -302,6 +314,18 @@ func TestGetTemplate_Success(t *testing.T) {
 	if len(template.Tags) != 1 || template.Tags[0].Tag != "environment" {
 		t.Errorf("expected tag 'environment', got %v", template.Tags)
 	}
+	if len(template.ParentTemplates) != 1 || template.ParentTemplates[0].TemplateID != "10050" {
+		t.Errorf("expected linked template with id '10050', got %v", template.ParentTemplates)
+	}
+	if template.ItemsCount != 42 {
+		t.Errorf("expected items count 42, got %d", template.ItemsCount)
+	}
+	if template.TriggersCount != 7 {
+		t.Errorf("expected triggers count 7, got %d", template.TriggersCount)
+	}
+	if template.DiscoveryCount != 3 {
+		t.Errorf("expected discoveries count 3, got %d", template.DiscoveryCount)
+	}
 }
 
 func TestGetTemplate_NotFound(t *testing.T) {
@@ -319,7 +343,7 @@ func TestGetTemplate_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template, err := client.GetTemplate(context.Background(), "99999")
 
 	if err != nil {
@@ -330,6 +354,151 @@ func TestGetTemplate_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetTemplateWithExport_Success(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		params := req.Params.(map[string]interface{})
+		switch req.Method {
+		case "template.get":
+			if params["selectTags"] != "extend" {
+				t.Errorf("expected selectTags 'extend', got '%v'", params["selectTags"])
+			}
+			return json.RawMessage(`[{"templateid": "10001", "host": "Template OS Linux"}]`), nil
+		case "configuration.export":
+			if params["format"] != "yaml" {
+				t.Errorf("expected format 'yaml', got '%v'", params["format"])
+			}
+			return json.RawMessage(`"zabbix_export:\n  version: '7.0'\n"`), nil
+		}
+		t.Fatalf("unexpected method '%s'", req.Method)
+		return nil, nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.GetTemplateWithExport(context.Background(), "10001", "yaml")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batches != 1 {
+		t.Errorf("expected a single batch, got %d", batches)
+	}
+	if result.Template.Host != "Template OS Linux" {
+		t.Errorf("expected host 'Template OS Linux', got '%s'", result.Template.Host)
+	}
+	if result.ExportErr != nil || result.Exported != "zabbix_export:\n  version: '7.0'\n" {
+		t.Errorf("unexpected export %q, error %v", result.Exported, result.ExportErr)
+	}
+}
+
+func TestGetTemplateWithExport_ExportError(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		if req.Method == "configuration.export" {
+			return nil, &Error{Code: -32500, Message: "Application error.", Data: "No permissions."}
+		}
+		return json.RawMessage(`[{"templateid": "10001", "host": "Template OS Linux"}]`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.GetTemplateWithExport(context.Background(), "10001", "yaml")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Template == nil || result.ExportErr == nil || result.Exported != "" {
+		t.Errorf("expected the template with an export error, got %+v", result)
+	}
+}
+
+func TestGetTemplateWithExport_NotFound(t *testing.T) {
+	batches := 0
+	server := newBatchTestServer(t, &batches, func(req Request) (json.RawMessage, *Error) {
+		if req.Method == "configuration.export" {
+			return nil, &Error{Code: -32500, Message: "Application error.", Data: "No permissions."}
+		}
+		return json.RawMessage(`[]`), nil
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	result, err := client.GetTemplateWithExport(context.Background(), "99999", "yaml")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != nil {
+		t.Errorf("expected nil result, got %+v", result)
+	}
+}
+
+func TestExportConfiguration_Templates(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "configuration.export", &params, `"zabbix_export: {}"`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	exported, err := client.ExportConfiguration(context.Background(), "yaml", []string{"10001"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exported != "zabbix_export: {}" {
+		t.Errorf("unexpected export: %q", exported)
+	}
+
+	p := params.(map[string]interface{})
+	options := p["options"].(map[string]interface{})
+	if len(options) != 1 || options["templates"].([]interface{})[0] != "10001" {
+		t.Errorf("expected only templates in options, got %v", options)
+	}
+}
+
+func TestExportObjects_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "configuration.export", &params, `"{}"`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.ExportObjects(context.Background(), "json", ExportOptions{
+		HostGroups: []string{"2"},
+		Hosts:      []string{"10084"},
+		Maps:       []string{"1"},
+		Images:     []string{"5"},
+		MediaTypes: []string{"1", "3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := params.(map[string]interface{})
+	if p["format"] != "json" {
+		t.Errorf("expected format json, got %v", p["format"])
+	}
+	options := p["options"].(map[string]interface{})
+	for _, key := range []string{"host_groups", "hosts", "maps", "images", "mediaTypes"} {
+		if _, exists := options[key]; !exists {
+			t.Errorf("expected %s in options, got %v", key, options)
+		}
+	}
+	for _, key := range []string{"templates", "template_groups"} {
+		if _, exists := options[key]; exists {
+			t.Errorf("expected %s to be omitted, got %v", key, options)
+		}
+	}
+	if mediaTypes := options["mediaTypes"].([]interface{}); len(mediaTypes) != 2 {
+		t.Errorf("expected 2 media types, got %v", mediaTypes)
+	}
+}
+
+func TestExportObjects_NoObjects(t *testing.T) {
+	client := NewClient("http://127.0.0.1:1", WithToken("test-token"))
+	if _, err := client.ExportObjects(context.Background(), "yaml", ExportOptions{}); err == nil {
+		t.Fatal("expected error when no objects are selected")
+	}
+}
+
 func TestGetTemplateByHost_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -372,7 +541,7 @@ func TestGetTemplateByHost_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template, err := client.GetTemplateByHost(context.Background(), "my_template")
 
 	if err != nil {
@@ -404,7 +573,7 @@ func TestGetTemplateByHost_NotFound(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template, err := client.GetTemplateByHost(context.Background(), "nonexistent")
 
 	if err != nil {
@@ -447,7 +616,7 @@ func TestUpdateTemplate_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		TemplateID: "10001",
 		Name:       "Updated Template",
@@ -486,7 +655,7 @@ func TestUpdateTemplate_WithGroups(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		TemplateID: "10001",
 		Groups: []TemplateGroupID{
@@ -501,6 +670,45 @@ func TestUpdateTemplate_WithGroups(t *testing.T) {
 	}
 }
 
+func TestUpdateTemplate_UnlinkAllTemplates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		templates, ok := params["templates"].([]interface{})
+		if !ok || len(templates) != 0 {
+			t.Fatalf("expected templates to be an empty array, got %v", params["templates"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`{"templateids": ["10001"]}`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	template := &Template{
+		TemplateID: "10001",
+		Templates:  []TemplateID{},
+	}
+	err := client.UpdateTemplate(context.Background(), template)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestUpdateTemplate_EmptyResponse(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, _ := io.ReadAll(r.Body)
@@ -516,7 +724,7 @@ func TestUpdateTemplate_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	template := &Template{
 		TemplateID: "10001",
 		Name:       "Updated Template",
@@ -557,7 +765,7 @@ func TestDeleteTemplate_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteTemplate(context.Background(), "10001")
 
 	if err != nil {
@@ -580,7 +788,7 @@ func TestDeleteTemplate_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteTemplate(context.Background(), "10001")
 
 	if err == nil {
@@ -603,7 +811,7 @@ func TestDeleteTemplate_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-token")
+	client := NewClient(server.URL, WithToken("test-token"))
 	err := client.DeleteTemplate(context.Background(), "99999")
 
 	if err == nil {
@@ -618,3 +826,426 @@ func TestDeleteTemplate_APIError(t *testing.T) {
 		t.Errorf("expected method 'template.delete', got '%s'", apiErr.Method)
 	}
 }
+
+func TestImportConfiguration_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "configuration.import" {
+			t.Errorf("expected method 'configuration.import', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+		if params["format"] != "yaml" {
+			t.Errorf("expected format 'yaml', got '%v'", params["format"])
+		}
+
+		rules := params["rules"].(map[string]interface{})
+		items := rules["items"].(map[string]interface{})
+		if items["createMissing"] != true || items["updateExisting"] != true {
+			t.Errorf("expected items to be created and updated, got %v", items)
+		}
+		if _, ok := items["deleteMissing"]; ok {
+			t.Errorf("expected deleteMissing to be omitted, got %v", items)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`true`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.ImportConfiguration(context.Background(), "yaml", "zabbix_export: {}", false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestImportConfiguration_DeleteMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		params := req.Params.(map[string]interface{})
+		rules := params["rules"].(map[string]interface{})
+		for _, entity := range []string{"items", "triggers", "discoveryRules", "valueMaps"} {
+			rule := rules[entity].(map[string]interface{})
+			if rule["deleteMissing"] != true {
+				t.Errorf("expected deleteMissing for %s, got %v", entity, rule)
+			}
+		}
+		templates := rules["templates"].(map[string]interface{})
+		if _, ok := templates["deleteMissing"]; ok {
+			t.Errorf("expected templates not to be deleted, got %v", templates)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`true`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.ImportConfiguration(context.Background(), "yaml", "zabbix_export: {}", true)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCompareConfiguration_NoChanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "configuration.importcompare" {
+			t.Errorf("expected method 'configuration.importcompare', got '%s'", req.Method)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	changed, err := client.CompareConfiguration(context.Background(), "yaml", "zabbix_export: {}", false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed {
+		t.Error("expected no changes")
+	}
+}
+
+func TestCompareConfiguration_Changes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`{"templates": {"updated": [{
+				"before": {"uuid": "abc123", "template": "my_template"},
+				"after": {"uuid": "abc123", "template": "my_template"},
+				"items": {"added": [{"after": {"uuid": "def456", "name": "CPU load", "key": "system.cpu.load"}}]}
+			}]}}`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	changed, err := client.CompareConfiguration(context.Background(), "yaml", "zabbix_export: {}", false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Error("expected changes")
+	}
+}
+
+func TestSearchTemplates_ByTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "template.get" {
+			t.Errorf("expected method 'template.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		if _, exists := params["filter"]; exists {
+			t.Errorf("expected filter to be omitted, got '%v'", params["filter"])
+		}
+		tags, ok := params["tags"].([]interface{})
+		if !ok || len(tags) != 1 {
+			t.Fatalf("expected 1 tag filter, got '%v'", params["tags"])
+		}
+		tag := tags[0].(map[string]interface{})
+		if tag["tag"] != "class" || tag["value"] != "baseline" || tag["operator"] != float64(TagOperatorEquals) {
+			t.Errorf("unexpected tag filter: %v", tag)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"templateid": "10001",
+				"host": "baseline_linux",
+				"name": "Baseline Linux",
+				"tags": [{"tag": "class", "value": "baseline"}]
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	templates, err := client.SearchTemplates(context.Background(), "", []TagFilter{
+		{Tag: "class", Value: "baseline", Operator: TagOperatorEquals},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("expected 1 template, got %d", len(templates))
+	}
+	if templates[0].Host != "baseline_linux" {
+		t.Errorf("expected host 'baseline_linux', got '%s'", templates[0].Host)
+	}
+}
+
+func TestSearchTemplatesByHost_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "template.get" {
+			t.Errorf("expected method 'template.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		search, ok := params["search"].(map[string]interface{})
+		if !ok || search["host"] != "tf-acc-test*" {
+			t.Errorf("expected search host 'tf-acc-test*', got '%v'", params["search"])
+		}
+		if params["searchWildcardsEnabled"] != true {
+			t.Errorf("expected searchWildcardsEnabled true, got '%v'", params["searchWildcardsEnabled"])
+		}
+		if params["sortfield"] != "host" {
+			t.Errorf("expected sortfield 'host', got '%v'", params["sortfield"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[{"templateid": "10501", "host": "tf-acc-test-1234", "name": "tf-acc-test-1234"}]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	templates, err := client.SearchTemplatesByHost(context.Background(), "tf-acc-test*")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templates) != 1 || templates[0].TemplateID != "10501" {
+		t.Errorf("expected template 10501, got %+v", templates)
+	}
+}
+
+func TestSearchTemplates_ByHostAndTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		params := req.Params.(map[string]interface{})
+		filter, ok := params["filter"].(map[string]interface{})
+		if !ok || filter["host"] != "baseline_linux" {
+			t.Errorf("expected filter host 'baseline_linux', got '%v'", params["filter"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	templates, err := client.SearchTemplates(context.Background(), "baseline_linux", []TagFilter{
+		{Tag: "class", Operator: TagOperatorExists},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("expected no templates, got %d", len(templates))
+	}
+}
+
+func TestCreateTemplates_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "template.create", &params, `{"templateids": ["41", "42"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	templateIDs, err := client.CreateTemplates(context.Background(), []*Template{
+		{Host: "Template A", Groups: []TemplateGroupID{{GroupID: "1"}}},
+		{Host: "Template B", Groups: []TemplateGroupID{{GroupID: "1"}}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templateIDs) != 2 || templateIDs[0] != "41" || templateIDs[1] != "42" {
+		t.Errorf("expected template IDs [41 42], got %v", templateIDs)
+	}
+
+	templates, ok := params.([]interface{})
+	if !ok || len(templates) != 2 || templates[1].(map[string]interface{})["host"] != "Template B" {
+		t.Errorf("expected an array of 2 templates, got %v", params)
+	}
+}
+
+func TestUpdateTemplates_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "template.update", &params, `{"templateids": ["41", "42"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	err := client.UpdateTemplates(context.Background(), []*Template{
+		{TemplateID: "41", Description: "Linux"},
+		{TemplateID: "42", Description: "Databases"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	templates, ok := params.([]interface{})
+	if !ok || len(templates) != 2 || templates[0].(map[string]interface{})["templateid"] != "41" {
+		t.Errorf("expected an array of 2 templates, got %v", params)
+	}
+}
+
+func TestDeleteTemplates_Success(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "template.delete", &params, `{"templateids": ["41", "42"]}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if err := client.DeleteTemplates(context.Background(), []string{"41", "42"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids, ok := params.([]interface{})
+	if !ok || len(ids) != 2 {
+		t.Errorf("expected 2 template IDs, got %v", params)
+	}
+}
+
+func TestImportCompare_Changes(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "configuration.importcompare", &params, `{
+		"templates": {"updated": [{
+			"before": {"uuid": "abc123", "template": "my_template"},
+			"after": {"uuid": "abc123", "template": "my_template"},
+			"items": {
+				"added": [{"after": {"uuid": "def456", "name": "CPU load", "key": "system.cpu.load"}}],
+				"removed": [{"before": {"uuid": "ghi789", "name": "Uptime", "key": "system.uptime"}}]
+			}
+		}]},
+		"template_groups": {"added": [{"after": {"uuid": "jkl012", "name": "Templates/New"}}]}
+	}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	rules := map[string]interface{}{"templates": map[string]interface{}{"updateExisting": true}}
+	changes, err := client.ImportCompare(context.Background(), "yaml", "zabbix_export: {}", rules)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sent := params.(map[string]interface{})["rules"].(map[string]interface{})
+	if _, ok := sent["templates"]; !ok || len(sent) != 1 {
+		t.Errorf("expected the given rules to be sent, got %v", sent)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected changes of 2 entity types, got %v", changes)
+	}
+	if group := changes["template_groups"].Added; len(group) != 1 || group[0].After["name"] != "Templates/New" {
+		t.Errorf("expected an added template group, got %+v", changes["template_groups"])
+	}
+
+	updated := changes["templates"].Updated
+	if len(updated) != 1 || updated[0].Before["template"] != "my_template" {
+		t.Fatalf("expected an updated template, got %+v", changes["templates"])
+	}
+	items := updated[0].Changes["items"]
+	if len(items.Added) != 1 || items.Added[0].After["key"] != "system.cpu.load" || items.Added[0].Before != nil {
+		t.Errorf("expected an added item, got %+v", items.Added)
+	}
+	if len(items.Removed) != 1 || items.Removed[0].Before["key"] != "system.uptime" {
+		t.Errorf("expected a removed item, got %+v", items.Removed)
+	}
+}
+
+func TestImportCompare_NoChanges(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "configuration.importcompare", &params, `[]`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	changes, err := client.ImportCompare(context.Background(), "yaml", "zabbix_export: {}", TemplateImportRules(true))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestImportCompare_InvalidResponse(t *testing.T) {
+	var params interface{}
+	server := newBulkTestServer(t, "configuration.importcompare", &params, `{"templates": {"updated": [{"before": "abc"}]}}`)
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	if _, err := client.ImportCompare(context.Background(), "yaml", "zabbix_export: {}", TemplateImportRules(false)); err == nil {
+		t.Error("expected error for a malformed change")
+	}
+}
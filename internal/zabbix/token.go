@@ -0,0 +1,78 @@
+// ABOUTME: Provides API methods for reading Zabbix API token metadata.
+// ABOUTME: Implements lookups using the token.get JSON-RPC method; token secrets are never returned.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Token represents the metadata of a Zabbix API token. The secret itself is only
+// returned by token.generate and is deliberately not part of this type.
+type Token struct {
+	TokenID     string `json:"tokenid,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	UserID      string `json:"userid,omitempty"`
+	Status      int    `json:"-"`
+	ExpiresAt   int64  `json:"-"`
+	LastAccess  int64  `json:"-"`
+	CreatedAt   int64  `json:"-"`
+}
+
+// tokenJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
+type tokenJSON struct {
+	TokenID     string  `json:"tokenid,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	Description string  `json:"description,omitempty"`
+	UserID      string  `json:"userid,omitempty"`
+	Status      FlexInt `json:"status,omitempty"`
+	ExpiresAt   FlexInt `json:"expires_at,omitempty"`
+	LastAccess  FlexInt `json:"lastaccess,omitempty"`
+	CreatedAt   FlexInt `json:"created_at,omitempty"`
+}
+
+// UnmarshalJSON handles Zabbix API returning numeric values as strings.
+func (t *Token) UnmarshalJSON(data []byte) error {
+	var tj tokenJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+
+	t.TokenID = tj.TokenID
+	t.Name = tj.Name
+	t.Description = tj.Description
+	t.UserID = tj.UserID
+	t.Status = int(tj.Status)
+	t.ExpiresAt = int64(tj.ExpiresAt)
+	t.LastAccess = int64(tj.LastAccess)
+	t.CreatedAt = int64(tj.CreatedAt)
+
+	return nil
+}
+
+// GetTokenParams contains parameters for retrieving API tokens.
+type GetTokenParams struct {
+	TokenIDs  []string    `json:"tokenids,omitempty"`
+	UserIDs   []string    `json:"userids,omitempty"`
+	Output    interface{} `json:"output,omitempty"`
+	SortField string      `json:"sortfield,omitempty"`
+}
+
+// GetTokens retrieves the metadata of the API tokens owned by the given users, sorted by
+// name. When no user IDs are given, the tokens of all users visible to the caller are returned.
+func (c *Client) GetTokens(ctx context.Context, userIDs []string) ([]Token, error) {
+	params := GetTokenParams{
+		UserIDs:   userIDs,
+		Output:    []string{"tokenid", "name", "description", "userid", "status", "expires_at", "lastaccess", "created_at"},
+		SortField: "name",
+	}
+
+	tokens, err := Call[[]Token](ctx, c, "token.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
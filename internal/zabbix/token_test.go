@@ -0,0 +1,109 @@
+// ABOUTME: Unit tests for Zabbix API token methods.
+// ABOUTME: Uses httptest to mock Zabbix API responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTokens_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "token.get" {
+			t.Errorf("expected method 'token.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		userIDs, ok := params["userids"].([]interface{})
+		if !ok || len(userIDs) != 1 || userIDs[0] != "1" {
+			t.Errorf("expected userids ['1'], got '%v'", params["userids"])
+		}
+		output, ok := params["output"].([]interface{})
+		if !ok {
+			t.Fatalf("expected output to be a list, got %T", params["output"])
+		}
+		for _, field := range output {
+			if field == "token" {
+				t.Error("expected the token secret not to be requested")
+			}
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"tokenid": "2",
+				"name": "terraform",
+				"description": "Used by CI",
+				"userid": "1",
+				"status": "0",
+				"expires_at": "0",
+				"lastaccess": "1760518800",
+				"created_at": "1736000000"
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	tokens, err := client.GetTokens(context.Background(), []string{"1"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Fatalf("expected 1 token, got %d", len(tokens))
+	}
+	token := tokens[0]
+	if token.Name != "terraform" {
+		t.Errorf("expected name 'terraform', got '%s'", token.Name)
+	}
+	if token.ExpiresAt != 0 {
+		t.Errorf("expected expires_at 0, got %d", token.ExpiresAt)
+	}
+	if token.LastAccess != 1760518800 {
+		t.Errorf("expected lastaccess 1760518800, got %d", token.LastAccess)
+	}
+	if token.CreatedAt != 1736000000 {
+		t.Errorf("expected created_at 1736000000, got %d", token.CreatedAt)
+	}
+}
+
+func TestGetTokens_InvalidTimestamp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[{"tokenid": "2", "expires_at": "never"}]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.GetTokens(context.Background(), nil)
+
+	if err == nil {
+		t.Fatal("expected error for invalid expires_at, got nil")
+	}
+}
@@ -0,0 +1,135 @@
+// ABOUTME: Builds the HTTP transport used by the Zabbix API client.
+// ABOUTME: Supports proxies, custom CA certificates, client certificates, compression and connection tuning.
+
+package zabbix
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions configures the HTTP transport created by NewTransport.
+type TransportOptions struct {
+	// InsecureSkipVerify disables verification of the server certificate chain and host name.
+	InsecureSkipVerify bool
+	// CACertPEM contains PEM-encoded CA certificates trusted in addition to the system pool.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM contain a PEM-encoded certificate and private key
+	// presented to the server for mutual TLS. Both must be set to enable client authentication.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// ProxyURL is the http, https or socks5 proxy used for all requests. When empty, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+	ProxyURL string
+	// DisableCompression stops requesting gzip-compressed responses. Responses are compressed
+	// by default when the web server supports it and decompressed transparently.
+	DisableCompression bool
+	// MaxIdleConnsPerHost is the number of idle connections kept open for reuse. Zero uses
+	// DefaultMaxIdleConnsPerHost. Connections beyond it are closed after each request, so
+	// a value below the number of concurrent requests exhausts ephemeral ports.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections, including those in use. Requests
+	// beyond it wait for a connection. Zero means no limit.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open. Zero keeps the default
+	// of 90 seconds.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval of TCP keep-alive probes. Zero keeps the default of 30
+	// seconds and a negative value disables them.
+	KeepAlive time.Duration
+	// DisableKeepAlives opens a new connection for every request.
+	DisableKeepAlives bool
+	// TLSHandshakeTimeout limits the TLS handshake. Zero keeps the default of 10 seconds.
+	TLSHandshakeTimeout time.Duration
+}
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections kept per host by default. It
+// exceeds the default parallelism of Terraform, unlike the 2 of http.DefaultTransport.
+const DefaultMaxIdleConnsPerHost = 32
+
+// defaultDialTimeout is the connect timeout of http.DefaultTransport.
+const defaultDialTimeout = 30 * time.Second
+
+// NewTransport returns a transport based on http.DefaultTransport with the given options applied.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if len(opts.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no valid certificates found in CA certificate PEM")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(opts.ClientCertPEM) > 0 || len(opts.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https, socks5 or socks5h", opts.ProxyURL)
+		}
+		if proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: missing host", opts.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	transport.DisableCompression = opts.DisableCompression
+	applyConnectionOptions(transport, opts)
+	return transport, nil
+}
+
+// applyConnectionOptions applies the connection pooling and timeout options to the transport.
+func applyConnectionOptions(transport *http.Transport, opts TransportOptions) {
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if transport.MaxIdleConns < transport.MaxIdleConnsPerHost {
+		transport.MaxIdleConns = transport.MaxIdleConnsPerHost
+	}
+
+	transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	transport.DisableKeepAlives = opts.DisableKeepAlives
+
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+	if opts.KeepAlive != 0 {
+		dialer := &net.Dialer{
+			Timeout:   defaultDialTimeout,
+			KeepAlive: opts.KeepAlive,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+}
@@ -0,0 +1,359 @@
+// ABOUTME: Unit tests for the Zabbix API client HTTP transport.
+// ABOUTME: Uses TLS test servers to verify custom CA, client certificate and insecure settings.
+
+package zabbix
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTLSAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewTLSServer(apiVersionHandler())
+}
+
+// newMutualTLSAPIServer starts a TLS server that requires a client certificate signed by clientCA.
+func newMutualTLSAPIServer(t *testing.T, clientCA *x509.Certificate) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(apiVersionHandler())
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCA)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	server.StartTLS()
+	return server
+}
+
+// newClientCertificate creates a self-signed client certificate and returns it with its
+// PEM-encoded certificate and private key.
+func newClientCertificate(t *testing.T) (*x509.Certificate, []byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, certPEM, keyPEM
+}
+
+func apiVersionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`"7.0.0"`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func TestNewTransport_CACert(t *testing.T) {
+	server := newTLSAPIServer(t)
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err := NewTransport(TransportOptions{CACertPEM: caPEM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err != nil {
+		t.Fatalf("expected request to succeed with custom CA, got: %v", err)
+	}
+}
+
+func TestNewTransport_UntrustedCertificate(t *testing.T) {
+	server := newTLSAPIServer(t)
+	defer server.Close()
+
+	transport, err := NewTransport(TransportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err == nil {
+		t.Fatal("expected request to fail for an untrusted certificate")
+	}
+}
+
+func TestNewTransport_InsecureSkipVerify(t *testing.T) {
+	server := newTLSAPIServer(t)
+	defer server.Close()
+
+	transport, err := NewTransport(TransportOptions{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err != nil {
+		t.Fatalf("expected request to succeed without verification, got: %v", err)
+	}
+}
+
+func TestNewTransport_InvalidCACert(t *testing.T) {
+	_, err := NewTransport(TransportOptions{CACertPEM: []byte("not a certificate")})
+	if err == nil {
+		t.Fatal("expected error for invalid CA certificate, got nil")
+	}
+}
+
+func TestNewTransport_ClientCertificate(t *testing.T) {
+	clientCert, certPEM, keyPEM := newClientCertificate(t)
+	server := newMutualTLSAPIServer(t, clientCert)
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err := NewTransport(TransportOptions{
+		CACertPEM:     caPEM,
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err != nil {
+		t.Fatalf("expected request to succeed with client certificate, got: %v", err)
+	}
+}
+
+func TestNewTransport_MissingClientCertificate(t *testing.T) {
+	clientCert, _, _ := newClientCertificate(t)
+	server := newMutualTLSAPIServer(t, clientCert)
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err := NewTransport(TransportOptions{CACertPEM: caPEM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err == nil {
+		t.Fatal("expected request to fail without a client certificate")
+	}
+}
+
+func TestNewTransport_InvalidClientCertificate(t *testing.T) {
+	_, certPEM, _ := newClientCertificate(t)
+	_, _, otherKeyPEM := newClientCertificate(t)
+
+	_, err := NewTransport(TransportOptions{ClientCertPEM: certPEM, ClientKeyPEM: otherKeyPEM})
+	if err == nil {
+		t.Fatal("expected error for mismatched client certificate and key, got nil")
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		apiVersionHandler().ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(TransportOptions{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient("http://zabbix.invalid/api_jsonrpc.php", WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	if _, err := client.Request(context.Background(), "apiinfo.version", nil); err != nil {
+		t.Fatalf("expected request through proxy to succeed, got: %v", err)
+	}
+	if proxiedHost != "zabbix.invalid" {
+		t.Errorf("expected proxied host 'zabbix.invalid', got '%s'", proxiedHost)
+	}
+}
+
+func TestNewTransport_InvalidProxy(t *testing.T) {
+	for _, proxyURL := range []string{"ftp://proxy.example.com", "http://", "://bad"} {
+		if _, err := NewTransport(TransportOptions{ProxyURL: proxyURL}); err == nil {
+			t.Errorf("expected error for proxy URL %q, got nil", proxyURL)
+		}
+	}
+}
+
+func TestNewTransport_Compression(t *testing.T) {
+	var gzipAccepted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp, _ := json.Marshal(Response{JSONRPC: "2.0", Result: json.RawMessage(`"7.0.0"`), ID: req.ID})
+		gzipAccepted = strings.Contains(r.Header.Get("Accept-Encoding"), "gzip")
+		if !gzipAccepted {
+			_, _ = w.Write(resp)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write(resp)
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	for _, disabled := range []bool{false, true} {
+		transport, err := NewTransport(TransportOptions{DisableCompression: disabled})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		client := NewClient(server.URL, WithToken("test-token"))
+		client.HTTPClient.Transport = transport
+
+		version, err := client.APIVersion(context.Background())
+		if err != nil {
+			t.Fatalf("compression disabled %t: unexpected error: %v", disabled, err)
+		}
+		if version.String() != "7.0.0" {
+			t.Errorf("compression disabled %t: expected version 7.0.0, got %s", disabled, version)
+		}
+		if gzipAccepted == disabled {
+			t.Errorf("compression disabled %t: expected gzip accepted %t", disabled, !disabled)
+		}
+	}
+}
+
+func TestNewTransport_ConnectionOptions(t *testing.T) {
+	transport, err := NewTransport(TransportOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("expected %d idle connections per host by default, got %d", DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 0 || transport.DisableKeepAlives {
+		t.Errorf("expected unlimited reused connections by default, got limit %d and keep-alives disabled %t", transport.MaxConnsPerHost, transport.DisableKeepAlives)
+	}
+
+	transport, err = NewTransport(TransportOptions{
+		MaxIdleConnsPerHost: 200,
+		MaxConnsPerHost:     50,
+		IdleConnTimeout:     time.Minute,
+		KeepAlive:           15 * time.Second,
+		DisableKeepAlives:   true,
+		TLSHandshakeTimeout: 20 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.MaxIdleConnsPerHost != 200 || transport.MaxIdleConns < 200 {
+		t.Errorf("expected 200 idle connections per host and in total, got %d and %d", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+	if transport.MaxConnsPerHost != 50 {
+		t.Errorf("expected 50 connections per host, got %d", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("expected idle timeout 1m, got %s", transport.IdleConnTimeout)
+	}
+	if !transport.DisableKeepAlives {
+		t.Error("expected keep-alives to be disabled")
+	}
+	if transport.TLSHandshakeTimeout != 20*time.Second {
+		t.Errorf("expected TLS handshake timeout 20s, got %s", transport.TLSHandshakeTimeout)
+	}
+}
+
+func TestNewTransport_ReusesConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(apiVersionHandler())
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	transport, err := NewTransport(TransportOptions{MaxConnsPerHost: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	client.HTTPClient.Transport = transport
+
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Request(context.Background(), "apiinfo.version", nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := connections.Load(); n > 4 {
+		t.Errorf("expected at most 4 connections, got %d", n)
+	}
+}
@@ -0,0 +1,84 @@
+// ABOUTME: Provides API methods for reading Zabbix triggers.
+// ABOUTME: Implements lookups using the trigger.get JSON-RPC method.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Trigger represents a Zabbix trigger.
+type Trigger struct {
+	TriggerID   string `json:"triggerid,omitempty"`
+	Description string `json:"description,omitempty"`
+	Expression  string `json:"expression,omitempty"`
+	Priority    int    `json:"-"`
+	Status      int    `json:"-"`
+	Comments    string `json:"comments,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// triggerJSON is used for JSON unmarshaling with numeric fields sent as numbers or strings.
+type triggerJSON struct {
+	TriggerID   string  `json:"triggerid,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Expression  string  `json:"expression,omitempty"`
+	Priority    FlexInt `json:"priority,omitempty"`
+	Status      FlexInt `json:"status,omitempty"`
+	Comments    string  `json:"comments,omitempty"`
+	URL         string  `json:"url,omitempty"`
+}
+
+// UnmarshalJSON handles Zabbix API returning numeric values as strings.
+func (t *Trigger) UnmarshalJSON(data []byte) error {
+	var tj triggerJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+
+	t.TriggerID = tj.TriggerID
+	t.Description = tj.Description
+	t.Expression = tj.Expression
+	t.Comments = tj.Comments
+	t.URL = tj.URL
+	t.Priority = int(tj.Priority)
+	t.Status = int(tj.Status)
+
+	return nil
+}
+
+// GetTriggerParams contains parameters for retrieving triggers.
+type GetTriggerParams struct {
+	TriggerIDs       []string               `json:"triggerids,omitempty"`
+	HostIDs          []string               `json:"hostids,omitempty"`
+	GroupIDs         []string               `json:"groupids,omitempty"`
+	Host             string                 `json:"host,omitempty"`
+	Templated        *bool                  `json:"templated,omitempty"`
+	Filter           map[string]interface{} `json:"filter,omitempty"`
+	ExpandExpression bool                   `json:"expandExpression,omitempty"`
+	CountOutput      bool                   `json:"countOutput,omitempty"`
+	Output           interface{}            `json:"output,omitempty"`
+}
+
+// GetTriggersByHostDescription retrieves the triggers with the given name on the host or
+// template with the given technical name. The expression is returned with item references
+// expanded. Trigger names are not unique per host, so callers should report more than one
+// result as an ambiguous lookup.
+func (c *Client) GetTriggersByHostDescription(ctx context.Context, host, description string) ([]Trigger, error) {
+	params := GetTriggerParams{
+		Host: host,
+		Filter: map[string]interface{}{
+			"description": description,
+		},
+		ExpandExpression: true,
+		Output:           []string{"triggerid", "description", "expression", "priority", "status", "comments", "url"},
+	}
+
+	triggers, err := Call[[]Trigger](ctx, c, "trigger.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
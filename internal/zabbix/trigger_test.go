@@ -0,0 +1,102 @@
+// ABOUTME: Unit tests for Zabbix trigger API methods.
+// ABOUTME: Uses httptest to mock Zabbix API responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetTriggersByHostDescription_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "trigger.get" {
+			t.Errorf("expected method 'trigger.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		if params["host"] != "web-01" {
+			t.Errorf("expected host 'web-01', got '%v'", params["host"])
+		}
+		filter, ok := params["filter"].(map[string]interface{})
+		if !ok || filter["description"] != "Linux: /etc/passwd has been changed" {
+			t.Errorf("expected filter description 'Linux: /etc/passwd has been changed', got '%v'", params["filter"])
+		}
+		if params["expandExpression"] != true {
+			t.Errorf("expected expandExpression true, got '%v'", params["expandExpression"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[{
+				"triggerid": "13491",
+				"description": "Linux: /etc/passwd has been changed",
+				"expression": "last(/web-01/vfs.file.cksum[/etc/passwd,sha256],#1)<>last(/web-01/vfs.file.cksum[/etc/passwd,sha256],#2)",
+				"priority": "1",
+				"status": "0",
+				"comments": "",
+				"url": ""
+			}]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	triggers, err := client.GetTriggersByHostDescription(context.Background(), "web-01", "Linux: /etc/passwd has been changed")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(triggers) != 1 {
+		t.Fatalf("expected 1 trigger, got %d", len(triggers))
+	}
+	trigger := triggers[0]
+	if trigger.TriggerID != "13491" {
+		t.Errorf("expected triggerid '13491', got '%s'", trigger.TriggerID)
+	}
+	if trigger.Priority != 1 {
+		t.Errorf("expected priority 1, got %d", trigger.Priority)
+	}
+	if trigger.Status != 0 {
+		t.Errorf("expected status 0, got %d", trigger.Status)
+	}
+}
+
+func TestGetTriggersByHostDescription_InvalidPriority(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[{"triggerid": "13491", "priority": "high"}]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	_, err := client.GetTriggersByHostDescription(context.Background(), "web-01", "Trigger")
+
+	if err == nil {
+		t.Fatal("expected error for invalid priority, got nil")
+	}
+}
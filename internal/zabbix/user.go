@@ -0,0 +1,55 @@
+// ABOUTME: Provides API methods for reading Zabbix users.
+// ABOUTME: Implements lookups using the user.get JSON-RPC method.
+
+package zabbix
+
+import (
+	"context"
+)
+
+// User represents a Zabbix user.
+type User struct {
+	UserID   string `json:"userid,omitempty"`
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Surname  string `json:"surname,omitempty"`
+	RoleID   string `json:"roleid,omitempty"`
+}
+
+// GetUserParams contains parameters for retrieving users.
+type GetUserParams struct {
+	UserIDs      []string               `json:"userids,omitempty"`
+	UserGroupIDs []string               `json:"usrgrpids,omitempty"`
+	Filter       map[string]interface{} `json:"filter,omitempty"`
+	Output       interface{}            `json:"output,omitempty"`
+	SortField    string                 `json:"sortfield,omitempty"`
+}
+
+// UserSearch contains the criteria for SearchUsers. Empty criteria are not applied,
+// and users must match all criteria that are set.
+type UserSearch struct {
+	RoleIDs      []string
+	UserGroupIDs []string
+}
+
+// SearchUsers retrieves the users matching the search criteria, sorted by username.
+func (c *Client) SearchUsers(ctx context.Context, search UserSearch) ([]User, error) {
+	params := GetUserParams{
+		UserGroupIDs: search.UserGroupIDs,
+		Output:       []string{"userid", "username", "name", "surname", "roleid"},
+		SortField:    "username",
+	}
+
+	if len(search.RoleIDs) > 0 {
+		params.Filter = map[string]interface{}{
+			"roleid": search.RoleIDs,
+		}
+	}
+
+	users, err := Call[[]User](ctx, c, "user.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
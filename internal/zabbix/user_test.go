@@ -0,0 +1,104 @@
+// ABOUTME: Unit tests for Zabbix user API methods.
+// ABOUTME: Uses httptest to mock Zabbix API responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchUsers_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+
+		if req.Method != "user.get" {
+			t.Errorf("expected method 'user.get', got '%s'", req.Method)
+		}
+
+		params, ok := req.Params.(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected params to be a map, got %T", req.Params)
+		}
+
+		groupIDs, ok := params["usrgrpids"].([]interface{})
+		if !ok || len(groupIDs) != 1 || groupIDs[0] != "7" {
+			t.Errorf("expected usrgrpids ['7'], got '%v'", params["usrgrpids"])
+		}
+		filter, ok := params["filter"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected filter to be a map, got %T", params["filter"])
+		}
+		roleIDs, ok := filter["roleid"].([]interface{})
+		if !ok || len(roleIDs) != 1 || roleIDs[0] != "3" {
+			t.Errorf("expected filter roleid ['3'], got '%v'", filter["roleid"])
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result: json.RawMessage(`[
+				{"userid": "1", "username": "Admin", "name": "Zabbix", "surname": "Administrator", "roleid": "3"}
+			]`),
+			ID: req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	users, err := client.SearchUsers(context.Background(), UserSearch{
+		RoleIDs:      []string{"3"},
+		UserGroupIDs: []string{"7"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 1 {
+		t.Fatalf("expected 1 user, got %d", len(users))
+	}
+	if users[0].Username != "Admin" || users[0].RoleID != "3" {
+		t.Errorf("expected user 'Admin' with role '3', got %+v", users[0])
+	}
+}
+
+func TestSearchUsers_NoCriteria(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		params := req.Params.(map[string]interface{})
+		for _, key := range []string{"usrgrpids", "filter"} {
+			if _, exists := params[key]; exists {
+				t.Errorf("expected %s to be omitted, got '%v'", key, params[key])
+			}
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`[]`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+	users, err := client.SearchUsers(context.Background(), UserSearch{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("expected no users, got %d", len(users))
+	}
+}
@@ -0,0 +1,136 @@
+// ABOUTME: Detects and compares Zabbix API versions.
+// ABOUTME: Caches the version reported by apiinfo.version so callers can adjust behavior per version.
+
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a Zabbix API version such as 7.0.5.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// parts is the number of components given when parsing, used by Matches.
+	parts int
+}
+
+// ParseVersion parses a version with one to three numeric components, such as 7, 7.0 or
+// 7.0.5. Suffixes of the last component, as in 7.2.0rc1, are ignored.
+func ParseVersion(s string) (Version, error) {
+	components := strings.Split(strings.TrimSpace(s), ".")
+	if len(components) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+
+	numbers := make([]int, 3)
+	for i, component := range components {
+		digits := component
+		if i == len(components)-1 {
+			if end := strings.IndexFunc(component, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+				digits = component[:end]
+			}
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], parts: len(components)}, nil
+}
+
+// String returns the version as major.minor.patch.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or higher than other.
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is the same as or newer than major.minor.
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Compare(Version{Major: major, Minor: minor}) >= 0
+}
+
+// AtMost reports whether v is not newer than limit, considering only the components given
+// when limit was parsed. For example 7.0.5 is at most 7.0, but not at most 7.0.4.
+func (v Version) AtMost(limit Version) bool {
+	truncated := v
+	switch limit.parts {
+	case 1:
+		truncated.Minor, truncated.Patch = limit.Minor, limit.Patch
+	case 2:
+		truncated.Patch = limit.Patch
+	}
+	return truncated.Compare(limit) <= 0
+}
+
+// APIVersion returns the version of the Zabbix API. The version is requested once and
+// cached for the lifetime of the client, as is the error when it cannot be detected, so
+// that clients of servers hiding their version do not ask for it with every request.
+// Errors caused by the context of the caller are not cached.
+func (c *Client) APIVersion(ctx context.Context) (Version, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.version != nil {
+		return *c.version, nil
+	}
+	if c.versionErr != nil {
+		return Version{}, c.versionErr
+	}
+
+	version, err := c.detectVersion(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.versionErr = err
+		}
+		return Version{}, err
+	}
+
+	c.version = &version
+	return version, nil
+}
+
+// DetectedVersion returns the version cached by APIVersion without sending a request. It
+// reports false when the version has not been detected (yet).
+func (c *Client) DetectedVersion() (Version, bool) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.version == nil {
+		return Version{}, false
+	}
+	return *c.version, true
+}
+
+// detectVersion requests and parses the version of the Zabbix API.
+func (c *Client) detectVersion(ctx context.Context) (Version, error) {
+	raw, err := Call[string](ctx, c, "apiinfo.version", nil)
+	if err != nil {
+		return Version{}, err
+	}
+
+	version, err := ParseVersion(raw)
+	if err != nil {
+		return Version{}, err
+	}
+
+	return version, nil
+}
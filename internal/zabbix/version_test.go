@@ -0,0 +1,163 @@
+// ABOUTME: Unit tests for Zabbix API version parsing, comparison and detection.
+// ABOUTME: Uses httptest to mock apiinfo.version responses.
+
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]struct {
+		expected  string
+		expectErr bool
+	}{
+		"7.0.5":    {expected: "7.0.5"},
+		"7.0":      {expected: "7.0.0"},
+		"7":        {expected: "7.0.0"},
+		"7.2.0rc1": {expected: "7.2.0"},
+		"":         {expectErr: true},
+		"7.x":      {expectErr: true},
+		"7.0.1.2":  {expectErr: true},
+		"7.0.":     {expectErr: true},
+	}
+
+	for input, tc := range tests {
+		version, err := ParseVersion(input)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", input, version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+			continue
+		}
+		if version.String() != tc.expected {
+			t.Errorf("%q: expected %s, got %s", input, tc.expected, version)
+		}
+	}
+}
+
+func TestVersion_Bounds(t *testing.T) {
+	mustParse := func(s string) Version {
+		v, err := ParseVersion(s)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", s, err)
+		}
+		return v
+	}
+
+	version := mustParse("7.0.5")
+
+	if !version.AtLeast(7, 0) || version.AtLeast(7, 2) {
+		t.Error("expected 7.0.5 to be at least 7.0 but not at least 7.2")
+	}
+	for limit, expected := range map[string]bool{"7": true, "7.0": true, "7.0.5": true, "7.0.4": false, "6.4": false, "8": true} {
+		if got := version.AtMost(mustParse(limit)); got != expected {
+			t.Errorf("expected 7.0.5 at most %s to be %t, got %t", limit, expected, got)
+		}
+	}
+}
+
+func TestAPIVersion_Cached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		var req Request
+		_ = json.Unmarshal(body, &req)
+
+		if req.Method != "apiinfo.version" {
+			t.Errorf("expected method 'apiinfo.version', got '%s'", req.Method)
+		}
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Result:  json.RawMessage(`"7.0.5"`),
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	for i := 0; i < 2; i++ {
+		version, err := client.APIVersion(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if version.String() != "7.0.5" {
+			t.Errorf("expected version 7.0.5, got %s", version)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the version to be requested once, got %d requests", requests)
+	}
+}
+
+func TestAPIVersion_ErrorCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		resp := Response{
+			JSONRPC: "2.0",
+			Error:   &Error{Code: -32601, Message: "Method not found.", Data: "Incorrect API \"apiinfo\"."},
+			ID:      req.ID,
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.APIVersion(context.Background()); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the failed detection to be cached, got %d requests", requests)
+	}
+	if _, detected := client.DetectedVersion(); detected {
+		t.Error("expected no detected version")
+	}
+}
+
+func TestAPIVersion_ContextErrorNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(Response{JSONRPC: "2.0", Result: json.RawMessage(`"7.0.5"`), ID: req.ID})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, WithToken("test-token"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.APIVersion(ctx); err == nil {
+		t.Fatal("expected error for canceled context")
+	}
+
+	version, err := client.APIVersion(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.String() != "7.0.5" {
+		t.Errorf("expected version 7.0.5, got %s", version)
+	}
+	if detected, ok := client.DetectedVersion(); !ok || detected.String() != "7.0.5" {
+		t.Errorf("expected detected version 7.0.5, got %s (%v)", detected, ok)
+	}
+}